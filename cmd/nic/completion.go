@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+// completeConfigFile restricts -f/--file completion to YAML files. The
+// `completion` command itself is provided by cobra.
+func completeConfigFile(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{"yaml", "yml"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// completeNodeGroups completes --nodegroup with the node group names declared
+// in the config file the command would use (-f/--file or auto-discovery).
+// Completion stays silent when no config can be read.
+func completeNodeGroups(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	file, _ := cmd.Flags().GetString("file")
+	path, err := resolveConfigFile(file)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cfg, err := config.ParseConfig(ctx, path)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return nodeGroupNames(cfg, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// nodeGroupNames returns the sorted node_groups keys of the configured cluster
+// provider that start with prefix. Every provider declares its node groups
+// under node_groups.
+func nodeGroupNames(cfg *config.NebariConfig, prefix string) []string {
+	groups, _ := cfg.Cluster.ProviderConfig()["node_groups"].(map[string]any)
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(groups)) {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
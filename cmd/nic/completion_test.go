@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const completionTestConfig = `project_name: demo
+domain: demo.example.com
+cluster:
+  aws:
+    region: us-west-2
+    kubernetes_version: "1.34"
+    node_groups:
+      user:
+        instance: m7i.xlarge
+      general:
+        instance: m7i.2xlarge
+      gpu:
+        instance: g5.xlarge
+`
+
+func TestCompleteNodeGroups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(completionTestConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{name: "all groups sorted", want: []string{"general", "gpu", "user"}},
+		{name: "prefix filter", toComplete: "g", want: []string{"general", "gpu"}},
+		{name: "no match", toComplete: "x", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringP("file", "f", "", "")
+			if err := cmd.Flags().Set("file", path); err != nil {
+				t.Fatal(err)
+			}
+
+			got, directive := completeNodeGroups(cmd, nil, tt.toComplete)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("completions = %v, want %v", got, tt.want)
+			}
+			if directive != cobra.ShellCompDirectiveNoFileComp {
+				t.Errorf("directive = %v, want NoFileComp", directive)
+			}
+		})
+	}
+}
+
+func TestCompleteNodeGroups_NoConfig(t *testing.T) {
+	chdirTemp(t)
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP("file", "f", "", "")
+
+	got, directive := completeNodeGroups(cmd, nil, "")
+	if len(got) != 0 || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("completeNodeGroups() = %v, %v; want no completions", got, directive)
+	}
+}
+
+func TestRegisterCompletions_FileFlag(t *testing.T) {
+	for _, cmd := range []*cobra.Command{deployCmd, destroyCmd, validateCmd, kubeconfigCmd, exportCmd, scaleCmd} {
+		fn, ok := cmd.GetFlagCompletionFunc("file")
+		if !ok {
+			t.Errorf("%s: no completion registered for --file", cmd.Name())
+			continue
+		}
+		exts, directive := fn(cmd, nil, "")
+		if directive != cobra.ShellCompDirectiveFilterFileExt || !slices.Equal(exts, []string{"yaml", "yml"}) {
+			t.Errorf("%s: --file completion = %v, %v; want yaml/yml filter", cmd.Name(), exts, directive)
+		}
+	}
+	if _, ok := scaleCmd.GetFlagCompletionFunc("nodegroup"); !ok {
+		t.Error("scale: no completion registered for --nodegroup")
+	}
+}
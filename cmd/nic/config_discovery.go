@@ -4,43 +4,75 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 )
 
 // defaultConfigFilename is the name of the config file auto-discovered by NIC.
 const defaultConfigFilename = "config.yaml"
 
+// configFilenames are the names auto-discovered in the current directory, in
+// the order they are reported.
+var configFilenames = []string{defaultConfigFilename, "nebari-config.yaml", "nic.yaml"}
+
 // envConfigPath is the environment variable that can override the config file path.
 const envConfigPath = "NIC_CONFIG_PATH"
 
+// envConfig is a shorter alias for envConfigPath, consulted only when
+// envConfigPath is unset.
+const envConfig = "NIC_CONFIG"
+
 // resolveConfigFile determines the effective config file path using the following priority:
 //
 //  1. Explicit --file / -f flag (non-empty flagValue)
-//  2. NIC_CONFIG_PATH environment variable
-//  3. Auto-discovery: ./config.yaml in the current working directory
+//  2. NIC_CONFIG_PATH environment variable, then NIC_CONFIG
+//  3. Auto-discovery: exactly one of ./config.yaml, ./nebari-config.yaml or
+//     ./nic.yaml in the current working directory
 //
 // In all cases the resolved path is verified to be readable before it is
 // returned.  An error is returned when no path was explicitly supplied and
-// config.yaml is not present in the current directory, or when the resolved
-// file exists but cannot be read (e.g. incorrect permissions).
+// none or more than one of the discoverable files is present in the current
+// directory, or when the resolved file exists but cannot be read (e.g.
+// incorrect permissions). Such errors exit with exitConfig.
 func resolveConfigFile(flagValue string) (string, error) {
+	path, err := discoverConfigFile(flagValue)
+	return path, withExitCode(exitConfig, err)
+}
+
+// discoverConfigFile implements the precedence documented on resolveConfigFile.
+func discoverConfigFile(flagValue string) (string, error) {
 	if flagValue != "" {
 		return flagValue, checkReadable(flagValue)
 	}
 
-	if envPath := os.Getenv(envConfigPath); envPath != "" {
-		return envPath, checkReadable(envPath)
+	for _, env := range []string{envConfigPath, envConfig} {
+		if envPath := os.Getenv(env); envPath != "" {
+			return envPath, checkReadable(envPath)
+		}
 	}
 
-	if fileExists(defaultConfigFilename) {
+	var found []string
+	for _, name := range configFilenames {
+		if fileExists(name) {
+			found = append(found, name)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf(
+			"no config file found: provide --file/-f, set %s, or place one of %s in the current directory",
+			envConfigPath, strings.Join(configFilenames, ", "),
+		)
+	case 1:
 		// The file is present; make sure we can actually read it before
 		// reporting it as the resolved path.
-		return defaultConfigFilename, checkReadable(defaultConfigFilename)
+		return found[0], checkReadable(found[0])
+	default:
+		return "", fmt.Errorf(
+			"found multiple config files in the current directory (%s): choose one with --file/-f or %s",
+			strings.Join(found, ", "), envConfigPath,
+		)
 	}
-
-	return "", fmt.Errorf(
-		"no config file found: provide --file/-f, set %s, or place a %s in the current directory",
-		envConfigPath, defaultConfigFilename,
-	)
 }
 
 // checkReadable verifies that path refers to a file that the current process
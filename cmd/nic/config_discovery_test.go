@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -182,3 +183,95 @@ func TestFileExists_Missing(t *testing.T) {
 		t.Error("fileExists() = true for nonexistent path, want false")
 	}
 }
+
+// chdirTemp changes into a fresh temporary directory containing the named
+// files for the duration of the test, and clears the config env vars.
+func chdirTemp(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(dir+"/"+name, []byte("provider: local\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	t.Setenv(envConfigPath, "")
+	t.Setenv(envConfig, "")
+	return dir
+}
+
+func TestResolveConfigFile_Precedence(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     []string // created in the working directory
+		flag      string
+		envPath   bool // set NIC_CONFIG_PATH to a file outside the working directory
+		envShort  bool // set NIC_CONFIG to a different file outside the working directory
+		want      string
+		errSubstr string
+	}{
+		{name: "flag beats env and cwd", files: []string{"nic.yaml"}, flag: "flag", envPath: true, envShort: true, want: "flag"},
+		{name: "NIC_CONFIG_PATH beats NIC_CONFIG", files: []string{"nic.yaml"}, envPath: true, envShort: true, want: "env-path"},
+		{name: "NIC_CONFIG beats cwd", files: []string{"nic.yaml"}, envShort: true, want: "env-short"},
+		{name: "discovers nebari-config.yaml", files: []string{"nebari-config.yaml"}, want: "nebari-config.yaml"},
+		{name: "discovers nic.yaml", files: []string{"nic.yaml"}, want: "nic.yaml"},
+		{name: "multiple cwd files are ambiguous", files: []string{"config.yaml", "nic.yaml"}, errSubstr: "multiple config files in the current directory (config.yaml, nic.yaml)"},
+		{name: "nothing found", errSubstr: "config.yaml, nebari-config.yaml, nic.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t, tt.files...)
+			outside := t.TempDir()
+			paths := map[string]string{
+				"flag":      writeNamedConfig(t, outside, "flag.yaml"),
+				"env-path":  writeNamedConfig(t, outside, "env-path.yaml"),
+				"env-short": writeNamedConfig(t, outside, "env-short.yaml"),
+			}
+			if tt.envPath {
+				t.Setenv(envConfigPath, paths["env-path"])
+			}
+			if tt.envShort {
+				t.Setenv(envConfig, paths["env-short"])
+			}
+			flag := ""
+			if tt.flag != "" {
+				flag = paths[tt.flag]
+			}
+
+			got, err := resolveConfigFile(flag)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := tt.want
+			if p, ok := paths[tt.want]; ok {
+				want = p
+			}
+			if got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func writeNamedConfig(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := dir + "/" + name
+	if err := os.WriteFile(path, []byte("provider: local\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -19,6 +19,13 @@ var (
 	deployDryRun     bool
 	deployTimeout    string
 	deployRegenApps  bool
+	deployResume     bool
+	deployDetailed   bool
+	deployStrict     bool
+	deployInfraOnly  bool
+	deployRecreateNG bool
+	deployShowURLs   bool
+	deployNoWait     bool
 
 	deployCmd = &cobra.Command{
 		Use:   "deploy",
@@ -27,16 +34,32 @@ var (
 provided nebari-config.yaml file. This command will create all necessary
 resources to establish a fully functional Nebari cluster.
 
-Use --dry-run to preview changes without applying them.`,
+Use --dry-run to preview changes without applying them; add
+--detailed-exitcode to exit with code 3 when the plan is not empty. Use
+--resume after a failed deploy to skip the stages it already completed. Use
+--infra-only to deploy just the cluster, node groups and networking, without
+Argo CD, foundational services or DNS. Use --show-urls to print the service
+URLs and admin credential references afterwards (see 'nic info'). Use
+--no-wait to apply Argo CD and the foundational services without waiting for
+them to become ready, e.g. when readiness is checked separately with
+'nic status'.`,
 		RunE: runDeploy,
 	}
 )
 
 func init() {
 	deployCmd.Flags().StringVarP(&deployConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = deployCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
 	deployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "Show what would be deployed without making changes")
 	deployCmd.Flags().StringVar(&deployTimeout, "timeout", "", "Override default timeout (e.g., '45m', '1h')")
 	deployCmd.Flags().BoolVar(&deployRegenApps, "regen-apps", false, "Regenerate ArgoCD application manifests even if already bootstrapped")
+	deployCmd.Flags().BoolVar(&deployResume, "resume", false, "Skip stages completed by a previous failed deploy of the same config")
+	deployCmd.Flags().BoolVar(&deployStrict, "strict", false, "Fail instead of warning when preflight checks find conflicts (e.g. another Gateway API implementation) or a cluster too small for the foundational services")
+	deployCmd.Flags().BoolVar(&deployInfraOnly, "infra-only", false, "Deploy only the cluster, node groups and networking; skip Argo CD, foundational services and DNS")
+	deployCmd.Flags().BoolVar(&deployRecreateNG, "recreate-failed-nodegroups", false, "Delete node groups stuck in CREATE_FAILED or DEGRADED so this deploy recreates them (AWS)")
+	deployCmd.Flags().BoolVar(&deployNoWait, "no-wait", false, "Apply Argo CD and the foundational services without waiting for them (or the load balancer) to become ready")
+	deployCmd.Flags().BoolVar(&deployShowURLs, "show-urls", false, "Print service URLs and where to find the admin passwords after a successful deploy")
+	deployCmd.Flags().BoolVar(&deployDetailed, "detailed-exitcode", false, "With --dry-run, exit with code 3 when infrastructure changes are pending")
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
@@ -54,8 +77,13 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	span.SetAttributes(
 		attribute.String("config.file", configFile),
 		attribute.Bool("dry_run", deployDryRun),
+		attribute.Bool("resume", deployResume),
 	)
 
+	if deployDetailed && !deployDryRun {
+		return withExitCode(exitConfig, fmt.Errorf("--detailed-exitcode requires --dry-run"))
+	}
+
 	var timeout time.Duration
 	if deployTimeout != "" {
 		timeout, err = time.ParseDuration(deployTimeout)
@@ -82,9 +110,16 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	defer cleanup()
 
 	result, err := client.Deploy(ctx, cfg, nic.DeployOptions{
-		DryRun:    deployDryRun,
-		Timeout:   timeout,
-		RegenApps: deployRegenApps,
+		DryRun:        deployDryRun,
+		Timeout:       timeout,
+		RegenApps:     deployRegenApps,
+		Resume:        deployResume,
+		FailOnChanges: deployDetailed,
+		InfraOnly:     deployInfraOnly,
+		Strict:        deployStrict,
+		NoWait:        deployNoWait,
+
+		RecreateFailedNodeGroups: deployRecreateNG,
 	})
 	if err != nil {
 		span.RecordError(err)
@@ -102,9 +137,17 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	if result.KeycloakInstalled {
 		printKeycloakInstructions(cfg)
 	}
-	if cfg.DNS == nil && cfg.Domain != "" && !deployDryRun {
+	if !result.InfraOnly && cfg.RecordsDNS() == nil && cfg.Domain != "" && !deployDryRun {
 		printDNSGuidance(cfg, result.LBEndpoint)
 	}
+	if deployShowURLs && !result.InfraOnly && !deployDryRun {
+		services, err := client.AccessInfo(ctx, cfg)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		printAccessInfo(services, configFile)
+	}
 
 	return nil
 }
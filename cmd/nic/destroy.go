@@ -23,6 +23,8 @@ var (
 	destroyForce       bool
 	destroyTimeout     string
 	destroyDryRun      bool
+	destroyEnvironment string
+	destroyCascadeApps string
 
 	destroyCmd = &cobra.Command{
 		Use:   "destroy",
@@ -33,17 +35,24 @@ This includes node groups, EKS cluster, VPC, and IAM roles.
 WARNING: This operation is destructive and cannot be undone. All data will be lost.
 
 By default, you will be prompted to confirm before destruction begins.
-Use --auto-approve to skip the confirmation prompt.`,
+Use --auto-approve (or --yes) to skip the confirmation prompt.
+
+Destroying a production environment (environment: prod or production in the
+config) additionally requires --yes and --environment naming it.`,
 		RunE: runDestroy,
 	}
 )
 
 func init() {
 	destroyCmd.Flags().StringVarP(&destroyConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = destroyCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
 	destroyCmd.Flags().BoolVar(&destroyAutoApprove, "auto-approve", false, "Skip confirmation prompt and destroy immediately")
+	destroyCmd.Flags().BoolVarP(&destroyAutoApprove, "yes", "y", false, "Alias for --auto-approve")
+	destroyCmd.Flags().StringVar(&destroyEnvironment, "environment", "", "Confirm the config's environment by name (required for production environments)")
 	destroyCmd.Flags().BoolVar(&destroyForce, "force", false, "Continue destruction even if some resources fail to delete")
 	destroyCmd.Flags().StringVar(&destroyTimeout, "timeout", "", "Override default timeout (e.g., '45m', '1h')")
 	destroyCmd.Flags().BoolVar(&destroyDryRun, "dry-run", false, "Show what would be destroyed without actually deleting")
+	destroyCmd.Flags().StringVar(&destroyCascadeApps, "cascade-applications", "", "Delete the Argo CD Applications before the cluster: foreground, background or orphan")
 }
 
 func runDestroy(cmd *cobra.Command, args []string) error {
@@ -81,6 +90,11 @@ func runDestroy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := checkDestroyEnvironment(cfg, destroyAutoApprove, destroyEnvironment, destroyDryRun); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	client, err := nic.NewClient(ctx)
 	if err != nil {
 		span.RecordError(err)
@@ -91,9 +105,11 @@ func runDestroy(cmd *cobra.Command, args []string) error {
 	defer cleanup()
 
 	opts := nic.DestroyOptions{
-		DryRun:  destroyDryRun,
-		Force:   destroyForce,
-		Timeout: timeout,
+		DryRun:             destroyDryRun,
+		Force:              destroyForce,
+		Timeout:            timeout,
+		Environment:        destroyEnvironment,
+		ApplicationCascade: destroyCascadeApps,
 	}
 	if !destroyAutoApprove {
 		opts.Confirm = confirmDestruction
@@ -107,6 +123,21 @@ func runDestroy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkDestroyEnvironment enforces the explicit confirmation required to
+// destroy a production environment: --yes plus --environment naming it, so a
+// prompt answered by habit or a config pointed at the wrong stage cannot tear
+// down production. Dry runs are exempt.
+func checkDestroyEnvironment(cfg *config.NebariConfig, autoApprove bool, environment string, dryRun bool) error {
+	if dryRun || !cfg.IsProtectedEnvironment() {
+		return nil
+	}
+	if !autoApprove || environment != cfg.Environment {
+		return fmt.Errorf("%w: destroying the %q environment requires --yes --environment %s",
+			nic.ErrAborted, cfg.Environment, cfg.Environment)
+	}
+	return nil
+}
+
 // confirmDestruction renders the destroy warning panel and reads a yes/no
 // response from stdin. Returns a non-nil error when the user does not type
 // "yes", which causes Client.Destroy to abort.
@@ -114,6 +145,9 @@ func confirmDestruction(_ context.Context, s nic.DestroySummary) error {
 	fmt.Println("\n⚠️  WARNING: You are about to destroy the following infrastructure:")
 	fmt.Printf("   Provider:     %s\n", s.Provider)
 	fmt.Printf("   Project Name: %s\n", s.ProjectName)
+	if s.Environment != "" {
+		fmt.Printf("   Environment:  %s\n", s.Environment)
+	}
 
 	for key, value := range s.Details {
 		pad := max(13-len(key), 1)
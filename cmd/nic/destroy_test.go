@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+)
+
+func TestCheckDestroyEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		autoApprove bool
+		confirmEnv  string
+		dryRun      bool
+		wantErr     bool
+	}{
+		{name: "no environment", autoApprove: false},
+		{name: "unprotected environment", environment: "dev"},
+		{name: "prod confirmed with --yes and matching --environment", environment: "prod", autoApprove: true, confirmEnv: "prod"},
+		{name: "prod without --yes", environment: "prod", confirmEnv: "prod", wantErr: true},
+		{name: "prod without --environment", environment: "prod", autoApprove: true, wantErr: true},
+		{name: "prod with mismatched --environment", environment: "production", autoApprove: true, confirmEnv: "prod", wantErr: true},
+		{name: "prod dry run", environment: "prod", dryRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{ProjectName: "demo", Environment: tt.environment}
+			err := checkDestroyEnvironment(cfg, tt.autoApprove, tt.confirmEnv, tt.dryRun)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, nic.ErrAborted) {
+				t.Fatalf("error = %v, want ErrAborted", err)
+			}
+			if got := exitCode(err); got != exitAborted {
+				t.Errorf("exitCode = %d, want %d", got, exitAborted)
+			}
+		})
+	}
+}
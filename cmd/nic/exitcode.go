@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// Process exit codes. They are part of the CLI contract (see
+// docs/cli-reference.md) so scripts and CI can branch on the failure class
+// without parsing log output.
+const (
+	exitOK             = 0
+	exitError          = 1
+	exitConfig         = 2
+	exitChangesPending = 3
+	exitCredentials    = 4
+	exitAborted        = 5
+
+	// exitInterrupted follows the shell convention of 128+SIGINT.
+	exitInterrupted = 130
+)
+
+// exitCodeError attaches an exit code to errors raised by the CLI itself
+// (e.g. config file discovery) that have no sentinel in the library.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// withExitCode wraps err so exitCode reports code for it. A nil err stays nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{code: code, err: err}
+}
+
+// exitCode maps the error returned by a command to the process exit code.
+// The checks are ordered so the most specific classification wins.
+func exitCode(err error) int {
+	var codeErr *exitCodeError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.As(err, &codeErr):
+		return codeErr.code
+	case errors.Is(err, nic.ErrAborted):
+		return exitAborted
+	case errors.Is(err, config.ErrInvalidConfig):
+		return exitConfig
+	case errors.Is(err, cluster.ErrCredentials):
+		return exitCredentials
+	case errors.Is(err, cluster.ErrChangesPending):
+		return exitChangesPending
+	default:
+		return exitError
+	}
+}
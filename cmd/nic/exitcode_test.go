@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitOK},
+		{name: "generic failure", err: errors.New("tofu apply failed"), want: exitError},
+		{name: "invalid config", err: fmt.Errorf("%w: project_name is required", config.ErrInvalidConfig), want: exitConfig},
+		{name: "changes pending", err: fmt.Errorf("deploy infrastructure: %w", cluster.ErrChangesPending), want: exitChangesPending},
+		{name: "credentials", err: fmt.Errorf("create state bucket: %w", cluster.ErrCredentials), want: exitCredentials},
+		{name: "aborted", err: fmt.Errorf("%w: user did not type 'yes'", nic.ErrAborted), want: exitAborted},
+		{name: "interrupted", err: fmt.Errorf("tofu apply: %w", context.Canceled), want: exitInterrupted},
+		{name: "explicit code", err: withExitCode(exitConfig, errors.New("no config file found")), want: exitConfig},
+		{name: "explicit code wins over wrapped sentinel", err: withExitCode(exitAborted, cluster.ErrCredentials), want: exitAborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode_ConfigErrors(t *testing.T) {
+	t.Run("no config file discovered", func(t *testing.T) {
+		chdirTemp(t)
+		_, err := resolveConfigFile("")
+		if got := exitCode(err); got != exitConfig {
+			t.Errorf("exitCode = %d, want %d (err: %v)", got, exitConfig, err)
+		}
+	})
+
+	t.Run("malformed YAML", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte("cluster: [unterminated"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, err := config.ParseConfig(context.Background(), path)
+		if got := exitCode(err); got != exitConfig {
+			t.Errorf("exitCode = %d, want %d (err: %v)", got, exitConfig, err)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+)
+
+var (
+	exportConfigFile string
+	exportOutputFile string
+	exportRegion     string
+
+	exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export a portable description of the deployment",
+		Long: `Export the effective configuration of a Nebari deployment as a versioned
+YAML document that can be passed to 'nic deploy'. With --region, the cluster
+configuration is rewritten for a disaster-recovery rebuild in another region:
+region-scoped references such as availability zones, existing network IDs and
+KMS keys are removed.
+
+Only the configuration is exported. The live cluster is not queried, so state
+that exists only in the cloud account (for example resources changed outside
+NIC) is not part of the document.`,
+		RunE: runExport,
+	}
+)
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = exportCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
+	exportCmd.Flags().StringVarP(&exportOutputFile, "output", "o", "", "Path to output file (defaults to stdout)")
+	exportCmd.Flags().StringVar(&exportRegion, "region", "", "Rewrite the cluster config for a rebuild in this region")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	configFile, err := resolveConfigFile(exportConfigFile)
+	if err != nil {
+		return err
+	}
+
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "cmd.export")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("config.file", configFile),
+		attribute.String("region", exportRegion),
+	)
+
+	cfg, err := config.ParseConfig(ctx, configFile)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	client, err := nic.NewClient(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	ctx, cleanup := nic.StartSlogHandler(ctx, slog.Default())
+	defer cleanup()
+
+	doc, err := client.Export(ctx, cfg, nic.ExportOptions{Region: exportRegion})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if exportOutputFile != "" {
+		if err := os.WriteFile(exportOutputFile, doc, 0600); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("write export file %q: %w", exportOutputFile, err)
+		}
+		slog.Info("Export written successfully", "file", exportOutputFile)
+		return nil
+	}
+
+	if _, err := os.Stdout.Write(doc); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("write export to stdout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+)
+
+var (
+	infoConfigFile string
+
+	infoCmd = &cobra.Command{
+		Use:   "info",
+		Short: "Show how to access the deployed Nebari services",
+		Long: `Print the URLs of the services NIC deploys (Argo CD, Keycloak and, where
+enabled, Longhorn), their admin users, the secrets holding the admin
+passwords and the command to fetch the cluster kubeconfig.
+
+The information is derived from the configuration file; no cloud or cluster
+calls are made and passwords are never printed.`,
+		RunE: runInfo,
+	}
+)
+
+func init() {
+	infoCmd.Flags().StringVarP(&infoConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = infoCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	configFile, err := resolveConfigFile(infoConfigFile)
+	if err != nil {
+		return err
+	}
+
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "cmd.info")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("config.file", configFile))
+
+	cfg, err := config.ParseConfig(ctx, configFile)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	client, err := nic.NewClient(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	ctx, cleanup := nic.StartSlogHandler(ctx, slog.Default())
+	defer cleanup()
+
+	services, err := client.AccessInfo(ctx, cfg)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	cleanup()
+
+	printAccessInfo(services, configFile)
+	return nil
+}
+
+// printAccessInfo prints the consolidated access summary for services
+func printAccessInfo(services []argocd.ServiceAccess, configFile string) {
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Println("  ACCESS INFORMATION")
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Println()
+	for _, s := range services {
+		fmt.Printf("  %s\n", s.Name)
+		fmt.Printf("    URL:      %s\n", s.URL)
+		if s.SecretName != "" {
+			fmt.Printf("    Username: %s\n", s.Username)
+			fmt.Println("    Password:")
+			fmt.Printf("      kubectl -n %s get secret %s \\\n", s.SecretNamespace, s.SecretName)
+			fmt.Printf("        -o jsonpath=\"{.data.%s}\" | base64 -d\n", s.SecretKey)
+		} else {
+			fmt.Println("    Sign in through Keycloak")
+		}
+		fmt.Println()
+	}
+	fmt.Println("  Kubeconfig:")
+	fmt.Printf("    nic kubeconfig -f %s -o kubeconfig.yaml\n", configFile)
+	fmt.Println()
+	fmt.Println("  URLs resolve once DNS points at the cluster load balancer.")
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Println()
+}
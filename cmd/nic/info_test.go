@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestPrintAccessInfo(t *testing.T) {
+	cfg := &config.NebariConfig{Domain: "nebari.example.com"}
+	services := argocd.AccessInfo(cfg, cluster.InfraSettings{LonghornEnabled: true})
+
+	output := captureStdout(func() {
+		printAccessInfo(services, "nebari-config.yaml")
+	})
+
+	for _, want := range []string{
+		"https://argocd.nebari.example.com",
+		"https://keycloak.nebari.example.com",
+		"https://longhorn.nebari.example.com",
+		"kubectl -n argocd get secret argocd-initial-admin-secret",
+		`-o jsonpath="{.data.password}"`,
+		"kubectl -n keycloak get secret keycloak-admin-credentials",
+		`-o jsonpath="{.data.admin-password}"`,
+		"Username: admin",
+		"nic kubeconfig -f nebari-config.yaml",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -10,12 +10,16 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/kubeconfig"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
 )
 
 var (
 	kubeconfigConfigFile string
 	kubeconfigOutputFile string
+	kubeconfigContext    string
+	kubeconfigProxyURL   string
+	kubeconfigCAFile     string
 
 	kubeconfigCmd = &cobra.Command{
 		Use:   "kubeconfig",
@@ -23,14 +27,23 @@ var (
 		Long: `Generate and output the kubeconfig file for accessing the Kubernetes
 cluster deployed by Nebari. This command retrieves the necessary cluster
 information and constructs a kubeconfig file that can be used with kubectl
-or other Kubernetes clients.`,
+or other Kubernetes clients.
+
+The generated kubeconfig can be adjusted for the consumer: --context renames
+its context, --proxy-url routes API server traffic through a proxy and
+--certificate-authority adds a CA bundle (e.g. of a TLS-intercepting proxy)
+to the cluster's own CA.`,
 		RunE: runKubeconfig,
 	}
 )
 
 func init() {
 	kubeconfigCmd.Flags().StringVarP(&kubeconfigConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = kubeconfigCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
 	kubeconfigCmd.Flags().StringVarP(&kubeconfigOutputFile, "output", "o", "", "Path to output kubeconfig file (defaults to stdout)")
+	kubeconfigCmd.Flags().StringVar(&kubeconfigContext, "context", "", "Name of the kubeconfig context (defaults to the provider's)")
+	kubeconfigCmd.Flags().StringVar(&kubeconfigProxyURL, "proxy-url", "", "Proxy URL (http, https or socks5) for reaching the API server")
+	kubeconfigCmd.Flags().StringVar(&kubeconfigCAFile, "certificate-authority", "", "Path to a PEM CA bundle to trust in addition to the cluster CA")
 }
 
 func runKubeconfig(cmd *cobra.Command, args []string) error {
@@ -62,7 +75,13 @@ func runKubeconfig(cmd *cobra.Command, args []string) error {
 	ctx, cleanup := nic.StartSlogHandler(ctx, slog.Default())
 	defer cleanup()
 
-	kubeconfigBytes, err := client.Kubeconfig(ctx, cfg)
+	opts, err := kubeconfigOptions(kubeconfigContext, kubeconfigProxyURL, kubeconfigCAFile)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	kubeconfigBytes, err := client.Kubeconfig(ctx, cfg, opts...)
 	if err != nil {
 		span.RecordError(err)
 		return err
@@ -83,3 +102,22 @@ func runKubeconfig(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// kubeconfigOptions turns the post-processing flags into kubeconfig options.
+func kubeconfigOptions(contextName, proxyURL, caFile string) ([]kubeconfig.Option, error) {
+	var opts []kubeconfig.Option
+	if contextName != "" {
+		opts = append(opts, kubeconfig.WithContextName(contextName))
+	}
+	if proxyURL != "" {
+		opts = append(opts, kubeconfig.WithProxyURL(proxyURL))
+	}
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read certificate authority %q: %w", caFile, err)
+		}
+		opts = append(opts, kubeconfig.WithCABundle(caPEM))
+	}
+	return opts, nil
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/redact"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// Values accepted by --log-format.
+const (
+	logFormatAuto   = "auto"
+	logFormatJSON   = "json"
+	logFormatPretty = "pretty"
+)
+
+// logFormat selects the log handler (--log-format). "auto" picks pretty
+// output on a terminal and JSON everywhere else.
+var logFormat = logFormatAuto
+
+// newLogHandler returns the slog handler for format writing to w. isTTY
+// reports whether w is an interactive terminal and only matters for "auto".
+// Both handlers redact sensitive attributes.
+func newLogHandler(w io.Writer, format string, isTTY bool) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: redact.ReplaceAttr,
+	}
+	switch format {
+	case logFormatAuto:
+		if isTTY {
+			return newPrettyHandler(w, opts, os.Getenv("NO_COLOR") == ""), nil
+		}
+		return slog.NewJSONHandler(w, opts), nil
+	case logFormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	case logFormatPretty:
+		return newPrettyHandler(w, opts, isTTY && os.Getenv("NO_COLOR") == ""), nil
+	default:
+		return nil, fmt.Errorf("invalid --log-format %q (must be %s, %s or %s)", format, logFormatAuto, logFormatJSON, logFormatPretty)
+	}
+}
+
+// isTerminal reports whether f is a character device, which is how a
+// terminal shows up on every platform NIC supports.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// completeLogFormat completes --log-format with its accepted values.
+func completeLogFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{logFormatAuto, logFormatJSON, logFormatPretty}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// ANSI escape sequences used by prettyHandler.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// levelStyle is the symbol and color a record is rendered with.
+type levelStyle struct {
+	symbol string
+	color  string
+}
+
+// prettyHandler is a slog.Handler for people watching a terminal: one line
+// per record with a timestamp, a level symbol, the message and key=value
+// attributes. Status updates carry their status.Level in the "status"
+// attribute (see nic.SlogHandler), which picks the symbol for levels slog
+// itself cannot express, such as success and progress.
+type prettyHandler struct {
+	w     io.Writer
+	mu    *sync.Mutex
+	opts  *slog.HandlerOptions
+	color bool
+
+	// prefix is the pre-rendered output of WithAttrs, groups the open
+	// WithGroup names.
+	prefix string
+	groups []string
+}
+
+func newPrettyHandler(w io.Writer, opts *slog.HandlerOptions, color bool) *prettyHandler {
+	return &prettyHandler{w: w, mu: &sync.Mutex{}, opts: opts, color: color}
+}
+
+func (h *prettyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+func (h *prettyHandler) Handle(_ context.Context, r slog.Record) error {
+	var attrs bytes.Buffer
+	attrs.WriteString(h.prefix)
+	statusLevel := ""
+	r.Attrs(func(a slog.Attr) bool {
+		if len(h.groups) == 0 && a.Key == "status" {
+			statusLevel = a.Value.String()
+			return true
+		}
+		// The raw machine-readable event is only useful in JSON output.
+		if a.Key == status.MetadataKeyDetail {
+			return true
+		}
+		h.appendAttr(&attrs, h.groups, a)
+		return true
+	})
+
+	style := styleFor(r.Level, status.Level(statusLevel))
+
+	var buf bytes.Buffer
+	if !r.Time.IsZero() {
+		buf.WriteString(h.paint(ansiDim, r.Time.Format(time.TimeOnly)))
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(h.paint(style.color, style.symbol))
+	buf.WriteByte(' ')
+	buf.WriteString(r.Message)
+	if attrs.Len() > 0 {
+		buf.WriteString(h.paint(ansiDim, attrs.String()))
+	}
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *prettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	var buf bytes.Buffer
+	buf.WriteString(h.prefix)
+	for _, a := range attrs {
+		h.appendAttr(&buf, h.groups, a)
+	}
+	h2 := *h
+	h2.prefix = buf.String()
+	return &h2
+}
+
+func (h *prettyHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &h2
+}
+
+// appendAttr writes a as " key=value", flattening groups into dotted keys and
+// applying ReplaceAttr (redaction) to every leaf.
+func (h *prettyHandler) appendAttr(buf *bytes.Buffer, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup && h.opts.ReplaceAttr != nil {
+		a = h.opts.ReplaceAttr(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		inner := groups
+		if a.Key != "" {
+			inner = append(groups[:len(groups):len(groups)], a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			h.appendAttr(buf, inner, ga)
+		}
+		return
+	}
+
+	buf.WriteByte(' ')
+	for _, g := range groups {
+		buf.WriteString(g)
+		buf.WriteByte('.')
+	}
+	buf.WriteString(a.Key)
+	buf.WriteByte('=')
+	value := a.Value.String()
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = fmt.Sprintf("%q", value)
+	}
+	buf.WriteString(value)
+}
+
+// paint wraps s in color when colored output is enabled.
+func (h *prettyHandler) paint(color, s string) string {
+	if !h.color || color == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// styleFor picks the symbol and color for a record. The status level, when
+// present, wins over the slog level because it distinguishes success and
+// progress from plain info.
+func styleFor(level slog.Level, statusLevel status.Level) levelStyle {
+	switch statusLevel {
+	case status.LevelSuccess:
+		return levelStyle{symbol: "✓", color: ansiGreen}
+	case status.LevelProgress:
+		return levelStyle{symbol: "→", color: ansiCyan}
+	case status.LevelWarning:
+		return levelStyle{symbol: "!", color: ansiYellow}
+	case status.LevelError:
+		return levelStyle{symbol: "✗", color: ansiRed}
+	}
+	switch {
+	case level >= slog.LevelError:
+		return levelStyle{symbol: "✗", color: ansiRed}
+	case level >= slog.LevelWarn:
+		return levelStyle{symbol: "!", color: ansiYellow}
+	default:
+		return levelStyle{symbol: "•"}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/redact"
+)
+
+func TestNewLogHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		isTTY     bool
+		wantJSON  bool
+		errSubstr string
+	}{
+		{name: "auto non-tty is json", format: logFormatAuto, wantJSON: true},
+		{name: "auto tty is pretty", format: logFormatAuto, isTTY: true},
+		{name: "json forced on tty", format: logFormatJSON, isTTY: true, wantJSON: true},
+		{name: "pretty forced when piped", format: logFormatPretty},
+		{name: "invalid", format: "xml", errSubstr: "invalid --log-format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", "")
+			var buf bytes.Buffer
+			handler, err := newLogHandler(&buf, tt.format, tt.isTTY)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newLogHandler() error = %v", err)
+			}
+
+			slog.New(handler).Info("hello", "api_token", "cf-123")
+			out := buf.String()
+			if strings.Contains(out, "cf-123") {
+				t.Errorf("log output leaked secret: %s", out)
+			}
+			var record map[string]any
+			isJSON := json.Unmarshal(buf.Bytes(), &record) == nil
+			if isJSON != tt.wantJSON {
+				t.Errorf("JSON output = %v, want %v: %s", isJSON, tt.wantJSON, out)
+			}
+		})
+	}
+}
+
+func TestPrettyHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		color bool
+		log   func(*slog.Logger)
+		want  []string
+		skip  []string
+	}{
+		{
+			name: "success status",
+			log:  func(l *slog.Logger) { l.Info("Cluster ready", "status", "success", "resource", "cluster") },
+			want: []string{"✓ Cluster ready", "resource=cluster"},
+			skip: []string{"status="},
+		},
+		{
+			name: "progress status",
+			log:  func(l *slog.Logger) { l.Info("Applying", "status", "progress") },
+			want: []string{"→ Applying"},
+		},
+		{
+			name: "warn level",
+			log:  func(l *slog.Logger) { l.Warn("Slow", "step", "dns") },
+			want: []string{"! Slow", "step=dns"},
+		},
+		{
+			name: "error level quotes values",
+			log:  func(l *slog.Logger) { l.Error("Command execution failed", "error", "boom now") },
+			want: []string{"✗ Command execution failed", `error="boom now"`},
+		},
+		{
+			name: "groups and redaction",
+			log: func(l *slog.Logger) {
+				l.WithGroup("dns").Info("Configured", "api_token", "cf-123", "zone", "example.com")
+			},
+			want: []string{"dns.api_token=***", "dns.zone=example.com"},
+			skip: []string{"cf-123"},
+		},
+		{
+			name:  "colored",
+			color: true,
+			log:   func(l *slog.Logger) { l.Error("Failed") },
+			want:  []string{ansiRed + "✗" + ansiReset + " Failed"},
+		},
+		{
+			name: "detail is dropped",
+			log:  func(l *slog.Logger) { l.Info("tofu", "detail", json.RawMessage(`{"a":1}`)) },
+			skip: []string{"detail"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := &slog.HandlerOptions{Level: slog.LevelInfo, ReplaceAttr: redact.ReplaceAttr}
+			tt.log(slog.New(newPrettyHandler(&buf, opts, tt.color)))
+
+			out := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q: %q", want, out)
+				}
+			}
+			for _, skip := range tt.skip {
+				if strings.Contains(out, skip) {
+					t.Errorf("output unexpectedly contains %q: %q", skip, out)
+				}
+			}
+			if !tt.color && strings.Contains(out, "\x1b[") {
+				t.Errorf("uncolored output contains escape codes: %q", out)
+			}
+		})
+	}
+}
+
+// An invalid --log-format is a runtime error reported once by main(), not a
+// misuse that prints the usage block.
+func TestInvalidLogFormatSkipsUsage(t *testing.T) {
+	prevFormat, prevReached := logFormat, reachedRunE
+	t.Cleanup(func() {
+		logFormat, reachedRunE = prevFormat, prevReached
+		versionCmd.SilenceErrors, versionCmd.SilenceUsage = false, false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"--log-format", "xml", "version"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --log-format") {
+		t.Fatalf("Execute() error = %v, want invalid --log-format", err)
+	}
+	if !reachedRunE {
+		t.Error("reachedRunE = false, want true so main() logs the error")
+	}
+	if strings.Contains(out.String(), "Usage:") {
+		t.Errorf("output contains the usage block:\n%s", out.String())
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -10,36 +11,61 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/secretenv"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/telemetry"
 )
 
 // reachedRunE reports whether cobra parsed flags and validated args
-// successfully, i.e. PersistentPreRun ran and a command's RunE is about to (or
+// successfully, i.e. PersistentPreRunE ran and a command's RunE is about to (or
 // did) execute. main() uses it to distinguish runtime failures (which we log)
 // from usage-class errors (bad flag, unknown command, wrong number of args),
-// which surface before PersistentPreRun and are already printed by cobra.
+// which surface before PersistentPreRunE and are already printed by cobra.
 var reachedRunE bool
 
+// noTelemetry disables the CLI's own OpenTelemetry traces (--no-telemetry).
+var noTelemetry bool
+
+// shutdownTelemetry flushes and stops the tracer provider installed in
+// PersistentPreRunE. It is nil until telemetry has been set up.
+var shutdownTelemetry func(context.Context) error
+
 var rootCmd = &cobra.Command{
 	Use:   "nic",
 	Short: "Nebari Infrastructure Core - Cloud infrastructure management for Nebari",
 	Long: `Nebari Infrastructure Core (NIC) is a standalone CLI tool that manages
 cloud infrastructure for Nebari using native cloud SDKs with declarative semantics.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-		}))
-		slog.SetDefault(logger)
-
-		// PersistentPreRun runs only after cobra has parsed flags and validated
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// PersistentPreRunE runs only after cobra has parsed flags and validated
 		// args. Any failure from here on is a runtime error and not a misuse,
 		// so silence cobra's own error/usage output and let main() report it
 		// once via slog. Usage-class errors (bad flag, unknown command, wrong
 		// number of args) surface before this hook runs, so cobra still prints
-		// the error and usage block for those.
+		// the error and usage block for those. This comes first so that a bad
+		// --log-format value is reported without the usage block too.
 		cmd.SilenceErrors = true
 		cmd.SilenceUsage = true
 		reachedRunE = true
+
+		handler, err := newLogHandler(os.Stderr, logFormat, isTerminal(os.Stderr))
+		if err != nil {
+			return err
+		}
+		slog.SetDefault(slog.New(handler))
+
+		// Resolve file-mounted credentials (e.g. AWS_SECRET_ACCESS_KEY_FILE)
+		// into the environment before any SDK or subprocess reads it.
+		if err := secretenv.Export(secretenv.CredentialVars...); err != nil {
+			return withExitCode(exitCredentials, err)
+		}
+
+		// Telemetry is set up here rather than in main() so --no-telemetry has
+		// been parsed before any provider is installed.
+		_, shutdown, err := telemetry.Setup(cmd.Context(), telemetry.Options{Disabled: noTelemetry})
+		if err != nil {
+			return fmt.Errorf("failed to setup telemetry: %w", err)
+		}
+		shutdownTelemetry = shutdown
+		return nil
 	},
 }
 
@@ -48,11 +74,20 @@ func init() {
 	// This allows users to optionally use .env for local development
 	_ = godotenv.Load()
 
+	rootCmd.PersistentFlags().BoolVar(&noTelemetry, "no-telemetry", false, "Disable OpenTelemetry tracing (same as NIC_TELEMETRY=off)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logFormatAuto, "Log output format: auto (pretty on a terminal, JSON otherwise), json or pretty")
+	_ = rootCmd.RegisterFlagCompletionFunc("log-format", completeLogFormat)
+
 	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(destroyCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(kubeconfigCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(scaleCmd)
+	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(outputCmd)
 }
 
 func main() {
@@ -68,22 +103,16 @@ func main() {
 		cancel()
 	}()
 
-	_, shutdown, err := telemetry.Setup(ctx)
-	if err != nil {
-		slog.Error("Failed to setup telemetry", "error", err)
-		os.Exit(1) //nolint:gocritic // TODO: refactor to run() pattern to allow defers to run
-	}
-	defer func() {
-		shutdownCtx := context.Background()
-		if err := shutdown(shutdownCtx); err != nil {
+	err := rootCmd.ExecuteContext(ctx)
+	if shutdownTelemetry != nil {
+		if err := shutdownTelemetry(context.Background()); err != nil {
 			slog.Error("Failed to shutdown telemetry", "error", err)
 		}
-	}()
-
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
+	}
+	if err != nil {
 		if ctx.Err() == context.Canceled {
 			slog.Info("Shutdown complete")
-			os.Exit(130)
+			os.Exit(exitInterrupted)
 		}
 		// Log only runtime failures (those that occur once RunE is reached) and
 		// leave usage-class errors (bad flag, unknown command, bad args) to
@@ -91,6 +120,6 @@ func main() {
 		if reachedRunE {
 			slog.Error("Command execution failed", "error", err)
 		}
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
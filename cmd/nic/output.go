@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+)
+
+var (
+	outputConfigFile     string
+	outputKubeconfigFile string
+	outputFormat         string
+
+	outputCmd = &cobra.Command{
+		Use:   "output",
+		Short: "Print the kubeconfig, service URLs and load balancer address of a deployed cluster",
+		Long: `Print what a deployed cluster exposes: its kubeconfig, the URLs of the
+foundational services (Keycloak, Argo CD and, where enabled, Longhorn) and the
+address of the gateway load balancer.
+
+Nothing is reconciled: the kubeconfig comes from the provider and the load
+balancer address is read from the cluster as it is now.
+
+In text mode the kubeconfig is written to stdout, and the URLs and address to
+stderr, so the output can be redirected to a kubeconfig file. With
+--kubeconfig the kubeconfig is written to that file and the rest to stdout.
+With --output json a single JSON document is printed, holding the kubeconfig
+unless --kubeconfig is set.`,
+		Example: `  nic output > kubeconfig.yaml
+  nic output --kubeconfig kubeconfig.yaml
+  nic output --output json | jq -r .load_balancer.hostname`,
+		RunE: runOutput,
+	}
+)
+
+func init() {
+	outputCmd.Flags().StringVarP(&outputConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = outputCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
+	outputCmd.Flags().StringVar(&outputKubeconfigFile, "kubeconfig", "", "Path to write the kubeconfig to (defaults to stdout)")
+	outputCmd.Flags().StringVar(&outputFormat, "output", outputFormatText, "Output format: text or json")
+	_ = outputCmd.RegisterFlagCompletionFunc("output", completeOutputFormat)
+}
+
+func runOutput(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+
+	configFile, err := resolveConfigFile(outputConfigFile)
+	if err != nil {
+		return err
+	}
+
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "cmd.output")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("config.file", configFile))
+
+	cfg, err := config.ParseConfig(ctx, configFile)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	client, err := nic.NewClient(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	ctx, cleanup := nic.StartSlogHandler(ctx, slog.Default())
+	defer cleanup()
+
+	out, err := client.Outputs(ctx, cfg)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	cleanup()
+
+	if outputKubeconfigFile != "" {
+		if err := os.WriteFile(outputKubeconfigFile, out.Kubeconfig, 0600); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("write kubeconfig file %q: %w", outputKubeconfigFile, err)
+		}
+	}
+
+	if outputFormat == outputFormatJSON {
+		if err := printOutputsJSON(os.Stdout, out, outputKubeconfigFile); err != nil {
+			span.RecordError(err)
+			return err
+		}
+		return nil
+	}
+
+	summary := io.Writer(os.Stdout)
+	if outputKubeconfigFile == "" {
+		if _, err := os.Stdout.Write(out.Kubeconfig); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("write kubeconfig to stdout: %w", err)
+		}
+		summary = os.Stderr
+	}
+	printOutputs(summary, out, outputKubeconfigFile)
+	return nil
+}
+
+// outputsJSON is the --output json document of nic output.
+type outputsJSON struct {
+	// Kubeconfig is omitted when it was written to KubeconfigPath.
+	Kubeconfig     string            `json:"kubeconfig,omitempty"`
+	KubeconfigPath string            `json:"kubeconfig_path,omitempty"`
+	Services       []serviceJSON     `json:"services"`
+	LoadBalancer   *loadBalancerJSON `json:"load_balancer"`
+}
+
+type serviceJSON struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type loadBalancerJSON struct {
+	Hostname string `json:"hostname,omitempty"`
+	IP       string `json:"ip,omitempty"`
+}
+
+// printOutputsJSON writes out as indented JSON. The kubeconfig is included
+// only when kubeconfigPath is empty.
+func printOutputsJSON(w io.Writer, out *nic.Outputs, kubeconfigPath string) error {
+	doc := outputsJSON{KubeconfigPath: kubeconfigPath, Services: []serviceJSON{}}
+	if kubeconfigPath == "" {
+		doc.Kubeconfig = string(out.Kubeconfig)
+	}
+	for _, s := range out.Services {
+		doc.Services = append(doc.Services, serviceJSON{Name: s.Name, URL: s.URL})
+	}
+	if out.LoadBalancer != nil {
+		doc.LoadBalancer = &loadBalancerJSON{Hostname: out.LoadBalancer.Hostname, IP: out.LoadBalancer.IP}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode outputs: %w", err)
+	}
+	return nil
+}
+
+// printOutputs writes the service URLs and load balancer address of out,
+// and where the kubeconfig was written when kubeconfigPath is set.
+func printOutputs(w io.Writer, out *nic.Outputs, kubeconfigPath string) {
+	if kubeconfigPath != "" {
+		_, _ = fmt.Fprintf(w, "Kubeconfig:     %s\n", kubeconfigPath)
+	}
+	for _, s := range out.Services {
+		_, _ = fmt.Fprintf(w, "%-15s %s\n", s.Name+":", s.URL)
+	}
+	// Infra-only deployments have no services and no gateway.
+	switch {
+	case len(out.Services) == 0:
+	case out.LoadBalancer == nil:
+		_, _ = fmt.Fprintln(w, "Load balancer:  not available yet")
+	case out.LoadBalancer.Hostname != "":
+		_, _ = fmt.Fprintf(w, "Load balancer:  %s\n", out.LoadBalancer.Hostname)
+	default:
+		_, _ = fmt.Fprintf(w, "Load balancer:  %s\n", out.LoadBalancer.IP)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/endpoint"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+)
+
+func testOutputs() *nic.Outputs {
+	return &nic.Outputs{
+		Kubeconfig: []byte("apiVersion: v1\nkind: Config\n"),
+		Services: []argocd.ServiceAccess{
+			{Name: "Argo CD", URL: "https://argocd.nebari.example.com"},
+			{Name: "Keycloak", URL: "https://keycloak.nebari.example.com/auth"},
+		},
+		LoadBalancer: &endpoint.LoadBalancerEndpoint{Hostname: "abc123.us-west-2.elb.amazonaws.com"},
+	}
+}
+
+func TestPrintOutputs(t *testing.T) {
+	tests := []struct {
+		name           string
+		outputs        *nic.Outputs
+		kubeconfigPath string
+		want           []string
+	}{
+		{
+			name:    "load balancer hostname",
+			outputs: testOutputs(),
+			want:    []string{"https://argocd.nebari.example.com", "https://keycloak.nebari.example.com/auth", "abc123.us-west-2.elb.amazonaws.com"},
+		},
+		{
+			name:           "kubeconfig file",
+			outputs:        testOutputs(),
+			kubeconfigPath: "kubeconfig.yaml",
+			want:           []string{"Kubeconfig:     kubeconfig.yaml"},
+		},
+		{
+			name:    "load balancer pending",
+			outputs: &nic.Outputs{Services: testOutputs().Services},
+			want:    []string{"not available yet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printOutputs(&buf, tt.outputs, tt.kubeconfigPath)
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output should contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPrintOutputsJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		kubeconfigPath string
+		wantKubeconfig string
+	}{
+		{name: "kubeconfig inline", wantKubeconfig: "apiVersion: v1\nkind: Config\n"},
+		{name: "kubeconfig file", kubeconfigPath: "kubeconfig.yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := printOutputsJSON(&buf, testOutputs(), tt.kubeconfigPath); err != nil {
+				t.Fatalf("printOutputsJSON() error = %v", err)
+			}
+			var got outputsJSON
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+			}
+			if got.Kubeconfig != tt.wantKubeconfig || got.KubeconfigPath != tt.kubeconfigPath {
+				t.Errorf("kubeconfig = %q, kubeconfig_path = %q", got.Kubeconfig, got.KubeconfigPath)
+			}
+			if len(got.Services) != 2 || got.Services[1].URL != "https://keycloak.nebari.example.com/auth" {
+				t.Errorf("services = %+v", got.Services)
+			}
+			if got.LoadBalancer == nil || got.LoadBalancer.Hostname != "abc123.us-west-2.elb.amazonaws.com" {
+				t.Errorf("load_balancer = %+v", got.LoadBalancer)
+			}
+		})
+	}
+}
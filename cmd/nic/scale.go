@@ -0,0 +1,104 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+var (
+	scaleConfigFile string
+	scaleNodeGroup  string
+	scaleMin        int
+	scaleMax        int
+	scaleDesired    int
+
+	scaleCmd = &cobra.Command{
+		Use:   "scale",
+		Short: "Resize a node group without a full deploy",
+		Long: `Update the minimum, maximum, and desired size of a single node group
+without running a deploy, e.g. to scale development node groups to zero outside
+business hours. Only the flags you pass are changed. --max 0 scales the group
+to zero.
+
+The config file is not modified: the next 'nic deploy' restores the sizes it
+declares.`,
+		Example: `  nic scale --nodegroup user --max 0
+  nic scale --nodegroup user --min 1 --max 5 --desired 2`,
+		RunE: runScale,
+	}
+)
+
+func init() {
+	scaleCmd.Flags().StringVarP(&scaleConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = scaleCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
+	scaleCmd.Flags().StringVar(&scaleNodeGroup, "nodegroup", "", "Name of the node group to scale, as defined in the config (required)")
+	scaleCmd.Flags().IntVar(&scaleMin, "min", 0, "Minimum number of nodes")
+	scaleCmd.Flags().IntVar(&scaleMax, "max", 0, "Maximum number of nodes (0 scales the group to zero)")
+	scaleCmd.Flags().IntVar(&scaleDesired, "desired", 0, "Desired number of nodes")
+	_ = scaleCmd.MarkFlagRequired("nodegroup")
+	_ = scaleCmd.RegisterFlagCompletionFunc("nodegroup", completeNodeGroups)
+}
+
+func runScale(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	configFile, err := resolveConfigFile(scaleConfigFile)
+	if err != nil {
+		return err
+	}
+
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "cmd.scale")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("config.file", configFile),
+		attribute.String("node_group", scaleNodeGroup),
+	)
+
+	scaling := scalingFromFlags(cmd)
+
+	cfg, err := config.ParseConfig(ctx, configFile)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	client, err := nic.NewClient(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	ctx, cleanup := nic.StartSlogHandler(ctx, slog.Default())
+	defer cleanup()
+
+	if err := client.Scale(ctx, cfg, scaleNodeGroup, scaling); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// scalingFromFlags builds a NodeGroupScaling from the flags the user actually
+// passed, so an omitted flag leaves that size unchanged rather than zeroing it.
+func scalingFromFlags(cmd *cobra.Command) cluster.NodeGroupScaling {
+	var s cluster.NodeGroupScaling
+	if cmd.Flags().Changed("min") {
+		s.Min = &scaleMin
+	}
+	if cmd.Flags().Changed("max") {
+		s.Max = &scaleMax
+	}
+	if cmd.Flags().Changed("desired") {
+		s.Desired = &scaleDesired
+	}
+	return s
+}
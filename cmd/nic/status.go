@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+)
+
+// Values of the --output flag of the status and output commands.
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+var (
+	statusConfigFile string
+	statusOutput     string
+
+	statusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Report the live health of the cluster and foundational services",
+		Long: `Fetch the cluster kubeconfig from the provider and report, at this point in
+time, the Kubernetes version, how many nodes of each node group are ready and
+the sync and health status of every Argo CD Application NIC manages.
+
+Nothing is changed and nothing is waited for. The command exits with a
+non-zero code when a node is not ready or an Application is not Healthy and
+Synced.`,
+		Example: `  nic status
+  nic status --output json`,
+		RunE: runStatus,
+	}
+)
+
+func init() {
+	statusCmd.Flags().StringVarP(&statusConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	_ = statusCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
+	statusCmd.Flags().StringVar(&statusOutput, "output", outputFormatText, "Output format: text or json")
+	_ = statusCmd.RegisterFlagCompletionFunc("output", completeOutputFormat)
+}
+
+func completeOutputFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{outputFormatText, outputFormatJSON}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// validateOutputFormat rejects --output values other than text and json.
+func validateOutputFormat(format string) error {
+	if format != outputFormatText && format != outputFormatJSON {
+		return fmt.Errorf("invalid --output %q (must be %s or %s)", format, outputFormatText, outputFormatJSON)
+	}
+	return nil
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if err := validateOutputFormat(statusOutput); err != nil {
+		return err
+	}
+
+	configFile, err := resolveConfigFile(statusConfigFile)
+	if err != nil {
+		return err
+	}
+
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "cmd.status")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("config.file", configFile))
+
+	cfg, err := config.ParseConfig(ctx, configFile)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	client, err := nic.NewClient(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	ctx, cleanup := nic.StartSlogHandler(ctx, slog.Default())
+	defer cleanup()
+
+	st, err := client.Status(ctx, cfg)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	cleanup()
+
+	if statusOutput == outputFormatJSON {
+		if err := printStatusJSON(os.Stdout, st); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	} else {
+		printStatus(os.Stdout, st)
+	}
+
+	if !st.Healthy() {
+		err := errors.New("cluster is not healthy")
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// printStatusJSON writes st as indented JSON, with a top-level healthy field
+// for scripts.
+func printStatusJSON(w io.Writer, st *nic.ClusterStatus) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(struct {
+		Healthy bool `json:"healthy"`
+		*nic.ClusterStatus
+	}{st.Healthy(), st}); err != nil {
+		return fmt.Errorf("failed to encode status: %w", err)
+	}
+	return nil
+}
+
+// printStatus writes st as a human-readable report.
+func printStatus(w io.Writer, st *nic.ClusterStatus) {
+	_, _ = fmt.Fprintf(w, "Cluster (%s): Kubernetes %s\n", st.Provider, st.KubernetesVersion)
+
+	_, _ = fmt.Fprintln(w, "\nNode groups:")
+	for _, ng := range st.NodeGroups {
+		name := ng.Name
+		if name == "" {
+			name = "(no node group)"
+		}
+		_, _ = fmt.Fprintf(w, "  %-30s %d/%d ready\n", name, ng.Ready, ng.Total)
+	}
+
+	if len(st.Applications) > 0 {
+		_, _ = fmt.Fprintln(w, "\nApplications:")
+		for _, app := range st.Applications {
+			_, _ = fmt.Fprintf(w, "  %-30s %-12s %s\n", app.Name, orUnknown(app.Health), orUnknown(app.Sync))
+		}
+	}
+}
+
+// orUnknown returns s, or "Unknown" when Argo CD has not reported it yet.
+func orUnknown(s string) string {
+	if s == "" {
+		return "Unknown"
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/nic"
+)
+
+func testClusterStatus() *nic.ClusterStatus {
+	return &nic.ClusterStatus{
+		Provider:          "aws",
+		KubernetesVersion: "v1.31.2",
+		NodeGroups:        []nic.NodeGroupStatus{{Name: "general", Ready: 1, Total: 2}},
+		Applications: []argocd.ApplicationStatus{
+			{Name: "cert-manager", Health: "Healthy", Sync: "Synced"},
+			{Name: "keycloak"},
+		},
+	}
+}
+
+func TestPrintStatus(t *testing.T) {
+	var buf bytes.Buffer
+	printStatus(&buf, testClusterStatus())
+
+	for _, want := range []string{
+		"Cluster (aws): Kubernetes v1.31.2",
+		"general",
+		"1/2 ready",
+		"cert-manager",
+		"Healthy",
+		"keycloak",
+		"Unknown",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestPrintStatusJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printStatusJSON(&buf, testClusterStatus()); err != nil {
+		t.Fatalf("printStatusJSON() error = %v", err)
+	}
+
+	var got struct {
+		Healthy           bool   `json:"healthy"`
+		KubernetesVersion string `json:"kubernetes_version"`
+		NodeGroups        []struct {
+			Name  string `json:"name"`
+			Ready int    `json:"ready"`
+		} `json:"node_groups"`
+		Applications []struct {
+			Name   string `json:"name"`
+			Health string `json:"health"`
+		} `json:"applications"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got.Healthy {
+		t.Error("healthy = true, want false")
+	}
+	if got.KubernetesVersion != "v1.31.2" || len(got.NodeGroups) != 1 || len(got.Applications) != 2 {
+		t.Errorf("unexpected status: %+v", got)
+	}
+}
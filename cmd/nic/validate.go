@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
 
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel"
@@ -13,19 +14,30 @@ import (
 
 var (
 	validateConfigFile string
+	validateShowConfig bool
+	validateFormat     string
 
 	validateCmd = &cobra.Command{
 		Use:   "validate",
 		Short: "Validate configuration file",
 		Long: `Validate the nebari-config.yaml file without deploying any infrastructure.
 This command checks that the configuration file is properly formatted and contains
-all required fields.`,
+all required fields.
+
+With --show-config, the validated configuration is printed instead of the
+summary, with secret values redacted, so you can confirm exactly what NIC
+will act on.`,
 		RunE: runValidate,
 	}
 )
 
 func init() {
 	validateCmd.Flags().StringVarP(&validateConfigFile, "file", "f", "", "Path to nebari-config.yaml file (auto-discovered if omitted)")
+	validateCmd.Flags().BoolVar(&validateShowConfig, "show-config", false, "Print the effective configuration with secrets redacted")
+	validateCmd.Flags().StringVar(&validateFormat, "format", nic.ConfigFormatYAML, "Output format for --show-config (yaml or json)")
+	_ = validateCmd.RegisterFlagCompletionFunc("file", completeConfigFile)
+	_ = validateCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{nic.ConfigFormatYAML, nic.ConfigFormatJSON}, cobra.ShellCompDirectiveNoFileComp))
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
@@ -40,7 +52,10 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	ctx, span := tracer.Start(ctx, "cmd.validate")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("config.file", configFile))
+	span.SetAttributes(
+		attribute.String("config.file", configFile),
+		attribute.Bool("show_config", validateShowConfig),
+	)
 
 	cfg, err := config.ParseConfig(ctx, configFile)
 	if err != nil {
@@ -53,14 +68,31 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		span.RecordError(err)
 		return err
 	}
+	if validateShowConfig {
+		out, err := client.EffectiveConfig(ctx, cfg, validateFormat)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(out)
+		return err
+	}
+
+	ctx, cleanup := nic.StartSlogHandler(ctx, slog.Default())
+	defer cleanup()
+
 	if err := client.Validate(ctx, cfg); err != nil {
 		span.RecordError(err)
 		return err
 	}
+	cleanup()
 
 	fmt.Printf("✓ Configuration file is valid\n")
 	fmt.Printf("  Provider: %s\n", cfg.Cluster.ProviderName())
 	fmt.Printf("  Project: %s\n", cfg.ProjectName)
+	if cfg.Environment != "" {
+		fmt.Printf("  Environment: %s\n", cfg.Environment)
+	}
 
 	return nil
 }
@@ -0,0 +1,152 @@
+// Package apitiming records how long individual cloud API operations take so
+// slow deploys can be attributed to provider throttling or to NIC itself.
+//
+// Provider SDK middleware calls Record for every operation. Each call is
+// exported as OTel metrics (a duration histogram and an error counter), added
+// as an event on the active span, and, when the context carries a Recorder,
+// aggregated per operation for an end-of-run summary.
+package apitiming
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OperationStats aggregates every call of one service operation.
+type OperationStats struct {
+	// Service is the cloud service, e.g. "EC2".
+	Service string
+	// Operation is the API operation, e.g. "DescribeVpcs".
+	Operation string
+	// Calls is the number of times the operation was called.
+	Calls int
+	// Errors is the number of calls that returned an error.
+	Errors int
+	// Total is the summed duration of all calls, retries included.
+	Total time.Duration
+	// Max is the duration of the slowest single call.
+	Max time.Duration
+}
+
+// Name returns "Service.Operation".
+func (s OperationStats) Name() string {
+	return s.Service + "." + s.Operation
+}
+
+// Recorder aggregates API timings for one run. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	stats map[string]*OperationStats
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{stats: make(map[string]*OperationStats)}
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context whose API calls are aggregated into r.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the Recorder attached to ctx, or nil.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Record reports one completed API call: it updates the OTel metrics, adds a
+// "cloud_api_call" event to the span in ctx, and aggregates the call into the
+// context's Recorder if there is one.
+func Record(ctx context.Context, service, operation string, d time.Duration, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("cloud.service", service),
+		attribute.String("cloud.operation", operation),
+	}
+
+	inst := instruments()
+	inst.duration.Record(ctx, d.Seconds(), metric.WithAttributes(attrs...))
+	if err != nil {
+		inst.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	eventAttrs := append(attrs,
+		attribute.Int64("duration_ms", d.Milliseconds()),
+		attribute.Bool("error", err != nil),
+	)
+	trace.SpanFromContext(ctx).AddEvent("cloud_api_call", trace.WithAttributes(eventAttrs...))
+
+	if r := FromContext(ctx); r != nil {
+		r.add(service, operation, d, err)
+	}
+}
+
+func (r *Recorder) add(service, operation string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := service + "." + operation
+	s, ok := r.stats[key]
+	if !ok {
+		s = &OperationStats{Service: service, Operation: operation}
+		r.stats[key] = s
+	}
+	s.Calls++
+	if err != nil {
+		s.Errors++
+	}
+	s.Total += d
+	s.Max = max(s.Max, d)
+}
+
+// Slowest returns up to n operations ordered by total time spent, slowest
+// first. Ties are ordered by name so the result is stable.
+func (r *Recorder) Slowest(n int) []OperationStats {
+	r.mu.Lock()
+	all := make([]OperationStats, 0, len(r.stats))
+	for _, s := range r.stats {
+		all = append(all, *s)
+	}
+	r.mu.Unlock()
+
+	slices.SortFunc(all, func(a, b OperationStats) int {
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name(), b.Name())
+	})
+	if len(all) > n {
+		all = all[:n]
+	}
+	return all
+}
+
+type apiInstruments struct {
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// instruments builds the OTel instruments from the global meter provider on
+// every call. Creation is cheap and cached by the SDK, and resolving per call
+// picks up a provider installed after the first API call.
+func instruments() apiInstruments {
+	meter := otel.Meter("nebari-infrastructure-core")
+	// Instrument creation only fails for invalid names or units; the no-op
+	// instrument returned alongside the error is still safe to use.
+	duration, _ := meter.Float64Histogram("nic.cloud_api.duration",
+		metric.WithDescription("Duration of cloud provider API operations, including SDK retries"),
+		metric.WithUnit("s"))
+	errors, _ := meter.Int64Counter("nic.cloud_api.errors",
+		metric.WithDescription("Cloud provider API operations that returned an error"))
+	return apiInstruments{duration: duration, errors: errors}
+}
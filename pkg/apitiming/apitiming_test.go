@@ -0,0 +1,44 @@
+package apitiming
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecorderSlowest(t *testing.T) {
+	r := NewRecorder()
+	ctx := WithRecorder(context.Background(), r)
+
+	Record(ctx, "EC2", "DescribeVpcs", 100*time.Millisecond, nil)
+	Record(ctx, "EKS", "DescribeCluster", 3*time.Second, nil)
+	Record(ctx, "EC2", "DescribeVpcs", 300*time.Millisecond, errors.New("throttled"))
+	Record(ctx, "S3", "HeadBucket", 10*time.Millisecond, nil)
+
+	got := r.Slowest(2)
+	if len(got) != 2 {
+		t.Fatalf("Slowest(2) returned %d operations, want 2", len(got))
+	}
+	if got[0].Name() != "EKS.DescribeCluster" || got[0].Total != 3*time.Second {
+		t.Errorf("slowest = %s (%s), want EKS.DescribeCluster (3s)", got[0].Name(), got[0].Total)
+	}
+	vpcs := got[1]
+	if vpcs.Name() != "EC2.DescribeVpcs" {
+		t.Fatalf("second slowest = %s, want EC2.DescribeVpcs", vpcs.Name())
+	}
+	if vpcs.Calls != 2 || vpcs.Errors != 1 {
+		t.Errorf("DescribeVpcs calls/errors = %d/%d, want 2/1", vpcs.Calls, vpcs.Errors)
+	}
+	if vpcs.Total != 400*time.Millisecond || vpcs.Max != 300*time.Millisecond {
+		t.Errorf("DescribeVpcs total/max = %s/%s, want 400ms/300ms", vpcs.Total, vpcs.Max)
+	}
+}
+
+func TestRecordWithoutRecorder(t *testing.T) {
+	// Calls outside a recorded run still reach metrics and spans and must not panic.
+	Record(context.Background(), "EC2", "DescribeVpcs", time.Millisecond, nil)
+	if FromContext(context.Background()) != nil {
+		t.Error("FromContext() on a bare context should be nil")
+	}
+}
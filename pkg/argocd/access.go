@@ -0,0 +1,97 @@
+package argocd
+
+import (
+	"fmt"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+const (
+	// ArgoCDInitialAdminSecretName is the secret Argo CD stores the generated
+	// admin password in, under ArgoCDInitialAdminPasswordKey.
+	ArgoCDInitialAdminSecretName = "argocd-initial-admin-secret" //nolint:gosec // This is a secret name reference, not a credential
+
+	// ArgoCDInitialAdminPasswordKey is the key of the admin password in
+	// ArgoCDInitialAdminSecretName.
+	ArgoCDInitialAdminPasswordKey = "password" //nolint:gosec // This is a secret key reference, not a credential
+
+	// KeycloakAdminPasswordKey is the key of the admin password in
+	// KeycloakDefaultAdminSecretName.
+	KeycloakAdminPasswordKey = "admin-password" //nolint:gosec // This is a secret key reference, not a credential
+
+	// GrafanaAdminPasswordKey is the key of the admin password in
+	// GrafanaAdminSecretName.
+	GrafanaAdminPasswordKey = "admin-password" //nolint:gosec // This is a secret key reference, not a credential
+
+	// adminUsername is the admin user of Argo CD, of the Keycloak master
+	// realm and of Grafana.
+	adminUsername = "admin"
+)
+
+// ServiceAccess describes how to reach a foundational service once it is
+// deployed: its public URL, the admin user, and the secret holding that
+// user's password. It never carries the password itself.
+type ServiceAccess struct {
+	Name            string
+	URL             string
+	Username        string
+	SecretNamespace string
+	SecretName      string
+	SecretKey       string
+}
+
+// serviceHostname returns the gateway hostname of a service exposed under
+// domain, as used by its HTTPRoute and checked in the gateway certificate.
+func serviceHostname(service, domain string) string {
+	return service + "." + domain
+}
+
+// AccessInfo returns the access details of the foundational services NIC
+// installs for cfg, with URLs built from the same hostnames, HTTPS port and
+// Keycloak base path as the rendered HTTPRoutes. Longhorn is included only
+// when the provider runs it; its UI signs in through Keycloak. Grafana is
+// included only when monitoring is enabled.
+func AccessInfo(cfg *config.NebariConfig, settings cluster.InfraSettings) []ServiceAccess {
+	data := NewTemplateData(cfg, nil, settings)
+	url := func(service, path string) string {
+		host := serviceHostname(service, data.Domain)
+		if data.HTTPSPort != 443 {
+			host = fmt.Sprintf("%s:%d", host, data.HTTPSPort)
+		}
+		return "https://" + host + path
+	}
+
+	services := []ServiceAccess{
+		{
+			Name:            "Argo CD",
+			URL:             url("argocd", ""),
+			Username:        adminUsername,
+			SecretNamespace: defaultNamespace,
+			SecretName:      ArgoCDInitialAdminSecretName,
+			SecretKey:       ArgoCDInitialAdminPasswordKey,
+		},
+		{
+			Name:            "Keycloak",
+			URL:             url("keycloak", data.KeycloakBasePath),
+			Username:        adminUsername,
+			SecretNamespace: data.KeycloakAdminSecretNamespace,
+			SecretName:      data.KeycloakAdminSecretName,
+			SecretKey:       KeycloakAdminPasswordKey,
+		},
+	}
+	if data.LonghornEnabled {
+		services = append(services, ServiceAccess{Name: "Longhorn", URL: url("longhorn", "")})
+	}
+	if data.MonitoringEnabled {
+		services = append(services, ServiceAccess{
+			Name:            "Grafana",
+			URL:             url("grafana", ""),
+			Username:        adminUsername,
+			SecretNamespace: MonitoringNamespace,
+			SecretName:      GrafanaAdminSecretName,
+			SecretKey:       GrafanaAdminPasswordKey,
+		})
+	}
+	return services
+}
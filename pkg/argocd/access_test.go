@@ -0,0 +1,94 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestAccessInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		domain     string
+		settings   cluster.InfraSettings
+		monitoring bool
+		wantURLs   map[string]string
+	}{
+		{
+			name:   "default port",
+			domain: "nebari.example.com",
+			wantURLs: map[string]string{
+				"Argo CD":  "https://argocd.nebari.example.com",
+				"Keycloak": "https://keycloak.nebari.example.com",
+			},
+		},
+		{
+			name:     "custom port and keycloak base path",
+			domain:   "nebari.example.com",
+			settings: cluster.InfraSettings{HTTPSPort: 8443, KeycloakBasePath: "/auth"},
+			wantURLs: map[string]string{
+				"Argo CD":  "https://argocd.nebari.example.com:8443",
+				"Keycloak": "https://keycloak.nebari.example.com:8443/auth",
+			},
+		},
+		{
+			name:     "longhorn enabled",
+			domain:   "nebari.example.com",
+			settings: cluster.InfraSettings{LonghornEnabled: true},
+			wantURLs: map[string]string{
+				"Argo CD":  "https://argocd.nebari.example.com",
+				"Keycloak": "https://keycloak.nebari.example.com",
+				"Longhorn": "https://longhorn.nebari.example.com",
+			},
+		},
+		{
+			name:       "monitoring enabled",
+			domain:     "nebari.example.com",
+			monitoring: true,
+			wantURLs: map[string]string{
+				"Argo CD":  "https://argocd.nebari.example.com",
+				"Keycloak": "https://keycloak.nebari.example.com",
+				"Grafana":  "https://grafana.nebari.example.com",
+			},
+		},
+		{
+			name: "no domain",
+			wantURLs: map[string]string{
+				"Argo CD":  "https://argocd.nebari.local",
+				"Keycloak": "https://keycloak.nebari.local",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{Domain: tt.domain, Monitoring: &config.MonitoringConfig{Enabled: tt.monitoring}}
+			services := AccessInfo(cfg, tt.settings)
+			if len(services) != len(tt.wantURLs) {
+				t.Fatalf("AccessInfo() returned %d services, want %d: %+v", len(services), len(tt.wantURLs), services)
+			}
+			for _, s := range services {
+				if want := tt.wantURLs[s.Name]; s.URL != want {
+					t.Errorf("%s URL = %q, want %q", s.Name, s.URL, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAccessInfoSecrets(t *testing.T) {
+	cfg := &config.NebariConfig{Domain: "nebari.example.com", Monitoring: &config.MonitoringConfig{Enabled: true}}
+	services := AccessInfo(cfg, cluster.InfraSettings{})
+	want := map[string]ServiceAccess{
+		"Argo CD":  {Username: "admin", SecretNamespace: "argocd", SecretName: "argocd-initial-admin-secret", SecretKey: "password"},
+		"Keycloak": {Username: "admin", SecretNamespace: "keycloak", SecretName: "keycloak-admin-credentials", SecretKey: "admin-password"},
+		"Grafana":  {Username: "admin", SecretNamespace: "monitoring", SecretName: "grafana-admin-credentials", SecretKey: "admin-password"},
+	}
+	for _, s := range services {
+		w := want[s.Name]
+		if s.Username != w.Username || s.SecretNamespace != w.SecretNamespace || s.SecretName != w.SecretName || s.SecretKey != w.SecretKey {
+			t.Errorf("%s = %+v, want secret %s/%s key %s for user %s", s.Name, s, w.SecretNamespace, w.SecretName, w.SecretKey, w.Username)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -92,73 +94,115 @@ func ApplyApplication(ctx context.Context, client dynamic.Interface, appManifest
 	return nil
 }
 
-// WaitForApplication waits for an Argo CD Application to reach a healthy and synced state.
+// Readiness criteria for WaitForApplicationOptions.Readiness.
+const (
+	// ReadyHealthyAndSynced waits for the Application to be both Healthy and
+	// Synced.
+	ReadyHealthyAndSynced = "healthy-synced"
+
+	// ReadyHealthy waits for the Application to be Healthy, whatever its sync
+	// status.
+	ReadyHealthy = "healthy"
+
+	// ReadySynced waits for the Application to be Synced, whatever its
+	// health.
+	ReadySynced = "synced"
+)
+
+// ReadinessCriteria lists the valid WaitForApplicationOptions.Readiness values.
+var ReadinessCriteria = []string{ReadyHealthyAndSynced, ReadyHealthy, ReadySynced}
+
+const (
+	defaultWaitTimeout      = 10 * time.Minute
+	defaultWaitPollInterval = 5 * time.Second
+)
+
+// WaitForApplicationOptions configures WaitForApplication.
+type WaitForApplicationOptions struct {
+	// Timeout bounds the wait. Defaults to 10 minutes.
+	Timeout time.Duration
+
+	// PollInterval is how often the Application is checked. Defaults to 5s.
+	PollInterval time.Duration
+
+	// Readiness is one of ReadinessCriteria. Defaults to
+	// ReadyHealthyAndSynced.
+	Readiness string
+}
+
+// WaitForApplication waits for an Argo CD Application to meet opts.Readiness,
+// by default Healthy and Synced. The Application is checked immediately and
+// then every opts.PollInterval until it is ready, opts.Timeout passes or ctx
+// is cancelled. An Application that does not exist yet is waited for.
 // The client parameter allows for dependency injection - use NewDynamicClient for production
 // or fake.NewSimpleDynamicClient for tests.
-func WaitForApplication(ctx context.Context, client dynamic.Interface, appName, appNamespace string, timeout time.Duration) error {
+func WaitForApplication(ctx context.Context, client dynamic.Interface, appName, appNamespace string, opts WaitForApplicationOptions) error {
 	tracer := otel.Tracer("nebari-infrastructure-core")
 	ctx, span := tracer.Start(ctx, "argocd.WaitForApplication")
 	defer span.End()
 
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultWaitTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWaitPollInterval
+	}
+	if opts.Readiness == "" {
+		opts.Readiness = ReadyHealthyAndSynced
+	}
+
 	span.SetAttributes(
 		attribute.String("application_name", appName),
 		attribute.String("application_namespace", appNamespace),
-		attribute.String("timeout", timeout.String()),
+		attribute.String("timeout", opts.Timeout.String()),
+		attribute.String("poll_interval", opts.PollInterval.String()),
+		attribute.String("readiness", opts.Readiness),
 	)
 
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	if !slices.Contains(ReadinessCriteria, opts.Readiness) {
+		err := fmt.Errorf("invalid readiness %q: must be one of %s", opts.Readiness, strings.Join(ReadinessCriteria, ", "))
+		span.RecordError(err)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
 	defer cancel()
 
 	status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Waiting for Argo CD Application to be ready: %s", appName)).
 		WithResource("argocd-application").
 		WithAction("waiting").
-		WithMetadata("application", appName))
+		WithMetadata("application", appName).
+		WithMetadata("readiness", opts.Readiness))
 
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(opts.PollInterval)
 	defer ticker.Stop()
 
 	for {
-		select {
-		case <-ctx.Done():
-			err := fmt.Errorf("timeout waiting for Argo CD Application %s: %w", appName, ctx.Err())
-			span.RecordError(err)
-			return err
-		case <-ticker.C:
-			app, err := client.Resource(ApplicationGVR).Namespace(appNamespace).Get(ctx, appName, metav1.GetOptions{})
-			if err != nil {
-				// Application not found yet, continue waiting
-				continue
-			}
-
-			// Check application status
-			statusObj, found, err := unstructured.NestedMap(app.Object, "status")
-			if err != nil || !found {
-				continue
-			}
-
-			// Check health status
-			healthStatus, found, err := unstructured.NestedString(statusObj, "health", "status")
-			if err != nil || !found {
-				continue
-			}
-
-			// Check sync status
-			syncStatus, found, err := unstructured.NestedString(statusObj, "sync", "status")
-			if err != nil || !found {
-				continue
-			}
-
-			// Application is ready if it's Healthy and Synced
-			if healthStatus == "Healthy" && syncStatus == "Synced" {
+		// A missing Application or status is not an error: Argo CD may not
+		// have created or reconciled it yet.
+		app, err := client.Resource(ApplicationGVR).Namespace(appNamespace).Get(ctx, appName, metav1.GetOptions{})
+		if err == nil {
+			health, _, _ := unstructured.NestedString(app.Object, "status", "health", "status")
+			sync, _, _ := unstructured.NestedString(app.Object, "status", "sync", "status")
+			appStatus := ApplicationStatus{Name: appName, Health: health, Sync: sync}
+			if appStatus.Meets(opts.Readiness) {
 				status.Send(ctx, status.NewUpdate(status.LevelSuccess, fmt.Sprintf("Argo CD Application is ready: %s", appName)).
 					WithResource("argocd-application").
 					WithAction("ready").
 					WithMetadata("application", appName).
-					WithMetadata("health", healthStatus).
-					WithMetadata("sync", syncStatus))
+					WithMetadata("health", health).
+					WithMetadata("sync", sync))
 				return nil
 			}
 		}
+
+		select {
+		case <-ctx.Done():
+			err := fmt.Errorf("timeout waiting for Argo CD Application %s: %w", appName, ctx.Err())
+			span.RecordError(err)
+			return err
+		case <-ticker.C:
+		}
 	}
 }
 
@@ -181,3 +225,74 @@ func GetApplicationStatus(ctx context.Context, client dynamic.Interface, appName
 
 	return health, sync, nil
 }
+
+// ApplicationStatus is the health and sync status of an Argo CD Application
+// at one point in time. Health and Sync are empty until Argo CD has reported
+// them.
+type ApplicationStatus struct {
+	Name   string `json:"name"`
+	Health string `json:"health"`
+	Sync   string `json:"sync"`
+}
+
+// Ready reports whether the Application is Healthy and Synced, the state
+// WaitForApplication waits for by default.
+func (s ApplicationStatus) Ready() bool {
+	return s.Meets(ReadyHealthyAndSynced)
+}
+
+// Meets reports whether the Application satisfies readiness, one of
+// ReadinessCriteria. An unknown readiness is never met.
+func (s ApplicationStatus) Meets(readiness string) bool {
+	switch readiness {
+	case ReadyHealthyAndSynced:
+		return s.Health == "Healthy" && s.Sync == "Synced"
+	case ReadyHealthy:
+		return s.Health == "Healthy"
+	case ReadySynced:
+		return s.Sync == "Synced"
+	default:
+		return false
+	}
+}
+
+// ListApplicationStatuses returns the status of every Argo CD Application NIC
+// manages in namespace, sorted by name. Unlike WaitForApplication it reads
+// the current state once and does not wait.
+func ListApplicationStatuses(ctx context.Context, client dynamic.Interface, namespace string) ([]ApplicationStatus, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "argocd.ListApplicationStatuses")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("namespace", namespace))
+
+	list, err := client.Resource(ApplicationGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: ManagedByLabel + "=" + NebariManagedByValue,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list Argo CD Applications: %w", err)
+	}
+
+	statuses := make([]ApplicationStatus, 0, len(list.Items))
+	for _, app := range list.Items {
+		health, _, _ := unstructured.NestedString(app.Object, "status", "health", "status")
+		sync, _, _ := unstructured.NestedString(app.Object, "status", "sync", "status")
+		statuses = append(statuses, ApplicationStatus{Name: app.GetName(), Health: health, Sync: sync})
+	}
+	slices.SortFunc(statuses, func(a, b ApplicationStatus) int { return strings.Compare(a.Name, b.Name) })
+
+	span.SetAttributes(attribute.Int("applications", len(statuses)))
+	return statuses, nil
+}
+
+// FoundationalApplicationStatuses returns the status of the Argo CD
+// Applications NIC manages in the Argo CD namespace of the cluster
+// kubeconfigBytes points at.
+func FoundationalApplicationStatuses(ctx context.Context, kubeconfigBytes []byte) ([]ApplicationStatus, error) {
+	dynamicClient, err := NewDynamicClient(kubeconfigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return ListApplicationStatuses(ctx, dynamicClient, defaultNamespace)
+}
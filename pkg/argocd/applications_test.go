@@ -1,10 +1,17 @@
 package argocd
 
 import (
+	"context"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestApplicationGVR(t *testing.T) {
@@ -43,3 +50,175 @@ func TestApplicationGVR_String(t *testing.T) {
 		t.Errorf("ApplicationGVR.String() = %q, should contain 'applications'", str)
 	}
 }
+
+func TestListApplicationStatuses(t *testing.T) {
+	keycloak := newApplication("keycloak", true)
+	keycloak.Object["status"] = map[string]any{
+		"health": map[string]any{"status": "Progressing"},
+		"sync":   map[string]any{"status": "OutOfSync"},
+	}
+	certManager := newApplication("cert-manager", true)
+	certManager.Object["status"] = map[string]any{
+		"health": map[string]any{"status": "Healthy"},
+		"sync":   map[string]any{"status": "Synced"},
+	}
+	listKinds := map[schema.GroupVersionResource]string{ApplicationGVR: "ApplicationList"}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+		keycloak, certManager, newApplication("envoy-gateway", true), newApplication("user-app", false))
+
+	got, err := ListApplicationStatuses(context.Background(), client, defaultNamespace)
+	if err != nil {
+		t.Fatalf("ListApplicationStatuses() error = %v", err)
+	}
+	want := []ApplicationStatus{
+		{Name: "cert-manager", Health: "Healthy", Sync: "Synced"},
+		{Name: "envoy-gateway"},
+		{Name: "keycloak", Health: "Progressing", Sync: "OutOfSync"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListApplicationStatuses() = %+v, want %+v", got, want)
+	}
+	for _, s := range got {
+		if s.Ready() != (s.Name == "cert-manager") {
+			t.Errorf("%s Ready() = %v", s.Name, s.Ready())
+		}
+	}
+}
+
+// progressingClient returns a dynamic client whose "keycloak" Application
+// reports each of statuses in turn, one per Get, and then the last one for
+// good. A nil status means the Application does not exist yet. The returned
+// counter is the number of Gets served.
+func progressingClient(statuses []*ApplicationStatus) (*dynamicfake.FakeDynamicClient, *int) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	gets := 0
+	client.PrependReactor("get", "applications", func(k8stesting.Action) (bool, runtime.Object, error) {
+		s := statuses[min(gets, len(statuses)-1)]
+		gets++
+		if s == nil {
+			return true, nil, apierrors.NewNotFound(ApplicationGVR.GroupResource(), "keycloak")
+		}
+		app := newApplication("keycloak", true)
+		app.Object["status"] = map[string]any{
+			"health": map[string]any{"status": s.Health},
+			"sync":   map[string]any{"status": s.Sync},
+		}
+		return true, app, nil
+	})
+	return client, &gets
+}
+
+func TestWaitForApplication(t *testing.T) {
+	statuses := []*ApplicationStatus{
+		nil,
+		{Health: "Progressing", Sync: "OutOfSync"},
+		{Health: "Progressing", Sync: "Synced"},
+		{Health: "Healthy", Sync: "Synced"},
+	}
+
+	tests := []struct {
+		name      string
+		readiness string
+		wantGets  int
+	}{
+		{name: "default waits for healthy and synced", readiness: "", wantGets: 4},
+		{name: "healthy and synced", readiness: ReadyHealthyAndSynced, wantGets: 4},
+		{name: "healthy", readiness: ReadyHealthy, wantGets: 4},
+		{name: "synced", readiness: ReadySynced, wantGets: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, gets := progressingClient(statuses)
+			opts := WaitForApplicationOptions{
+				Timeout:      5 * time.Second,
+				PollInterval: time.Millisecond,
+				Readiness:    tt.readiness,
+			}
+			if err := WaitForApplication(context.Background(), client, "keycloak", defaultNamespace, opts); err != nil {
+				t.Fatalf("WaitForApplication() error = %v", err)
+			}
+			if *gets != tt.wantGets {
+				t.Errorf("WaitForApplication() returned after %d checks, want %d", *gets, tt.wantGets)
+			}
+		})
+	}
+}
+
+func TestWaitForApplication_ReadyOnFirstCheck(t *testing.T) {
+	client, gets := progressingClient([]*ApplicationStatus{{Health: "Healthy", Sync: "Synced"}})
+	// The first check must not wait for the poll interval.
+	opts := WaitForApplicationOptions{Timeout: time.Second, PollInterval: time.Hour}
+	if err := WaitForApplication(context.Background(), client, "keycloak", defaultNamespace, opts); err != nil {
+		t.Fatalf("WaitForApplication() error = %v", err)
+	}
+	if *gets != 1 {
+		t.Errorf("WaitForApplication() checked %d times, want 1", *gets)
+	}
+}
+
+func TestWaitForApplication_Timeout(t *testing.T) {
+	client, _ := progressingClient([]*ApplicationStatus{{Health: "Progressing", Sync: "Synced"}})
+	opts := WaitForApplicationOptions{Timeout: 50 * time.Millisecond, PollInterval: time.Millisecond}
+	err := WaitForApplication(context.Background(), client, "keycloak", defaultNamespace, opts)
+	if err == nil || !strings.Contains(err.Error(), "timeout waiting for Argo CD Application keycloak") {
+		t.Errorf("WaitForApplication() error = %v, want timeout", err)
+	}
+}
+
+func TestWaitForApplication_ContextCancelled(t *testing.T) {
+	client, _ := progressingClient([]*ApplicationStatus{{Health: "Progressing", Sync: "OutOfSync"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	opts := WaitForApplicationOptions{Timeout: time.Minute, PollInterval: time.Hour}
+	err := WaitForApplication(ctx, client, "keycloak", defaultNamespace, opts)
+	if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Errorf("WaitForApplication() error = %v, want context canceled", err)
+	}
+}
+
+func TestWaitForApplication_InvalidReadiness(t *testing.T) {
+	client, gets := progressingClient([]*ApplicationStatus{{Health: "Healthy", Sync: "Synced"}})
+	err := WaitForApplication(context.Background(), client, "keycloak", defaultNamespace, WaitForApplicationOptions{Readiness: "degraded"})
+	if err == nil || !strings.Contains(err.Error(), `invalid readiness "degraded"`) {
+		t.Errorf("WaitForApplication() error = %v, want invalid readiness", err)
+	}
+	if *gets != 0 {
+		t.Errorf("WaitForApplication() checked the Application %d times, want 0", *gets)
+	}
+}
+
+func TestApplicationStatus_Meets(t *testing.T) {
+	tests := []struct {
+		status ApplicationStatus
+		want   map[string]bool
+	}{
+		{
+			status: ApplicationStatus{Health: "Healthy", Sync: "Synced"},
+			want:   map[string]bool{ReadyHealthyAndSynced: true, ReadyHealthy: true, ReadySynced: true},
+		},
+		{
+			status: ApplicationStatus{Health: "Healthy", Sync: "OutOfSync"},
+			want:   map[string]bool{ReadyHealthyAndSynced: false, ReadyHealthy: true, ReadySynced: false},
+		},
+		{
+			status: ApplicationStatus{Health: "Progressing", Sync: "Synced"},
+			want:   map[string]bool{ReadyHealthyAndSynced: false, ReadyHealthy: false, ReadySynced: true},
+		},
+		{
+			status: ApplicationStatus{},
+			want:   map[string]bool{ReadyHealthyAndSynced: false, ReadyHealthy: false, ReadySynced: false},
+		},
+	}
+
+	for _, tt := range tests {
+		for readiness, want := range tt.want {
+			if got := tt.status.Meets(readiness); got != want {
+				t.Errorf("%+v.Meets(%q) = %v, want %v", tt.status, readiness, got, want)
+			}
+		}
+		if got := tt.status.Meets("unknown"); got {
+			t.Errorf("%+v.Meets(\"unknown\") = true, want false", tt.status)
+		}
+	}
+}
@@ -1,16 +1,14 @@
 package argocd
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"strings"
-	"text/template"
 
 	"go.opentelemetry.io/otel"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
@@ -31,8 +29,8 @@ metadata:
 spec:
   project: foundational
   source:
-    repoURL: {{ .GitRepoURL }}
-    targetRevision: {{ .GitBranch }}
+    repoURL: {{ required "GitRepoURL" .GitRepoURL }}
+    targetRevision: {{ required "GitBranch" .GitBranch }}
     path: {{ if .GitPath }}{{ .GitPath }}/{{ end }}apps
     directory:
       recurse: false
@@ -71,37 +69,10 @@ func ApplyRootAppOfApps(ctx context.Context, kubeconfigBytes []byte, gitConfig *
 		WithResource("nebari-root").
 		WithAction("installing"))
 
-	// Prepare template data
-	data := struct {
-		GitRepoURL string
-		GitBranch  string
-		GitPath    string
-	}{
-		GitRepoURL: gitConfig.URL,
-		GitBranch:  gitConfig.GetBranch(),
-		GitPath:    gitConfig.Path,
-	}
-
-	// Parse and execute template
-	tmpl, err := template.New("root-app").Parse(rootAppOfAppsTemplate)
+	obj, err := renderRootAppOfApps(gitConfig)
 	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to parse root App-of-Apps template: %w", err)
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to execute root App-of-Apps template: %w", err)
-	}
-
-	// Parse the rendered manifest
-	decoder := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
-	obj := &unstructured.Unstructured{}
-	_, _, err = decoder.Decode(buf.Bytes(), nil, obj)
-	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to decode root App-of-Apps manifest: %w", err)
+		return err
 	}
 
 	// Create dynamic client
@@ -125,6 +96,25 @@ func ApplyRootAppOfApps(ctx context.Context, kubeconfigBytes []byte, gitConfig *
 	return nil
 }
 
+// renderRootAppOfApps renders the root App-of-Apps Application for gitConfig.
+func renderRootAppOfApps(gitConfig *git.Config) (*unstructured.Unstructured, error) {
+	data := struct {
+		GitRepoURL string
+		GitBranch  string
+		GitPath    string
+	}{
+		GitRepoURL: gitConfig.URL,
+		GitBranch:  gitConfig.GetBranch(),
+		GitPath:    gitConfig.Path,
+	}
+
+	objs, err := renderManifests("root-app", rootAppOfAppsTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render root App-of-Apps manifest: %w", err)
+	}
+	return objs[0], nil
+}
+
 // InstallProject installs the foundational, nebari-apps, and locked-down default
 // ArgoCD AppProjects. foundational is scoped to the repos and namespaces derived
 // from NIC's own app templates; nebari-apps is the home for software packs;
@@ -149,9 +139,14 @@ func InstallProject(ctx context.Context, kubeconfigBytes []byte, data TemplateDa
 		span.RecordError(err)
 		return fmt.Errorf("failed to create dynamic client: %w", err)
 	}
+	k8sClient, err := newK8sClient(kubeconfigBytes)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
 
 	for _, obj := range objs {
-		if err := applyResource(ctx, dynamicClient, obj); err != nil {
+		if err := applyResource(ctx, dynamicClient, k8sClient.Discovery(), obj); err != nil {
 			span.RecordError(err)
 			return fmt.Errorf("failed to apply AppProject %q: %w", obj.GetName(), err)
 		}
@@ -164,10 +159,15 @@ func InstallProject(ctx context.Context, kubeconfigBytes []byte, data TemplateDa
 }
 
 // applyResource applies a Kubernetes resource using the dynamic client.
+// Resources whose CRD a foundational Application installs are only applied
+// once disc serves their kind; see ensureFoundationalCRD.
 // The client parameter allows for dependency injection - use NewDynamicClient for production
 // or fake.NewSimpleDynamicClient for tests.
-func applyResource(ctx context.Context, client dynamic.Interface, obj *unstructured.Unstructured) error {
+func applyResource(ctx context.Context, client dynamic.Interface, disc discovery.DiscoveryInterface, obj *unstructured.Unstructured) error {
 	gvk := obj.GroupVersionKind()
+	if err := ensureFoundationalCRD(ctx, disc, gvk); err != nil {
+		return err
+	}
 	resourceName := pluralizeKind(gvk.Kind)
 	gvr := gvk.GroupVersion().WithResource(resourceName)
 	namespace := obj.GetNamespace()
@@ -56,7 +56,7 @@ func TestPluralizeKind(t *testing.T) {
 
 func TestRootAppOfAppsTemplate(t *testing.T) {
 	// Test that the template parses correctly
-	tmpl, err := template.New("test").Parse(rootAppOfAppsTemplate)
+	tmpl, err := template.New("test").Funcs(templateFuncs).Parse(rootAppOfAppsTemplate)
 	if err != nil {
 		t.Fatalf("failed to parse rootAppOfAppsTemplate: %v", err)
 	}
@@ -155,7 +155,7 @@ func TestRootAppOfAppsTemplate(t *testing.T) {
 }
 
 func TestRootAppOfAppsTemplate_SyncPolicy(t *testing.T) {
-	tmpl, err := template.New("test").Parse(rootAppOfAppsTemplate)
+	tmpl, err := template.New("test").Funcs(templateFuncs).Parse(rootAppOfAppsTemplate)
 	if err != nil {
 		t.Fatalf("failed to parse template: %v", err)
 	}
@@ -194,7 +194,7 @@ func TestRootAppOfAppsTemplate_SyncPolicy(t *testing.T) {
 }
 
 func TestRootAppOfAppsTemplate_Finalizers(t *testing.T) {
-	tmpl, err := template.New("test").Parse(rootAppOfAppsTemplate)
+	tmpl, err := template.New("test").Funcs(templateFuncs).Parse(rootAppOfAppsTemplate)
 	if err != nil {
 		t.Fatalf("failed to parse template: %v", err)
 	}
@@ -0,0 +1,193 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// ComponentRequest is what a foundational component's pods request in total.
+type ComponentRequest struct {
+	Name      string
+	Namespace string
+	CPU       resource.Quantity
+	Memory    resource.Quantity
+}
+
+// foundationalRequests mirrors the resource requests set in the app
+// templates' Helm values, summed over each chart's pods. Keep it in sync
+// when those values change.
+var foundationalRequests = []ComponentRequest{
+	{Name: "cert-manager", Namespace: "cert-manager", CPU: resource.MustParse("200m"), Memory: resource.MustParse("256Mi")},
+	{Name: "cloudnative-pg", Namespace: "cnpg-system", CPU: resource.MustParse("100m"), Memory: resource.MustParse("256Mi")},
+	{Name: "envoy-gateway", Namespace: "envoy-gateway-system", CPU: resource.MustParse("100m"), Memory: resource.MustParse("256Mi")},
+	{Name: "keycloak", Namespace: KeycloakDefaultNamespace, CPU: resource.MustParse("500m"), Memory: resource.MustParse("1Gi")},
+	{Name: "metallb", Namespace: "metallb-system", CPU: resource.MustParse("200m"), Memory: resource.MustParse("256Mi")},
+	{Name: "monitoring", Namespace: MonitoringNamespace, CPU: resource.MustParse("450m"), Memory: resource.MustParse("1600Mi")},
+	{Name: "opentelemetry-collector", Namespace: MonitoringNamespace, CPU: resource.MustParse("100m"), Memory: resource.MustParse("128Mi")},
+	{Name: "postgresql", Namespace: KeycloakDefaultNamespace, CPU: resource.MustParse("250m"), Memory: resource.MustParse("512Mi")},
+	{Name: "trust-manager", Namespace: certManagerNamespace, CPU: resource.MustParse("50m"), Memory: resource.MustParse("64Mi")},
+}
+
+// FoundationalRequests returns the requests of the foundational components
+// installed for cfg and settings. MetalLB is included only for providers that
+// need it, trust-manager only when a trust bundle is configured, PostgreSQL
+// only when Keycloak has no external database and the monitoring stack only
+// when it is enabled.
+func FoundationalRequests(cfg *config.NebariConfig, settings cluster.InfraSettings, trustManager bool) []ComponentRequest {
+	var requests []ComponentRequest
+	for _, r := range foundationalRequests {
+		if (r.Name == "metallb" && !settings.NeedsMetalLB) || (r.Name == "trust-manager" && !trustManager) ||
+			(r.Name == "postgresql" && cfg.Keycloak.ExternalDatabase() != nil) ||
+			(r.Name == "monitoring" && !cfg.Monitoring.IsEnabled()) {
+			continue
+		}
+		requests = append(requests, r)
+	}
+	return requests
+}
+
+// CapacityShortfall is a resource the foundational components request more
+// of than the cluster's schedulable nodes have free.
+type CapacityShortfall struct {
+	Resource  corev1.ResourceName
+	Requested resource.Quantity
+	Available resource.Quantity
+	// Largest names the components requesting the most of Resource, largest
+	// first.
+	Largest []string
+}
+
+func (s CapacityShortfall) String() string {
+	return fmt.Sprintf("%s: %s requested, %s available", s.Resource, s.Requested.String(), s.Available.String())
+}
+
+// largestConsumers is how many components CapacityShortfall.Largest lists.
+const largestConsumers = 3
+
+// DetectCapacityShortfall compares the total of requests with the
+// allocatable CPU and memory of the cluster's schedulable nodes, less what
+// the pods already running on them request. Pods in the components' own
+// namespaces are not subtracted, so a redeploy does not count the
+// components twice. Returns one shortfall per resource that does not fit.
+//
+// The check is a lower bound on what is needed: it ignores how requests are
+// spread over nodes and the pods of Argo CD and the cluster add-ons that are
+// not scheduled yet.
+func DetectCapacityShortfall(ctx context.Context, client kubernetes.Interface, requests []ComponentRequest) ([]CapacityShortfall, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "argocd.DetectCapacityShortfall")
+	defer span.End()
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	available := map[corev1.ResourceName]*resource.Quantity{
+		corev1.ResourceCPU:    resource.NewQuantity(0, resource.DecimalSI),
+		corev1.ResourceMemory: resource.NewQuantity(0, resource.BinarySI),
+	}
+	schedulable := map[string]bool{}
+	for _, node := range nodes.Items {
+		if !nodeSchedulable(node) {
+			continue
+		}
+		schedulable[node.Name] = true
+		for name, q := range available {
+			q.Add(node.Status.Allocatable[name])
+		}
+	}
+
+	namespaces := map[string]bool{}
+	for _, r := range requests {
+		namespaces[r.Namespace] = true
+	}
+	for _, pod := range pods.Items {
+		if !schedulable[pod.Spec.NodeName] || namespaces[pod.Namespace] ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			for name, q := range available {
+				q.Sub(c.Resources.Requests[name])
+			}
+		}
+	}
+
+	var shortfalls []CapacityShortfall
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		requested := resource.NewQuantity(0, available[name].Format)
+		for _, r := range requests {
+			requested.Add(componentRequest(r, name))
+		}
+		if requested.Cmp(*available[name]) <= 0 {
+			continue
+		}
+		sorted := slices.Clone(requests)
+		slices.SortStableFunc(sorted, func(a, b ComponentRequest) int {
+			q := componentRequest(b, name)
+			return q.Cmp(componentRequest(a, name))
+		})
+		var largest []string
+		for _, r := range sorted[:min(largestConsumers, len(sorted))] {
+			largest = append(largest, r.Name)
+		}
+		shortfalls = append(shortfalls, CapacityShortfall{
+			Resource:  name,
+			Requested: *requested,
+			Available: *available[name],
+			Largest:   largest,
+		})
+	}
+
+	span.SetAttributes(
+		attribute.Int("schedulable_nodes", len(schedulable)),
+		attribute.Int("shortfalls", len(shortfalls)),
+	)
+	return shortfalls, nil
+}
+
+// componentRequest returns r's request of the named resource.
+func componentRequest(r ComponentRequest, name corev1.ResourceName) resource.Quantity {
+	if name == corev1.ResourceCPU {
+		return r.CPU
+	}
+	return r.Memory
+}
+
+// nodeSchedulable reports whether new pods without tolerations can be
+// scheduled on node: it is Ready, not cordoned and has no NoSchedule or
+// NoExecute taint.
+func nodeSchedulable(node corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
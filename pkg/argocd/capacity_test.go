@@ -0,0 +1,156 @@
+package argocd
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// capacityNode returns a Ready node with the given allocatable CPU and memory.
+func capacityNode(name, cpu, memory string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+// capacityPod returns a running pod on node requesting cpu and memory.
+func capacityPod(namespace, name, node, cpu, memory string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "main",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestFoundationalRequests(t *testing.T) {
+	names := func(requests []ComponentRequest) []string {
+		var out []string
+		for _, r := range requests {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+
+	got := names(FoundationalRequests(&config.NebariConfig{}, cluster.InfraSettings{}, false))
+	if slices.Contains(got, "metallb") || slices.Contains(got, "trust-manager") {
+		t.Errorf("requests = %v, want neither metallb nor trust-manager", got)
+	}
+	got = names(FoundationalRequests(&config.NebariConfig{}, cluster.InfraSettings{NeedsMetalLB: true}, true))
+	if !slices.Contains(got, "metallb") || !slices.Contains(got, "trust-manager") {
+		t.Errorf("requests = %v, want metallb and trust-manager", got)
+	}
+
+	external := &config.NebariConfig{Keycloak: &config.KeycloakConfig{Database: &config.KeycloakDatabaseConfig{Mode: config.KeycloakDatabaseExternal}}}
+	if got = names(FoundationalRequests(external, cluster.InfraSettings{}, false)); slices.Contains(got, "postgresql") {
+		t.Errorf("requests = %v, want no postgresql with an external database", got)
+	}
+}
+
+func TestDetectCapacityShortfall(t *testing.T) {
+	requests := FoundationalRequests(&config.NebariConfig{}, cluster.InfraSettings{}, false)
+
+	cordoned := capacityNode("cordoned", "16", "64Gi")
+	cordoned.Spec.Unschedulable = true
+	tainted := capacityNode("control-plane", "16", "64Gi")
+	tainted.Spec.Taints = []corev1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}}
+	completed := capacityPod("batch", "done", "big", "8", "16Gi")
+	completed.Status.Phase = corev1.PodSucceeded
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    []corev1.ResourceName
+	}{
+		{
+			name:    "large node fits",
+			objects: []runtime.Object{capacityNode("big", "8", "16Gi")},
+		},
+		{
+			name:    "small node is short of cpu and memory",
+			objects: []runtime.Object{capacityNode("small", "1", "1Gi")},
+			want:    []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+		},
+		{
+			name:    "unschedulable nodes do not count",
+			objects: []runtime.Object{capacityNode("small", "1", "8Gi"), cordoned, tainted},
+			want:    []corev1.ResourceName{corev1.ResourceCPU},
+		},
+		{
+			name: "running pods use up capacity",
+			objects: []runtime.Object{
+				capacityNode("big", "8", "16Gi"),
+				capacityPod("default", "hog", "big", "7500m", "1Gi"),
+				completed,
+			},
+			want: []corev1.ResourceName{corev1.ResourceCPU},
+		},
+		{
+			name: "pods of the components themselves are not subtracted",
+			objects: []runtime.Object{
+				capacityNode("big", "2", "4Gi"),
+				capacityPod(KeycloakDefaultNamespace, "keycloak-0", "big", "500m", "1Gi"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := k8sfake.NewSimpleClientset(tt.objects...)
+			shortfalls, err := DetectCapacityShortfall(context.Background(), client, requests)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []corev1.ResourceName
+			for _, s := range shortfalls {
+				got = append(got, s.Resource)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("shortfalls = %v, want %v", shortfalls, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectCapacityShortfall_NamesLargestComponents(t *testing.T) {
+	client := k8sfake.NewSimpleClientset(capacityNode("small", "1", "1Gi"))
+	shortfalls, err := DetectCapacityShortfall(context.Background(), client, FoundationalRequests(&config.NebariConfig{}, cluster.InfraSettings{}, false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(shortfalls) != 2 {
+		t.Fatalf("shortfalls = %v, want cpu and memory", shortfalls)
+	}
+
+	memory := shortfalls[1]
+	if want := []string{"keycloak", "postgresql", "cert-manager"}; !slices.Equal(memory.Largest, want) {
+		t.Errorf("Largest = %v, want %v", memory.Largest, want)
+	}
+	if want := "memory: 2432Mi requested, 1Gi available"; memory.String() != want {
+		t.Errorf("String() = %q, want %q", memory.String(), want)
+	}
+}
@@ -168,7 +168,7 @@ func createGatewayTLSSecret(ctx context.Context, client kubernetes.Interface, cf
 	status.Send(ctx, status.NewUpdate(status.LevelProgress, "Configuring user-supplied gateway TLS secret").
 		WithResource("certificate").
 		WithAction("configuring").
-		WithMetadata("secret", name).
+		WithMetadata("secret_name", name).
 		WithMetadata("namespace", namespace))
 
 	_, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -183,7 +183,7 @@ func createGatewayTLSSecret(ctx context.Context, client kubernetes.Interface, cf
 	status.Send(ctx, status.NewUpdate(status.LevelSuccess, "Gateway TLS secret configured from user-supplied certificate").
 		WithResource("certificate").
 		WithAction("configured").
-		WithMetadata("secret", name).
+		WithMetadata("secret_name", name).
 		WithMetadata("namespace", namespace))
 	return nil
 }
@@ -198,7 +198,7 @@ func checkExistingSecretSANs(ctx context.Context, client kubernetes.Interface, c
 	if err != nil {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning,
 			"Could not fetch user-supplied TLS secret to verify SANs; ensure it exists before traffic is served").
-			WithMetadata("secret", name).
+			WithMetadata("secret_name", name).
 			WithMetadata("namespace", namespace).
 			WithMetadata("error", err.Error()))
 		return
@@ -208,7 +208,7 @@ func checkExistingSecretSANs(ctx context.Context, client kubernetes.Interface, c
 	if !ok {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning,
 			fmt.Sprintf("TLS secret %s/%s has no %s key", namespace, name, corev1.TLSCertKey)).
-			WithMetadata("secret", name).
+			WithMetadata("secret_name", name).
 			WithMetadata("namespace", namespace))
 		return
 	}
@@ -249,7 +249,7 @@ func missingSANs(certPEM []byte, domain string) ([]string, error) {
 		return nil, fmt.Errorf("parse certificate: %w", err)
 	}
 
-	required := []string{domain, "keycloak." + domain, "argocd." + domain}
+	required := []string{domain, serviceHostname("keycloak", domain), serviceHostname("argocd", domain)}
 	var missing []string
 	for _, host := range required {
 		if cert.VerifyHostname(host) != nil {
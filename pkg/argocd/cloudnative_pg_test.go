@@ -22,6 +22,10 @@ func TestCloudNativePGTemplate_PinsChartAndTarget(t *testing.T) {
 	if err != nil {
 		t.Fatalf("read cloudnative-pg template: %v", err)
 	}
+	content, err = processTemplate("apps/cloudnative-pg.yaml", content, NewTemplateData(&config.NebariConfig{}, nil, provider.InfraSettings{}))
+	if err != nil {
+		t.Fatalf("render cloudnative-pg template: %v", err)
+	}
 
 	var doc map[string]any
 	if err := yaml.Unmarshal(content, &doc); err != nil {
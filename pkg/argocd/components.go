@@ -0,0 +1,217 @@
+package argocd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+)
+
+// componentFilePrefix names the Application files of configured components
+// in apps/, which the root App-of-Apps syncs.
+const componentFilePrefix = "component-"
+
+// builtinSyncWaves is the sync wave of each foundational Application when no
+// components are configured. Each one implicitly depends on every
+// foundational Application in an earlier wave, so a component inserted
+// between two waves pushes the later ones back.
+var builtinSyncWaves = map[string]int{
+	"envoy-gateway":           1,
+	"metallb":                 1,
+	"metallb-config":          1,
+	"cert-manager":            2,
+	"gateway-config":          2,
+	"certificates":            3,
+	"cloudnative-pg":          3,
+	"cluster-issuers":         3,
+	"httproutes":              3,
+	"longhorn-backup":         3,
+	"securitypolicies":        3,
+	"trust-manager":           3,
+	"keycloak":                4,
+	"monitoring":              4,
+	"opentelemetry-collector": 4,
+	"postgresql":              4,
+	"trust-bundle":            4,
+	"nebari-operator":         5,
+	"nebari-landingpage":      6,
+}
+
+// SyncWave returns the sync wave of the named Application. Called from the
+// app templates.
+func (d TemplateData) SyncWave(name string) int {
+	if wave, ok := d.SyncWaves[name]; ok {
+		return wave
+	}
+	return builtinSyncWaves[name]
+}
+
+// ComponentWaves orders the foundational Applications and the configured
+// components by their dependencies and returns the sync wave of each: one
+// more than the latest of its dependencies, and never earlier than a
+// foundational Application's built-in wave. A component without
+// dependencies syncs in wave 0, before the foundational Applications.
+// Returns an error for unknown dependencies, components without a path or
+// namespace, and dependency cycles.
+func ComponentWaves(components []config.ComponentConfig) (map[string]int, error) {
+	deps := make(map[string][]string, len(builtinSyncWaves)+len(components))
+	for name, wave := range builtinSyncWaves {
+		for other, otherWave := range builtinSyncWaves {
+			if otherWave < wave {
+				deps[name] = append(deps[name], other)
+			}
+		}
+	}
+	for _, c := range components {
+		_, builtin := builtinSyncWaves[c.Name]
+		switch {
+		case builtin && c.HasSource():
+			return nil, fmt.Errorf("component %s is a foundational Application; only depends_on can be set for it", c.Name)
+		case !builtin && (c.Path == "" || c.Namespace == ""):
+			return nil, fmt.Errorf("component %s needs a path and a namespace", c.Name)
+		}
+		deps[c.Name] = append(deps[c.Name], c.DependsOn...)
+	}
+	for _, c := range components {
+		for _, dep := range c.DependsOn {
+			if _, ok := deps[dep]; !ok {
+				return nil, fmt.Errorf("component %s depends on unknown component %q", c.Name, dep)
+			}
+		}
+	}
+
+	waves := make(map[string]int, len(deps))
+	visiting := map[string]bool{}
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		if _, done := waves[name]; done {
+			return nil
+		}
+		if visiting[name] {
+			cycle := slices.Concat(path[slices.Index(path, name):], []string{name})
+			return fmt.Errorf("component dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+		visiting[name] = true
+		path = append(path, name)
+		wave := builtinSyncWaves[name]
+		for _, dep := range slices.Sorted(slices.Values(deps[name])) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+			wave = max(wave, waves[dep]+1)
+		}
+		path = path[:len(path)-1]
+		visiting[name] = false
+		waves[name] = wave
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(deps)) {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return waves, nil
+}
+
+// renderComponent renders c as an Argo CD Application in the nebari-apps
+// project, synced in wave.
+func renderComponent(c config.ComponentConfig, wave int, data TemplateData) ([]byte, error) {
+	repoURL, revision := c.RepoURL, c.TargetRevision
+	if repoURL == "" {
+		repoURL = data.GitRepoURL
+		if revision == "" {
+			revision = data.GitBranch
+		}
+	}
+	if revision == "" {
+		revision = "HEAD"
+	}
+
+	syncPolicy := map[string]any{
+		"syncOptions": []string{"CreateNamespace=true"},
+	}
+	if data.SyncAutomated {
+		syncPolicy["automated"] = map[string]any{"prune": data.SyncPrune, "selfHeal": data.SyncSelfHeal}
+	}
+	app := map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]any{
+			"name":      c.Name,
+			"namespace": "argocd",
+			"labels": map[string]any{
+				"app.kubernetes.io/part-of":    "nebari-components",
+				"app.kubernetes.io/managed-by": "nebari-infrastructure-core",
+			},
+			"annotations": map[string]any{
+				syncWaveAnnotation: strconv.Itoa(wave),
+			},
+			"finalizers": []string{"resources-finalizer.argocd.argoproj.io"},
+		},
+		"spec": map[string]any{
+			"project": "nebari-apps",
+			"source": map[string]any{
+				"repoURL":        repoURL,
+				"targetRevision": revision,
+				"path":           c.Path,
+			},
+			"destination": map[string]any{
+				"server":    inClusterServer,
+				"namespace": c.Namespace,
+			},
+			"syncPolicy": syncPolicy,
+		},
+	}
+	out, err := yaml.Marshal(app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render component %s: %w", c.Name, err)
+	}
+	return out, nil
+}
+
+// writeComponents writes an Application file for every configured component
+// that declares its own manifests, and removes files of components no longer
+// configured, so the root App-of-Apps prunes them.
+func writeComponents(workDir string, components []config.ComponentConfig, data TemplateData) error {
+	dir := filepath.Join(workDir, "apps")
+	stale, err := filepath.Glob(filepath.Join(dir, componentFilePrefix+"*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to list components: %w", err)
+	}
+	keep := make(map[string]bool, len(components))
+	for _, c := range components {
+		if !c.HasSource() {
+			continue
+		}
+		path := filepath.Join(dir, componentFilePrefix+c.Name+".yaml")
+		keep[path] = true
+		content, err := renderComponent(c, data.SyncWave(c.Name), data)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, git.GitOpsDirMode); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, content, git.GitOpsFileMode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	for _, path := range stale {
+		if keep[path] {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale component %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package argocd
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+)
+
+func TestComponentWaves(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []config.ComponentConfig
+		want       map[string]int // subset of the result to check
+		errSubstr  string         // "" means no error expected
+	}{
+		{name: "defaults keep the built-in waves", want: builtinSyncWaves},
+		{
+			name:       "component without dependencies",
+			components: []config.ComponentConfig{{Name: "crds", Path: "crds", Namespace: "crds"}},
+			want:       map[string]int{"crds": 0, "envoy-gateway": 1},
+		},
+		{
+			name: "component between built-ins",
+			components: []config.ComponentConfig{
+				{Name: "vault", Path: "vault", Namespace: "vault", DependsOn: []string{"cert-manager"}},
+				{Name: "keycloak", DependsOn: []string{"vault"}},
+			},
+			want: map[string]int{"cert-manager": 2, "vault": 3, "keycloak": 4, "nebari-operator": 5},
+		},
+		{
+			name: "component after a late built-in pushes later built-ins back",
+			components: []config.ComponentConfig{
+				{Name: "audit", Path: "audit", Namespace: "audit", DependsOn: []string{"keycloak"}},
+				{Name: "nebari-operator", DependsOn: []string{"audit"}},
+			},
+			want: map[string]int{"keycloak": 4, "audit": 5, "nebari-operator": 6, "nebari-landingpage": 7},
+		},
+		{
+			name: "chain of components",
+			components: []config.ComponentConfig{
+				{Name: "c", Path: "c", Namespace: "x", DependsOn: []string{"b"}},
+				{Name: "b", Path: "b", Namespace: "x", DependsOn: []string{"a"}},
+				{Name: "a", Path: "a", Namespace: "x", DependsOn: []string{"nebari-landingpage"}},
+			},
+			want: map[string]int{"a": 7, "b": 8, "c": 9},
+		},
+		{
+			name: "cycle between components",
+			components: []config.ComponentConfig{
+				{Name: "a", Path: "a", Namespace: "x", DependsOn: []string{"b"}},
+				{Name: "b", Path: "b", Namespace: "x", DependsOn: []string{"a"}},
+			},
+			errSubstr: "component dependency cycle: a -> b -> a",
+		},
+		{
+			name: "cycle through built-in order",
+			components: []config.ComponentConfig{
+				{Name: "late", Path: "late", Namespace: "x", DependsOn: []string{"keycloak"}},
+				{Name: "cert-manager", DependsOn: []string{"late"}},
+			},
+			errSubstr: "component dependency cycle",
+		},
+		{
+			name:       "unknown dependency",
+			components: []config.ComponentConfig{{Name: "a", Path: "a", Namespace: "x", DependsOn: []string{"vault"}}},
+			errSubstr:  `depends on unknown component "vault"`,
+		},
+		{
+			name:       "built-in with a source",
+			components: []config.ComponentConfig{{Name: "keycloak", Path: "keycloak"}},
+			errSubstr:  "only depends_on can be set",
+		},
+		{
+			name:       "component without a path",
+			components: []config.ComponentConfig{{Name: "a", Namespace: "x"}},
+			errSubstr:  "needs a path and a namespace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComponentWaves(tt.components)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("ComponentWaves() error = %v, want containing %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ComponentWaves() error = %v", err)
+			}
+			for name, wave := range tt.want {
+				if got[name] != wave {
+					t.Errorf("wave of %s = %d, want %d", name, got[name], wave)
+				}
+			}
+			if tt.components == nil && !maps.Equal(got, builtinSyncWaves) {
+				t.Errorf("ComponentWaves(nil) = %v, want the built-in waves", got)
+			}
+		})
+	}
+}
+
+func TestWriteAllToGit_Components(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.NebariConfig{
+		Domain: "test.example.com",
+		Components: []config.ComponentConfig{
+			{Name: "vault", Path: "components/vault", Namespace: "vault", DependsOn: []string{"cert-manager"}},
+			{Name: "keycloak", DependsOn: []string{"vault"}},
+		},
+	}
+	gitCfg := &git.Config{URL: "https://github.com/org/gitops.git", Branch: "main"}
+
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, gitCfg, additionalGatewaySettings(), ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+
+	vaultPath := filepath.Join(tmpDir, "apps", "component-vault.yaml")
+	vault := readManifest(t, vaultPath)
+	if vault.GetKind() != "Application" || vault.GetName() != "vault" {
+		t.Fatalf("component file holds %s %s", vault.GetKind(), vault.GetName())
+	}
+	if got := vault.GetAnnotations()["argocd.argoproj.io/sync-wave"]; got != "3" {
+		t.Errorf("vault sync-wave = %q, want 3", got)
+	}
+	content, _ := os.ReadFile(vaultPath) //nolint:gosec // test path
+	for _, want := range []string{"project: nebari-apps", "path: components/vault", "repoURL: " + gitCfg.URL, "namespace: vault"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("component application missing %q:\n%s", want, content)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "apps", "component-keycloak.yaml")); !os.IsNotExist(err) {
+		t.Errorf("a built-in with only depends_on should not get a component file (stat err %v)", err)
+	}
+
+	keycloak, _ := os.ReadFile(filepath.Join(tmpDir, "apps", "keycloak.yaml")) //nolint:gosec // test path
+	if !strings.Contains(string(keycloak), `sync-wave: "4"`) {
+		t.Errorf("keycloak should sync after vault in wave 4:\n%s", keycloak)
+	}
+	operator, _ := os.ReadFile(filepath.Join(tmpDir, "apps", "nebari-operator.yaml")) //nolint:gosec // test path
+	if !strings.Contains(string(operator), `sync-wave: "5"`) {
+		t.Errorf("nebari-operator should keep wave 5:\n%s", operator)
+	}
+
+	// Dropping the component removes its Application.
+	cfg.Components = nil
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, gitCfg, additionalGatewaySettings(), ""); err != nil {
+		t.Fatalf("second WriteAllToGit() error: %v", err)
+	}
+	if _, err := os.Stat(vaultPath); !os.IsNotExist(err) {
+		t.Errorf("stale component file not removed (stat err %v)", err)
+	}
+
+	cfg.Components = []config.ComponentConfig{{Name: "cert-manager", DependsOn: []string{"keycloak"}}}
+	err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, gitCfg, additionalGatewaySettings(), "")
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("WriteAllToGit() with a cycle error = %v", err)
+	}
+}
@@ -28,6 +28,11 @@ type Config struct {
 	// Timeout is the maximum time to wait for installation
 	Timeout time.Duration
 
+	// NoWait skips every readiness wait of Install: for the cluster nodes,
+	// the Helm release and the Argo CD deployments. Resources are applied
+	// and Install returns without checking that they came up.
+	NoWait bool
+
 	// Values are custom Helm values to apply
 	Values map[string]any
 }
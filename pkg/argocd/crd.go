@@ -0,0 +1,111 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// foundationalCRDGroups maps the API groups whose CRDs a foundational
+// Application installs to the name of that Application.
+var foundationalCRDGroups = map[string]string{
+	"gateway.networking.k8s.io": "envoy-gateway",
+	"gateway.envoyproxy.io":     "envoy-gateway",
+	"cert-manager.io":           "cert-manager",
+	"acme.cert-manager.io":      "cert-manager",
+	"trust.cert-manager.io":     "trust-manager",
+	"postgresql.cnpg.io":        "cloudnative-pg",
+}
+
+// How long applyResource waits for a foundational CRD to be served before
+// giving up. Variables so tests can shorten them.
+var (
+	crdWaitTimeout  = 30 * time.Second
+	crdPollInterval = 2 * time.Second
+)
+
+// MissingCRDError is returned when a resource cannot be applied because the
+// CRD defining its kind is not installed, typically because the foundational
+// Application that provides it has not synced yet.
+type MissingCRDError struct {
+	// GroupVersionKind is the kind of the resource that was being applied.
+	GroupVersionKind schema.GroupVersionKind
+	// Application is the foundational Application that installs the CRD.
+	Application string
+}
+
+func (e *MissingCRDError) Error() string {
+	return fmt.Sprintf("CRD for %s (%s) is not installed: it is provided by the foundational %q Application, which has not synced yet",
+		e.GroupVersionKind.Kind, e.GroupVersionKind.GroupVersion(), e.Application)
+}
+
+// ensureFoundationalCRD waits up to crdWaitTimeout for the API server to
+// serve gvk when gvk belongs to a group installed by a foundational
+// Application. Discovery only lists a CRD's kinds once it is Established.
+// Kinds of any other group are assumed to exist. On timeout it returns a
+// *MissingCRDError naming the Application.
+func ensureFoundationalCRD(ctx context.Context, disc discovery.DiscoveryInterface, gvk schema.GroupVersionKind) error {
+	application, ok := foundationalCRDGroups[gvk.Group]
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, crdWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(crdPollInterval)
+	defer ticker.Stop()
+
+	waiting := false
+	for {
+		served, err := servesKind(disc, gvk)
+		if err != nil {
+			return err
+		}
+		if served {
+			return nil
+		}
+
+		if !waiting {
+			waiting = true
+			status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Waiting for the %s CRD from %s", gvk.Kind, application)).
+				WithResource("crd").
+				WithAction("waiting").
+				WithMetadata("kind", gvk.Kind).
+				WithMetadata("group_version", gvk.GroupVersion().String()).
+				WithMetadata("application", application))
+		}
+
+		select {
+		case <-ctx.Done():
+			return &MissingCRDError{GroupVersionKind: gvk, Application: application}
+		case <-ticker.C:
+		}
+	}
+}
+
+// servesKind reports whether the API server lists gvk under its group
+// version.
+func servesKind(disc discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (bool, error) {
+	resources, err := disc.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to discover %s resources: %w", gvk.GroupVersion(), err)
+	}
+	for _, r := range resources.APIResources {
+		// Subresources such as certificates/status share the kind.
+		if r.Kind == gvk.Kind && !strings.Contains(r.Name, "/") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,176 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// shortCRDWait shortens the foundational CRD wait for the duration of a test.
+func shortCRDWait(t *testing.T) {
+	t.Helper()
+	timeout, interval := crdWaitTimeout, crdPollInterval
+	crdWaitTimeout, crdPollInterval = 50*time.Millisecond, time.Millisecond
+	t.Cleanup(func() { crdWaitTimeout, crdPollInterval = timeout, interval })
+}
+
+// newFakeDiscovery returns a fake discovery client serving resources.
+func newFakeDiscovery(resources ...*metav1.APIResourceList) *fakediscovery.FakeDiscovery {
+	disc := k8sfake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	disc.Resources = resources
+	return disc
+}
+
+var certManagerResources = &metav1.APIResourceList{
+	GroupVersion: "cert-manager.io/v1",
+	APIResources: []metav1.APIResource{
+		{Name: "certificates", Kind: "Certificate", Namespaced: true},
+		{Name: "certificates/status", Kind: "Certificate", Namespaced: true},
+		{Name: "clusterissuers", Kind: "ClusterIssuer"},
+	},
+}
+
+func newCertificate() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata":   map[string]any{"name": "addon-cert", "namespace": "addons"},
+		"spec":       map[string]any{"secretName": "addon-cert-tls"},
+	}}
+}
+
+var certificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+func TestApplyResource_MissingFoundationalCRD(t *testing.T) {
+	shortCRDWait(t)
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	err := applyResource(context.Background(), client, newFakeDiscovery(), newCertificate())
+
+	var missing *MissingCRDError
+	if !errors.As(err, &missing) {
+		t.Fatalf("applyResource() error = %v, want *MissingCRDError", err)
+	}
+	if missing.Application != "cert-manager" {
+		t.Errorf("Application = %q, want cert-manager", missing.Application)
+	}
+	want := `CRD for Certificate (cert-manager.io/v1) is not installed: it is provided by the foundational "cert-manager" Application`
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("applyResource() error = %q, want it to contain %q", err, want)
+	}
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Errorf("applyResource() sent %d requests before the CRD existed, want 0", len(actions))
+	}
+}
+
+func TestApplyResource_WaitsForFoundationalCRD(t *testing.T) {
+	shortCRDWait(t)
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	disc := newFakeDiscovery()
+	checks := 0
+	disc.PrependReactor("get", "resource", func(k8stesting.Action) (bool, runtime.Object, error) {
+		checks++
+		if checks == 3 {
+			disc.Resources = []*metav1.APIResourceList{certManagerResources}
+		}
+		return false, nil, nil
+	})
+
+	if err := applyResource(context.Background(), client, disc, newCertificate()); err != nil {
+		t.Fatalf("applyResource() error = %v", err)
+	}
+	if checks != 3 {
+		t.Errorf("discovery checked %d times, want 3", checks)
+	}
+	if _, err := client.Resource(certificateGVR).Namespace("addons").Get(context.Background(), "addon-cert", metav1.GetOptions{}); err != nil {
+		t.Errorf("Certificate not applied: %v", err)
+	}
+}
+
+func TestEnsureFoundationalCRD(t *testing.T) {
+	gatewayAPIRoutesOnly := &metav1.APIResourceList{
+		GroupVersion: "gateway.networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "httproutes", Kind: "HTTPRoute", Namespaced: true}},
+	}
+
+	tests := []struct {
+		name            string
+		gvk             schema.GroupVersionKind
+		resources       []*metav1.APIResourceList
+		wantApplication string
+	}{
+		{
+			name: "core kind is not checked",
+			gvk:  schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		},
+		{
+			name: "unknown group is not checked",
+			gvk:  schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"},
+		},
+		{
+			name:      "served foundational kind",
+			gvk:       schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "ClusterIssuer"},
+			resources: []*metav1.APIResourceList{certManagerResources},
+		},
+		{
+			name:            "missing group version",
+			gvk:             schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"},
+			wantApplication: "envoy-gateway",
+		},
+		{
+			name:            "group version served without the kind",
+			gvk:             schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"},
+			resources:       []*metav1.APIResourceList{gatewayAPIRoutesOnly},
+			wantApplication: "envoy-gateway",
+		},
+		{
+			name:            "trust-manager bundle",
+			gvk:             schema.GroupVersionKind{Group: "trust.cert-manager.io", Version: "v1alpha1", Kind: "Bundle"},
+			resources:       []*metav1.APIResourceList{certManagerResources},
+			wantApplication: "trust-manager",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortCRDWait(t)
+			err := ensureFoundationalCRD(context.Background(), newFakeDiscovery(tt.resources...), tt.gvk)
+			if tt.wantApplication == "" {
+				if err != nil {
+					t.Errorf("ensureFoundationalCRD() error = %v", err)
+				}
+				return
+			}
+			var missing *MissingCRDError
+			if !errors.As(err, &missing) {
+				t.Fatalf("ensureFoundationalCRD() error = %v, want *MissingCRDError", err)
+			}
+			if missing.Application != tt.wantApplication || missing.GroupVersionKind != tt.gvk {
+				t.Errorf("MissingCRDError = %+v, want %s from %s", missing, tt.gvk, tt.wantApplication)
+			}
+		})
+	}
+}
+
+func TestEnsureFoundationalCRD_DiscoveryError(t *testing.T) {
+	disc := newFakeDiscovery()
+	disc.PrependReactor("get", "resource", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	err := ensureFoundationalCRD(context.Background(), disc, schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"})
+	if err == nil || !strings.Contains(err.Error(), "failed to discover cert-manager.io/v1 resources: connection refused") {
+		t.Errorf("ensureFoundationalCRD() error = %v, want discovery error", err)
+	}
+}
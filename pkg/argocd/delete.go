@@ -0,0 +1,270 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// Cascade modes for DeleteApplications. They mirror `argocd app delete
+// --cascade --propagation-policy`.
+const (
+	// CascadeOrphan deletes the Application objects and leaves the resources
+	// they manage in the cluster.
+	CascadeOrphan = "orphan"
+
+	// CascadeForeground deletes each Application's resources, waiting for
+	// their dependents, before the Application itself is removed.
+	CascadeForeground = "foreground"
+
+	// CascadeBackground deletes each Application's resources without waiting
+	// for their dependents.
+	CascadeBackground = "background"
+)
+
+// CascadeModes lists the valid DeleteApplicationsOptions.Cascade values.
+var CascadeModes = []string{CascadeOrphan, CascadeForeground, CascadeBackground}
+
+const (
+	// resourcesFinalizer makes Argo CD delete an Application's resources
+	// before the Application. The "/background" suffix selects background
+	// propagation; the bare name is foreground.
+	resourcesFinalizer = "resources-finalizer.argocd.argoproj.io"
+
+	// rootApplicationName is the App-of-Apps applied by ApplyRootAppOfApps.
+	rootApplicationName = "nebari-root"
+
+	// syncWaveAnnotation orders the sync, and the deletion, of Applications.
+	syncWaveAnnotation = "argocd.argoproj.io/sync-wave"
+
+	defaultDeleteTimeout      = 10 * time.Minute
+	defaultDeletePollInterval = 5 * time.Second
+)
+
+// DeleteApplicationsOptions configures DeleteApplications.
+type DeleteApplicationsOptions struct {
+	// Cascade is one of CascadeModes.
+	Cascade string
+
+	// Timeout bounds the wait for the Applications (and, when cascading,
+	// their resources) to be gone. Defaults to 10 minutes.
+	Timeout time.Duration
+
+	// PollInterval is how often deletion progress is checked. Defaults to 5s.
+	PollInterval time.Duration
+}
+
+// DeleteFoundationalApplications deletes the NIC-managed Argo CD Applications
+// in the cluster described by kubeconfigBytes. See DeleteApplications.
+func DeleteFoundationalApplications(ctx context.Context, kubeconfigBytes []byte, opts DeleteApplicationsOptions) error {
+	dynamicClient, err := NewDynamicClient(kubeconfigBytes)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return DeleteApplications(ctx, dynamicClient, defaultNamespace, opts)
+}
+
+// DeleteApplications deletes every Application in namespace labelled as
+// managed by NIC and waits until they are gone. With a cascading mode each
+// Application gets the Argo CD resources finalizer first, so Argo CD removes
+// the resources it manages (load balancers, volumes) before the Application
+// disappears; with CascadeOrphan the finalizer is removed instead.
+//
+// The root App-of-Apps is always deleted first and without cascading: it
+// would otherwise recreate the child Applications from git, or delete them
+// ignoring their own cascade settings. The children are then deleted in
+// reverse dependency order, latest sync wave first, each wave waiting for the
+// previous one to be gone, so controllers (cert-manager, Envoy Gateway) are
+// still running while the resources they finalize are removed.
+// The client parameter allows for dependency injection - use NewDynamicClient for production
+// or fake.NewSimpleDynamicClient for tests.
+func DeleteApplications(ctx context.Context, client dynamic.Interface, namespace string, opts DeleteApplicationsOptions) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "argocd.DeleteApplications")
+	defer span.End()
+
+	if !slices.Contains(CascadeModes, opts.Cascade) {
+		err := fmt.Errorf("invalid cascade mode %q (must be one of: %s)", opts.Cascade, strings.Join(CascadeModes, ", "))
+		span.RecordError(err)
+		return err
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultDeleteTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultDeletePollInterval
+	}
+	span.SetAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("cascade", opts.Cascade),
+		attribute.String("timeout", opts.Timeout.String()),
+	)
+
+	apps := client.Resource(ApplicationGVR).Namespace(namespace)
+	listOpts := metav1.ListOptions{LabelSelector: ManagedByLabel + "=" + NebariManagedByValue}
+	list, err := apps.List(ctx, listOpts)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to list Argo CD Applications: %w", err)
+	}
+	if len(list.Items) == 0 {
+		status.Info(ctx, "No Argo CD Applications to delete")
+		return nil
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Deleting %d Argo CD Applications", len(list.Items))).
+		WithResource("argocd-application").
+		WithAction("deleting").
+		WithMetadata("cascade", opts.Cascade))
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// Root first, so nothing recreates the children while they are deleted.
+	for _, wave := range deletionWaves(list.Items) {
+		for _, app := range wave {
+			mode := opts.Cascade
+			if app.GetName() == rootApplicationName {
+				mode = CascadeOrphan
+			}
+			if err := deleteApplication(ctx, apps, app, mode); err != nil {
+				span.RecordError(err)
+				return err
+			}
+		}
+		if err := waitForApplicationsDeleted(waitCtx, apps, listOpts, applicationNames(wave), opts.PollInterval); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelSuccess, "Argo CD Applications deleted").
+		WithResource("argocd-application").
+		WithAction("deleted").
+		WithMetadata("cascade", opts.Cascade))
+	return nil
+}
+
+// deleteApplication sets the finalizer matching mode on app and deletes it.
+func deleteApplication(ctx context.Context, apps dynamic.ResourceInterface, app *unstructured.Unstructured, mode string) error {
+	name := app.GetName()
+
+	finalizers := applicationFinalizers(app.GetFinalizers(), mode)
+	if !slices.Equal(finalizers, app.GetFinalizers()) {
+		app.SetFinalizers(finalizers)
+		if _, err := apps.Update(ctx, app, metav1.UpdateOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to set finalizers on Argo CD Application %s: %w", name, err)
+		}
+	}
+
+	propagation := metav1.DeletePropagationForeground
+	if mode == CascadeBackground {
+		propagation = metav1.DeletePropagationBackground
+	}
+	err := apps.Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete Argo CD Application %s: %w", name, err)
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Deleting Argo CD Application: %s", name)).
+		WithResource("argocd-application").
+		WithAction("deleting").
+		WithMetadata("application", name).
+		WithMetadata("cascade", mode))
+	return nil
+}
+
+// applicationFinalizers returns existing with any Argo CD resources
+// finalizer replaced by the one mode needs (none for CascadeOrphan).
+func applicationFinalizers(existing []string, mode string) []string {
+	finalizers := slices.DeleteFunc(slices.Clone(existing), func(f string) bool {
+		return f == resourcesFinalizer || strings.HasPrefix(f, resourcesFinalizer+"/")
+	})
+	switch mode {
+	case CascadeForeground:
+		finalizers = append(finalizers, resourcesFinalizer)
+	case CascadeBackground:
+		finalizers = append(finalizers, resourcesFinalizer+"/background")
+	}
+	return finalizers
+}
+
+// deletionWaves groups apps into the order they are deleted in: the root
+// App-of-Apps on its own, then the children by descending sync wave.
+// Applications without a parsable sync-wave annotation are in wave 0.
+func deletionWaves(apps []unstructured.Unstructured) [][]*unstructured.Unstructured {
+	var root []*unstructured.Unstructured
+	byWave := map[int][]*unstructured.Unstructured{}
+	for i := range apps {
+		app := &apps[i]
+		if app.GetName() == rootApplicationName {
+			root = append(root, app)
+			continue
+		}
+		wave, _ := strconv.Atoi(app.GetAnnotations()[syncWaveAnnotation])
+		byWave[wave] = append(byWave[wave], app)
+	}
+
+	var waves [][]*unstructured.Unstructured
+	if len(root) > 0 {
+		waves = append(waves, root)
+	}
+	for _, wave := range slices.Backward(slices.Sorted(maps.Keys(byWave))) {
+		waves = append(waves, byWave[wave])
+	}
+	return waves
+}
+
+// applicationNames returns the set of names of apps.
+func applicationNames(apps []*unstructured.Unstructured) map[string]bool {
+	names := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		names[app.GetName()] = true
+	}
+	return names
+}
+
+// waitForApplicationsDeleted polls until none of the Applications matching
+// listOpts is named in names, or ctx is done.
+func waitForApplicationsDeleted(ctx context.Context, apps dynamic.ResourceInterface, listOpts metav1.ListOptions, names map[string]bool, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var pending []string
+		list, err := apps.List(ctx, listOpts)
+		if err == nil {
+			for _, item := range list.Items {
+				if names[item.GetName()] {
+					pending = append(pending, item.GetName())
+				}
+			}
+			if len(pending) == 0 {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			remaining := "unknown"
+			if err == nil {
+				remaining = strings.Join(pending, ", ")
+			}
+			return fmt.Errorf("timeout waiting for Argo CD Applications to be deleted (remaining: %s): %w", remaining, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
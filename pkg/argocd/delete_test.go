@@ -0,0 +1,159 @@
+package argocd
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newApplication(name string, managed bool, finalizers ...string) *unstructured.Unstructured {
+	app := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": name, "namespace": defaultNamespace},
+	}}
+	if managed {
+		app.SetLabels(map[string]string{ManagedByLabel: NebariManagedByValue})
+	}
+	app.SetFinalizers(finalizers)
+	return app
+}
+
+func TestDeleteApplications(t *testing.T) {
+	tests := []struct {
+		name          string
+		cascade       string
+		wantFinalizer []string
+	}{
+		{name: "foreground cascade", cascade: CascadeForeground, wantFinalizer: []string{resourcesFinalizer}},
+		{name: "background cascade", cascade: CascadeBackground, wantFinalizer: []string{resourcesFinalizer + "/background"}},
+		{name: "orphan", cascade: CascadeOrphan, wantFinalizer: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listKinds := map[schema.GroupVersionResource]string{ApplicationGVR: "ApplicationList"}
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+				newApplication(rootApplicationName, true, resourcesFinalizer),
+				newApplication("keycloak", true),
+				newApplication("cert-manager", true, resourcesFinalizer+"/background"),
+				newApplication("user-app", false, resourcesFinalizer),
+			)
+
+			// Record each Application's finalizers as stored when it is deleted.
+			finalizers := map[string][]string{}
+			var deleted []string
+			client.PrependReactor("delete", "applications", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				name := action.(k8stesting.DeleteAction).GetName()
+				obj, err := client.Tracker().Get(ApplicationGVR, defaultNamespace, name)
+				if err != nil {
+					t.Fatalf("delete of missing Application %s", name)
+				}
+				finalizers[name] = obj.(*unstructured.Unstructured).GetFinalizers()
+				deleted = append(deleted, name)
+				return false, nil, nil
+			})
+
+			err := DeleteApplications(context.Background(), client, defaultNamespace, DeleteApplicationsOptions{
+				Cascade:      tt.cascade,
+				Timeout:      time.Second,
+				PollInterval: 10 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatalf("DeleteApplications() error = %v", err)
+			}
+
+			if len(deleted) != 3 || deleted[0] != rootApplicationName {
+				t.Errorf("deleted %v, want the root first and then the two managed children", deleted)
+			}
+			if slices.Contains(deleted, "user-app") {
+				t.Error("deleted an Application not managed by NIC")
+			}
+			if got := finalizers[rootApplicationName]; len(got) != 0 {
+				t.Errorf("root finalizers = %v, want none so it does not cascade to children", got)
+			}
+			for _, name := range []string{"keycloak", "cert-manager"} {
+				if got := finalizers[name]; !slices.Equal(got, tt.wantFinalizer) {
+					t.Errorf("%s finalizers at delete = %v, want %v", name, got, tt.wantFinalizer)
+				}
+			}
+
+			if _, err := client.Resource(ApplicationGVR).Namespace(defaultNamespace).Get(context.Background(), "user-app", metav1.GetOptions{}); err != nil {
+				t.Errorf("unmanaged Application was removed: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteApplications_InvalidCascade(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	if err := DeleteApplications(context.Background(), client, defaultNamespace, DeleteApplicationsOptions{Cascade: "cascade"}); err == nil {
+		t.Error("DeleteApplications() with an invalid cascade mode should fail")
+	}
+}
+
+func TestDeleteApplications_TimesOutWhileFinalizing(t *testing.T) {
+	listKinds := map[schema.GroupVersionResource]string{ApplicationGVR: "ApplicationList"}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+		newApplication("keycloak", true))
+	// Argo CD never finishes removing the resources, so the object stays.
+	client.PrependReactor("delete", "applications", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	err := DeleteApplications(context.Background(), client, defaultNamespace, DeleteApplicationsOptions{
+		Cascade:      CascadeForeground,
+		Timeout:      50 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("DeleteApplications() should time out while an Application is finalizing")
+	}
+	if want := "remaining: keycloak"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %v, want it to name %q", err, want)
+	}
+}
+
+func TestDeleteApplications_ReverseSyncWaveOrder(t *testing.T) {
+	withWave := func(app *unstructured.Unstructured, wave string) *unstructured.Unstructured {
+		app.SetAnnotations(map[string]string{syncWaveAnnotation: wave})
+		return app
+	}
+	listKinds := map[schema.GroupVersionResource]string{ApplicationGVR: "ApplicationList"}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+		withWave(newApplication("envoy-gateway", true), "1"),
+		withWave(newApplication("keycloak", true), "4"),
+		newApplication(rootApplicationName, true),
+		withWave(newApplication("cert-manager", true), "2"),
+		withWave(newApplication("vault", true), "0"),
+	)
+
+	var deleted []string
+	client.PrependReactor("delete", "applications", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		deleted = append(deleted, action.(k8stesting.DeleteAction).GetName())
+		return false, nil, nil
+	})
+
+	err := DeleteApplications(context.Background(), client, defaultNamespace, DeleteApplicationsOptions{
+		Cascade:      CascadeForeground,
+		Timeout:      time.Second,
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("DeleteApplications() error = %v", err)
+	}
+
+	want := []string{rootApplicationName, "keycloak", "cert-manager", "envoy-gateway", "vault"}
+	if !slices.Equal(deleted, want) {
+		t.Errorf("deleted %v, want %v", deleted, want)
+	}
+}
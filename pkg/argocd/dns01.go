@@ -0,0 +1,80 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+const (
+	// dns01SolverCloudflare selects cert-manager's Cloudflare DNS-01 solver.
+	dns01SolverCloudflare = "cloudflare"
+	// dns01SecretName and dns01SecretKey locate the DNS provider API token
+	// the letsencrypt ClusterIssuer's DNS-01 solver reads. cert-manager looks
+	// up ClusterIssuer secrets in its own namespace.
+	dns01SecretName = "acme-dns01-api-token"
+	dns01SecretKey  = "api-token"
+	// certManagerNamespace is where the cert-manager app is installed.
+	certManagerNamespace = "cert-manager"
+)
+
+// ACMEDNS01Solver returns the cert-manager DNS-01 solver Let's Encrypt
+// certificates for cfg are issued with, chosen by the DNS provider
+// cfg.ACMEDNS returns. DNS-01 lets the gateway certificate cover *.domain. Returns ""
+// for other certificate types and for DNS providers without a supported
+// solver, which keep using HTTP-01.
+func ACMEDNS01Solver(cfg *config.NebariConfig) string {
+	if cfg.Certificate == nil || cfg.Certificate.Type != config.CertificateTypeLetsEncrypt {
+		return ""
+	}
+	switch cfg.ACMEDNS().ProviderName() {
+	case "cloudflare":
+		return dns01SolverCloudflare
+	default:
+		return ""
+	}
+}
+
+// createDNS01Secret creates or updates the API token Secret the DNS-01
+// solver authenticates to the DNS provider with. Unlike the generated
+// credentials in createSecret, the token comes from the operator's
+// environment and may be rotated, so an existing Secret is overwritten.
+func createDNS01Secret(ctx context.Context, client kubernetes.Interface, token string) error {
+	if err := createNamespace(ctx, client, certManagerNamespace); err != nil {
+		return fmt.Errorf("ensure namespace %s: %w", certManagerNamespace, err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dns01SecretName,
+			Namespace: certManagerNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "cert-manager",
+				ManagedByLabel:           NebariManagedByValue,
+			},
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{dns01SecretKey: token},
+	}
+
+	secrets := client.CoreV1().Secrets(certManagerNamespace)
+	if _, err := secrets.Get(ctx, dns01SecretName, metav1.GetOptions{}); err != nil {
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("create secret %s/%s: %w", certManagerNamespace, dns01SecretName, err)
+		}
+	} else if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("update secret %s/%s: %w", certManagerNamespace, dns01SecretName, err)
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Configured DNS-01 API token for Let's Encrypt").
+		WithResource("secret").
+		WithAction("configured").
+		WithMetadata("secret_name", dns01SecretName))
+	return nil
+}
@@ -0,0 +1,132 @@
+package argocd
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func dns01Config(certType, dnsProvider string) *config.NebariConfig {
+	cfg := &config.NebariConfig{Domain: "example.com"}
+	if certType != "" {
+		cfg.Certificate = &config.CertificateConfig{Type: certType, ACME: &config.ACMEConfig{Email: "admin@example.com"}}
+	}
+	if dnsProvider != "" {
+		cfg.DNS = &config.DNSConfig{Providers: map[string]any{dnsProvider: map[string]any{"zone_name": "example.com"}}}
+	}
+	return cfg
+}
+
+// internalDNS01Config is a Let's Encrypt config for an internal gateway whose
+// records, and DNS-01 challenges, go to a Cloudflare private_dns zone.
+func internalDNS01Config() *config.NebariConfig {
+	cfg := dns01Config(config.CertificateTypeLetsEncrypt, "")
+	cfg.Gateway = &config.GatewayConfig{
+		Internal:   true,
+		PrivateDNS: &config.DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{"zone_name": "example.com"}}},
+	}
+	return cfg
+}
+
+func TestACMEDNS01Solver(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.NebariConfig
+		want string
+	}{
+		{name: "letsencrypt with cloudflare", cfg: dns01Config(config.CertificateTypeLetsEncrypt, "cloudflare"), want: dns01SolverCloudflare},
+		{name: "letsencrypt without dns", cfg: dns01Config(config.CertificateTypeLetsEncrypt, "")},
+		{name: "letsencrypt with unsupported dns", cfg: dns01Config(config.CertificateTypeLetsEncrypt, "route53")},
+		{name: "selfsigned with cloudflare", cfg: dns01Config(config.CertificateTypeSelfSigned, "cloudflare")},
+		{name: "no certificate block", cfg: dns01Config("", "cloudflare")},
+		{name: "internal gateway with cloudflare private_dns", cfg: internalDNS01Config(), want: dns01SolverCloudflare},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ACMEDNS01Solver(tt.cfg); got != tt.want {
+				t.Errorf("ACMEDNS01Solver() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLetsEncryptDNS01Manifests(t *testing.T) {
+	settings := cluster.InfraSettings{LonghornEnabled: true}
+
+	t.Run("cloudflare", func(t *testing.T) {
+		data := NewTemplateData(dns01Config(config.CertificateTypeLetsEncrypt, "cloudflare"), nil, settings)
+
+		issuer, err := renderEmbedded("manifests/security/issuers/letsencrypt-clusterissuer.yaml", data)
+		if err != nil {
+			t.Fatalf("render issuer: %v", err)
+		}
+		solvers, _, _ := unstructured.NestedSlice(issuer.Object, "spec", "acme", "solvers")
+		if len(solvers) != 1 {
+			t.Fatalf("solvers = %v, want one", solvers)
+		}
+		ref, _, _ := unstructured.NestedStringMap(solvers[0].(map[string]any), "dns01", "cloudflare", "apiTokenSecretRef")
+		if ref["name"] != dns01SecretName || ref["key"] != dns01SecretKey {
+			t.Errorf("cloudflare apiTokenSecretRef = %v, want %s/%s", ref, dns01SecretName, dns01SecretKey)
+		}
+
+		names, err := certificateDNSNames(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"example.com", "*.example.com"}; !slices.Equal(names, want) {
+			t.Errorf("dnsNames = %v, want %v", names, want)
+		}
+		if err := checkHTTP01Certificate(data); err != nil {
+			t.Errorf("checkHTTP01Certificate() = %v, want nil for DNS-01", err)
+		}
+		hosts, err := HTTP01Hostnames(dns01Config(config.CertificateTypeLetsEncrypt, "cloudflare"), settings)
+		if err != nil || hosts != nil {
+			t.Errorf("HTTP01Hostnames() = %v, %v; want nil for DNS-01", hosts, err)
+		}
+	})
+
+	t.Run("no dns provider keeps http01", func(t *testing.T) {
+		data := NewTemplateData(dns01Config(config.CertificateTypeLetsEncrypt, ""), nil, settings)
+		issuer, err := renderEmbedded("manifests/security/issuers/letsencrypt-clusterissuer.yaml", data)
+		if err != nil {
+			t.Fatalf("render issuer: %v", err)
+		}
+		solvers, _, _ := unstructured.NestedSlice(issuer.Object, "spec", "acme", "solvers")
+		if len(solvers) != 1 || solvers[0].(map[string]any)["http01"] == nil {
+			t.Errorf("solvers = %v, want a single http01 solver", solvers)
+		}
+		names, err := certificateDNSNames(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slices.Contains(names, "*.example.com") {
+			t.Errorf("HTTP-01 certificate requests a wildcard: %v", names)
+		}
+	})
+}
+
+func TestCreateDNS01Secret(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+
+	for _, token := range []string{"first-token", "rotated-token"} {
+		if err := createDNS01Secret(ctx, client, token); err != nil {
+			t.Fatalf("createDNS01Secret(%q) error: %v", token, err)
+		}
+		secret, err := client.CoreV1().Secrets(certManagerNamespace).Get(ctx, dns01SecretName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get secret: %v", err)
+		}
+		if got := secret.StringData[dns01SecretKey]; got != token {
+			t.Errorf("secret %s = %q, want %q", dns01SecretKey, got, token)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
@@ -53,6 +54,14 @@ const (
 	// value is written into both the keycloak namespace (read by realm-setup-job) and
 	// the longhorn-system namespace (read by the SecurityPolicy that fronts the UI).
 	LonghornOIDCClientSecretName = "longhorn-oidc-client-secret" //nolint:gosec // Secret name reference, not a credential
+
+	// MonitoringNamespace is the namespace of the OpenTelemetry Collector and
+	// the optional Prometheus and Grafana stack.
+	MonitoringNamespace = "monitoring"
+
+	// GrafanaAdminSecretName is the name of the Kubernetes secret holding the
+	// Grafana admin credentials, referenced by the monitoring Helm values.
+	GrafanaAdminSecretName = "grafana-admin-credentials" //nolint:gosec // Secret name reference, not a credential
 )
 
 // FoundationalConfig holds configuration for foundational services
@@ -72,6 +81,9 @@ type FoundationalConfig struct {
 	// MetalLB configuration (local deployments only)
 	MetalLB MetalLBConfig
 
+	// Monitoring configuration (Prometheus and Grafana)
+	Monitoring MonitoringConfig
+
 	// Backups configures Longhorn backup credentials (nil when disabled).
 	Backups *config.LonghornBackupConfig
 
@@ -79,6 +91,10 @@ type FoundationalConfig struct {
 	// target. Written to the credential Secret as AWS_IAM_ROLE_ARN so Longhorn
 	// accepts it without static keys. Empty for static-key / Azure targets.
 	BackupRoleARN string
+
+	// DNS01APIToken is the DNS provider API token the Let's Encrypt DNS-01
+	// solver uses. Empty when certificates are not issued over DNS-01.
+	DNS01APIToken string
 }
 
 // KeycloakConfig holds Keycloak-specific configuration
@@ -87,6 +103,7 @@ type KeycloakConfig struct {
 	AdminPassword         string
 	AdminUsername         string
 	DBPassword            string // Password for keycloak DB user
+	ExternalDatabase      bool   // DBPassword is the external database's; no in-cluster PostgreSQL
 	PostgresAdminPassword string // Password for postgres superuser
 	PostgresUserPassword  string // Password for postgres regular user
 	Hostname              string
@@ -105,6 +122,12 @@ type MetalLBConfig struct {
 	AddressPool string // e.g., "192.168.1.100-192.168.1.110"
 }
 
+// MonitoringConfig holds monitoring stack configuration.
+// GrafanaAdminPassword is empty when the monitoring stack is disabled.
+type MonitoringConfig struct {
+	GrafanaAdminPassword string
+}
+
 // ArgoCDSSOConfig holds ArgoCD SSO configuration
 type ArgoCDSSOConfig struct {
 	ClientSecret string // Pre-generated OIDC client secret for ArgoCD's Keycloak integration
@@ -143,7 +166,7 @@ func InstallFoundationalServices(ctx context.Context, cfg *config.NebariConfig,
 		WithAction("installing"))
 
 	// Get kubeconfig from provider
-	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ProjectName, cfg.Cluster)
+	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ResourceName(), cfg.Cluster)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -223,6 +246,54 @@ func InstallFoundationalServices(ctx context.Context, cfg *config.NebariConfig,
 		}
 	}
 
+	// Create the Grafana admin credentials the monitoring Application
+	// references. Not gated on Keycloak; Grafana signs in with its own admin.
+	if foundationalCfg.Monitoring.GrafanaAdminPassword != "" {
+		k8sClient, err := newK8sClient(kubeconfigBytes)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
+		if err := createMonitoringSecrets(ctx, k8sClient, foundationalCfg.Monitoring); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to create monitoring secrets: %w", err)
+		}
+	}
+
+	// Create the DNS-01 API token Secret the letsencrypt ClusterIssuer
+	// (synced from git) references.
+	if foundationalCfg.DNS01APIToken != "" {
+		k8sClient, err := newK8sClient(kubeconfigBytes)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
+		if err := createDNS01Secret(ctx, k8sClient, foundationalCfg.DNS01APIToken); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to create DNS-01 secret: %w", err)
+		}
+	}
+
+	// Apply user kustomizations. They may depend on the namespaces and
+	// secrets created above, and run before the root App-of-Apps so add-ons
+	// the GitOps apps rely on already exist when ArgoCD starts syncing.
+	if len(cfg.Kustomizations) > 0 {
+		dynamicClient, err := NewDynamicClient(kubeconfigBytes)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to create dynamic client: %w", err)
+		}
+		k8sClient, err := newK8sClient(kubeconfigBytes)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
+		if err := ApplyKustomizations(ctx, dynamicClient, k8sClient.Discovery(), filesys.MakeFsOnDisk(), cfg.Kustomizations); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to apply kustomizations: %w", err)
+		}
+	}
+
 	// 3. Apply root App-of-Apps if git configuration is available
 	if gitConfig != nil {
 		if err := ApplyRootAppOfApps(ctx, kubeconfigBytes, gitConfig); err != nil {
@@ -378,6 +449,44 @@ func createSecret(ctx context.Context, client kubernetes.Interface, secret *core
 	return nil
 }
 
+// createOrUpdateSecretData creates the secret, or replaces the data of an
+// existing one when its StringData differs. For operator-supplied credentials
+// that rotate, unlike the generated ones createSecret never overwrites.
+func createOrUpdateSecretData(ctx context.Context, client kubernetes.Interface, secret *corev1.Secret) error {
+	namespace := secret.Namespace
+	existing, err := client.CoreV1().Secrets(namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret %s: %w", secret.Name, err)
+		}
+		if _, err := client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create secret %s: %w", secret.Name, err)
+		}
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Created secret %s", secret.Name)).
+			WithResource("secret").
+			WithAction("created").
+			WithMetadata("secret_name", secret.Name))
+		return nil
+	}
+
+	data := make(map[string][]byte, len(secret.StringData))
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+	if reflect.DeepEqual(existing.Data, data) {
+		return nil
+	}
+	existing.Data = data
+	if _, err := client.CoreV1().Secrets(namespace).Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", secret.Name, err)
+	}
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Updated secret %s", secret.Name)).
+		WithResource("secret").
+		WithAction("updated").
+		WithMetadata("secret_name", secret.Name))
+	return nil
+}
+
 // createKeycloakSecrets creates the required secrets for Keycloak and PostgreSQL
 func createKeycloakSecrets(ctx context.Context, client kubernetes.Interface, keycloakCfg KeycloakConfig, argocdSSO ArgoCDSSOConfig) error {
 	namespace := KeycloakDefaultNamespace
@@ -390,15 +499,18 @@ func createKeycloakSecrets(ctx context.Context, client kubernetes.Interface, key
 		},
 		Type: corev1.SecretTypeOpaque,
 		StringData: map[string]string{
-			"admin-username": keycloakCfg.AdminUsername,
-			"admin-password": keycloakCfg.AdminPassword,
+			"admin-username":         keycloakCfg.AdminUsername,
+			KeycloakAdminPasswordKey: keycloakCfg.AdminPassword,
 		},
 	}); err != nil {
 		return err
 	}
 
-	// 2. Create Keycloak PostgreSQL user credentials secret
-	if err := createSecret(ctx, client, &corev1.Secret{
+	// 2. Create Keycloak PostgreSQL user credentials secret. The password of
+	// an external database is operator-supplied and may rotate, so it is
+	// kept in sync; without one there is no in-cluster PostgreSQL to create
+	// credentials for.
+	dbSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "keycloak-postgresql-credentials",
 			Namespace: namespace,
@@ -407,23 +519,30 @@ func createKeycloakSecrets(ctx context.Context, client kubernetes.Interface, key
 		StringData: map[string]string{
 			"password": keycloakCfg.DBPassword,
 		},
-	}); err != nil {
-		return err
 	}
+	if keycloakCfg.ExternalDatabase {
+		if err := createOrUpdateSecretData(ctx, client, dbSecret); err != nil {
+			return err
+		}
+	} else {
+		if err := createSecret(ctx, client, dbSecret); err != nil {
+			return err
+		}
 
-	// 3. Create PostgreSQL main credentials secret (for PostgreSQL deployment)
-	if err := createSecret(ctx, client, &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "postgresql-credentials",
-			Namespace: namespace,
-		},
-		Type: corev1.SecretTypeOpaque,
-		StringData: map[string]string{
-			"postgres-password": keycloakCfg.PostgresAdminPassword,
-			"user-password":     keycloakCfg.PostgresUserPassword,
-		},
-	}); err != nil {
-		return err
+		// 3. Create PostgreSQL main credentials secret (for PostgreSQL deployment)
+		if err := createSecret(ctx, client, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "postgresql-credentials",
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			StringData: map[string]string{
+				"postgres-password": keycloakCfg.PostgresAdminPassword,
+				"user-password":     keycloakCfg.PostgresUserPassword,
+			},
+		}); err != nil {
+			return err
+		}
 	}
 
 	// 4. Create Nebari realm admin credentials secret
@@ -571,6 +690,37 @@ func createLonghornSecrets(ctx context.Context, client kubernetes.Interface, lon
 	return nil
 }
 
+// createMonitoringSecrets creates the monitoring namespace and the Grafana
+// admin credentials secret. The namespace carries the nebari.dev/managed
+// label the opentelemetry-collector Application would otherwise set when it
+// creates it, so NebariApps in it are still reconciled.
+func createMonitoringSecrets(ctx context.Context, client kubernetes.Interface, monitoringCfg MonitoringConfig) error {
+	if _, err := client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   MonitoringNamespace,
+			Labels: map[string]string{"nebari.dev/managed": "true"},
+		},
+	}, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", MonitoringNamespace, err)
+	}
+
+	return createSecret(ctx, client, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GrafanaAdminSecretName,
+			Namespace: MonitoringNamespace,
+			Labels: map[string]string{
+				PartOfLabel:    NebariFoundationalPartOf,
+				ManagedByLabel: NebariManagedByValue,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			"admin-user":            adminUsername,
+			GrafanaAdminPasswordKey: monitoringCfg.GrafanaAdminPassword,
+		},
+	})
+}
+
 // createLandingPageSecrets creates the required secrets for the nebari-landing service
 func createLandingPageSecrets(ctx context.Context, client kubernetes.Interface, landingCfg LandingPageConfig) error {
 	namespace := NebariSystemNamespace
@@ -218,6 +218,49 @@ func TestCreateKeycloakSecrets(t *testing.T) {
 			t.Errorf("secret should not be overwritten, got %q, want %q", got, "existing-password")
 		}
 	})
+
+	t.Run("external database updates the password", func(t *testing.T) {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "keycloak",
+			},
+		}
+		existingSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "keycloak-postgresql-credentials",
+				Namespace: "keycloak",
+			},
+			Data: map[string][]byte{
+				"password": []byte("old-password"),
+			},
+		}
+		client := fake.NewSimpleClientset(ns, existingSecret)
+
+		cfg := KeycloakConfig{
+			Enabled:          true,
+			AdminPassword:    "admin-pass",
+			DBPassword:       "rotated-password",
+			ExternalDatabase: true,
+		}
+
+		err := createKeycloakSecrets(ctx, client, cfg, ArgoCDSSOConfig{})
+		if err != nil {
+			t.Fatalf("createKeycloakSecrets() error = %v", err)
+		}
+
+		secret, err := client.CoreV1().Secrets("keycloak").Get(ctx, "keycloak-postgresql-credentials", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get db secret: %v", err)
+		}
+		if got := getSecretValue(secret, "password"); got != "rotated-password" {
+			t.Errorf("db password = %q, want %q", got, "rotated-password")
+		}
+
+		// No in-cluster PostgreSQL, so no credentials for it
+		if _, err := client.CoreV1().Secrets("keycloak").Get(ctx, "postgresql-credentials", metav1.GetOptions{}); err == nil {
+			t.Error("postgresql-credentials was created for an external database")
+		}
+	})
 }
 
 func TestFoundationalConfig(t *testing.T) {
@@ -362,6 +405,56 @@ func TestCreateKeycloakSecrets_SkipsArgoCDSecretWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestCreateMonitoringSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the labelled namespace and the admin secret", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		if err := createMonitoringSecrets(ctx, client, MonitoringConfig{GrafanaAdminPassword: "grafana-pass"}); err != nil {
+			t.Fatalf("createMonitoringSecrets() error = %v", err)
+		}
+
+		ns, err := client.CoreV1().Namespaces().Get(ctx, "monitoring", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get namespace: %v", err)
+		}
+		if ns.Labels["nebari.dev/managed"] != "true" {
+			t.Errorf("namespace labels = %v, want nebari.dev/managed=true", ns.Labels)
+		}
+
+		secret, err := client.CoreV1().Secrets("monitoring").Get(ctx, GrafanaAdminSecretName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if got := getSecretValue(secret, "admin-user"); got != "admin" {
+			t.Errorf("admin-user = %q, want %q", got, "admin")
+		}
+		if got := getSecretValue(secret, "admin-password"); got != "grafana-pass" {
+			t.Errorf("admin-password = %q, want %q", got, "grafana-pass")
+		}
+	})
+
+	t.Run("does not overwrite an existing password", func(t *testing.T) {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}}
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: GrafanaAdminSecretName, Namespace: "monitoring"},
+			Data:       map[string][]byte{"admin-password": []byte("existing-password")},
+		}
+		client := fake.NewSimpleClientset(ns, existing)
+		if err := createMonitoringSecrets(ctx, client, MonitoringConfig{GrafanaAdminPassword: "new-password"}); err != nil {
+			t.Fatalf("createMonitoringSecrets() error = %v", err)
+		}
+
+		secret, err := client.CoreV1().Secrets("monitoring").Get(ctx, GrafanaAdminSecretName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if got := getSecretValue(secret, "admin-password"); got != "existing-password" {
+			t.Errorf("secret should not be overwritten, got %q, want %q", got, "existing-password")
+		}
+	})
+}
+
 func TestCreateLonghornSecrets(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,104 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// EnvoyGatewayControllerName is the GatewayClass controllerName of the
+	// Envoy Gateway NIC installs (see manifests/networking/gatewayclass.yaml).
+	EnvoyGatewayControllerName = "gateway.envoyproxy.io/gatewayclass-controller"
+
+	// EnvoyGatewayNamespace is where Envoy Gateway and its proxy Services run.
+	EnvoyGatewayNamespace = "envoy-gateway-system"
+)
+
+// gatewayPorts are the ports the Envoy Gateway LoadBalancer Service listens on.
+var gatewayPorts = []int32{80, 443}
+
+var gatewayClassGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses"}
+
+// GatewayConflict describes an existing cluster resource likely to clash with
+// the Envoy Gateway NIC installs.
+type GatewayConflict struct {
+	// Kind is the conflicting resource kind (GatewayClass or Service).
+	Kind string
+	// Name is the resource name, namespace-qualified for Services.
+	Name string
+	// Reason explains the conflict.
+	Reason string
+}
+
+func (c GatewayConflict) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Kind, c.Name, c.Reason)
+}
+
+// DetectGatewayConflicts looks for other Gateway API implementations
+// (GatewayClasses owned by a different controller) and for LoadBalancer
+// Services outside EnvoyGatewayNamespace that already listen on the gateway's
+// ports, such as an ingress controller. Clusters without the Gateway API CRDs
+// are not an error. Resources NIC itself created are never reported, so the
+// check is safe to run on redeploys.
+func DetectGatewayConflicts(ctx context.Context, dynamicClient dynamic.Interface, k8sClient kubernetes.Interface) ([]GatewayConflict, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "argocd.DetectGatewayConflicts")
+	defer span.End()
+
+	var conflicts []GatewayConflict
+
+	classes, err := dynamicClient.Resource(gatewayClassGVR).List(ctx, metav1.ListOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// Gateway API CRDs not installed: no other implementation can exist.
+	case err != nil:
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list GatewayClasses: %w", err)
+	default:
+		for _, gc := range classes.Items {
+			controller, _, _ := unstructured.NestedString(gc.Object, "spec", "controllerName")
+			if controller != EnvoyGatewayControllerName {
+				conflicts = append(conflicts, GatewayConflict{
+					Kind:   "GatewayClass",
+					Name:   gc.GetName(),
+					Reason: fmt.Sprintf("managed by another Gateway API implementation (%s)", controller),
+				})
+			}
+		}
+	}
+
+	services, err := k8sClient.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list Services: %w", err)
+	}
+	for _, svc := range services.Items {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer || svc.Namespace == EnvoyGatewayNamespace {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			if slices.Contains(gatewayPorts, port.Port) {
+				conflicts = append(conflicts, GatewayConflict{
+					Kind:   "Service",
+					Name:   svc.Namespace + "/" + svc.Name,
+					Reason: fmt.Sprintf("LoadBalancer already listening on port %d", port.Port),
+				})
+				break
+			}
+		}
+	}
+
+	span.SetAttributes(attribute.Int("conflicts", len(conflicts)))
+	return conflicts, nil
+}
@@ -0,0 +1,97 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newGatewayClass(name, controller string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "GatewayClass",
+		"metadata":   map[string]any{"name": name},
+		"spec":       map[string]any{"controllerName": controller},
+	}}
+}
+
+func newGatewayFakeDynamic(objs ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	listKinds := map[schema.GroupVersionResource]string{gatewayClassGVR: "GatewayClassList"}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objs...)
+}
+
+func newService(namespace, name string, svcType corev1.ServiceType, port int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.ServiceSpec{
+			Type:  svcType,
+			Ports: []corev1.ServicePort{{Port: port}},
+		},
+	}
+}
+
+func TestDetectGatewayConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		classes  []runtime.Object
+		services []runtime.Object
+		want     []GatewayConflict
+	}{
+		{
+			name: "clean cluster",
+		},
+		{
+			name:    "NIC's own resources are not conflicts",
+			classes: []runtime.Object{newGatewayClass("envoy-gateway", EnvoyGatewayControllerName)},
+			services: []runtime.Object{
+				newService(EnvoyGatewayNamespace, "envoy-nebari", corev1.ServiceTypeLoadBalancer, 443),
+			},
+		},
+		{
+			name:    "GatewayClass from another implementation",
+			classes: []runtime.Object{newGatewayClass("istio", "istio.io/gateway-controller")},
+			want: []GatewayConflict{{
+				Kind:   "GatewayClass",
+				Name:   "istio",
+				Reason: "managed by another Gateway API implementation (istio.io/gateway-controller)",
+			}},
+		},
+		{
+			name: "ingress controller LoadBalancer on a gateway port",
+			services: []runtime.Object{
+				newService("ingress-nginx", "ingress-nginx-controller", corev1.ServiceTypeLoadBalancer, 80),
+				newService("default", "web", corev1.ServiceTypeClusterIP, 80),
+				newService("db", "postgres", corev1.ServiceTypeLoadBalancer, 5432),
+			},
+			want: []GatewayConflict{{
+				Kind:   "Service",
+				Name:   "ingress-nginx/ingress-nginx-controller",
+				Reason: "LoadBalancer already listening on port 80",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectGatewayConflicts(context.Background(), newGatewayFakeDynamic(tt.classes...), k8sfake.NewSimpleClientset(tt.services...))
+			if err != nil {
+				t.Fatalf("DetectGatewayConflicts() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("conflicts = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("conflict[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package argocd
+
+import (
+	"fmt"
+	"path"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// gatewayManifestPath is the template of the Nebari Gateway.
+const gatewayManifestPath = "manifests/networking/gateway.yaml"
+
+// secretRef is a namespaced Secret reference.
+type secretRef struct {
+	Namespace string
+	Name      string
+}
+
+func (r secretRef) String() string { return r.Namespace + "/" + r.Name }
+
+// checkGatewayTLSSecret renders the Gateway, the gateway Certificate and the
+// ReferenceGrant as they will be written for data and checks they agree on
+// the TLS secret: the Certificate must write the secret the HTTPS listener
+// references, and a listener reference into another namespace must be
+// allowed by the ReferenceGrant. A mismatch would leave the Gateway without a
+// certificate, which only shows up as failing TLS handshakes after deploy.
+func checkGatewayTLSSecret(data TemplateData) error {
+	gateway, err := renderEmbedded(gatewayManifestPath, data)
+	if err != nil {
+		return err
+	}
+	var cert, grant *unstructured.Unstructured
+	if !skipCertificateTemplate(gatewayCertificatePath, data) {
+		if cert, err = renderEmbedded(gatewayCertificatePath, data); err != nil {
+			return err
+		}
+	}
+	if !skipCertificateTemplate(gatewayReferenceGrantPath, data) {
+		if grant, err = renderEmbedded(gatewayReferenceGrantPath, data); err != nil {
+			return err
+		}
+	}
+	return checkGatewayTLSObjects(gateway, cert, grant)
+}
+
+// checkGatewayTLSObjects checks the rendered Gateway against the Certificate
+// and ReferenceGrant, either of which is nil when not rendered.
+func checkGatewayTLSObjects(gateway, cert, grant *unstructured.Unstructured) error {
+	ref, err := listenerSecretRef(gateway)
+	if err != nil {
+		return err
+	}
+
+	if cert != nil {
+		secretName, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName")
+		if written := (secretRef{Namespace: cert.GetNamespace(), Name: secretName}); written != ref {
+			return fmt.Errorf("gateway TLS secret mismatch: the HTTPS listener references %s but Certificate %q writes %s", ref, cert.GetName(), written)
+		}
+	}
+
+	if ref.Namespace == gateway.GetNamespace() {
+		return nil
+	}
+	if grant == nil {
+		return fmt.Errorf("gateway TLS secret %s is outside the Gateway namespace %s but no ReferenceGrant is rendered", ref, gateway.GetNamespace())
+	}
+	granted, _, _ := unstructured.NestedSlice(grant.Object, "spec", "to")
+	for _, to := range granted {
+		m, _ := to.(map[string]any)
+		if name, _ := m["name"].(string); m["kind"] == "Secret" && name == ref.Name && grant.GetNamespace() == ref.Namespace {
+			return nil
+		}
+	}
+	return fmt.Errorf("gateway TLS secret mismatch: ReferenceGrant %q in namespace %s does not grant the Gateway access to %s", grant.GetName(), grant.GetNamespace(), ref)
+}
+
+// listenerSecretRef returns the first certificate reference of the Gateway's
+// HTTPS listener, defaulting its namespace to the Gateway's.
+func listenerSecretRef(gateway *unstructured.Unstructured) (secretRef, error) {
+	listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+	for _, l := range listeners {
+		listener, _ := l.(map[string]any)
+		if listener["protocol"] != "HTTPS" {
+			continue
+		}
+		refs, _, _ := unstructured.NestedSlice(listener, "tls", "certificateRefs")
+		if len(refs) == 0 {
+			break
+		}
+		m, _ := refs[0].(map[string]any)
+		ref := secretRef{Namespace: gateway.GetNamespace()}
+		ref.Name, _ = m["name"].(string)
+		if ns, _ := m["namespace"].(string); ns != "" {
+			ref.Namespace = ns
+		}
+		return ref, nil
+	}
+	return secretRef{}, fmt.Errorf("gateway %q has no HTTPS listener with a certificate reference", gateway.GetName())
+}
+
+// renderEmbedded renders the single object of the embedded template at
+// relPath (relative to templateDir).
+func renderEmbedded(relPath string, data TemplateData) (*unstructured.Unstructured, error) {
+	content, err := templates.ReadFile(path.Join(templateDir, relPath))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", relPath, err)
+	}
+	objs, err := renderManifests(relPath, string(content), data)
+	if err != nil {
+		return nil, err
+	}
+	return objs[0], nil
+}
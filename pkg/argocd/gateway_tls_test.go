@@ -0,0 +1,99 @@
+package argocd
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestCheckGatewayTLSSecret(t *testing.T) {
+	tests := []struct {
+		name string
+		cert *config.CertificateConfig
+	}{
+		{name: "selfsigned default", cert: nil},
+		{name: "letsencrypt", cert: &config.CertificateConfig{Type: config.CertificateTypeLetsEncrypt, ACME: &config.ACMEConfig{Email: "ops@example.com"}}},
+		{name: "existing secret same namespace", cert: &config.CertificateConfig{Type: config.CertificateTypeExisting, ExistingSecret: &config.ExistingSecretRef{Name: "user-tls"}}},
+		{name: "existing secret other namespace", cert: &config.CertificateConfig{Type: config.CertificateTypeExisting, ExistingSecret: &config.ExistingSecretRef{Name: "user-tls", Namespace: "user-ns"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{Domain: "nebari.example.com", Certificate: tt.cert}
+			if err := checkGatewayTLSSecret(NewTemplateData(cfg, nil, cluster.InfraSettings{})); err != nil {
+				t.Errorf("checkGatewayTLSSecret() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckGatewayTLSObjectsMismatch(t *testing.T) {
+	render := func(t *testing.T, relPath string, data TemplateData) *unstructured.Unstructured {
+		t.Helper()
+		obj, err := renderEmbedded(relPath, data)
+		if err != nil {
+			t.Fatalf("renderEmbedded(%s) error = %v", relPath, err)
+		}
+		return obj
+	}
+	sameNS := NewTemplateData(&config.NebariConfig{Domain: "nebari.example.com"}, nil, cluster.InfraSettings{})
+	crossNS := NewTemplateData(&config.NebariConfig{
+		Domain:      "nebari.example.com",
+		Certificate: &config.CertificateConfig{Type: config.CertificateTypeExisting, ExistingSecret: &config.ExistingSecretRef{Name: "user-tls", Namespace: "user-ns"}},
+	}, nil, cluster.InfraSettings{})
+
+	tests := []struct {
+		name      string
+		objects   func(t *testing.T) (gateway, cert, grant *unstructured.Unstructured)
+		errSubstr string
+	}{
+		{
+			name: "certificate writes another secret name",
+			objects: func(t *testing.T) (*unstructured.Unstructured, *unstructured.Unstructured, *unstructured.Unstructured) {
+				cert := render(t, gatewayCertificatePath, sameNS)
+				_ = unstructured.SetNestedField(cert.Object, "other-tls", "spec", "secretName")
+				return render(t, gatewayManifestPath, sameNS), cert, nil
+			},
+			errSubstr: "writes envoy-gateway-system/other-tls",
+		},
+		{
+			name: "certificate in another namespace",
+			objects: func(t *testing.T) (*unstructured.Unstructured, *unstructured.Unstructured, *unstructured.Unstructured) {
+				cert := render(t, gatewayCertificatePath, sameNS)
+				cert.SetNamespace("cert-manager")
+				return render(t, gatewayManifestPath, sameNS), cert, nil
+			},
+			errSubstr: "gateway TLS secret mismatch: the HTTPS listener references envoy-gateway-system/",
+		},
+		{
+			name: "cross namespace without reference grant",
+			objects: func(t *testing.T) (*unstructured.Unstructured, *unstructured.Unstructured, *unstructured.Unstructured) {
+				return render(t, gatewayManifestPath, crossNS), nil, nil
+			},
+			errSubstr: "no ReferenceGrant is rendered",
+		},
+		{
+			name: "reference grant for another secret",
+			objects: func(t *testing.T) (*unstructured.Unstructured, *unstructured.Unstructured, *unstructured.Unstructured) {
+				grant := render(t, gatewayReferenceGrantPath, crossNS)
+				_ = unstructured.SetNestedSlice(grant.Object, []any{map[string]any{"group": "", "kind": "Secret", "name": "old-tls"}}, "spec", "to")
+				return render(t, gatewayManifestPath, crossNS), nil, grant
+			},
+			errSubstr: "does not grant the Gateway access to user-ns/user-tls",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, cert, grant := tt.objects(t)
+			err := checkGatewayTLSObjects(gateway, cert, grant)
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("checkGatewayTLSObjects() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package argocd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+const (
+	// additionalGatewayFilePrefix names the files additional gateways are
+	// written to, next to gateway.yaml so the gateway-config app syncs them.
+	additionalGatewayFilePrefix = "additional-gateway-"
+	// defaultRouteListener is the default gateway's HTTPS listener.
+	defaultRouteListener = "https"
+)
+
+// GatewayData is an additional Gateway resolved for rendering.
+type GatewayData struct {
+	Name        string
+	Listeners   []config.GatewayListener
+	Annotations map[string]string
+	// TLSSecretName and TLSSecretNamespace are the secret its HTTPS
+	// listeners serve. The namespace is empty when the secret is in
+	// envoy-gateway-system.
+	TLSSecretName      string
+	TLSSecretNamespace string
+}
+
+// RouteParent is the gateway listener a foundational HTTPRoute attaches to.
+type RouteParent struct {
+	Gateway string
+	Section string
+}
+
+// RouteParent returns the listener route attaches to: the listener of the
+// additional gateway that claims it, or the default gateway's HTTPS
+// listener. Called from the route templates.
+func (d TemplateData) RouteParent(route string) RouteParent {
+	if p, ok := d.RouteParents[route]; ok {
+		return p
+	}
+	return RouteParent{Gateway: config.DefaultGatewayName, Section: defaultRouteListener}
+}
+
+// additionalGateways resolves the configured additional gateways against the
+// provider settings and the default gateway's TLS secret, and returns them
+// with the routes they claim.
+func additionalGateways(cfg *config.NebariConfig, settings cluster.InfraSettings, data TemplateData) ([]GatewayData, map[string]RouteParent) {
+	if cfg.Gateway == nil || len(cfg.Gateway.Additional) == 0 {
+		return nil, nil
+	}
+	gateways := make([]GatewayData, 0, len(cfg.Gateway.Additional))
+	parents := map[string]RouteParent{}
+	for _, g := range cfg.Gateway.Additional {
+		gd := GatewayData{
+			Name:          g.Name,
+			Listeners:     g.EffectiveListeners(),
+			Annotations:   mergeGatewayAnnotations(settings, g.Internal, g.LoadBalancerAnnotations),
+			TLSSecretName: g.TLSSecretName,
+		}
+		if gd.TLSSecretName == "" {
+			gd.TLSSecretName = data.GatewayTLSSecretName
+			if data.GatewayTLSCrossNamespace {
+				gd.TLSSecretNamespace = data.GatewayTLSSecretNamespace
+			}
+		}
+		gateways = append(gateways, gd)
+		for _, route := range g.Routes {
+			parents[route] = RouteParent{Gateway: g.Name, Section: g.RouteListener()}
+		}
+	}
+	return gateways, parents
+}
+
+// renderGateway renders g as a Gateway manifest in the shape of
+// manifests/networking/gateway.yaml.
+func renderGateway(g GatewayData) ([]byte, error) {
+	listeners := make([]map[string]any, 0, len(g.Listeners))
+	for _, l := range g.Listeners {
+		listener := map[string]any{
+			"name":     l.Name,
+			"protocol": l.Protocol,
+			"port":     l.Port,
+			"allowedRoutes": map[string]any{
+				"namespaces": map[string]any{"from": "All"},
+			},
+		}
+		if l.Hostname != "" {
+			listener["hostname"] = l.Hostname
+		}
+		if l.Protocol == config.GatewayProtocolHTTPS {
+			ref := map[string]any{"name": g.TLSSecretName, "kind": "Secret"}
+			if g.TLSSecretNamespace != "" {
+				ref["namespace"] = g.TLSSecretNamespace
+			}
+			listener["tls"] = map[string]any{
+				"mode":            "Terminate",
+				"certificateRefs": []map[string]any{ref},
+			}
+		}
+		listeners = append(listeners, listener)
+	}
+
+	spec := map[string]any{
+		"gatewayClassName": "envoy-gateway",
+		"listeners":        listeners,
+	}
+	if len(g.Annotations) > 0 {
+		spec["infrastructure"] = map[string]any{"annotations": g.Annotations}
+	}
+	gateway := map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "Gateway",
+		"metadata": map[string]any{
+			"name":      g.Name,
+			"namespace": EnvoyGatewayNamespace,
+			"labels": map[string]any{
+				"app.kubernetes.io/name":       g.Name,
+				"app.kubernetes.io/managed-by": "nebari-infrastructure-core",
+			},
+		},
+		"spec": spec,
+	}
+	out, err := yaml.Marshal(gateway)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render gateway %s: %w", g.Name, err)
+	}
+	return out, nil
+}
+
+// writeAdditionalGateways writes one file per additional gateway into the
+// gateway-config app's directory and removes files of gateways no longer
+// configured, so Argo CD prunes them.
+func writeAdditionalGateways(workDir string, gateways []GatewayData) error {
+	dir := filepath.Join(workDir, "manifests", "networking")
+	stale, err := filepath.Glob(filepath.Join(dir, additionalGatewayFilePrefix+"*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to list additional gateways: %w", err)
+	}
+	keep := make(map[string]bool, len(gateways))
+	for _, g := range gateways {
+		path := filepath.Join(dir, additionalGatewayFilePrefix+g.Name+".yaml")
+		keep[path] = true
+		content, err := renderGateway(g)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, git.GitOpsDirMode); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, content, git.GitOpsFileMode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	for _, path := range stale {
+		if keep[path] {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale gateway %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package argocd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+const schemeAnnotation = "service.beta.kubernetes.io/aws-load-balancer-scheme"
+
+func additionalGatewaySettings() cluster.InfraSettings {
+	return cluster.InfraSettings{
+		StorageClass:                    "gp2",
+		LoadBalancerAnnotations:         map[string]string{schemeAnnotation: "internet-facing"},
+		InternalLoadBalancerAnnotations: map[string]string{schemeAnnotation: "internal"},
+	}
+}
+
+func readManifest(t *testing.T, path string) *unstructured.Unstructured {
+	t.Helper()
+	content, err := os.ReadFile(path) //nolint:gosec // path is t.TempDir() + constant
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(content, &obj.Object); err != nil {
+		t.Fatalf("parse %s: %v", path, err)
+	}
+	return obj
+}
+
+func listenerNames(t *testing.T, gw *unstructured.Unstructured) []string {
+	t.Helper()
+	listeners, _, err := unstructured.NestedSlice(gw.Object, "spec", "listeners")
+	if err != nil {
+		t.Fatalf("listeners: %v", err)
+	}
+	var names []string
+	for _, l := range listeners {
+		names = append(names, l.(map[string]any)["name"].(string))
+	}
+	return names
+}
+
+func TestWriteAllToGit_AdditionalGateways(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.NebariConfig{
+		Domain: "test.example.com",
+		Gateway: &config.GatewayConfig{Additional: []config.AdditionalGateway{
+			{
+				Name:     "admin-gateway",
+				Internal: true,
+				Listeners: []config.GatewayListener{
+					{Name: "admin-https", Protocol: config.GatewayProtocolHTTPS, Port: 8443},
+				},
+				Routes: []string{"argocd"},
+			},
+			{
+				Name:                    "public-gateway",
+				LoadBalancerAnnotations: map[string]string{"example.com/tier": "public"},
+				TLSSecretName:           "public-tls",
+			},
+		}},
+	}
+
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, nil, additionalGatewaySettings(), ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+
+	netDir := filepath.Join(tmpDir, "manifests", "networking")
+	admin := readManifest(t, filepath.Join(netDir, "additional-gateway-admin-gateway.yaml"))
+	public := readManifest(t, filepath.Join(netDir, "additional-gateway-public-gateway.yaml"))
+
+	if admin.GetName() != "admin-gateway" || public.GetName() != "public-gateway" {
+		t.Errorf("gateway names = %q, %q", admin.GetName(), public.GetName())
+	}
+	if got := listenerNames(t, admin); strings.Join(got, ",") != "admin-https" {
+		t.Errorf("admin listeners = %v, want [admin-https]", got)
+	}
+	if got := listenerNames(t, public); strings.Join(got, ",") != "http,https" {
+		t.Errorf("public listeners = %v, want the defaults [http https]", got)
+	}
+
+	adminAnn, _, _ := unstructured.NestedStringMap(admin.Object, "spec", "infrastructure", "annotations")
+	if adminAnn[schemeAnnotation] != "internal" {
+		t.Errorf("admin scheme = %q, want internal", adminAnn[schemeAnnotation])
+	}
+	publicAnn, _, _ := unstructured.NestedStringMap(public.Object, "spec", "infrastructure", "annotations")
+	if publicAnn[schemeAnnotation] != "internet-facing" || publicAnn["example.com/tier"] != "public" {
+		t.Errorf("public annotations = %v", publicAnn)
+	}
+
+	publicContent, _ := os.ReadFile(filepath.Join(netDir, "additional-gateway-public-gateway.yaml")) //nolint:gosec // test path
+	if !strings.Contains(string(publicContent), "name: public-tls") {
+		t.Errorf("public gateway should serve its own TLS secret:\n%s", publicContent)
+	}
+	adminContent, _ := os.ReadFile(filepath.Join(netDir, "additional-gateway-admin-gateway.yaml")) //nolint:gosec // test path
+	if !strings.Contains(string(adminContent), "name: "+config.DefaultGatewayTLSSecretName) {
+		t.Errorf("admin gateway should default to the gateway TLS secret:\n%s", adminContent)
+	}
+
+	// argocd moves to the admin gateway; keycloak stays on the default.
+	routes := filepath.Join(netDir, "routes")
+	argoRoute, _ := os.ReadFile(filepath.Join(routes, "argocd-httproute.yaml"))       //nolint:gosec // test path
+	keycloakRoute, _ := os.ReadFile(filepath.Join(routes, "keycloak-httproute.yaml")) //nolint:gosec // test path
+	if !strings.Contains(string(argoRoute), "name: admin-gateway") || !strings.Contains(string(argoRoute), "sectionName: admin-https") {
+		t.Errorf("argocd route should attach to admin-gateway/admin-https:\n%s", argoRoute)
+	}
+	if !strings.Contains(string(keycloakRoute), "name: nebari-gateway") || !strings.Contains(string(keycloakRoute), "sectionName: https") {
+		t.Errorf("keycloak route should stay on nebari-gateway/https:\n%s", keycloakRoute)
+	}
+
+	// Dropping a gateway from config removes its file.
+	cfg.Gateway.Additional = cfg.Gateway.Additional[:1]
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, nil, additionalGatewaySettings(), ""); err != nil {
+		t.Fatalf("second WriteAllToGit() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(netDir, "additional-gateway-public-gateway.yaml")); !os.IsNotExist(err) {
+		t.Errorf("stale public gateway file not removed (stat err %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(netDir, "additional-gateway-admin-gateway.yaml")); err != nil {
+		t.Errorf("admin gateway file missing: %v", err)
+	}
+}
+
+func TestWriteAllToGit_NoAdditionalGateways(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.NebariConfig{Domain: "test.example.com"}
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, nil, additionalGatewaySettings(), ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(tmpDir, "manifests", "networking", "additional-gateway-*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("unexpected additional gateway files: %v", matches)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "manifests", "networking", "gateway.yaml")); err != nil {
+		t.Errorf("default gateway missing: %v", err)
+	}
+}
+
+func TestAdditionalGateways_CrossNamespaceSecret(t *testing.T) {
+	cfg := &config.NebariConfig{
+		Domain: "test.example.com",
+		Certificate: &config.CertificateConfig{
+			Type:           config.CertificateTypeExisting,
+			ExistingSecret: &config.ExistingSecretRef{Name: "wildcard", Namespace: "certs"},
+		},
+		Gateway: &config.GatewayConfig{Additional: []config.AdditionalGateway{{Name: "admin"}}},
+	}
+	data := NewTemplateData(cfg, nil, additionalGatewaySettings())
+	if len(data.AdditionalGateways) != 1 {
+		t.Fatalf("got %d additional gateways, want 1", len(data.AdditionalGateways))
+	}
+	gw := data.AdditionalGateways[0]
+	if gw.TLSSecretName != "wildcard" || gw.TLSSecretNamespace != "certs" {
+		t.Errorf("TLS secret = %s/%s, want certs/wildcard", gw.TLSSecretNamespace, gw.TLSSecretName)
+	}
+}
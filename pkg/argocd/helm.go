@@ -121,7 +121,7 @@ func InstallHelm(ctx context.Context, kubeconfigBytes []byte, config Config) err
 	client.Namespace = config.Namespace
 	client.ReleaseName = config.ReleaseName
 	client.CreateNamespace = true
-	client.Wait = true
+	client.Wait = !config.NoWait
 	client.Timeout = config.Timeout
 	// Pin the chart version to ensure we install the requested version
 	client.Version = config.Version
@@ -171,7 +171,7 @@ func upgradeHelm(ctx context.Context, actionConfig *action.Configuration, config
 
 	client := action.NewUpgrade(actionConfig)
 	client.Namespace = config.Namespace
-	client.Wait = true
+	client.Wait = !config.NoWait
 	client.Timeout = config.Timeout
 	// Pin the chart version to ensure we upgrade to the requested version
 	client.Version = config.Version
@@ -0,0 +1,82 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// certificateDNSNames renders the gateway Certificate for data and returns
+// its dnsNames, or nil when no Certificate is rendered.
+func certificateDNSNames(data TemplateData) ([]string, error) {
+	if skipCertificateTemplate(gatewayCertificatePath, data) {
+		return nil, nil
+	}
+	cert, err := renderEmbedded(gatewayCertificatePath, data)
+	if err != nil {
+		return nil, err
+	}
+	names, _, err := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	if err != nil {
+		return nil, fmt.Errorf("%s: dnsNames: %w", gatewayCertificatePath, err)
+	}
+	return names, nil
+}
+
+// checkHTTP01Certificate rejects wildcard names on a Let's Encrypt gateway
+// certificate solved over HTTP-01, which cannot validate a wildcard, so such
+// a certificate would never be issued and the gateway would keep serving
+// cert-manager's temporary one. DNS-01 issuers are not checked.
+func checkHTTP01Certificate(data TemplateData) error {
+	if data.CertificateIssuer != certificateIssuerLetsEncrypt || data.ACMEDNS01Solver != "" {
+		return nil
+	}
+	names, err := certificateDNSNames(data)
+	if err != nil {
+		return err
+	}
+	return checkHTTP01Names(names)
+}
+
+// checkHTTP01Names returns an error naming every wildcard in names.
+func checkHTTP01Names(names []string) error {
+	var wildcards []string
+	for _, name := range names {
+		if strings.HasPrefix(name, "*.") {
+			wildcards = append(wildcards, name)
+		}
+	}
+	if len(wildcards) > 0 {
+		return fmt.Errorf("certificate type %q uses HTTP-01 challenges, which cannot issue wildcard names %v; list each subdomain instead",
+			config.CertificateTypeLetsEncrypt, wildcards)
+	}
+	return nil
+}
+
+// HTTP01Hostnames returns the names the Let's Encrypt gateway certificate
+// requests for cfg, each of which must resolve to the gateway for its HTTP-01
+// challenge to pass. Returns nil for other certificate types and for
+// certificates solved over DNS-01.
+func HTTP01Hostnames(cfg *config.NebariConfig, settings cluster.InfraSettings) ([]string, error) {
+	data := NewTemplateData(cfg, nil, settings)
+	if data.CertificateIssuer != certificateIssuerLetsEncrypt || data.ACMEDNS01Solver != "" {
+		return nil, nil
+	}
+	return certificateDNSNames(data)
+}
+
+// UnresolvableHostnames returns the hostnames lookup finds no address for.
+func UnresolvableHostnames(ctx context.Context, hostnames []string, lookup func(ctx context.Context, host string) ([]string, error)) []string {
+	var missing []string
+	for _, host := range hostnames {
+		if addrs, err := lookup(ctx, host); err != nil || len(addrs) == 0 {
+			missing = append(missing, host)
+		}
+	}
+	return missing
+}
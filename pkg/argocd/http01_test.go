@@ -0,0 +1,93 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestCheckHTTP01Names(t *testing.T) {
+	tests := []struct {
+		name      string
+		names     []string
+		errSubstr string // "" means no error expected
+	}{
+		{name: "explicit subdomains", names: []string{"example.com", "keycloak.example.com", "argocd.example.com"}},
+		{name: "wildcard", names: []string{"example.com", "*.example.com"}, errSubstr: "[*.example.com]"},
+		{name: "nested wildcard", names: []string{"*.apps.example.com"}, errSubstr: "cannot issue wildcard"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHTTP01Names(tt.names)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestHTTP01Hostnames(t *testing.T) {
+	letsencrypt := &config.CertificateConfig{Type: config.CertificateTypeLetsEncrypt, ACME: &config.ACMEConfig{Email: "admin@example.com"}}
+
+	tests := []struct {
+		name     string
+		cert     *config.CertificateConfig
+		settings cluster.InfraSettings
+		want     []string
+	}{
+		{name: "selfsigned", cert: nil, want: nil},
+		{
+			name: "letsencrypt",
+			cert: letsencrypt,
+			want: []string{"example.com", "keycloak.example.com", "argocd.example.com"},
+		},
+		{
+			name:     "letsencrypt with longhorn",
+			cert:     letsencrypt,
+			settings: cluster.InfraSettings{LonghornEnabled: true},
+			want:     []string{"example.com", "keycloak.example.com", "argocd.example.com", "longhorn.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{Domain: "example.com", Certificate: tt.cert}
+			got, err := HTTP01Hostnames(cfg, tt.settings)
+			if err != nil {
+				t.Fatalf("HTTP01Hostnames() error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("HTTP01Hostnames() = %v, want %v", got, tt.want)
+			}
+			if err := checkHTTP01Certificate(NewTemplateData(cfg, nil, tt.settings)); err != nil {
+				t.Errorf("rendered certificate rejected: %v", err)
+			}
+		})
+	}
+}
+
+func TestUnresolvableHostnames(t *testing.T) {
+	lookup := func(_ context.Context, host string) ([]string, error) {
+		switch host {
+		case "example.com":
+			return []string{"203.0.113.10"}, nil
+		case "empty.example.com":
+			return nil, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	got := UnresolvableHostnames(context.Background(), []string{"example.com", "empty.example.com", "argocd.example.com"}, lookup)
+	if want := []string{"empty.example.com", "argocd.example.com"}; !slices.Equal(got, want) {
+		t.Errorf("UnresolvableHostnames() = %v, want %v", got, want)
+	}
+}
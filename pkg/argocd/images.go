@@ -0,0 +1,81 @@
+package argocd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+// imageValues locates the main image of a foundational component's chart in
+// its Helm values. Charts either split the image into repository, tag and
+// digest values, or take one full reference.
+type imageValues struct {
+	// registry, when set, is cleared so the chart uses repository as is.
+	registry   string
+	repository string
+	tag        string
+	digest     string
+	// reference takes the full image reference instead of the split values.
+	reference string
+}
+
+// componentImageValues lists the foundational Applications whose image can
+// be overridden through the images config, for the chart versions pinned in
+// the app templates.
+var componentImageValues = map[string]imageValues{
+	"cert-manager":            {repository: "image.repository", tag: "image.tag", digest: "image.digest"},
+	"envoy-gateway":           {reference: "global.images.envoyGateway.image"},
+	"keycloak":                {repository: "image.repository", tag: "image.tag", digest: "image.digest"},
+	"opentelemetry-collector": {repository: "image.repository", tag: "image.tag", digest: "image.digest"},
+	"postgresql":              {registry: "image.registry", repository: "image.repository", tag: "image.tag", digest: "image.digest"},
+	"trust-manager":           {repository: "image.repository", tag: "image.tag", digest: "image.digest"},
+}
+
+// HelmParameter is an Argo CD Helm parameter, which overrides one value of
+// the chart's values.
+type HelmParameter struct {
+	Name  string
+	Value string
+}
+
+// CheckImageOverrides returns an error for image overrides of components
+// whose image cannot be overridden.
+func CheckImageOverrides(images map[string]config.ImageOverride) error {
+	for _, name := range slices.Sorted(maps.Keys(images)) {
+		if _, ok := componentImageValues[name]; !ok {
+			return fmt.Errorf("image of %q cannot be overridden (supported: %s)",
+				name, strings.Join(slices.Sorted(maps.Keys(componentImageValues)), ", "))
+		}
+	}
+	return nil
+}
+
+// ImageParameters returns the Helm parameters that set the image override
+// configured for the named Application, or nil when there is none. Called
+// from the app templates.
+func (d TemplateData) ImageParameters(name string) []HelmParameter {
+	o, ok := d.Images[name]
+	if !ok {
+		return nil
+	}
+	v := componentImageValues[name]
+	if v.reference != "" {
+		return []HelmParameter{{Name: v.reference, Value: o.Reference()}}
+	}
+
+	var params []HelmParameter
+	if v.registry != "" {
+		params = append(params, HelmParameter{Name: v.registry, Value: ""})
+	}
+	params = append(params, HelmParameter{Name: v.repository, Value: o.Repository})
+	if o.Tag != "" {
+		params = append(params, HelmParameter{Name: v.tag, Value: o.Tag})
+	}
+	if o.Digest != "" {
+		params = append(params, HelmParameter{Name: v.digest, Value: o.Digest})
+	}
+	return params
+}
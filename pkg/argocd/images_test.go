@@ -0,0 +1,95 @@
+package argocd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// helmParameters renders the named app template and returns its Helm
+// parameters as name/value pairs.
+func helmParameters(t *testing.T, name string, data TemplateData) map[string]string {
+	t.Helper()
+	app, err := renderEmbedded("apps/"+name+".yaml", data)
+	if err != nil {
+		t.Fatalf("render %s: %v", name, err)
+	}
+	helm, found, _ := unstructured.NestedMap(app.Object, "spec", "source", "helm")
+	if !found {
+		sources, _, _ := unstructured.NestedSlice(app.Object, "spec", "sources")
+		helm, _, _ = unstructured.NestedMap(sources[0].(map[string]any), "helm")
+	}
+	params, _, _ := unstructured.NestedSlice(helm, "parameters")
+	got := map[string]string{}
+	for _, p := range params {
+		p := p.(map[string]any)
+		if p["forceString"] != true {
+			t.Errorf("%s parameter %v is not forceString", name, p["name"])
+		}
+		got[p["name"].(string)] = p["value"].(string)
+	}
+	return got
+}
+
+func TestImageParameters(t *testing.T) {
+	cfg := &config.NebariConfig{
+		Domain: "example.com",
+		Images: map[string]config.ImageOverride{
+			"keycloak":      {Repository: "registry.example.com/keycloak/keycloak", Tag: "26.1.0", Digest: testDigest},
+			"envoy-gateway": {Repository: "registry.example.com:5000/envoyproxy/gateway", Digest: testDigest},
+			"postgresql":    {Repository: "registry.example.com/bitnami/postgresql", Digest: testDigest},
+		},
+	}
+	data := NewTemplateData(cfg, nil, cluster.InfraSettings{})
+
+	tests := []struct {
+		app  string
+		want map[string]string
+	}{
+		{
+			app: "keycloak",
+			want: map[string]string{
+				"image.repository": "registry.example.com/keycloak/keycloak",
+				"image.tag":        "26.1.0",
+				"image.digest":     testDigest,
+			},
+		},
+		{
+			app:  "envoy-gateway",
+			want: map[string]string{"global.images.envoyGateway.image": "registry.example.com:5000/envoyproxy/gateway@" + testDigest},
+		},
+		{
+			app: "postgresql",
+			want: map[string]string{
+				"image.registry":   "",
+				"image.repository": "registry.example.com/bitnami/postgresql",
+				"image.digest":     testDigest,
+			},
+		},
+		{app: "cert-manager", want: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.app, func(t *testing.T) {
+			if got := helmParameters(t, tt.app, data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parameters = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckImageOverrides(t *testing.T) {
+	if err := CheckImageOverrides(map[string]config.ImageOverride{"keycloak": {}, "trust-manager": {}}); err != nil {
+		t.Errorf("CheckImageOverrides() error = %v", err)
+	}
+	err := CheckImageOverrides(map[string]config.ImageOverride{"metallb": {}})
+	if err == nil || !strings.Contains(err.Error(), `"metallb" cannot be overridden`) {
+		t.Errorf("CheckImageOverrides() error = %v, want metallb rejected", err)
+	}
+}
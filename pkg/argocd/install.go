@@ -82,10 +82,10 @@ func Install(ctx context.Context, cfg *config.NebariConfig, clusterProvider clus
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Installing Argo CD on cluster").
 		WithResource("argocd").
 		WithAction("installing").
-		WithMetadata("cluster_name", cfg.ProjectName))
+		WithMetadata("cluster_name", cfg.ResourceName()))
 
 	// Get kubeconfig from provider
-	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ProjectName, cfg.Cluster)
+	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ResourceName(), cfg.Cluster)
 	if err != nil {
 		span.RecordError(err)
 		status.Send(ctx, status.NewUpdate(status.LevelError, "Failed to get kubeconfig").
@@ -111,11 +111,12 @@ func Install(ctx context.Context, cfg *config.NebariConfig, clusterProvider clus
 	}
 
 	// Wait for cluster to be ready
-	status.Send(ctx, status.NewUpdate(status.LevelProgress, "Waiting for cluster to be ready").
-		WithResource("cluster").
-		WithAction("waiting"))
-
-	if err := waitForClusterReady(ctx, k8sClient, 5*time.Minute); err != nil {
+	if err := waitUnlessNoWait(ctx, argoCDCfg.NoWait, "cluster", func() error {
+		status.Send(ctx, status.NewUpdate(status.LevelProgress, "Waiting for cluster to be ready").
+			WithResource("cluster").
+			WithAction("waiting"))
+		return waitForClusterReady(ctx, k8sClient, 5*time.Minute)
+	}); err != nil {
 		span.RecordError(err)
 		status.Send(ctx, status.NewUpdate(status.LevelError, "Cluster not ready").
 			WithResource("cluster").
@@ -171,7 +172,9 @@ func Install(ctx context.Context, cfg *config.NebariConfig, clusterProvider clus
 	}
 
 	// Wait for Argo CD to be ready
-	if err := waitForArgoCDReady(ctx, k8sClient, argoCDCfg.Namespace, 5*time.Minute); err != nil {
+	if err := waitUnlessNoWait(ctx, argoCDCfg.NoWait, "argocd", func() error {
+		return waitForArgoCDReady(ctx, k8sClient, argoCDCfg.Namespace, 5*time.Minute)
+	}); err != nil {
 		span.RecordError(err)
 		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Argo CD may not be fully ready yet").
 			WithResource("argocd").
@@ -253,6 +256,18 @@ func isClusterReady(nodes []corev1.Node) bool {
 	return false
 }
 
+// waitUnlessNoWait runs wait, or only reports that waiting for resource was
+// skipped when noWait is set.
+func waitUnlessNoWait(ctx context.Context, noWait bool, resource string, wait func() error) error {
+	if noWait {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Not waiting for %s to be ready (--no-wait)", resource)).
+			WithResource(resource).
+			WithAction("wait-skipped"))
+		return nil
+	}
+	return wait()
+}
+
 // waitForClusterReadyWithLister waits for the cluster to be ready using the provided node lister.
 // This function separates the polling logic from the Kubernetes client, making it testable.
 func waitForClusterReadyWithLister(ctx context.Context, listNodes NodeListFunc, timeout time.Duration) error {
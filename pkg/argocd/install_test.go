@@ -635,3 +635,31 @@ func TestWaitForArgoCDReadyWithLister(t *testing.T) {
 		}
 	})
 }
+
+func TestWaitUnlessNoWait(t *testing.T) {
+	waitErr := errors.New("timed out")
+	tests := []struct {
+		name       string
+		noWait     bool
+		wantCalled bool
+		wantErr    error
+	}{
+		{name: "waits", wantCalled: true, wantErr: waitErr},
+		{name: "no wait skips the wait", noWait: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			err := waitUnlessNoWait(context.Background(), tt.noWait, "argocd", func() error {
+				called = true
+				return waitErr
+			})
+			if called != tt.wantCalled {
+				t.Errorf("wait called = %v, want %v", called, tt.wantCalled)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
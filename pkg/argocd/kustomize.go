@@ -0,0 +1,75 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// RenderKustomization builds the kustomization at path on fSys, exactly like
+// `kustomize build`, and returns the resulting resources. Overlays work as
+// usual: path may reference bases elsewhere on fSys.
+func RenderKustomization(fSys filesys.FileSystem, path string) ([]*unstructured.Unstructured, error) {
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, path)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build %s: %w", path, err)
+	}
+
+	resources := resMap.Resources()
+	objs := make([]*unstructured.Unstructured, 0, len(resources))
+	for _, res := range resources {
+		m, err := res.Map()
+		if err != nil {
+			return nil, fmt.Errorf("kustomize build %s: convert %s: %w", path, res.CurId(), err)
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: m})
+	}
+	return objs, nil
+}
+
+// ApplyKustomizations renders each configured kustomization from fSys and
+// applies its resources in the order kustomize emits them (namespaces and
+// CRDs first). It stops at the first kustomization that fails to render or
+// apply. A resource whose CRD a foundational Application installs, such as a
+// cert-manager Certificate, fails with a *MissingCRDError if disc does not
+// serve its kind within a short wait.
+func ApplyKustomizations(ctx context.Context, client dynamic.Interface, disc discovery.DiscoveryInterface, fSys filesys.FileSystem, kustomizations []config.KustomizationConfig) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "argocd.ApplyKustomizations")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("kustomizations", len(kustomizations)))
+
+	for _, k := range kustomizations {
+		objs, err := RenderKustomization(fSys, k.Path)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+
+		for _, obj := range objs {
+			if err := applyResource(ctx, client, disc, obj); err != nil {
+				span.RecordError(err)
+				return fmt.Errorf("kustomization %s: failed to apply %s %q: %w", k.Path, obj.GetKind(), obj.GetName(), err)
+			}
+		}
+
+		status.Send(ctx, status.NewUpdate(status.LevelSuccess, fmt.Sprintf("Applied kustomization %s", k.Path)).
+			WithResource("kustomization").
+			WithAction("applied").
+			WithMetadata("path", k.Path).
+			WithMetadata("resources", len(objs)))
+	}
+
+	return nil
+}
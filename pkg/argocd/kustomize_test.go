@@ -0,0 +1,118 @@
+package argocd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+// kustomizeTestFS returns an in-memory filesystem with a base (a namespace
+// and a ConfigMap) and an overlay that prefixes names, adds a label and
+// patches the ConfigMap. kustomize never prefixes Namespace names.
+func kustomizeTestFS(t *testing.T) filesys.FileSystem {
+	t.Helper()
+	fSys := filesys.MakeFsInMemory()
+	files := map[string]string{
+		"/addons/base/kustomization.yaml": `resources:
+- namespace.yaml
+- configmap.yaml
+`,
+		"/addons/base/namespace.yaml": `apiVersion: v1
+kind: Namespace
+metadata:
+  name: addons
+`,
+		"/addons/base/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: addons
+data:
+  mode: base
+`,
+		"/addons/overlays/prod/kustomization.yaml": `resources:
+- ../../base
+namePrefix: prod-
+labels:
+- pairs:
+    stage: prod
+patches:
+- patch: |-
+    apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: settings
+      namespace: addons
+    data:
+      mode: prod
+`,
+	}
+	for path, content := range files {
+		if err := fSys.WriteFile(path, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fSys
+}
+
+func TestRenderKustomization(t *testing.T) {
+	fSys := kustomizeTestFS(t)
+
+	objs, err := RenderKustomization(fSys, "/addons/base")
+	if err != nil {
+		t.Fatalf("RenderKustomization() error = %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("rendered %d resources, want 2", len(objs))
+	}
+	// kustomize orders namespaces before the resources that live in them.
+	if objs[0].GetKind() != "Namespace" || objs[1].GetKind() != "ConfigMap" {
+		t.Errorf("resource order = %s, %s; want Namespace, ConfigMap", objs[0].GetKind(), objs[1].GetKind())
+	}
+
+	if _, err := RenderKustomization(fSys, "/addons/missing"); err == nil || !strings.Contains(err.Error(), "kustomize build /addons/missing") {
+		t.Errorf("missing directory error = %v", err)
+	}
+}
+
+func TestApplyKustomizations(t *testing.T) {
+	ctx := context.Background()
+	fSys := kustomizeTestFS(t)
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	err := ApplyKustomizations(ctx, client, newFakeDiscovery(), fSys, []config.KustomizationConfig{{Path: "/addons/overlays/prod"}})
+	if err != nil {
+		t.Fatalf("ApplyKustomizations() error = %v", err)
+	}
+
+	cm, err := client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace("addons").Get(ctx, "prod-settings", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("overlay ConfigMap not applied: %v", err)
+	}
+	if mode, _, _ := unstructured.NestedString(cm.Object, "data", "mode"); mode != "prod" {
+		t.Errorf("ConfigMap data.mode = %q, want prod (overlay patch)", mode)
+	}
+	if cm.GetLabels()["stage"] != "prod" {
+		t.Errorf("ConfigMap labels = %v, want stage=prod", cm.GetLabels())
+	}
+
+	if _, err := client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}).
+		Get(ctx, "addons", metav1.GetOptions{}); err != nil {
+		t.Errorf("overlay Namespace not applied: %v", err)
+	}
+
+	// Re-applying updates the existing resources instead of failing.
+	if err := ApplyKustomizations(ctx, client, newFakeDiscovery(), fSys, []config.KustomizationConfig{{Path: "/addons/overlays/prod"}}); err != nil {
+		t.Fatalf("second ApplyKustomizations() error = %v", err)
+	}
+}
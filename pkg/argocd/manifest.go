@@ -0,0 +1,173 @@
+package argocd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+)
+
+// errNoManifests is returned by renderManifests when the template renders no
+// documents, which is expected for templates gated off by the data.
+var errNoManifests = errors.New("no manifests")
+
+// renderManifests executes the manifest template text against data and
+// decodes every YAML document in the result into an unstructured object.
+// Values are substituted by the template, never patched into the decoded
+// objects afterwards, so the template is the single source of truth for what
+// gets applied. Templates get templateFuncs; use required for values the
+// manifest cannot do without.
+func renderManifests(name, text string, data any) ([]*unstructured.Unstructured, error) {
+	rendered, err := executeTemplate(name, text, data)
+	if err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+
+	decoder := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	var objs []*unstructured.Unstructured
+	for i, doc := range splitYAMLDocs(string(rendered)) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if _, _, err := decoder.Decode([]byte(doc), nil, obj); err != nil {
+			return nil, fmt.Errorf("decode %s document %d: %w", name, i, err)
+		}
+		objs = append(objs, obj)
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("render %s: %w", name, errNoManifests)
+	}
+	return objs, nil
+}
+
+// manifestIdentity is the apiVersion, kind and name an embedded manifest
+// must render to. Name is empty for objects without one (Kustomization).
+type manifestIdentity struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+// application returns the identity of an Argo CD Application named name.
+func application(name string) manifestIdentity {
+	return manifestIdentity{APIVersion: "argoproj.io/v1alpha1", Kind: "Application", Name: name}
+}
+
+// manifestRegistry lists every embedded template, keyed by its path under
+// templateDir, with the object it renders to. ValidateEmbeddedManifests
+// rejects templates missing from the registry, so a new template must be
+// registered here.
+var manifestRegistry = map[string]manifestIdentity{
+	"apps/cert-manager.yaml":            application("cert-manager"),
+	"apps/certificates.yaml":            application("certificates"),
+	"apps/cloudnative-pg.yaml":          application("cloudnative-pg"),
+	"apps/cluster-issuers.yaml":         application("cluster-issuers"),
+	"apps/envoy-gateway.yaml":           application("envoy-gateway"),
+	"apps/gateway-config.yaml":          application("gateway-config"),
+	"apps/httproutes.yaml":              application("httproutes"),
+	"apps/keycloak.yaml":                application("keycloak"),
+	"apps/longhorn-backup.yaml":         application("longhorn-backup"),
+	"apps/metallb-config.yaml":          application("metallb-config"),
+	"apps/metallb.yaml":                 application("metallb"),
+	"apps/monitoring.yaml":              application("monitoring"),
+	"apps/nebari-landingpage.yaml":      application("nebari-landingpage"),
+	"apps/nebari-operator.yaml":         application("nebari-operator"),
+	"apps/opentelemetry-collector.yaml": application("opentelemetry-collector"),
+	"apps/postgresql.yaml":              application("postgresql"),
+	"apps/root.yaml":                    application("nebari-root"),
+	"apps/securitypolicies.yaml":        application("securitypolicies"),
+	"apps/trust-bundle.yaml":            application("trust-bundle"),
+	"apps/trust-manager.yaml":           application("trust-manager"),
+
+	"manifests/keycloak/realm-setup-job.yaml":                                                  {"batch/v1", "Job", "keycloak-realm-setup"},
+	"manifests/metallb/ipaddresspool.yaml":                                                     {"metallb.io/v1beta1", "IPAddressPool", "default-pool"},
+	"manifests/metallb/l2advertisement.yaml":                                                   {"metallb.io/v1beta1", "L2Advertisement", "default-l2"},
+	"manifests/nebari-operator/deployment-patch.yaml":                                          {"apps/v1", "Deployment", "nebari-operator-controller-manager"},
+	"manifests/nebari-operator/kustomization.yaml":                                             {"kustomize.config.k8s.io/v1beta1", "Kustomization", ""},
+	"manifests/networking/gateway-tls-referencegrant.yaml":                                     {"gateway.networking.k8s.io/v1beta1", "ReferenceGrant", "nebari-gateway-tls-grant"},
+	"manifests/networking/gateway.yaml":                                                        {"gateway.networking.k8s.io/v1", "Gateway", "nebari-gateway"},
+	"manifests/networking/gatewayclass.yaml":                                                   {"gateway.networking.k8s.io/v1", "GatewayClass", "envoy-gateway"},
+	"manifests/networking/policies/longhorn-securitypolicy.yaml":                               {"gateway.envoyproxy.io/v1alpha1", "SecurityPolicy", "longhorn-oidc"},
+	"manifests/networking/routes/argocd-httproute.yaml":                                        {"gateway.networking.k8s.io/v1", "HTTPRoute", "argocd"},
+	"manifests/networking/routes/grafana-httproute.yaml":                                       {"gateway.networking.k8s.io/v1", "HTTPRoute", "grafana"},
+	"manifests/networking/routes/http-to-https-redirect.yaml":                                  {"gateway.networking.k8s.io/v1", "HTTPRoute", "http-to-https-redirect"},
+	"manifests/networking/routes/keycloak-httproute.yaml":                                      {"gateway.networking.k8s.io/v1", "HTTPRoute", "keycloak"},
+	"manifests/networking/routes/longhorn-httproute.yaml":                                      {"gateway.networking.k8s.io/v1", "HTTPRoute", "longhorn"},
+	"manifests/security/certificates/gateway-certificate.yaml":                                 {"cert-manager.io/v1", "Certificate", "nebari-gateway-cert"},
+	"manifests/security/issuers/letsencrypt-clusterissuer.yaml":                                {"cert-manager.io/v1", "ClusterIssuer", "letsencrypt-issuer"},
+	"manifests/security/issuers/selfsigned-clusterissuer.yaml":                                 {"cert-manager.io/v1", "ClusterIssuer", "selfsigned-issuer"},
+	"manifests/security/trust-bundle/bundle.yaml":                                              {"trust.cert-manager.io/v1alpha1", "Bundle", "nebari-trust-bundle"},
+	"manifests/storage/longhorn-backup/backuptarget.yaml":                                      {"longhorn.io/v1beta2", "BackupTarget", "default"},
+	"manifests/storage/longhorn-backup/recurringjob-backup.yaml":                               {"longhorn.io/v1beta2", "RecurringJob", "default-daily-backup"},
+	"manifests/storage/longhorn-backup/recurringjob-snapshot.yaml":                             {"longhorn.io/v1beta2", "RecurringJob", "default-hourly-snapshot"},
+	"manifests/storage/longhorn-backup/setting-allow-recurring-job-while-volume-detached.yaml": {"longhorn.io/v1beta2", "Setting", "allow-recurring-job-while-volume-detached"},
+}
+
+// ValidateEmbeddedManifests renders every embedded template with data and
+// checks that it decodes to exactly the object recorded in the manifest
+// registry. Templates gated off by data (rendering nothing) are skipped, so
+// callers should validate with several configurations. It catches malformed YAML and renamed or retyped objects before
+// they reach a cluster, and fails for templates that are not registered.
+// All problems are reported together.
+func ValidateEmbeddedManifests(data TemplateData) error {
+	var errs []error
+	seen := make(map[string]bool, len(manifestRegistry))
+
+	walkErr := fs.WalkDir(templates, templateDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(templateDir, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		seen[relPath] = true
+
+		want, ok := manifestRegistry[relPath]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: not in the manifest registry", relPath))
+			return nil
+		}
+		content, err := templates.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		objs, err := renderManifests(relPath, string(content), data)
+		if errors.Is(err, errNoManifests) {
+			return nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if len(objs) != 1 {
+			errs = append(errs, fmt.Errorf("%s: rendered %d objects, want 1", relPath, len(objs)))
+			return nil
+		}
+		got := manifestIdentity{APIVersion: objs[0].GetAPIVersion(), Kind: objs[0].GetKind(), Name: objs[0].GetName()}
+		if got != want {
+			errs = append(errs, fmt.Errorf("%s: rendered %s %s %q, want %s %s %q",
+				relPath, got.APIVersion, got.Kind, got.Name, want.APIVersion, want.Kind, want.Name))
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return fmt.Errorf("walk embedded templates: %w", walkErr)
+	}
+
+	for relPath := range manifestRegistry {
+		if !seen[relPath] {
+			errs = append(errs, fmt.Errorf("%s: registered but not embedded", relPath))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,182 @@
+package argocd
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestRenderManifests_SubstitutesValues(t *testing.T) {
+	cfg := &config.NebariConfig{
+		Domain: "nebari.example.com",
+		Certificate: &config.CertificateConfig{
+			Type: config.CertificateTypeLetsEncrypt,
+			ACME: &config.ACMEConfig{Email: "ops@example.com", Server: "https://acme-staging-v02.api.letsencrypt.org/directory"},
+		},
+	}
+	data := NewTemplateData(cfg, &git.Config{URL: "https://github.com/org/gitops.git", Branch: "main"}, cluster.InfraSettings{})
+
+	content, err := templates.ReadFile("templates/manifests/security/issuers/letsencrypt-clusterissuer.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuers, err := renderManifests("letsencrypt-clusterissuer.yaml", string(content), data)
+	if err != nil {
+		t.Fatalf("renderManifests() error = %v", err)
+	}
+	if len(issuers) != 1 || issuers[0].GetKind() != "ClusterIssuer" {
+		t.Fatalf("rendered %v, want one ClusterIssuer", issuers)
+	}
+	for field, want := range map[string]string{
+		"email":  "ops@example.com",
+		"server": "https://acme-staging-v02.api.letsencrypt.org/directory",
+	} {
+		if got, _, _ := unstructured.NestedString(issuers[0].Object, "spec", "acme", field); got != want {
+			t.Errorf("spec.acme.%s = %q, want %q", field, got, want)
+		}
+	}
+
+	root, err := renderManifests("root-app", rootAppOfAppsTemplate, struct{ GitRepoURL, GitBranch, GitPath string }{
+		GitRepoURL: data.GitRepoURL, GitBranch: data.GitBranch, GitPath: "clusters/prod",
+	})
+	if err != nil {
+		t.Fatalf("renderManifests(root-app) error = %v", err)
+	}
+	if got, _, _ := unstructured.NestedString(root[0].Object, "spec", "source", "repoURL"); got != "https://github.com/org/gitops.git" {
+		t.Errorf("root app repoURL = %q", got)
+	}
+	if got, _, _ := unstructured.NestedString(root[0].Object, "spec", "source", "path"); got != "clusters/prod/apps" {
+		t.Errorf("root app path = %q, want clusters/prod/apps", got)
+	}
+}
+
+func TestRenderManifests_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		data        any
+		errContains string
+	}{
+		{
+			name:        "missing required value",
+			text:        rootAppOfAppsTemplate,
+			data:        struct{ GitRepoURL, GitBranch, GitPath string }{GitBranch: "main"},
+			errContains: "required value GitRepoURL is missing",
+		},
+		{
+			name:        "unknown field",
+			text:        "kind: ConfigMap\nmetadata:\n  name: {{ .Hostname }}\n",
+			data:        struct{ Domain string }{Domain: "example.com"},
+			errContains: "can't evaluate field Hostname",
+		},
+		{
+			name:        "malformed YAML",
+			text:        "apiVersion: v1\nkind: ConfigMap\nmetadata: [unclosed\n",
+			errContains: "decode bad document 0",
+		},
+		{
+			name:        "renders nothing",
+			text:        "{{/* empty */}}\n",
+			errContains: "no manifests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderManifests("bad", tt.text, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("renderManifests() error = %v, want containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestValidateEmbeddedManifests(t *testing.T) {
+	gitCfg := &git.Config{URL: "https://github.com/org/gitops.git", Branch: "main"}
+	base := func(cert *config.CertificateConfig, settings cluster.InfraSettings) TemplateData {
+		return NewTemplateData(&config.NebariConfig{Domain: "nebari.example.com", Certificate: cert}, gitCfg, settings)
+	}
+
+	tests := []struct {
+		name string
+		data TemplateData
+	}{
+		{
+			name: "self-signed defaults",
+			data: base(nil, cluster.InfraSettings{}),
+		},
+		{
+			name: "letsencrypt with longhorn and metallb",
+			data: base(&config.CertificateConfig{
+				Type: config.CertificateTypeLetsEncrypt,
+				ACME: &config.ACMEConfig{Email: "ops@example.com"},
+			}, cluster.InfraSettings{LonghornEnabled: true, NeedsMetalLB: true, MetalLBAddressPool: "192.168.1.100-192.168.1.110"}),
+		},
+		{
+			name: "monitoring",
+			data: func() TemplateData {
+				d := base(nil, cluster.InfraSettings{})
+				d.MonitoringEnabled = true
+				return d
+			}(),
+		},
+		{
+			name: "existing cross-namespace certificate with backups and trust bundle",
+			data: func() TemplateData {
+				d := base(nil, cluster.InfraSettings{LonghornEnabled: true})
+				d.UseExistingCertificate = true
+				d.GatewayTLSSecretName = "wildcard-tls"
+				d.GatewayTLSSecretNamespace = "certs"
+				d.GatewayTLSCrossNamespace = true
+				d.LonghornBackupEnabled = true
+				d.LonghornBackupTargetURL = "s3://backups@us-east-1/"
+				d.LonghornBackupCredentialSecret = "longhorn-backup-credentials"
+				d.LonghornSnapshotCron = "0 * * * *"
+				d.LonghornSnapshotRetain = 24
+				d.LonghornSnapshotConcurrency = 1
+				d.LonghornBackupCron = "0 2 * * *"
+				d.LonghornBackupRetain = 7
+				d.LonghornBackupConcurrency = 1
+				d.LonghornAllowDetached = "true"
+				d.TrustManagerEnabled = true
+				d.TrustBundlePEM = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+				return d
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateEmbeddedManifests(tt.data); err != nil {
+				t.Errorf("ValidateEmbeddedManifests() error:\n%v", err)
+			}
+		})
+	}
+}
+
+func TestValidateEmbeddedManifests_DetectsDrift(t *testing.T) {
+	const path = "manifests/networking/gateway.yaml"
+	orig := manifestRegistry[path]
+	t.Cleanup(func() {
+		manifestRegistry[path] = orig
+		delete(manifestRegistry, "apps/removed.yaml")
+	})
+	manifestRegistry[path] = manifestIdentity{APIVersion: orig.APIVersion, Kind: orig.Kind, Name: "renamed-gateway"}
+	manifestRegistry["apps/removed.yaml"] = application("removed")
+
+	data := NewTemplateData(&config.NebariConfig{Domain: "nebari.example.com"}, &git.Config{URL: "https://github.com/org/gitops.git", Branch: "main"}, cluster.InfraSettings{})
+	err := ValidateEmbeddedManifests(data)
+	if err == nil {
+		t.Fatal("ValidateEmbeddedManifests() = nil, want drift errors")
+	}
+	for _, want := range []string{`want gateway.networking.k8s.io/v1 Gateway "renamed-gateway"`, "apps/removed.yaml: registered but not embedded"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package argocd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+const (
+	// metalLBL2AdvertisementPath is the template announcing the pool in L2
+	// mode. It is not written in BGP mode.
+	metalLBL2AdvertisementPath = "manifests/metallb/l2advertisement.yaml"
+
+	// metalLBPoolName is the IPAddressPool in manifests/metallb/ipaddresspool.yaml.
+	metalLBPoolName = "default-pool"
+
+	// BGP objects are written one per file next to the IPAddressPool, so the
+	// metallb-config app syncs them and prunes the ones removed from config.
+	bgpPeerFilePrefix          = "bgppeer-"
+	bgpAdvertisementFilePrefix = "bgpadvertisement-"
+)
+
+// metalLBMode returns the MetalLB mode of settings, defaulting to L2.
+func metalLBMode(settings cluster.InfraSettings) string {
+	if settings.MetalLBMode == "" {
+		return cluster.MetalLBModeL2
+	}
+	return settings.MetalLBMode
+}
+
+// metalLBObject returns a MetalLB resource in metallb-system.
+func metalLBObject(apiVersion, kind, name string, spec map[string]any) map[string]any {
+	return map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "metallb-system",
+			"labels": map[string]any{
+				"app.kubernetes.io/name":       "metallb",
+				"app.kubernetes.io/managed-by": "nebari-infrastructure-core",
+			},
+		},
+		"spec": spec,
+	}
+}
+
+// renderBGPPeer renders the BGPPeer named name for peer.
+func renderBGPPeer(name string, peer cluster.MetalLBBGPPeer) ([]byte, error) {
+	obj := metalLBObject("metallb.io/v1beta2", "BGPPeer", name, map[string]any{
+		"peerAddress": peer.PeerAddress,
+		"myASN":       peer.MyASN,
+		"peerASN":     peer.PeerASN,
+	})
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render BGPPeer %s: %w", name, err)
+	}
+	return out, nil
+}
+
+// renderBGPAdvertisement renders the BGPAdvertisement named name announcing
+// the default pool as adv configures.
+func renderBGPAdvertisement(name string, adv cluster.MetalLBBGPAdvertisement) ([]byte, error) {
+	spec := map[string]any{"ipAddressPools": []string{metalLBPoolName}}
+	if adv.AggregationLength != 0 {
+		spec["aggregationLength"] = adv.AggregationLength
+	}
+	if adv.LocalPref != 0 {
+		spec["localPref"] = adv.LocalPref
+	}
+	if len(adv.Communities) > 0 {
+		spec["communities"] = adv.Communities
+	}
+	out, err := yaml.Marshal(metalLBObject("metallb.io/v1beta1", "BGPAdvertisement", name, spec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render BGPAdvertisement %s: %w", name, err)
+	}
+	return out, nil
+}
+
+// writeMetalLBBGP writes the BGPPeers and BGPAdvertisements of BGP mode into
+// the metallb-config app's directory and removes those no longer configured.
+// Without MetalLB, or in L2 mode, all of them are removed. Objects are named
+// by position (bgp-peer-0, ...). With no advertisements configured, one with
+// MetalLB's defaults is written so the pool is announced.
+func writeMetalLBBGP(workDir string, data TemplateData, needsMetalLB bool) error {
+	dir := filepath.Join(workDir, "manifests", "metallb")
+	var stale []string
+	for _, prefix := range []string{bgpPeerFilePrefix, bgpAdvertisementFilePrefix} {
+		matches, err := filepath.Glob(filepath.Join(dir, prefix+"*.yaml"))
+		if err != nil {
+			return fmt.Errorf("failed to list MetalLB BGP manifests: %w", err)
+		}
+		stale = append(stale, matches...)
+	}
+
+	files := map[string][]byte{}
+	if needsMetalLB && data.MetalLBMode == cluster.MetalLBModeBGP {
+		for i, peer := range data.MetalLBBGPPeers {
+			content, err := renderBGPPeer("bgp-peer-"+strconv.Itoa(i), peer)
+			if err != nil {
+				return err
+			}
+			files[filepath.Join(dir, bgpPeerFilePrefix+strconv.Itoa(i)+".yaml")] = content
+		}
+		advertisements := data.MetalLBBGPAdvertisements
+		if len(advertisements) == 0 {
+			advertisements = []cluster.MetalLBBGPAdvertisement{{}}
+		}
+		for i, adv := range advertisements {
+			content, err := renderBGPAdvertisement("bgp-advertisement-"+strconv.Itoa(i), adv)
+			if err != nil {
+				return err
+			}
+			files[filepath.Join(dir, bgpAdvertisementFilePrefix+strconv.Itoa(i)+".yaml")] = content
+		}
+	}
+
+	for path, content := range files {
+		if err := os.MkdirAll(dir, git.GitOpsDirMode); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, content, git.GitOpsFileMode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	for _, path := range stale {
+		if _, ok := files[path]; ok {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale MetalLB manifest %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package argocd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func metalLBSettings(mode string) cluster.InfraSettings {
+	return cluster.InfraSettings{
+		StorageClass:       "standard",
+		NeedsMetalLB:       true,
+		MetalLBAddressPool: "192.168.1.100-192.168.1.110",
+		MetalLBMode:        mode,
+		MetalLBBGPPeers: []cluster.MetalLBBGPPeer{
+			{PeerAddress: "10.0.0.1", MyASN: 64512, PeerASN: 64500},
+			{PeerAddress: "10.0.0.2", MyASN: 64512, PeerASN: 64501},
+		},
+	}
+}
+
+func TestWriteAllToGit_MetalLBBGP(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.NebariConfig{Domain: "test.example.com"}
+	dir := filepath.Join(tmpDir, "manifests", "metallb")
+
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, nil, metalLBSettings(cluster.MetalLBModeBGP), ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+
+	pool := readManifest(t, filepath.Join(dir, "ipaddresspool.yaml"))
+	if pool.GetName() != metalLBPoolName {
+		t.Errorf("IPAddressPool name = %q, want %q", pool.GetName(), metalLBPoolName)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "l2advertisement.yaml")); !os.IsNotExist(err) {
+		t.Errorf("L2Advertisement written in BGP mode (stat err %v)", err)
+	}
+
+	peer := readManifest(t, filepath.Join(dir, "bgppeer-1.yaml"))
+	if peer.GetKind() != "BGPPeer" || peer.GetAPIVersion() != "metallb.io/v1beta2" || peer.GetName() != "bgp-peer-1" {
+		t.Errorf("bgppeer-1.yaml = %s %s %q", peer.GetAPIVersion(), peer.GetKind(), peer.GetName())
+	}
+	spec, _, _ := unstructured.NestedMap(peer.Object, "spec")
+	want := map[string]any{"peerAddress": "10.0.0.2", "myASN": float64(64512), "peerASN": float64(64501)}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("BGPPeer spec = %v, want %v", spec, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bgppeer-0.yaml")); err != nil {
+		t.Errorf("bgppeer-0.yaml missing: %v", err)
+	}
+
+	// No advertisements configured: the pool is advertised with defaults.
+	adv := readManifest(t, filepath.Join(dir, "bgpadvertisement-0.yaml"))
+	pools, _, _ := unstructured.NestedStringSlice(adv.Object, "spec", "ipAddressPools")
+	if adv.GetKind() != "BGPAdvertisement" || !reflect.DeepEqual(pools, []string{metalLBPoolName}) {
+		t.Errorf("BGPAdvertisement = %s with pools %v, want pool %s", adv.GetKind(), pools, metalLBPoolName)
+	}
+
+	// Back to L2: the BGP objects are removed and the L2Advertisement returns.
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, nil, metalLBSettings(""), ""); err != nil {
+		t.Fatalf("second WriteAllToGit() error: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "bgp*"))
+	if len(matches) != 0 {
+		t.Errorf("stale BGP manifests not removed: %v", matches)
+	}
+	for _, name := range []string{"ipaddresspool.yaml", "l2advertisement.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s missing in L2 mode: %v", name, err)
+		}
+	}
+}
+
+func TestWriteAllToGit_MetalLBBGPPeerRemoved(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.NebariConfig{Domain: "test.example.com"}
+	settings := metalLBSettings(cluster.MetalLBModeBGP)
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, nil, settings, ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+
+	settings.MetalLBBGPPeers = settings.MetalLBBGPPeers[:1]
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, cfg, nil, settings, ""); err != nil {
+		t.Fatalf("second WriteAllToGit() error: %v", err)
+	}
+	dir := filepath.Join(tmpDir, "manifests", "metallb")
+	if _, err := os.Stat(filepath.Join(dir, "bgppeer-1.yaml")); !os.IsNotExist(err) {
+		t.Errorf("removed peer still written (stat err %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bgppeer-0.yaml")); err != nil {
+		t.Errorf("bgppeer-0.yaml missing: %v", err)
+	}
+}
+
+func TestWriteAllToGit_MetalLBBGPWithoutMetalLB(t *testing.T) {
+	tmpDir := t.TempDir()
+	settings := metalLBSettings(cluster.MetalLBModeBGP)
+	settings.NeedsMetalLB = false
+	if err := WriteAllToGit(context.Background(), &mockGitClient{workDir: tmpDir}, &config.NebariConfig{Domain: "test.example.com"}, nil, settings, ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "manifests", "metallb")); !os.IsNotExist(err) {
+		t.Errorf("MetalLB manifests written for a provider without MetalLB (stat err %v)", err)
+	}
+}
+
+func TestRenderBGPAdvertisement(t *testing.T) {
+	content, err := renderBGPAdvertisement("bgp-advertisement-0", cluster.MetalLBBGPAdvertisement{
+		AggregationLength: 24,
+		LocalPref:         100,
+		Communities:       []string{"65535:65282"},
+	})
+	if err != nil {
+		t.Fatalf("renderBGPAdvertisement() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "adv.yaml")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	spec, _, _ := unstructured.NestedMap(readManifest(t, path).Object, "spec")
+	want := map[string]any{
+		"ipAddressPools":    []any{metalLBPoolName},
+		"aggregationLength": float64(24),
+		"localPref":         float64(100),
+		"communities":       []any{"65535:65282"},
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("BGPAdvertisement spec = %v, want %v", spec, want)
+	}
+}
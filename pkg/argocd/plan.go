@@ -0,0 +1,166 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// Actions reported in a ResourceChange.
+const (
+	ChangeCreate    = "create"
+	ChangeUpdate    = "update"
+	ChangeUnchanged = "unchanged"
+)
+
+// ResourceChange is one manifest InstallFoundationalServices would apply and
+// what applying it would do to the live object.
+type ResourceChange struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Action    string
+}
+
+// PlanFoundationalServices reports what InstallFoundationalServices would
+// apply through the dynamic client (the ArgoCD AppProjects, user
+// kustomizations and the root App-of-Apps) without changing the cluster:
+// every manifest is only compared against the live object. Generated
+// credentials are create-only and not part of the plan. When the cluster is
+// not reachable yet (e.g. a first dry run) the returned error says so.
+func PlanFoundationalServices(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, gitConfig *git.Config) ([]ResourceChange, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "argocd.PlanFoundationalServices")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("provider", clusterProvider.Name()),
+		attribute.String("project_name", cfg.ProjectName),
+	)
+
+	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ResourceName(), cfg.Cluster)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	dynamicClient, err := NewDynamicClient(kubeconfigBytes)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	data := NewTemplateData(cfg, gitConfig, clusterProvider.InfraSettings(cfg.Cluster))
+	changes, err := planFoundationalServices(ctx, dynamicClient, filesys.MakeFsOnDisk(), data, cfg.Kustomizations, gitConfig)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return changes, nil
+}
+
+// planFoundationalServices renders the foundational manifests and plans each
+// against the live cluster, reporting every change as a status update.
+func planFoundationalServices(ctx context.Context, client dynamic.Interface, fSys filesys.FileSystem, data TemplateData, kustomizations []config.KustomizationConfig, gitConfig *git.Config) ([]ResourceChange, error) {
+	objs, err := RenderProjects(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render AppProjects: %w", err)
+	}
+	for _, k := range kustomizations {
+		rendered, err := RenderKustomization(fSys, k.Path)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, rendered...)
+	}
+	if gitConfig != nil {
+		root, err := renderRootAppOfApps(gitConfig)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, root)
+	}
+
+	changes := make([]ResourceChange, 0, len(objs))
+	for _, obj := range objs {
+		action, err := planResource(ctx, client, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		change := ResourceChange{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName(), Action: action}
+		changes = append(changes, change)
+
+		if action == ChangeUnchanged {
+			continue
+		}
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Would %s %s %s", action, change.Kind, change.Name)).
+			WithResource("foundational").
+			WithAction("plan").
+			WithMetadata("kind", change.Kind).
+			WithMetadata("namespace", change.Namespace).
+			WithMetadata("name", change.Name).
+			WithMetadata("change", action))
+	}
+	return changes, nil
+}
+
+// planResource returns the action applying obj would take. It only reads
+// from the cluster.
+func planResource(ctx context.Context, client dynamic.Interface, obj *unstructured.Unstructured) (string, error) {
+	gvk := obj.GroupVersionKind()
+	gvr := gvk.GroupVersion().WithResource(pluralizeKind(gvk.Kind))
+
+	var resource dynamic.ResourceInterface = client.Resource(gvr)
+	if namespace := obj.GetNamespace(); namespace != "" {
+		resource = client.Resource(gvr).Namespace(namespace)
+	}
+
+	live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return ChangeCreate, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if desiredMatchesLive(obj.Object, live.Object) {
+		return ChangeUnchanged, nil
+	}
+	return ChangeUpdate, nil
+}
+
+// desiredMatchesLive reports whether every field set in desired has the same
+// value in live. Fields only present in live (server defaults, status,
+// metadata the API server manages) are ignored, so an object that was applied
+// unchanged compares equal.
+func desiredMatchesLive(desired, live map[string]any) bool {
+	for key, want := range desired {
+		got, ok := live[key]
+		if !ok {
+			return false
+		}
+		wantMap, wantIsMap := want.(map[string]any)
+		gotMap, gotIsMap := got.(map[string]any)
+		if wantIsMap && gotIsMap {
+			if !desiredMatchesLive(wantMap, gotMap) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(want, got) {
+			return false
+		}
+	}
+	return true
+}
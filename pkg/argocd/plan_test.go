@@ -0,0 +1,125 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestPlanFoundationalServices(t *testing.T) {
+	ctx := context.Background()
+	gitCfg := &git.Config{URL: "https://github.com/org/gitops.git", Branch: "main"}
+	data := NewTemplateData(&config.NebariConfig{Domain: "nebari.example.com"}, gitCfg, cluster.InfraSettings{})
+
+	projects, err := RenderProjects(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := renderRootAppOfApps(gitCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Seed the cluster with one AppProject exactly as NIC renders it (plus
+	// server-managed fields) and a root App-of-Apps pointing elsewhere.
+	var live []runtime.Object
+	var unchanged string
+	for _, p := range projects {
+		if p.GetName() == "default" {
+			obj := p.DeepCopy()
+			obj.SetResourceVersion("42")
+			obj.SetUID("abc")
+			_ = unstructured.SetNestedField(obj.Object, map[string]any{"phase": "Ready"}, "status")
+			live = append(live, obj)
+			unchanged = p.GetName()
+		}
+	}
+	if unchanged == "" {
+		t.Fatal("rendered projects have no default AppProject")
+	}
+	drifted := root.DeepCopy()
+	_ = unstructured.SetNestedField(drifted.Object, "old-branch", "spec", "source", "targetRevision")
+	live = append(live, drifted)
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live...)
+	client.ClearActions()
+
+	changes, err := planFoundationalServices(ctx, client, kustomizeTestFS(t), data,
+		[]config.KustomizationConfig{{Path: "/addons/overlays/prod"}}, gitCfg)
+	if err != nil {
+		t.Fatalf("planFoundationalServices() error = %v", err)
+	}
+
+	for _, action := range client.Actions() {
+		if action.GetVerb() != "get" {
+			t.Errorf("dry-run issued %s %s, want only get", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+
+	got := make(map[string]string, len(changes))
+	for _, c := range changes {
+		got[c.Kind+"/"+c.Name] = c.Action
+	}
+	want := map[string]string{
+		"AppProject/" + unchanged: ChangeUnchanged,
+		"AppProject/foundational": ChangeCreate,
+		"AppProject/nebari-apps":  ChangeCreate,
+		"Namespace/addons":        ChangeCreate,
+		"ConfigMap/prod-settings": ChangeCreate,
+		"Application/nebari-root": ChangeUpdate,
+	}
+	for key, action := range want {
+		if got[key] != action {
+			t.Errorf("%s: action = %q, want %q (all changes: %v)", key, got[key], action, got)
+		}
+	}
+	if len(changes) != len(want) {
+		t.Errorf("got %d changes, want %d: %v", len(changes), len(want), got)
+	}
+}
+
+func TestDesiredMatchesLive(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string]any
+		live    map[string]any
+		want    bool
+	}{
+		{
+			name:    "live adds defaults",
+			desired: map[string]any{"spec": map[string]any{"a": "x"}},
+			live:    map[string]any{"spec": map[string]any{"a": "x", "b": "default"}, "status": map[string]any{}},
+			want:    true,
+		},
+		{
+			name:    "value differs",
+			desired: map[string]any{"spec": map[string]any{"a": "x"}},
+			live:    map[string]any{"spec": map[string]any{"a": "y"}},
+		},
+		{
+			name:    "field missing from live",
+			desired: map[string]any{"data": map[string]any{"k": "v"}},
+			live:    map[string]any{},
+		},
+		{
+			name:    "lists compare exactly",
+			desired: map[string]any{"spec": map[string]any{"l": []any{"a"}}},
+			live:    map[string]any{"spec": map[string]any{"l": []any{"a", "b"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := desiredMatchesLive(tt.desired, tt.live); got != tt.want {
+				t.Errorf("desiredMatchesLive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,19 +1,19 @@
 package argocd
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
-	"text/template"
 
 	"go.opentelemetry.io/otel"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 	"sigs.k8s.io/yaml"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
 )
 
 // deriveProjectScopes renders NIC's own embedded app and manifest templates and
@@ -167,10 +167,14 @@ func mapKeysSorted(m map[string]struct{}) []string {
 // packHelmRepository is the documented source for software-pack Helm charts.
 const packHelmRepository = "https://nebari-dev.github.io/helm-repository"
 
+// inClusterServer is the Argo CD destination server of the local cluster.
+const inClusterServer = "https://kubernetes.default.svc"
+
 // projectsTemplate renders the three AppProjects. foundational and nebari-apps
-// keep wildcard resource whitelists on purpose (kind-level restriction is the
-// admission-controller follow-up, #480). default is deny-all so it cannot be a
-// project-escape hatch.
+// keep wildcard resource whitelists on purpose unless app_project narrows the
+// nebari-apps one (kind-level restriction is the admission-controller
+// follow-up, #480). default is deny-all so it cannot be a project-escape
+// hatch.
 const projectsTemplate = `
 apiVersion: argoproj.io/v1alpha1
 kind: AppProject
@@ -203,14 +207,19 @@ metadata:
 spec:
   description: Software packs (NebariApp-based user applications).
   sourceRepos:
-    - '{{ .PackHelmRepository }}'
-    - '{{ .GitRepoURL }}'
+{{- range .PackRepos }}
+    - '{{ . }}'
+{{- end }}
   destinations:
-    - namespace: '*'
-      server: https://kubernetes.default.svc
+{{- range .PackDestinations }}
+    - namespace: '{{ .Namespace }}'
+      server: {{ .Server }}
+{{- end }}
   clusterResourceWhitelist:
-    - group: '*'
-      kind: '*'
+{{- range .PackClusterResources }}
+    - group: '{{ .Group }}'
+      kind: '{{ .Kind }}'
+{{- end }}
   namespaceResourceWhitelist:
     - group: '*'
       kind: '*'
@@ -230,6 +239,8 @@ spec:
 
 // RenderProjects returns the foundational, nebari-apps, and default AppProject
 // objects, with foundational's scopes derived from the embedded templates.
+// Configured source repos replace the repos of both foundational and
+// nebari-apps, and must permit every repo the foundational Applications use.
 func RenderProjects(ctx context.Context, data TemplateData) ([]*unstructured.Unstructured, error) {
 	tracer := otel.Tracer("nebari-infrastructure-core")
 	ctx, span := tracer.Start(ctx, "argocd.RenderProjects")
@@ -241,36 +252,65 @@ func RenderProjects(ctx context.Context, data TemplateData) ([]*unstructured.Uns
 		return nil, fmt.Errorf("failed to derive project scopes: %w", err)
 	}
 
+	packRepos := []string{packHelmRepository, data.GitRepoURL}
+	if len(data.ProjectSourceRepos) > 0 {
+		if missing := unpermittedRepos(data.ProjectSourceRepos, repos); len(missing) > 0 {
+			err := fmt.Errorf("app_project.source_repos does not permit %v, used by the foundational Applications", missing)
+			span.RecordError(err)
+			return nil, err
+		}
+		repos = data.ProjectSourceRepos
+		packRepos = data.ProjectSourceRepos
+	}
+
+	packDestinations := []config.AppProjectDestination{{Namespace: "*"}}
+	if len(data.ProjectDestinations) > 0 {
+		packDestinations = slices.Clone(data.ProjectDestinations)
+	}
+	for i := range packDestinations {
+		if packDestinations[i].Server == "" {
+			packDestinations[i].Server = inClusterServer
+		}
+	}
+
+	packClusterResources := []config.AppProjectResource{{Group: "*", Kind: "*"}}
+	if len(data.ProjectClusterResources) > 0 {
+		packClusterResources = data.ProjectClusterResources
+	}
+
 	tmplData := struct {
-		SourceRepos        []string
-		Namespaces         []string
-		GitRepoURL         string
-		PackHelmRepository string
-	}{repos, namespaces, data.GitRepoURL, packHelmRepository}
+		SourceRepos          []string
+		Namespaces           []string
+		PackRepos            []string
+		PackDestinations     []config.AppProjectDestination
+		PackClusterResources []config.AppProjectResource
+	}{repos, namespaces, packRepos, packDestinations, packClusterResources}
 
-	tmpl, err := template.New("projects").Parse(projectsTemplate)
+	objs, err := renderManifests("projects", projectsTemplate, tmplData)
 	if err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to parse projects template: %w", err)
-	}
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, tmplData); err != nil {
-		span.RecordError(err)
-		return nil, fmt.Errorf("failed to execute projects template: %w", err)
+		return nil, fmt.Errorf("failed to render project manifests: %w", err)
 	}
+	return objs, nil
+}
 
-	decoder := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
-	var objs []*unstructured.Unstructured
-	for _, doc := range splitYAMLDocs(buf.String()) {
-		if strings.TrimSpace(doc) == "" {
-			continue
-		}
-		obj := &unstructured.Unstructured{}
-		if _, _, err := decoder.Decode([]byte(doc), nil, obj); err != nil {
-			span.RecordError(err)
-			return nil, fmt.Errorf("failed to decode project manifest: %w", err)
+// unpermittedRepos returns the repos not matched by any of patterns, using
+// Argo CD's sourceRepos glob semantics: "*" matches any run of characters,
+// including "/".
+func unpermittedRepos(patterns, repos []string) []string {
+	var missing []string
+	for _, repo := range repos {
+		if !slices.ContainsFunc(patterns, func(p string) bool { return repoGlob(p).MatchString(repo) }) {
+			missing = append(missing, repo)
 		}
-		objs = append(objs, obj)
 	}
-	return objs, nil
+	return missing
+}
+
+// repoGlob compiles a sourceRepos pattern into an anchored regular expression.
+func repoGlob(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("^" + quoted + "$")
 }
@@ -10,6 +10,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
 )
 
 func TestDeriveProjectScopes(t *testing.T) {
@@ -131,6 +133,81 @@ func TestRenderProjects(t *testing.T) {
 	}
 }
 
+func TestRenderProjectsAppProjectOverrides(t *testing.T) {
+	const mirror = "https://mirror.example.com/helm"
+	base := TemplateData{GitRepoURL: "https://git.example.com/org/repo", GitBranch: "main"}
+	derived, _, err := deriveProjectScopes(context.Background(), base)
+	if err != nil {
+		t.Fatalf("deriveProjectScopes() error = %v", err)
+	}
+
+	data := base
+	data.ProjectSourceRepos = append([]string{mirror, "https://*.github.io/*"}, derived...)
+	data.ProjectDestinations = []config.AppProjectDestination{{Namespace: "team-*"}}
+	data.ProjectClusterResources = []config.AppProjectResource{{Group: "", Kind: "Namespace"}}
+	objs, err := RenderProjects(context.Background(), data)
+	if err != nil {
+		t.Fatalf("RenderProjects() error = %v", err)
+	}
+	byName := map[string]map[string]interface{}{}
+	for _, o := range objs {
+		spec, _, _ := unstructuredNestedMap(o, "spec")
+		byName[o.GetName()] = spec
+	}
+
+	for _, name := range []string{"foundational", "nebari-apps"} {
+		repos := toStringSlice(byName[name]["sourceRepos"])
+		if !slices.Contains(repos, mirror) {
+			t.Errorf("%s sourceRepos = %v, want the mirror %s", name, repos, mirror)
+		}
+		if missing := unpermittedRepos(repos, derived); len(missing) > 0 {
+			t.Errorf("%s sourceRepos do not permit foundational repos %v", name, missing)
+		}
+	}
+	dests := specList(byName["nebari-apps"], "destinations")
+	if len(dests) != 1 {
+		t.Fatalf("nebari-apps destinations = %v, want the configured one", dests)
+	}
+	if m, _ := dests[0].(map[string]interface{}); m["namespace"] != "team-*" || m["server"] != inClusterServer {
+		t.Errorf("nebari-apps destination = %v, want namespace team-* on %s", m, inClusterServer)
+	}
+	resources := specList(byName["nebari-apps"], "clusterResourceWhitelist")
+	if m, _ := resources[0].(map[string]interface{}); len(resources) != 1 || m["kind"] != "Namespace" {
+		t.Errorf("nebari-apps clusterResourceWhitelist = %v, want only Namespace", resources)
+	}
+	if fDests := specList(byName["foundational"], "destinations"); len(fDests) < 2 {
+		t.Errorf("foundational destinations = %v, want the derived namespaces kept", fDests)
+	}
+
+	// A mirror alone would lock the foundational Applications out.
+	data.ProjectSourceRepos = []string{mirror}
+	if _, err := RenderProjects(context.Background(), data); err == nil || !strings.Contains(err.Error(), "does not permit") {
+		t.Errorf("RenderProjects() error = %v, want foundational repos reported as not permitted", err)
+	}
+}
+
+func TestUnpermittedRepos(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		repos    []string
+		want     []string
+	}{
+		{name: "exact", patterns: []string{"https://charts.jetstack.io"}, repos: []string{"https://charts.jetstack.io"}},
+		{name: "wildcard spans slashes", patterns: []string{"https://github.com/*"}, repos: []string{"https://github.com/nebari-dev/nebari-landing"}},
+		{name: "everything", patterns: []string{"*"}, repos: []string{"docker.io/envoyproxy"}},
+		{name: "dots are literal", patterns: []string{"https://charts.jetstack.io"}, repos: []string{"https://chartsXjetstack.io"}, want: []string{"https://chartsXjetstack.io"}},
+		{name: "missing", patterns: []string{"https://mirror.example.com/*"}, repos: []string{"https://mirror.example.com/a", "https://charts.jetstack.io"}, want: []string{"https://charts.jetstack.io"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unpermittedRepos(tt.patterns, tt.repos); !slices.Equal(got, tt.want) {
+				t.Errorf("unpermittedRepos() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestFoundationalAppsHaveAllowedDestinationNamespace guards the regression the
 // live journey-3 verification caught: a foundational Application with an empty
 // spec.destination.namespace is rejected at admission by the scoped
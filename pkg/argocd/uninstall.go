@@ -0,0 +1,144 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/storage/longhorn"
+)
+
+// foundationalNamespaces are the namespaces InstallFoundationalServices
+// creates that hold nothing but foundational services.
+var foundationalNamespaces = []string{KeycloakDefaultNamespace, NebariSystemNamespace, MonitoringNamespace}
+
+// foundationalSecrets are the secrets InstallFoundationalServices creates in
+// namespaces that outlive the foundational services: cert-manager's and the
+// provider-installed Longhorn's.
+var foundationalSecrets = []struct{ Namespace, Name string }{
+	{certManagerNamespace, dns01SecretName},
+	{longhorn.Namespace, LonghornOIDCClientSecretName},
+	{longhorn.Namespace, longhorn.BackupCredentialSecretName},
+}
+
+// UninstallFoundationalServices removes what InstallFoundationalServices
+// installed, leaving Argo CD itself in place: the NIC-managed Argo CD
+// Applications, deleted in reverse dependency order with opts.Cascade, and
+// then the namespaces and secrets NIC created for them. Resources that are
+// already gone are skipped.
+//
+// With CascadeOrphan only the Applications are deleted; the namespaces and
+// secrets are kept because the orphaned workloads still use them.
+func UninstallFoundationalServices(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, opts DeleteApplicationsOptions) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "argocd.UninstallFoundationalServices")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("provider", clusterProvider.Name()),
+		attribute.String("project_name", cfg.ProjectName),
+		attribute.String("cascade", opts.Cascade),
+	)
+
+	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ResourceName(), cfg.Cluster)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	dynamicClient, err := NewDynamicClient(kubeconfigBytes)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	k8sClient, err := newK8sClient(kubeconfigBytes)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	if err := uninstallFoundationalServices(ctx, dynamicClient, k8sClient, opts); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// uninstallFoundationalServices implements UninstallFoundationalServices
+// with injected clients.
+func uninstallFoundationalServices(ctx context.Context, dynamicClient dynamic.Interface, k8sClient kubernetes.Interface, opts DeleteApplicationsOptions) error {
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Uninstalling foundational services").
+		WithResource("foundational").
+		WithAction("uninstalling").
+		WithMetadata("cascade", opts.Cascade))
+
+	if err := DeleteApplications(ctx, dynamicClient, defaultNamespace, opts); err != nil {
+		return err
+	}
+
+	if opts.Cascade == CascadeOrphan {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Keeping foundational namespaces and secrets for the orphaned resources").
+			WithResource("foundational").
+			WithAction("kept"))
+		return nil
+	}
+
+	for _, s := range foundationalSecrets {
+		if err := deleteSecret(ctx, k8sClient, s.Namespace, s.Name); err != nil {
+			return err
+		}
+	}
+	for _, namespace := range foundationalNamespaces {
+		if err := deleteNamespace(ctx, k8sClient, namespace); err != nil {
+			return err
+		}
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelSuccess, "Foundational services uninstalled").
+		WithResource("foundational").
+		WithAction("uninstalled"))
+	return nil
+}
+
+// deleteSecret deletes a secret. A secret that does not exist is not an
+// error.
+func deleteSecret(ctx context.Context, client kubernetes.Interface, namespace, name string) error {
+	err := client.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete secret %s in %s: %w", name, namespace, err)
+	}
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Deleted secret %s", name)).
+		WithResource("secret").
+		WithAction("deleted").
+		WithMetadata("secret_name", name).
+		WithMetadata("namespace", namespace))
+	return nil
+}
+
+// deleteNamespace deletes a namespace without waiting for it to terminate.
+// A namespace that does not exist is not an error.
+func deleteNamespace(ctx context.Context, client kubernetes.Interface, namespace string) error {
+	err := client.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", namespace, err)
+	}
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Deleting namespace: %s", namespace)).
+		WithResource("namespace").
+		WithAction("deleting").
+		WithMetadata("namespace", namespace))
+	return nil
+}
@@ -0,0 +1,93 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUninstallFoundationalServices(t *testing.T) {
+	newClients := func() (*dynamicfake.FakeDynamicClient, *fake.Clientset) {
+		listKinds := map[schema.GroupVersionResource]string{ApplicationGVR: "ApplicationList"}
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+			newApplication(rootApplicationName, true),
+			newApplication("keycloak", true),
+		)
+		// The monitoring namespace and the backup secret were never created.
+		k8sClient := fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "keycloak"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "nebari-system"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "longhorn-system"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: LonghornOIDCClientSecretName, Namespace: "longhorn-system"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "acme-dns01-api-token", Namespace: "cert-manager"}},
+		)
+		return dynamicClient, k8sClient
+	}
+	opts := func(cascade string) DeleteApplicationsOptions {
+		return DeleteApplicationsOptions{Cascade: cascade, Timeout: time.Second, PollInterval: 10 * time.Millisecond}
+	}
+
+	t.Run("cascade removes applications, secrets and namespaces", func(t *testing.T) {
+		ctx := context.Background()
+		dynamicClient, k8sClient := newClients()
+		if err := uninstallFoundationalServices(ctx, dynamicClient, k8sClient, opts(CascadeForeground)); err != nil {
+			t.Fatalf("uninstallFoundationalServices() error = %v", err)
+		}
+
+		apps, err := dynamicClient.Resource(ApplicationGVR).Namespace(defaultNamespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("list applications: %v", err)
+		}
+		if len(apps.Items) != 0 {
+			t.Errorf("%d Applications left, want none", len(apps.Items))
+		}
+		for _, ns := range []string{"keycloak", "nebari-system"} {
+			if _, err := k8sClient.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+				t.Errorf("namespace %s not deleted (get error: %v)", ns, err)
+			}
+		}
+		// Longhorn is installed by the provider, so only NIC's secret goes.
+		if _, err := k8sClient.CoreV1().Namespaces().Get(ctx, "longhorn-system", metav1.GetOptions{}); err != nil {
+			t.Errorf("longhorn-system was deleted: %v", err)
+		}
+		for _, s := range []struct{ ns, name string }{
+			{"longhorn-system", LonghornOIDCClientSecretName},
+			{"cert-manager", "acme-dns01-api-token"},
+		} {
+			if _, err := k8sClient.CoreV1().Secrets(s.ns).Get(ctx, s.name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+				t.Errorf("secret %s/%s not deleted (get error: %v)", s.ns, s.name, err)
+			}
+		}
+	})
+
+	t.Run("orphan keeps namespaces and secrets", func(t *testing.T) {
+		ctx := context.Background()
+		dynamicClient, k8sClient := newClients()
+		if err := uninstallFoundationalServices(ctx, dynamicClient, k8sClient, opts(CascadeOrphan)); err != nil {
+			t.Fatalf("uninstallFoundationalServices() error = %v", err)
+		}
+
+		if _, err := k8sClient.CoreV1().Namespaces().Get(ctx, "keycloak", metav1.GetOptions{}); err != nil {
+			t.Errorf("keycloak namespace was deleted: %v", err)
+		}
+		if _, err := k8sClient.CoreV1().Secrets("longhorn-system").Get(ctx, LonghornOIDCClientSecretName, metav1.GetOptions{}); err != nil {
+			t.Errorf("Longhorn OIDC secret was deleted: %v", err)
+		}
+	})
+
+	t.Run("nothing installed is not an error", func(t *testing.T) {
+		listKinds := map[schema.GroupVersionResource]string{ApplicationGVR: "ApplicationList"}
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+		if err := uninstallFoundationalServices(context.Background(), dynamicClient, fake.NewSimpleClientset(), opts(CascadeForeground)); err != nil {
+			t.Errorf("uninstallFoundationalServices() error = %v", err)
+		}
+	})
+}
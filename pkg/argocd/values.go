@@ -0,0 +1,154 @@
+package argocd
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// helmValuesApplications lists the foundational Applications whose app
+// template sets Helm values, which the overrides config is merged into.
+var helmValuesApplications = []string{
+	"cert-manager",
+	"cloudnative-pg",
+	"envoy-gateway",
+	"keycloak",
+	"metallb",
+	"monitoring",
+	"nebari-landingpage",
+	"opentelemetry-collector",
+	"postgresql",
+	"trust-manager",
+}
+
+// CheckValueOverrides returns an error for Helm value overrides of
+// Applications that take no Helm values.
+func CheckValueOverrides(overrides map[string]map[string]any) error {
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		if !slices.Contains(helmValuesApplications, name) {
+			return fmt.Errorf("values of %q cannot be overridden (supported: %s)",
+				name, strings.Join(helmValuesApplications, ", "))
+		}
+	}
+	return nil
+}
+
+// applicationName returns the name of the Application whose app template is
+// at relPath, or "" when relPath is not an app template.
+func applicationName(relPath string) string {
+	name, ok := strings.CutPrefix(relPath, "apps/")
+	if !ok || strings.Contains(name, "/") {
+		return ""
+	}
+	return strings.TrimSuffix(name, ".yaml")
+}
+
+// mergeHelmValues deep-merges overrides into the Helm values of the rendered
+// Application manifest app and returns the re-encoded manifest. The values
+// are those of spec.source, or of the first of spec.sources with Helm
+// values.
+func mergeHelmValues(app []byte, overrides map[string]any) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(app, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse application: %w", err)
+	}
+
+	valuesNode := helmValuesNode(&doc)
+	if valuesNode == nil {
+		return nil, fmt.Errorf("application has no Helm values to override")
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal([]byte(valuesNode.Value), &values); err != nil {
+		return nil, fmt.Errorf("failed to parse Helm values: %w", err)
+	}
+	mergeValues(values, overrides)
+
+	merged, err := encodeYAML(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Helm values: %w", err)
+	}
+	valuesNode.Value = string(merged)
+	valuesNode.Style = yaml.LiteralStyle
+
+	out, err := encodeYAML(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode application: %w", err)
+	}
+	return out, nil
+}
+
+// encodeYAML encodes v with the two-space indent of the app templates.
+func encodeYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// helmValuesNode returns the scalar node holding the Helm values of the
+// Application document doc, or nil when it has none.
+func helmValuesNode(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	spec := mappingValue(doc.Content[0], "spec")
+	if source := mappingValue(spec, "source"); source != nil {
+		return mappingValue(mappingValue(source, "helm"), "values")
+	}
+	if sources := mappingValue(spec, "sources"); sources != nil && sources.Kind == yaml.SequenceNode {
+		for _, source := range sources.Content {
+			if values := mappingValue(mappingValue(source, "helm"), "values"); values != nil {
+				return values
+			}
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in the mapping node m, or nil when
+// m is not a mapping or has no such key.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mergeValues deep-merges src into dst the way Helm merges values files:
+// maps are merged key by key, any other value replaces the one in dst, and a
+// null removes the key.
+func mergeValues(dst, src map[string]any) {
+	for k, v := range src {
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+		srcMap, ok := v.(map[string]any)
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		dstMap, ok := dst[k].(map[string]any)
+		if !ok {
+			dstMap = map[string]any{}
+			dst[k] = dstMap
+		}
+		mergeValues(dstMap, srcMap)
+	}
+}
@@ -0,0 +1,141 @@
+package argocd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// writtenHelmValues reads the Application written for name under workDir and
+// returns its parsed Helm values.
+func writtenHelmValues(t *testing.T, workDir, name string) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(workDir, "apps", name+".yaml"))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	var app map[string]any
+	if err := yaml.Unmarshal(data, &app); err != nil {
+		t.Fatalf("parse %s: %v", name, err)
+	}
+	u := unstructured.Unstructured{Object: app}
+	raw, found, _ := unstructured.NestedString(u.Object, "spec", "source", "helm", "values")
+	if !found {
+		sources, _, _ := unstructured.NestedSlice(u.Object, "spec", "sources")
+		raw, _, _ = unstructured.NestedString(sources[0].(map[string]any), "helm", "values")
+	}
+	values := map[string]any{}
+	if err := yaml.Unmarshal([]byte(raw), &values); err != nil {
+		t.Fatalf("parse %s Helm values: %v", name, err)
+	}
+	return values
+}
+
+func TestWriteAllToGit_MergesValueOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.NebariConfig{
+		Domain: "test.example.com",
+		Overrides: map[string]map[string]any{
+			"postgresql": {
+				"primary": map[string]any{
+					"persistence": map[string]any{"storageClass": "fast-ssd"},
+				},
+				"metrics": nil,
+			},
+			"keycloak": {"replicas": 2},
+		},
+	}
+	mock := &mockGitClient{workDir: tmpDir}
+	if err := WriteAllToGit(context.Background(), mock, cfg, nil, cluster.InfraSettings{StorageClass: "standard"}, ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+
+	pg := writtenHelmValues(t, tmpDir, "postgresql")
+	primary := pg["primary"].(map[string]any)
+	persistence := primary["persistence"].(map[string]any)
+	if persistence["storageClass"] != "fast-ssd" {
+		t.Errorf("storageClass = %v, want fast-ssd", persistence["storageClass"])
+	}
+	if persistence["size"] != "10Gi" {
+		t.Errorf("size = %v, want the template's 10Gi kept", persistence["size"])
+	}
+	if _, ok := primary["initdb"]; !ok {
+		t.Error("initdb was dropped by the merge")
+	}
+	if _, ok := pg["metrics"]; ok {
+		t.Error("metrics is still set, want it removed by null")
+	}
+
+	kc := writtenHelmValues(t, tmpDir, "keycloak")
+	if kc["replicas"] != 2 {
+		t.Errorf("keycloak replicas = %v, want 2", kc["replicas"])
+	}
+
+	// Applications without overrides are written as rendered.
+	certManager, err := os.ReadFile(filepath.Join(tmpDir, "apps", "cert-manager.yaml"))
+	if err != nil {
+		t.Fatalf("read cert-manager: %v", err)
+	}
+	rendered, err := processTemplate("apps/cert-manager.yaml", mustReadTemplate(t, "apps/cert-manager.yaml"), NewTemplateData(cfg, nil, cluster.InfraSettings{StorageClass: "standard"}))
+	if err != nil {
+		t.Fatalf("render cert-manager: %v", err)
+	}
+	if string(certManager) != string(rendered) {
+		t.Error("cert-manager.yaml was re-encoded without an override")
+	}
+}
+
+func mustReadTemplate(t *testing.T, relPath string) []byte {
+	t.Helper()
+	content, err := templates.ReadFile(templateDir + "/" + relPath)
+	if err != nil {
+		t.Fatalf("read template %s: %v", relPath, err)
+	}
+	return content
+}
+
+func TestMergeValues(t *testing.T) {
+	dst := map[string]any{
+		"image":     map[string]any{"repository": "a", "tag": "1"},
+		"args":      []any{"start"},
+		"resources": map[string]any{"limits": map[string]any{"cpu": "1"}},
+	}
+	mergeValues(dst, map[string]any{
+		"image":     map[string]any{"tag": "2"},
+		"args":      []any{"start-dev"},
+		"resources": nil,
+		"extra":     map[string]any{"enabled": true},
+	})
+
+	image := dst["image"].(map[string]any)
+	if image["repository"] != "a" || image["tag"] != "2" {
+		t.Errorf("image = %v, want repository kept and tag replaced", image)
+	}
+	if args := dst["args"].([]any); len(args) != 1 || args[0] != "start-dev" {
+		t.Errorf("args = %v, want the list replaced", args)
+	}
+	if _, ok := dst["resources"]; ok {
+		t.Error("resources is still set, want it removed by null")
+	}
+	if extra := dst["extra"].(map[string]any); extra["enabled"] != true {
+		t.Errorf("extra = %v, want it added", extra)
+	}
+}
+
+func TestCheckValueOverrides(t *testing.T) {
+	if err := CheckValueOverrides(map[string]map[string]any{"postgresql": {}, "metallb": {}}); err != nil {
+		t.Errorf("CheckValueOverrides() error = %v", err)
+	}
+	err := CheckValueOverrides(map[string]map[string]any{"httproutes": {}})
+	if err == nil || !strings.Contains(err.Error(), `"httproutes" cannot be overridden`) {
+		t.Errorf("CheckValueOverrides() error = %v, want httproutes rejected", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,6 +33,9 @@ const (
 	// certificateIssuerSelfSigned is the cert-manager Issuer used when the user has
 	// not configured a real ACME provider.
 	certificateIssuerSelfSigned = "selfsigned-issuer"
+	// certificateIssuerLetsEncrypt is the ACME ClusterIssuer, which solves
+	// HTTP-01 challenges through the Nebari Gateway.
+	certificateIssuerLetsEncrypt = "letsencrypt-issuer"
 )
 
 // TemplateData holds the dynamic values for template processing
@@ -50,6 +54,9 @@ type TemplateData struct {
 	CertificateIssuer string // "selfsigned-issuer" or "letsencrypt-issuer"
 	ACMEEmail         string
 	ACMEServer        string
+	// ACMEDNS01Solver is the cert-manager DNS-01 solver the Let's Encrypt
+	// issuer uses, e.g. "cloudflare". Empty means HTTP-01.
+	ACMEDNS01Solver string
 
 	// UseExistingCertificate is true when the user supplies their own TLS cert
 	// (certificate.type=existing). When true, the cert-manager Certificate is
@@ -66,6 +73,12 @@ type TemplateData struct {
 
 	// MetalLB configuration (for local provider)
 	MetalLBAddressRange string
+	// MetalLBMode is cluster.MetalLBModeL2 or cluster.MetalLBModeBGP. The
+	// L2Advertisement is only written in L2 mode; BGP mode writes a BGPPeer
+	// per peer and a BGPAdvertisement per advertisement instead.
+	MetalLBMode              string
+	MetalLBBGPPeers          []cluster.MetalLBBGPPeer
+	MetalLBBGPAdvertisements []cluster.MetalLBBGPAdvertisement
 
 	// TrustManagerEnabled gates the trust-manager app and Bundle manifest. True
 	// when a top-level trust_bundle is configured.
@@ -78,9 +91,39 @@ type TemplateData struct {
 	// HTTPSPort is the port used for HTTPS redirects (default: 443).
 	HTTPSPort int
 
+	// SyncAutomated, SyncPrune and SyncSelfHeal set the automated sync policy
+	// of the foundational Applications (see config.SyncPolicyConfig). The root
+	// App-of-Apps always syncs automatically so policy changes committed to
+	// git reach the existing Applications.
+	SyncAutomated bool
+	SyncPrune     bool
+	SyncSelfHeal  bool
+
+	// ProjectSourceRepos, ProjectDestinations and ProjectClusterResources
+	// override the AppProject scopes (see config.AppProjectConfig). Empty
+	// keeps the defaults.
+	ProjectSourceRepos      []string
+	ProjectDestinations     []config.AppProjectDestination
+	ProjectClusterResources []config.AppProjectResource
+
 	// LoadBalancerAnnotations are added to the Gateway's provisioned LoadBalancer Service.
 	LoadBalancerAnnotations map[string]string
 
+	// AdditionalGateways are rendered beside the default gateway, one file
+	// each (see config.AdditionalGateway). RouteParents maps the foundational
+	// routes they claim to their listener; see RouteParent.
+	AdditionalGateways []GatewayData
+	RouteParents       map[string]RouteParent
+
+	// SyncWaves is the sync wave of every Application, ordered by the
+	// configured components (see ComponentWaves). Nil keeps the built-in
+	// waves; see SyncWave.
+	SyncWaves map[string]int
+
+	// Images overrides the images of foundational Applications; see
+	// ImageParameters.
+	Images map[string]config.ImageOverride
+
 	// KeycloakBasePath is appended to the Keycloak in-cluster URL (e.g., "/auth").
 	KeycloakBasePath string
 
@@ -93,6 +136,14 @@ type TemplateData struct {
 	KeycloakAdminSecretName      string // Name of the Kubernetes secret containing Keycloak admin credentials
 	KeycloakAdminSecretNamespace string // Namespace of the Kubernetes secret containing Keycloak admin credentials
 
+	// Keycloak database connection. The in-cluster PostgreSQL Application is
+	// only rendered when ExternalKeycloakDatabase is false.
+	ExternalKeycloakDatabase bool
+	KeycloakDatabaseHost     string
+	KeycloakDatabasePort     int
+	KeycloakDatabaseName     string
+	KeycloakDatabaseUser     string
+
 	// Longhorn backup configuration (rendered into manifests/storage/longhorn-backup)
 	LonghornBackupEnabled          bool
 	LonghornBackupTargetURL        string
@@ -112,6 +163,15 @@ type TemplateData struct {
 	// gate is not part of the conditional.
 	LonghornEnabled bool
 
+	// MonitoringEnabled gates the monitoring Application (kube-prometheus-stack),
+	// the Grafana HTTPRoute and the collector's remote write to Prometheus.
+	MonitoringEnabled bool
+
+	// GrafanaAdminSecretName is the name of the secret holding the Grafana
+	// admin credentials, threaded from the Go constant like
+	// LonghornOIDCSecretName.
+	GrafanaAdminSecretName string
+
 	// LonghornOIDCSecretName is the name of the Kubernetes secret holding the
 	// Longhorn UI OIDC client secret, threaded from LonghornOIDCClientSecretName
 	// so the Go constant and the rendered manifests cannot drift.
@@ -134,10 +194,16 @@ func NewTemplateData(cfg *config.NebariConfig, gitConfig *git.Config, settings c
 		StorageClass:            settings.StorageClass,
 		HTTPSPort:               httpsPort,
 		MetalLBAddressRange:     settings.MetalLBAddressPool,
-		LoadBalancerAnnotations: settings.LoadBalancerAnnotations,
+		MetalLBMode:             metalLBMode(settings),
+		LoadBalancerAnnotations: gatewayAnnotations(cfg, settings),
 		KeycloakBasePath:        settings.KeycloakBasePath,
 		LonghornEnabled:         settings.LonghornEnabled,
 		LonghornOIDCSecretName:  LonghornOIDCClientSecretName,
+		MonitoringEnabled:       cfg.Monitoring.IsEnabled(),
+		GrafanaAdminSecretName:  GrafanaAdminSecretName,
+		SyncAutomated:           cfg.SyncPolicy.IsAutomated(),
+		SyncPrune:               cfg.SyncPolicy.PruneEnabled(),
+		SyncSelfHeal:            cfg.SyncPolicy.SelfHealEnabled(),
 
 		KeycloakNamespace:            KeycloakDefaultNamespace,
 		KeycloakServiceName:          keycloakServiceName,
@@ -146,6 +212,23 @@ func NewTemplateData(cfg *config.NebariConfig, gitConfig *git.Config, settings c
 		KeycloakRealm:                "nebari",
 		KeycloakAdminSecretName:      KeycloakDefaultAdminSecretName,
 		KeycloakAdminSecretNamespace: KeycloakDefaultNamespace,
+		KeycloakDatabaseHost:         fmt.Sprintf("postgresql.%s.svc.cluster.local", KeycloakDefaultNamespace),
+		KeycloakDatabasePort:         5432,
+		KeycloakDatabaseName:         "keycloak",
+		KeycloakDatabaseUser:         "keycloak",
+	}
+
+	if db := cfg.Keycloak.ExternalDatabase(); db != nil {
+		data.ExternalKeycloakDatabase = true
+		data.KeycloakDatabaseHost = db.Host
+		data.KeycloakDatabasePort = db.PortOrDefault()
+		data.KeycloakDatabaseName = db.Database
+		data.KeycloakDatabaseUser = db.Username
+	}
+
+	if data.MetalLBMode == cluster.MetalLBModeBGP {
+		data.MetalLBBGPPeers = settings.MetalLBBGPPeers
+		data.MetalLBBGPAdvertisements = settings.MetalLBBGPAdvertisements
 	}
 
 	// Set git repository info
@@ -155,9 +238,15 @@ func NewTemplateData(cfg *config.NebariConfig, gitConfig *git.Config, settings c
 		data.GitPath = gitConfig.Path
 	}
 
+	if cfg.AppProject != nil {
+		data.ProjectSourceRepos = cfg.AppProject.SourceRepos
+		data.ProjectDestinations = cfg.AppProject.Destinations
+		data.ProjectClusterResources = cfg.AppProject.ClusterResourceWhitelist
+	}
+
 	// Set certificate configuration
 	if cfg.Certificate != nil && cfg.Certificate.Type == config.CertificateTypeLetsEncrypt {
-		data.CertificateIssuer = "letsencrypt-issuer"
+		data.CertificateIssuer = certificateIssuerLetsEncrypt
 		if cfg.Certificate.ACME != nil {
 			data.ACMEEmail = cfg.Certificate.ACME.Email
 			data.ACMEServer = cfg.Certificate.ACME.Server
@@ -165,6 +254,7 @@ func NewTemplateData(cfg *config.NebariConfig, gitConfig *git.Config, settings c
 				data.ACMEServer = "https://acme-v02.api.letsencrypt.org/directory"
 			}
 		}
+		data.ACMEDNS01Solver = ACMEDNS01Solver(cfg)
 	} else {
 		data.CertificateIssuer = certificateIssuerSelfSigned
 	}
@@ -174,6 +264,11 @@ func NewTemplateData(cfg *config.NebariConfig, gitConfig *git.Config, settings c
 	data.UseExistingCertificate = cfg.Certificate != nil && cfg.Certificate.Type == config.CertificateTypeExisting
 	data.GatewayTLSSecretName, data.GatewayTLSSecretNamespace = cfg.Certificate.GatewaySecretRef()
 	data.GatewayTLSCrossNamespace = cfg.Certificate.IsCrossNamespaceSecret()
+	data.AdditionalGateways, data.RouteParents = additionalGateways(cfg, settings, data)
+	// Invalid components are reported by WriteAllToGit; until then the
+	// built-in waves apply.
+	data.SyncWaves, _ = ComponentWaves(cfg.Components)
+	data.Images = cfg.Images
 
 	// Default domain if not set
 	if data.Domain == "" {
@@ -221,6 +316,37 @@ func NewTemplateData(cfg *config.NebariConfig, gitConfig *git.Config, settings c
 	return data
 }
 
+// gatewayAnnotations merges the provider's Gateway LoadBalancer annotations,
+// its internal load balancer annotations when the gateway is internal, and
+// the user's gateway.load_balancer_annotations, in that order of precedence
+// (later wins). The provider maps are copied, never modified.
+func gatewayAnnotations(cfg *config.NebariConfig, settings cluster.InfraSettings) map[string]string {
+	if cfg.Gateway == nil {
+		return settings.LoadBalancerAnnotations
+	}
+	return mergeGatewayAnnotations(settings, cfg.Gateway.Internal, cfg.Gateway.LoadBalancerAnnotations)
+}
+
+// mergeGatewayAnnotations applies the precedence of gatewayAnnotations to
+// one gateway's internal flag and user annotations.
+func mergeGatewayAnnotations(settings cluster.InfraSettings, internal bool, user map[string]string) map[string]string {
+	if !internal && len(user) == 0 {
+		return settings.LoadBalancerAnnotations
+	}
+	annotations := maps.Clone(settings.LoadBalancerAnnotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if internal {
+		maps.Copy(annotations, settings.InternalLoadBalancerAnnotations)
+	}
+	maps.Copy(annotations, user)
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
 // Applications returns the list of available application names from the apps/ directory.
 // Names are derived from filenames (without .yaml extension).
 func Applications() ([]string, error) {
@@ -339,6 +465,27 @@ func WriteAllToGit(ctx context.Context, gitClient git.Client, cfg *config.Nebari
 		attribute.String("git_repo_url", data.GitRepoURL),
 	)
 
+	if _, err := ComponentWaves(cfg.Components); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := CheckImageOverrides(cfg.Images); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := CheckValueOverrides(cfg.Overrides); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := checkGatewayTLSSecret(data); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := checkHTTP01Certificate(data); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	// Walk all files in the templates directory
 	err := fs.WalkDir(templates, templateDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -373,6 +520,11 @@ func WriteAllToGit(ctx context.Context, gitClient git.Client, cfg *config.Nebari
 			return removeStaleTemplate(destPath, d)
 		}
 
+		// BGP mode announces the pool with BGPAdvertisements instead
+		if relPath == metalLBL2AdvertisementPath && data.MetalLBMode == cluster.MetalLBModeBGP {
+			return removeStaleTemplate(destPath, d)
+		}
+
 		// Longhorn-only templates are gated on LonghornEnabled. The
 		// securitypolicies Application targets manifests/networking/policies,
 		// whose only content is the Longhorn SecurityPolicy; writing the app
@@ -387,6 +539,16 @@ func WriteAllToGit(ctx context.Context, gitClient git.Client, cfg *config.Nebari
 			return removeStaleTemplate(destPath, d)
 		}
 
+		// The in-cluster PostgreSQL is not deployed for an external database
+		if relPath == "apps/postgresql.yaml" && data.ExternalKeycloakDatabase {
+			return removeStaleTemplate(destPath, d)
+		}
+
+		// The monitoring stack and its Grafana route are opt-in
+		if isMonitoringPath(relPath) && !data.MonitoringEnabled {
+			return removeStaleTemplate(destPath, d)
+		}
+
 		// trust-manager templates only apply when a trust bundle is configured
 		if isTrustBundlePath(relPath) && !data.TrustManagerEnabled {
 			return removeStaleTemplate(destPath, d)
@@ -413,6 +575,14 @@ func WriteAllToGit(ctx context.Context, gitClient git.Client, cfg *config.Nebari
 			return fmt.Errorf("failed to process template %s: %w", path, err)
 		}
 
+		// Helm value overrides are merged into the rendered Application
+		if values, ok := cfg.Overrides[applicationName(relPath)]; ok {
+			processed, err = mergeHelmValues(processed, values)
+			if err != nil {
+				return fmt.Errorf("failed to override Helm values in %s: %w", path, err)
+			}
+		}
+
 		// Ensure parent directory exists
 		if err := os.MkdirAll(filepath.Dir(destPath), git.GitOpsDirMode); err != nil {
 			return fmt.Errorf("failed to create directory for %s: %w", destPath, err)
@@ -431,6 +601,19 @@ func WriteAllToGit(ctx context.Context, gitClient git.Client, cfg *config.Nebari
 		return fmt.Errorf("failed to write templates to git: %w", err)
 	}
 
+	if err := writeAdditionalGateways(workDir, data.AdditionalGateways); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := writeMetalLBBGP(workDir, data, settings.NeedsMetalLB); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := writeComponents(workDir, cfg.Components, data); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	return nil
 }
 
@@ -475,6 +658,14 @@ func isLonghornOnlyPath(relPath string) bool {
 		strings.HasPrefix(relPath, "manifests/networking/policies")
 }
 
+// isMonitoringPath returns true if the relative path is part of the optional
+// monitoring stack (the kube-prometheus-stack Application or the Grafana
+// HTTPRoute).
+func isMonitoringPath(relPath string) bool {
+	return relPath == "apps/monitoring.yaml" ||
+		relPath == "manifests/networking/routes/grafana-httproute.yaml"
+}
+
 // isTrustBundlePath returns true if the relative path is a trust-manager-related
 // template (the chart Application, the Bundle Application, or the Bundle manifest).
 func isTrustBundlePath(relPath string) bool {
@@ -513,10 +704,26 @@ func skipCertificateTemplate(relPath string, data TemplateData) bool {
 // templateFuncs is the single extension point for helpers available to every
 // template; it is consumed only by processTemplate, not a broader public surface.
 // indent and nindent mirror the common Helm helpers for embedding multi-line
-// values (e.g. a PEM bundle) at a fixed YAML indentation.
+// values (e.g. a PEM bundle) at a fixed YAML indentation; required fails the
+// render when a value the manifest cannot do without is empty.
 var templateFuncs = template.FuncMap{
-	"indent":  indentLines,
-	"nindent": func(spaces int, s string) string { return "\n" + indentLines(spaces, s) },
+	"indent":   indentLines,
+	"nindent":  func(spaces int, s string) string { return "\n" + indentLines(spaces, s) },
+	"required": requiredValue,
+}
+
+// requiredValue returns v, or an error naming the missing value when v is an
+// empty string or nil. Use it as {{ required "GitRepoURL" .GitRepoURL }}.
+func requiredValue(name string, v any) (any, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, fmt.Errorf("required value %s is missing", name)
+	case string:
+		if val == "" {
+			return nil, fmt.Errorf("required value %s is missing", name)
+		}
+	}
+	return v, nil
 }
 
 // indentLines prefixes every non-empty line of s with the given number of spaces.
@@ -540,7 +747,13 @@ func processTemplate(name string, content []byte, data TemplateData) ([]byte, er
 		return content, nil
 	}
 
-	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(content))
+	return executeTemplate(name, string(content), data)
+}
+
+// executeTemplate parses text as a template named name, with templateFuncs,
+// and executes it against data.
+func executeTemplate(name, text string, data any) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
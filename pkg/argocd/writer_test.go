@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -263,6 +264,208 @@ func TestGatewayTemplate_WithoutAnnotations(t *testing.T) {
 	}
 }
 
+func TestGatewayTemplate_ConfiguredAnnotations(t *testing.T) {
+	providerAnnotations := map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-type":   "external",
+		"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing",
+	}
+
+	tests := []struct {
+		name     string
+		provider map[string]string
+		gateway  *config.GatewayConfig
+		want     map[string]string
+	}{
+		{
+			name:     "no gateway config keeps provider annotations",
+			provider: providerAnnotations,
+			want:     providerAnnotations,
+		},
+		{
+			name:     "configured annotations without provider annotations",
+			provider: nil,
+			gateway: &config.GatewayConfig{LoadBalancerAnnotations: map[string]string{
+				"load-balancer.hetzner.cloud/health-check-http-path": "/healthz",
+			}},
+			want: map[string]string{
+				"load-balancer.hetzner.cloud/health-check-http-path": "/healthz",
+			},
+		},
+		{
+			name:     "configured annotations override and extend provider annotations",
+			provider: providerAnnotations,
+			gateway: &config.GatewayConfig{LoadBalancerAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme":           "internal",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-path": "/healthz",
+			}},
+			want: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":             "external",
+				"service.beta.kubernetes.io/aws-load-balancer-scheme":           "internal",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-path": "/healthz",
+			},
+		},
+	}
+
+	content, err := templates.ReadFile("templates/manifests/networking/gateway.yaml")
+	if err != nil {
+		t.Fatalf("failed to read gateway template: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{Domain: "test.example.com", Gateway: tt.gateway}
+			data := NewTemplateData(cfg, nil, cluster.InfraSettings{LoadBalancerAnnotations: tt.provider})
+
+			processed, err := processTemplate("manifests/networking/gateway.yaml", content, data)
+			if err != nil {
+				t.Fatalf("processTemplate() error: %v", err)
+			}
+
+			var gateway struct {
+				Spec struct {
+					Infrastructure struct {
+						Annotations map[string]string `yaml:"annotations"`
+					} `yaml:"infrastructure"`
+				} `yaml:"spec"`
+			}
+			if err := yaml.Unmarshal(processed, &gateway); err != nil {
+				t.Fatalf("rendered gateway is not valid YAML: %v\n%s", err, processed)
+			}
+			got := gateway.Spec.Infrastructure.Annotations
+			if len(got) != len(tt.want) {
+				t.Fatalf("infrastructure annotations = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("annotation %q = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+
+	// The provider's map must not be modified by the merge.
+	if providerAnnotations["service.beta.kubernetes.io/aws-load-balancer-scheme"] != "internet-facing" {
+		t.Error("provider LoadBalancerAnnotations were mutated")
+	}
+}
+
+func TestGatewayAnnotations_Internal(t *testing.T) {
+	settings := cluster.InfraSettings{
+		LoadBalancerAnnotations: map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-type":   "external",
+			"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing",
+		},
+		InternalLoadBalancerAnnotations: map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		gateway    *config.GatewayConfig
+		wantScheme string
+	}{
+		{name: "public gateway", wantScheme: "internet-facing"},
+		{name: "internal gateway", gateway: &config.GatewayConfig{Internal: true}, wantScheme: "internal"},
+		{
+			name: "user annotation wins over internal annotation",
+			gateway: &config.GatewayConfig{Internal: true, LoadBalancerAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme": "custom",
+			}},
+			wantScheme: "custom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{Domain: "test.example.com", Gateway: tt.gateway}
+			data := NewTemplateData(cfg, nil, settings)
+
+			if got := data.LoadBalancerAnnotations["service.beta.kubernetes.io/aws-load-balancer-scheme"]; got != tt.wantScheme {
+				t.Errorf("scheme annotation = %q, want %q", got, tt.wantScheme)
+			}
+			if got := data.LoadBalancerAnnotations["service.beta.kubernetes.io/aws-load-balancer-type"]; got != "external" {
+				t.Errorf("type annotation = %q, want external", got)
+			}
+		})
+	}
+
+	if settings.LoadBalancerAnnotations["service.beta.kubernetes.io/aws-load-balancer-scheme"] != "internet-facing" {
+		t.Error("provider LoadBalancerAnnotations were mutated")
+	}
+}
+
+func TestKeycloakApplication_SyncPolicy(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name          string
+		syncPolicy    *config.SyncPolicyConfig
+		wantAutomated bool
+		wantPrune     bool
+		wantSelfHeal  bool
+	}{
+		{
+			name:          "default is automated with prune and self-heal",
+			wantAutomated: true, wantPrune: true, wantSelfHeal: true,
+		},
+		{
+			name:          "automated without prune",
+			syncPolicy:    &config.SyncPolicyConfig{Prune: boolPtr(false)},
+			wantAutomated: true, wantSelfHeal: true,
+		},
+		{
+			name:       "manual sync",
+			syncPolicy: &config.SyncPolicyConfig{Automated: boolPtr(false)},
+		},
+	}
+
+	content, err := templates.ReadFile("templates/apps/keycloak.yaml")
+	if err != nil {
+		t.Fatalf("failed to read keycloak template: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{Domain: "test.example.com", SyncPolicy: tt.syncPolicy}
+			data := NewTemplateData(cfg, nil, cluster.InfraSettings{})
+
+			processed, err := processTemplate("apps/keycloak.yaml", content, data)
+			if err != nil {
+				t.Fatalf("processTemplate() error: %v", err)
+			}
+
+			var app struct {
+				Spec struct {
+					SyncPolicy struct {
+						Automated *struct {
+							Prune    bool `yaml:"prune"`
+							SelfHeal bool `yaml:"selfHeal"`
+						} `yaml:"automated"`
+						SyncOptions []string `yaml:"syncOptions"`
+					} `yaml:"syncPolicy"`
+				} `yaml:"spec"`
+			}
+			if err := yaml.Unmarshal(processed, &app); err != nil {
+				t.Fatalf("rendered application is not valid YAML: %v\n%s", err, processed)
+			}
+			policy := app.Spec.SyncPolicy
+			if (policy.Automated != nil) != tt.wantAutomated {
+				t.Fatalf("syncPolicy.automated present = %v, want %v", policy.Automated != nil, tt.wantAutomated)
+			}
+			if policy.Automated != nil {
+				if policy.Automated.Prune != tt.wantPrune || policy.Automated.SelfHeal != tt.wantSelfHeal {
+					t.Errorf("prune/selfHeal = %v/%v, want %v/%v",
+						policy.Automated.Prune, policy.Automated.SelfHeal, tt.wantPrune, tt.wantSelfHeal)
+				}
+			}
+			if len(policy.SyncOptions) == 0 {
+				t.Error("syncOptions were dropped with the automated block")
+			}
+		})
+	}
+}
+
 func TestKeycloakTemplate_HealthProbes(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -845,29 +1048,31 @@ func (n *nopWriteCloser) Close() error {
 	return nil
 }
 
-func TestSyncWaveOrdering(t *testing.T) {
-	ctx := context.Background()
+// renderedSyncWave renders the named app template with default data and
+// returns its sync-wave annotation.
+func renderedSyncWave(t *testing.T, appName string) string {
+	t.Helper()
+	data := NewTemplateData(&config.NebariConfig{Domain: "test.example.com"}, nil, cluster.InfraSettings{})
+	app, err := renderEmbedded("apps/"+appName+".yaml", data)
+	if err != nil {
+		t.Fatalf("render %s: %v", appName, err)
+	}
+	return app.GetAnnotations()["argocd.argoproj.io/sync-wave"]
+}
 
-	// Read cert-manager and envoy-gateway templates
+func TestSyncWaveOrdering(t *testing.T) {
 	tests := []struct {
 		appName      string
 		expectedWave string
 	}{
-		{"envoy-gateway", `sync-wave: "1"`},
-		{"cert-manager", `sync-wave: "2"`},
+		{"envoy-gateway", "1"},
+		{"cert-manager", "2"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.appName, func(t *testing.T) {
-			var buf bytes.Buffer
-			err := WriteApplication(ctx, &buf, tt.appName)
-			if err != nil {
-				t.Fatalf("WriteApplication(%s) error: %v", tt.appName, err)
-			}
-
-			content := buf.String()
-			if !strings.Contains(content, tt.expectedWave) {
-				t.Errorf("%s should have %s, got:\n%s", tt.appName, tt.expectedWave, content)
+			if got := renderedSyncWave(t, tt.appName); got != tt.expectedWave {
+				t.Errorf("%s sync-wave = %q, want %q", tt.appName, got, tt.expectedWave)
 			}
 		})
 	}
@@ -979,35 +1184,15 @@ func TestWriteAllToGit_LonghornSecurityPolicy(t *testing.T) {
 }
 
 func TestEnvoyGatewayBeforeCertManager(t *testing.T) {
-	ctx := context.Background()
-
 	// Extract sync wave number as int for robust comparison
 	// (lexicographic comparison would fail for multi-digit numbers: "9" > "10")
 	getSyncWave := func(appName string) int {
-		var buf bytes.Buffer
-		if err := WriteApplication(ctx, &buf, appName); err != nil {
-			t.Fatalf("WriteApplication(%s) error: %v", appName, err)
-		}
-		content := buf.String()
-		for _, line := range strings.Split(content, "\n") {
-			if strings.Contains(line, "sync-wave") {
-				// Extract number from line like: argocd.argoproj.io/sync-wave: "1"
-				line = strings.TrimSpace(line)
-				// Find the quoted number
-				start := strings.Index(line, `"`)
-				end := strings.LastIndex(line, `"`)
-				if start != -1 && end > start {
-					numStr := line[start+1 : end]
-					num, err := strconv.Atoi(numStr)
-					if err != nil {
-						t.Fatalf("%s has invalid sync-wave value %q: %v", appName, numStr, err)
-					}
-					return num
-				}
-			}
+		numStr := renderedSyncWave(t, appName)
+		num, err := strconv.Atoi(numStr)
+		if err != nil {
+			t.Fatalf("%s has invalid sync-wave value %q: %v", appName, numStr, err)
 		}
-		t.Fatalf("%s has no sync-wave annotation", appName)
-		return 0
+		return num
 	}
 
 	envoyWaveNum := getSyncWave("envoy-gateway")
@@ -1177,3 +1362,111 @@ func TestWriteApplication_OtelCollector_OverridesExtensionPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteAllToGit_ExternalKeycloakDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.NebariConfig{
+		Domain: "test.example.com",
+		Keycloak: &config.KeycloakConfig{
+			Database: &config.KeycloakDatabaseConfig{
+				Mode:        config.KeycloakDatabaseExternal,
+				Host:        "keycloak.abc123.us-west-2.rds.amazonaws.com",
+				Port:        6432,
+				Database:    "kc",
+				Username:    "kc_user",
+				PasswordEnv: "KEYCLOAK_DB_PASSWORD",
+			},
+		},
+	}
+	mock := &mockGitClient{workDir: tmpDir}
+	if err := WriteAllToGit(context.Background(), mock, cfg, nil, cluster.InfraSettings{StorageClass: "standard"}, ""); err != nil {
+		t.Fatalf("WriteAllToGit() error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "apps", "postgresql.yaml")); !os.IsNotExist(err) {
+		t.Errorf("apps/postgresql.yaml was written for an external database (stat error: %v)", err)
+	}
+
+	kc, err := os.ReadFile(filepath.Join(tmpDir, "apps", "keycloak.yaml"))
+	if err != nil {
+		t.Fatalf("read keycloak: %v", err)
+	}
+	for _, want := range []string{
+		`value: "keycloak.abc123.us-west-2.rds.amazonaws.com"`,
+		`value: "6432"`,
+		`value: "kc"`,
+		`value: "kc_user"`,
+	} {
+		if !strings.Contains(string(kc), want) {
+			t.Errorf("keycloak.yaml does not contain %s:\n%s", want, kc)
+		}
+	}
+}
+
+func TestWriteAllToGit_Monitoring(t *testing.T) {
+	ctx := context.Background()
+	monitoringFiles := []string{
+		filepath.Join("apps", "monitoring.yaml"),
+		filepath.Join("manifests", "networking", "routes", "grafana-httproute.yaml"),
+	}
+
+	t.Run("enabled writes the stack, route and remote write", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		cfg := &config.NebariConfig{Domain: "test.example.com", Monitoring: &config.MonitoringConfig{Enabled: true}}
+		mock := &mockGitClient{workDir: tmpDir}
+		if err := WriteAllToGit(ctx, mock, cfg, nil, cluster.InfraSettings{StorageClass: "gp2"}, ""); err != nil {
+			t.Fatalf("WriteAllToGit() error: %v", err)
+		}
+
+		for _, f := range monitoringFiles {
+			if _, err := os.Stat(filepath.Join(tmpDir, f)); err != nil {
+				t.Errorf("%s not written: %v", f, err)
+			}
+		}
+
+		grafana := writtenHelmValues(t, tmpDir, "monitoring")["grafana"].(map[string]any)
+		admin := grafana["admin"].(map[string]any)
+		if admin["existingSecret"] != GrafanaAdminSecretName {
+			t.Errorf("grafana admin.existingSecret = %v, want %s", admin["existingSecret"], GrafanaAdminSecretName)
+		}
+
+		cert, err := os.ReadFile(filepath.Join(tmpDir, "manifests", "security", "certificates", "gateway-certificate.yaml")) //nolint:gosec // path is t.TempDir() + constant
+		if err != nil {
+			t.Fatalf("failed to read gateway-certificate: %v", err)
+		}
+		if !strings.Contains(string(cert), "grafana.test.example.com") {
+			t.Errorf("expected grafana.test.example.com in dnsNames, got:\n%s", cert)
+		}
+
+		collector := writtenHelmValues(t, tmpDir, "opentelemetry-collector")
+		exporters := collector["config"].(map[string]any)["service"].(map[string]any)["pipelines"].(map[string]any)["metrics"].(map[string]any)["exporters"].([]any)
+		if !slices.Contains(exporters, any("otlphttp/prometheus")) {
+			t.Errorf("metrics exporters = %v, want otlphttp/prometheus", exporters)
+		}
+	})
+
+	t.Run("disabled removes previously written files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		mock := &mockGitClient{workDir: tmpDir}
+		enabled := &config.NebariConfig{Domain: "test.example.com", Monitoring: &config.MonitoringConfig{Enabled: true}}
+		if err := WriteAllToGit(ctx, mock, enabled, nil, cluster.InfraSettings{}, ""); err != nil {
+			t.Fatalf("WriteAllToGit() error: %v", err)
+		}
+		if err := WriteAllToGit(ctx, mock, &config.NebariConfig{Domain: "test.example.com"}, nil, cluster.InfraSettings{}, ""); err != nil {
+			t.Fatalf("WriteAllToGit() error: %v", err)
+		}
+
+		for _, f := range monitoringFiles {
+			if _, err := os.Stat(filepath.Join(tmpDir, f)); !os.IsNotExist(err) {
+				t.Errorf("%s still present after disabling monitoring (stat error: %v)", f, err)
+			}
+		}
+		collector, err := os.ReadFile(filepath.Join(tmpDir, "apps", "opentelemetry-collector.yaml")) //nolint:gosec // path is t.TempDir() + constant
+		if err != nil {
+			t.Fatalf("failed to read opentelemetry-collector: %v", err)
+		}
+		if strings.Contains(string(collector), "otlphttp/prometheus") {
+			t.Error("collector exports to Prometheus with monitoring disabled")
+		}
+	})
+}
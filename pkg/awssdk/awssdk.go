@@ -0,0 +1,44 @@
+// Package awssdk builds the AWS SDK configuration shared by every AWS client
+// NIC creates, whether for the cluster provider or for remote state, so all
+// their calls go through the same API timing middleware.
+package awssdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/apitiming"
+)
+
+// LoadConfig loads the default AWS SDK configuration for region with the
+// API timing middleware installed, so every client built from it reports
+// per-operation latency and errors. All AWS clients should be created from it.
+func LoadConfig(ctx context.Context, region string) (aws.Config, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return aws.Config{}, err
+	}
+	cfg.APIOptions = append(cfg.APIOptions, addAPITimingMiddleware)
+	return cfg, nil
+}
+
+// apiTimingMiddlewareID identifies the timing middleware in the SDK stack.
+const apiTimingMiddlewareID = "NICAPITiming"
+
+// addAPITimingMiddleware adds the timing middleware at the end of the
+// Initialize step: after the service metadata is registered, and outside the
+// retry loop so the recorded duration includes throttling back-off.
+func addAPITimingMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(apiTimingMiddlewareID,
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			start := time.Now()
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			apitiming.Record(ctx, awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), time.Since(start), err)
+			return out, metadata, err
+		}), middleware.After)
+}
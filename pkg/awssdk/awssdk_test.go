@@ -0,0 +1,65 @@
+package awssdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/apitiming"
+)
+
+func TestAPITimingMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantErrors int
+	}{
+		{name: "slow success"},
+		{name: "slow throttled failure", err: errors.New("ThrottlingException"), wantErrors: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const delay = 50 * time.Millisecond
+
+			// Mirror the order the SDK builds: service metadata first, then
+			// the timing middleware, wrapping a deliberately slow operation.
+			stack := middleware.NewStack("DescribeVpcs", func() interface{} { return nil })
+			if err := stack.Initialize.Add(&awsmiddleware.RegisterServiceMetadata{ServiceID: "EC2", OperationName: "DescribeVpcs"}, middleware.Before); err != nil {
+				t.Fatal(err)
+			}
+			if err := addAPITimingMiddleware(stack); err != nil {
+				t.Fatal(err)
+			}
+			slow := middleware.HandlerFunc(func(ctx context.Context, _ interface{}) (interface{}, middleware.Metadata, error) {
+				time.Sleep(delay)
+				return nil, middleware.Metadata{}, tt.err
+			})
+
+			recorder := apitiming.NewRecorder()
+			ctx := apitiming.WithRecorder(context.Background(), recorder)
+			if _, _, err := middleware.DecorateHandler(slow, stack).Handle(ctx, struct{}{}); !errors.Is(err, tt.err) {
+				t.Fatalf("Handle() error = %v, want %v", err, tt.err)
+			}
+
+			summary := recorder.Slowest(5)
+			if len(summary) != 1 {
+				t.Fatalf("summary has %d operations, want 1: %+v", len(summary), summary)
+			}
+			got := summary[0]
+			if got.Name() != "EC2.DescribeVpcs" {
+				t.Errorf("operation = %q, want EC2.DescribeVpcs", got.Name())
+			}
+			if got.Calls != 1 || got.Errors != tt.wantErrors {
+				t.Errorf("calls/errors = %d/%d, want 1/%d", got.Calls, got.Errors, tt.wantErrors)
+			}
+			if got.Total < delay || got.Max < delay {
+				t.Errorf("recorded total/max = %s/%s, want at least %s", got.Total, got.Max, delay)
+			}
+		})
+	}
+}
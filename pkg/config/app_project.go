@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AppProjectConfig overrides the scopes of the Argo CD AppProjects NIC
+// installs, e.g. for air-gapped clusters that pull charts from a mirror.
+// Every field is optional; unset fields keep NIC's defaults.
+type AppProjectConfig struct {
+	// SourceRepos replaces the source repositories of both the foundational
+	// and the nebari-apps projects. Entries may use Argo CD glob patterns
+	// (e.g. "https://mirror.example.com/*"). The list must still permit every
+	// repository the foundational Applications use; deploy fails otherwise.
+	SourceRepos []string `yaml:"source_repos,omitempty"`
+
+	// Destinations replaces the nebari-apps destinations (by default any
+	// namespace of the local cluster). The foundational project keeps the
+	// namespaces derived from NIC's own Applications.
+	Destinations []AppProjectDestination `yaml:"destinations,omitempty"`
+
+	// ClusterResourceWhitelist replaces the cluster-scoped resources
+	// nebari-apps Applications may manage (by default all of them).
+	ClusterResourceWhitelist []AppProjectResource `yaml:"cluster_resource_whitelist,omitempty"`
+}
+
+// AppProjectDestination is a cluster and namespace Applications may deploy
+// to. Namespace may be a glob pattern; Server defaults to the local cluster.
+type AppProjectDestination struct {
+	Namespace string `yaml:"namespace"`
+	Server    string `yaml:"server,omitempty"`
+}
+
+// AppProjectResource is an API group and kind, either of which may be "*".
+// Group is empty for the core API group.
+type AppProjectResource struct {
+	Group string `yaml:"group"`
+	Kind  string `yaml:"kind"`
+}
+
+// Validate checks that every entry is filled in. Whether SourceRepos covers
+// the foundational Applications is checked when the projects are rendered.
+// A nil receiver is valid.
+func (a *AppProjectConfig) Validate() error {
+	if a == nil {
+		return nil
+	}
+	for i, repo := range a.SourceRepos {
+		if strings.TrimSpace(repo) == "" {
+			return fmt.Errorf("source_repos[%d] is empty", i)
+		}
+	}
+	for i, d := range a.Destinations {
+		if strings.TrimSpace(d.Namespace) == "" {
+			return fmt.Errorf("destinations[%d]: namespace is required", i)
+		}
+	}
+	for i, r := range a.ClusterResourceWhitelist {
+		if strings.TrimSpace(r.Kind) == "" {
+			return fmt.Errorf("cluster_resource_whitelist[%d]: kind is required", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAppProjectConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       *AppProjectConfig
+		errSubstr string // "" means no error expected
+	}{
+		{name: "nil", cfg: nil},
+		{
+			name: "valid",
+			cfg: &AppProjectConfig{
+				SourceRepos:              []string{"https://mirror.example.com/*"},
+				Destinations:             []AppProjectDestination{{Namespace: "team-*"}},
+				ClusterResourceWhitelist: []AppProjectResource{{Kind: "Namespace"}},
+			},
+		},
+		{name: "empty repo", cfg: &AppProjectConfig{SourceRepos: []string{"https://mirror.example.com", " "}}, errSubstr: "source_repos[1] is empty"},
+		{name: "destination without namespace", cfg: &AppProjectConfig{Destinations: []AppProjectDestination{{Server: "https://kubernetes.default.svc"}}}, errSubstr: "destinations[0]: namespace is required"},
+		{name: "resource without kind", cfg: &AppProjectConfig{ClusterResourceWhitelist: []AppProjectResource{{Group: "rbac.authorization.k8s.io"}}}, errSubstr: "cluster_resource_whitelist[0]: kind is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("Validate() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ComponentConfig declares an Argo CD Application installed with the
+// foundational services, or adds dependencies to a foundational one. The
+// installer orders all of them by DependsOn.
+type ComponentConfig struct {
+	// Name is the Application name. Naming a foundational Application
+	// (e.g. keycloak) only adds DependsOn to it; the source fields must then
+	// be empty.
+	Name string `yaml:"name"`
+
+	// RepoURL is the git repository holding the component's manifests.
+	// Defaults to git_repository.url; another repository must be allowed by
+	// app_project.source_repos.
+	RepoURL string `yaml:"repo_url,omitempty"`
+
+	// Path is the manifest directory within the repository.
+	Path string `yaml:"path,omitempty"`
+
+	// TargetRevision is the branch, tag or commit to sync. Defaults to the
+	// git_repository branch for the GitOps repository and HEAD otherwise.
+	TargetRevision string `yaml:"target_revision,omitempty"`
+
+	// Namespace is the Application's destination namespace, created when
+	// missing.
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// DependsOn names the components and foundational Applications that
+	// must sync before this one.
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// HasSource reports whether c declares its own manifests rather than only
+// adding dependencies to a foundational Application.
+func (c ComponentConfig) HasSource() bool {
+	return c.RepoURL != "" || c.Path != "" || c.TargetRevision != "" || c.Namespace != ""
+}
+
+// validateComponents checks each component on its own: names follow the
+// Kubernetes DNS label rule and are unique, and dependencies are named
+// once and not on the component itself. Whether dependencies exist and are
+// acyclic depends on the foundational Applications, and is checked by the
+// argocd package.
+func validateComponents(components []ComponentConfig) error {
+	seen := make(map[string]bool, len(components))
+	for i, c := range components {
+		if !dnsLabelPattern.MatchString(c.Name) {
+			return fmt.Errorf("components[%d]: invalid name %q (must be a lowercase DNS label)", i, c.Name)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("components[%d]: name %q is already used", i, c.Name)
+		}
+		seen[c.Name] = true
+		for j, dep := range c.DependsOn {
+			if dep == c.Name {
+				return fmt.Errorf("component %s depends on itself", c.Name)
+			}
+			if slices.Index(c.DependsOn, dep) < j {
+				return fmt.Errorf("component %s lists dependency %q twice", c.Name, dep)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateComponents(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []ComponentConfig
+		errSubstr  string // "" means no error expected
+	}{
+		{name: "none"},
+		{
+			name: "component and built-in dependency",
+			components: []ComponentConfig{
+				{Name: "vault", Path: "vault", Namespace: "vault", DependsOn: []string{"cert-manager"}},
+				{Name: "keycloak", DependsOn: []string{"vault"}},
+			},
+		},
+		{name: "invalid name", components: []ComponentConfig{{Name: "Vault"}}, errSubstr: `invalid name "Vault"`},
+		{name: "duplicate name", components: []ComponentConfig{{Name: "a"}, {Name: "a"}}, errSubstr: `name "a" is already used`},
+		{name: "self dependency", components: []ComponentConfig{{Name: "a", DependsOn: []string{"a"}}}, errSubstr: "depends on itself"},
+		{name: "repeated dependency", components: []ComponentConfig{{Name: "a", DependsOn: []string{"b", "b"}}}, errSubstr: `dependency "b" twice`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateComponents(tt.components)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("validateComponents() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("validateComponents() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
+	"strings"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
 )
@@ -42,6 +44,213 @@ type NebariConfig struct {
 
 	// Backups configures off-cluster backup scheduling (Longhorn). Optional.
 	Backups *BackupsConfig `yaml:"backups,omitempty"`
+
+	// Environment names the deployment stage (e.g. dev, staging, prod) so
+	// similar configs do not collide. When set it is appended to the names of
+	// provisioned resources (see ResourceName), tagged on them, and reported
+	// in status updates. Destroying a protected environment (see
+	// IsProtectedEnvironment) requires naming it explicitly. Optional; changing
+	// it on an existing deployment renames its resources.
+	Environment string `yaml:"environment,omitempty"`
+
+	// Kustomizations lists kustomize directories (bases or overlays) that are
+	// rendered and applied to the cluster during the foundational services
+	// step, after NIC's own resources and before the root App-of-Apps.
+	// Optional.
+	Kustomizations []KustomizationConfig `yaml:"kustomizations,omitempty"`
+
+	// Gateway tunes the Envoy Gateway that fronts all Nebari traffic.
+	// Optional.
+	Gateway *GatewayConfig `yaml:"gateway,omitempty"`
+
+	// InfraOnly limits deploy to the cloud cluster, node groups and
+	// networking: GitOps bootstrap, Argo CD, foundational services and DNS
+	// are skipped, and the platform-layer blocks (certificate, gateway) are
+	// not validated. For users who run their own platform layer. Optional;
+	// `nic deploy --infra-only` has the same effect.
+	InfraOnly bool `yaml:"infra_only,omitempty"`
+
+	// SyncPolicy overrides the Argo CD sync policy of the foundational
+	// Applications, e.g. manual sync in production. Optional; by default
+	// they sync automatically with prune and self-heal.
+	SyncPolicy *SyncPolicyConfig `yaml:"sync_policy,omitempty"`
+
+	// AppProject overrides the source repositories, destinations and
+	// cluster resources the Argo CD AppProjects allow. Optional.
+	AppProject *AppProjectConfig `yaml:"app_project,omitempty"`
+
+	// Components declares extra Argo CD Applications and the dependencies
+	// that order them among the foundational Applications. Optional; by
+	// default only the foundational Applications are installed, in their
+	// built-in order.
+	Components []ComponentConfig `yaml:"components,omitempty"`
+
+	// Images overrides the container image of foundational components,
+	// keyed by Application name (e.g. keycloak). Optional; by default the
+	// images of the pinned chart versions are used.
+	Images map[string]ImageOverride `yaml:"images,omitempty"`
+
+	// Keycloak configures the Keycloak NIC installs, e.g. to keep its data
+	// in an external PostgreSQL database. Optional.
+	Keycloak *KeycloakConfig `yaml:"keycloak,omitempty"`
+
+	// Monitoring installs Prometheus and Grafana as a foundational service.
+	// Optional; off by default.
+	Monitoring *MonitoringConfig `yaml:"monitoring,omitempty"`
+
+	// Overrides are Helm values deep-merged into the values of foundational
+	// Applications, keyed by Application name (e.g. postgresql). Maps are
+	// merged key by key, other values replace the default and null removes
+	// it. Optional.
+	Overrides map[string]map[string]any `yaml:"overrides,omitempty"`
+
+	// State selects where NIC keeps its deploy checkpoint and deploy lock.
+	// Optional; defaults to the local backend.
+	State *StateConfig `yaml:"state,omitempty"`
+}
+
+// SyncPolicyConfig sets the automated sync behaviour of the foundational
+// Argo CD Applications. Unset fields keep the default of true.
+type SyncPolicyConfig struct {
+	// Automated syncs Applications whenever the git repository changes.
+	// When false, changes wait for a manual sync.
+	Automated *bool `yaml:"automated,omitempty"`
+
+	// Prune deletes resources that were removed from the repository.
+	Prune *bool `yaml:"prune,omitempty"`
+
+	// SelfHeal reverts changes made to managed resources in the cluster.
+	SelfHeal *bool `yaml:"self_heal,omitempty"`
+}
+
+// IsAutomated reports whether Applications sync automatically. A nil
+// receiver returns the default.
+func (s *SyncPolicyConfig) IsAutomated() bool {
+	return s == nil || s.Automated == nil || *s.Automated
+}
+
+// PruneEnabled reports whether automated syncs prune removed resources.
+func (s *SyncPolicyConfig) PruneEnabled() bool {
+	return s.IsAutomated() && (s == nil || s.Prune == nil || *s.Prune)
+}
+
+// SelfHealEnabled reports whether automated syncs revert drift.
+func (s *SyncPolicyConfig) SelfHealEnabled() bool {
+	return s.IsAutomated() && (s == nil || s.SelfHeal == nil || *s.SelfHeal)
+}
+
+// Validate rejects prune or self-heal without automated sync, which Argo CD
+// would silently ignore. A nil receiver is valid.
+func (s *SyncPolicyConfig) Validate() error {
+	if s == nil || s.IsAutomated() {
+		return nil
+	}
+	if (s.Prune != nil && *s.Prune) || (s.SelfHeal != nil && *s.SelfHeal) {
+		return fmt.Errorf("prune and self_heal require automated: true")
+	}
+	return nil
+}
+
+// GatewayConfig holds provider-neutral settings for the Envoy Gateway.
+type GatewayConfig struct {
+	// LoadBalancerAnnotations are added to the Gateway's LoadBalancer Service,
+	// on top of any the cluster provider sets; on a key collision the value
+	// configured here wins. Use it to make the load balancer internal, set
+	// health check paths, and similar cloud-specific tuning, e.g.
+	// "service.beta.kubernetes.io/aws-load-balancer-scheme: internal".
+	LoadBalancerAnnotations map[string]string `yaml:"load_balancer_annotations,omitempty"`
+
+	// Internal provisions the Gateway behind an internal (private) load
+	// balancer only, for deployments reached over VPN or peering. The
+	// cluster provider supplies the cloud-specific annotation. Public DNS
+	// records are not provisioned for an internal gateway; see PrivateDNS.
+	Internal bool `yaml:"internal,omitempty"`
+
+	// PrivateDNS is the DNS provider that receives the gateway records when
+	// Internal is set, typically a private zone resolvable from the private
+	// network. Same shape as the top-level dns block. Optional.
+	PrivateDNS *DNSConfig `yaml:"private_dns,omitempty"`
+
+	// Additional are Gateways provisioned beside the default one, each with
+	// its own listeners, load balancer and certificate. Optional.
+	Additional []AdditionalGateway `yaml:"additional,omitempty"`
+}
+
+// Validate checks the gateway settings against the certificate config and
+// acmeDNS, the DNS provider Let's Encrypt challenges are solved through (see
+// NebariConfig.ACMEDNS). A nil receiver is valid.
+func (g *GatewayConfig) Validate(cert *CertificateConfig, acmeDNS *DNSConfig, dnsProviders []string) error {
+	if g == nil {
+		return nil
+	}
+	if g.PrivateDNS != nil {
+		if !g.Internal {
+			return fmt.Errorf("private_dns requires internal: true")
+		}
+		if err := g.PrivateDNS.Validate(dnsProviders); err != nil {
+			return fmt.Errorf("invalid private_dns: %w", err)
+		}
+	}
+	// Without a DNS provider Let's Encrypt issues through an HTTP-01
+	// challenge, which its servers must reach over the internet; an
+	// internal-only gateway is unreachable. DNS-01 works either way.
+	if g.Internal && cert != nil && cert.Type == CertificateTypeLetsEncrypt && acmeDNS == nil {
+		return fmt.Errorf("certificate type %q on an internal gateway needs dns or private_dns for the DNS-01 challenge: the HTTP-01 challenge must reach the gateway from the internet (or use %q or %q)",
+			CertificateTypeLetsEncrypt, CertificateTypeSelfSigned, CertificateTypeExisting)
+	}
+	return validateAdditionalGateways(g.Additional)
+}
+
+// IsInternalGateway reports whether the Gateway is provisioned behind an
+// internal load balancer only.
+func (c *NebariConfig) IsInternalGateway() bool {
+	return c.Gateway != nil && c.Gateway.Internal
+}
+
+// RecordsDNS returns the DNS provider config the gateway records are
+// provisioned into: Gateway.PrivateDNS for an internal gateway, DNS
+// otherwise. Returns nil when no records should be managed.
+func (c *NebariConfig) RecordsDNS() *DNSConfig {
+	if c.IsInternalGateway() {
+		return c.Gateway.PrivateDNS
+	}
+	return c.DNS
+}
+
+// ACMEDNS returns the DNS provider Let's Encrypt DNS-01 challenges are
+// solved through: private_dns for an internal gateway that has one, the
+// top-level dns block otherwise. Returns nil when neither is configured, in
+// which case certificates are issued over HTTP-01.
+func (c *NebariConfig) ACMEDNS() *DNSConfig {
+	if c.IsInternalGateway() && c.Gateway.PrivateDNS != nil {
+		return c.Gateway.PrivateDNS
+	}
+	return c.DNS
+}
+
+// KustomizationConfig references a directory containing a kustomization.yaml.
+type KustomizationConfig struct {
+	// Path is the kustomization directory, relative to the working directory
+	// nic runs in.
+	Path string `yaml:"path"`
+}
+
+// ResourceName returns the base name for provisioned resources: ProjectName,
+// suffixed with "-" and Environment when an environment is set.
+func (c *NebariConfig) ResourceName() string {
+	if c.Environment == "" {
+		return c.ProjectName
+	}
+	return c.ProjectName + "-" + c.Environment
+}
+
+// protectedEnvironments are the Environment values whose destruction must be
+// confirmed by naming the environment.
+var protectedEnvironments = []string{"prod", "production"}
+
+// IsProtectedEnvironment reports whether c targets a production environment.
+func (c *NebariConfig) IsProtectedEnvironment() bool {
+	return slices.Contains(protectedEnvironments, c.Environment)
 }
 
 // DNSConfig holds typed DNS provider configuration.
@@ -123,7 +332,7 @@ func (c *ClusterConfig) Validate(validProviders []string) error {
 		return fmt.Errorf("cluster block is present but no provider is configured")
 	}
 	if len(c.Providers) > 1 {
-		return fmt.Errorf("only one cluster provider can be configured at a time")
+		return fmt.Errorf("only one cluster provider can be configured at a time, found %s", strings.Join(slices.Sorted(maps.Keys(c.Providers)), ", "))
 	}
 	name := c.ProviderName()
 	if len(validProviders) > 0 && !slices.Contains(validProviders, name) {
@@ -327,6 +536,14 @@ type ACMEConfig struct {
 // Used to validate ProjectName before it is used as a filesystem path component.
 var safeProjectName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
 
+// safeEnvironment matches lowercase DNS-label-style names. Environment ends up
+// in resource names and tags, so it is kept short and conservative.
+var safeEnvironment = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// maxEnvironmentLength bounds Environment so the suffixed resource names stay
+// within cloud naming limits.
+const maxEnvironmentLength = 16
+
 // Validate checks that the configuration is valid.
 // The opts parameter provides the set of valid provider names, injected by the caller.
 // Returns an error describing the first validation failure encountered.
@@ -337,6 +554,11 @@ func (c *NebariConfig) Validate(opts ValidateOptions) error {
 	if !safeProjectName.MatchString(c.ProjectName) {
 		return fmt.Errorf("project_name %q contains invalid characters (must start with alphanumeric and contain only alphanumeric, hyphens, or underscores)", c.ProjectName)
 	}
+	if c.Environment != "" {
+		if len(c.Environment) > maxEnvironmentLength || !safeEnvironment.MatchString(c.Environment) {
+			return fmt.Errorf("environment %q is invalid (must be at most %d lowercase alphanumeric characters or hyphens, starting and ending with an alphanumeric)", c.Environment, maxEnvironmentLength)
+		}
+	}
 
 	if c.Cluster == nil {
 		return fmt.Errorf("cluster field is required")
@@ -363,13 +585,45 @@ func (c *NebariConfig) Validate(opts ValidateOptions) error {
 		}
 	}
 
-	if err := c.Certificate.Validate(); err != nil {
-		return fmt.Errorf("invalid certificate: %w", err)
+	// The certificate, gateway, sync policy and app project only configure the platform layer, which
+	// an infra-only deploy never installs.
+	if !c.InfraOnly {
+		if err := c.Certificate.Validate(); err != nil {
+			return fmt.Errorf("invalid certificate: %w", err)
+		}
+		if err := c.Gateway.Validate(c.Certificate, c.ACMEDNS(), opts.DNSProviders); err != nil {
+			return fmt.Errorf("invalid gateway: %w", err)
+		}
+		if err := c.SyncPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid sync_policy: %w", err)
+		}
+		if err := c.AppProject.Validate(); err != nil {
+			return fmt.Errorf("invalid app_project: %w", err)
+		}
+		if err := validateComponents(c.Components); err != nil {
+			return fmt.Errorf("invalid components: %w", err)
+		}
+		if err := validateImages(c.Images); err != nil {
+			return fmt.Errorf("invalid images: %w", err)
+		}
+		if err := c.Keycloak.Validate(); err != nil {
+			return fmt.Errorf("invalid keycloak: %w", err)
+		}
 	}
 
 	if err := c.Backups.Validate(c.Cluster.ProviderName()); err != nil {
 		return fmt.Errorf("invalid backups: %w", err)
 	}
 
+	if err := c.State.Validate(); err != nil {
+		return fmt.Errorf("invalid state: %w", err)
+	}
+
+	for i, k := range c.Kustomizations {
+		if strings.TrimSpace(k.Path) == "" {
+			return fmt.Errorf("invalid kustomizations[%d]: path is required", i)
+		}
+	}
+
 	return nil
 }
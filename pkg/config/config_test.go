@@ -236,6 +236,44 @@ cluster:
 			wantErr:     true,
 			errContains: "failed to parse YAML",
 		},
+		{
+			name: "provider aws but gcp block populated",
+			yaml: `
+project_name: test-project
+provider: aws
+google_cloud_platform:
+  project: my-project
+  region: us-central1
+cluster:
+  aws:
+    region: us-west-2
+`,
+			wantErr:     true,
+			errContains: `top-level "google_cloud_platform" block is not supported: configure the cluster provider under cluster.gcp`,
+		},
+		{
+			name: "provider selector disagrees with cluster block",
+			yaml: `
+project_name: test-project
+provider: gcp
+cluster:
+  aws:
+    region: us-west-2
+`,
+			wantErr:     true,
+			errContains: `top-level provider "gcp" does not match the configured cluster provider "aws"`,
+		},
+		{
+			name: "provider selector without cluster block",
+			yaml: `
+project_name: test-project
+provider: aws
+amazon_web_services:
+  region: us-west-2
+`,
+			wantErr:     true,
+			errContains: `top-level "amazon_web_services" block is not supported`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -371,6 +409,45 @@ func TestNebariConfigValidate(t *testing.T) {
 			wantErr:     true,
 			errContains: "project_name",
 		},
+		{
+			name: "valid environment",
+			config: NebariConfig{
+				ProjectName: "test-project",
+				Environment: "staging-2",
+				Cluster: &ClusterConfig{
+					Providers: map[string]any{"aws": map[string]any{}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid environment with uppercase",
+			config: NebariConfig{
+				ProjectName: "test-project",
+				Environment: "Prod",
+			},
+			wantErr:     true,
+			errContains: "environment",
+		},
+		{
+			name: "invalid environment too long",
+			config: NebariConfig{
+				ProjectName: "test-project",
+				Environment: "a-very-long-environment",
+			},
+			wantErr:     true,
+			errContains: "environment",
+		},
+		{
+			name: "kustomization without path",
+			config: NebariConfig{
+				ProjectName:    "test-project",
+				Cluster:        &ClusterConfig{Providers: map[string]any{"aws": map[string]any{}}},
+				Kustomizations: []KustomizationConfig{{Path: "addons/base"}, {Path: " "}},
+			},
+			wantErr:     true,
+			errContains: "kustomizations[1]: path is required",
+		},
 		{
 			name: "missing cluster",
 			config: NebariConfig{
@@ -473,6 +550,27 @@ func TestNebariConfigValidate(t *testing.T) {
 			wantErr:     true,
 			errContains: "invalid backups",
 		},
+		{
+			name: "invalid certificate fails a full deploy",
+			config: NebariConfig{
+				ProjectName: "test-project",
+				Cluster:     &ClusterConfig{Providers: map[string]any{"aws": map[string]any{}}},
+				Certificate: &CertificateConfig{Type: "bogus"},
+			},
+			wantErr:     true,
+			errContains: "invalid certificate",
+		},
+		{
+			name: "infra_only skips platform-layer validation",
+			config: NebariConfig{
+				ProjectName: "test-project",
+				InfraOnly:   true,
+				Cluster:     &ClusterConfig{Providers: map[string]any{"aws": map[string]any{}}},
+				Certificate: &CertificateConfig{Type: "bogus"},
+				Gateway:     &GatewayConfig{PrivateDNS: &DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{}}}},
+			},
+			wantErr: false,
+		},
 	}
 
 	opts := ValidateOptions{
@@ -502,6 +600,183 @@ func TestNebariConfigValidate(t *testing.T) {
 	}
 }
 
+func TestNebariConfigEnvironment(t *testing.T) {
+	tests := []struct {
+		environment   string
+		wantName      string
+		wantProtected bool
+	}{
+		{environment: "", wantName: "demo"},
+		{environment: "dev", wantName: "demo-dev"},
+		{environment: "prod", wantName: "demo-prod", wantProtected: true},
+		{environment: "production", wantName: "demo-production", wantProtected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			cfg := NebariConfig{ProjectName: "demo", Environment: tt.environment}
+			if got := cfg.ResourceName(); got != tt.wantName {
+				t.Errorf("ResourceName() = %q, want %q", got, tt.wantName)
+			}
+			if got := cfg.IsProtectedEnvironment(); got != tt.wantProtected {
+				t.Errorf("IsProtectedEnvironment() = %v, want %v", got, tt.wantProtected)
+			}
+		})
+	}
+}
+
+func TestACMEDNS(t *testing.T) {
+	public := &DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{"zone_name": "example.com"}}}
+	private := &DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{"zone_name": "internal.example.com"}}}
+
+	tests := []struct {
+		name    string
+		dns     *DNSConfig
+		gateway *GatewayConfig
+		want    *DNSConfig
+	}{
+		{name: "no dns"},
+		{name: "public gateway uses dns", dns: public, want: public},
+		{name: "internal gateway prefers private_dns", dns: public, gateway: &GatewayConfig{Internal: true, PrivateDNS: private}, want: private},
+		{name: "internal gateway falls back to dns", dns: public, gateway: &GatewayConfig{Internal: true}, want: public},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NebariConfig{DNS: tt.dns, Gateway: tt.gateway}
+			if got := cfg.ACMEDNS(); got != tt.want {
+				t.Errorf("ACMEDNS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGatewayConfigValidate(t *testing.T) {
+	cloudflare := &DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{"zone_name": "internal.example.com"}}}
+
+	tests := []struct {
+		name        string
+		gateway     *GatewayConfig
+		cert        *CertificateConfig
+		acmeDNS     *DNSConfig
+		errContains string
+	}{
+		{name: "nil gateway"},
+		{name: "internal with selfsigned certificate", gateway: &GatewayConfig{Internal: true}, cert: &CertificateConfig{Type: CertificateTypeSelfSigned}},
+		{name: "internal without certificate config", gateway: &GatewayConfig{Internal: true}},
+		{name: "public gateway with letsencrypt", gateway: &GatewayConfig{}, cert: &CertificateConfig{Type: CertificateTypeLetsEncrypt}},
+		{
+			name:        "internal with letsencrypt over HTTP-01 is incompatible",
+			gateway:     &GatewayConfig{Internal: true},
+			cert:        &CertificateConfig{Type: CertificateTypeLetsEncrypt},
+			errContains: "needs dns or private_dns for the DNS-01 challenge",
+		},
+		{
+			name:    "internal with letsencrypt over DNS-01",
+			gateway: &GatewayConfig{Internal: true, PrivateDNS: cloudflare},
+			cert:    &CertificateConfig{Type: CertificateTypeLetsEncrypt},
+			acmeDNS: cloudflare,
+		},
+		{name: "internal with private dns", gateway: &GatewayConfig{Internal: true, PrivateDNS: cloudflare}},
+		{
+			name:        "private dns on public gateway",
+			gateway:     &GatewayConfig{PrivateDNS: cloudflare},
+			errContains: "private_dns requires internal: true",
+		},
+		{
+			name:        "unknown private dns provider",
+			gateway:     &GatewayConfig{Internal: true, PrivateDNS: &DNSConfig{Providers: map[string]any{"route53": map[string]any{}}}},
+			errContains: "invalid private_dns",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.gateway.Validate(tt.cert, tt.acmeDNS, []string{"cloudflare"})
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestSyncPolicyConfig(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name          string
+		policy        *SyncPolicyConfig
+		wantAutomated bool
+		wantPrune     bool
+		wantSelfHeal  bool
+		errContains   string
+	}{
+		{name: "nil keeps defaults", wantAutomated: true, wantPrune: true, wantSelfHeal: true},
+		{name: "empty keeps defaults", policy: &SyncPolicyConfig{}, wantAutomated: true, wantPrune: true, wantSelfHeal: true},
+		{name: "disable prune", policy: &SyncPolicyConfig{Prune: boolPtr(false)}, wantAutomated: true, wantSelfHeal: true},
+		{name: "manual sync", policy: &SyncPolicyConfig{Automated: boolPtr(false)}},
+		{name: "manual sync with prune off", policy: &SyncPolicyConfig{Automated: boolPtr(false), Prune: boolPtr(false)}},
+		{
+			name:        "self heal without automated",
+			policy:      &SyncPolicyConfig{Automated: boolPtr(false), SelfHeal: boolPtr(true)},
+			errContains: "require automated: true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Validate() error = %v, want error containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() unexpected error: %v", err)
+			}
+			if got := tt.policy.IsAutomated(); got != tt.wantAutomated {
+				t.Errorf("IsAutomated() = %v, want %v", got, tt.wantAutomated)
+			}
+			if got := tt.policy.PruneEnabled(); got != tt.wantPrune {
+				t.Errorf("PruneEnabled() = %v, want %v", got, tt.wantPrune)
+			}
+			if got := tt.policy.SelfHealEnabled(); got != tt.wantSelfHeal {
+				t.Errorf("SelfHealEnabled() = %v, want %v", got, tt.wantSelfHeal)
+			}
+		})
+	}
+}
+
+func TestNebariConfigRecordsDNS(t *testing.T) {
+	public := &DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{"zone_name": "example.com"}}}
+	private := &DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{"zone_name": "internal.example.com"}}}
+
+	tests := []struct {
+		name    string
+		gateway *GatewayConfig
+		want    *DNSConfig
+	}{
+		{name: "public gateway uses dns", want: public},
+		{name: "internal gateway uses private_dns", gateway: &GatewayConfig{Internal: true, PrivateDNS: private}, want: private},
+		{name: "internal gateway without private_dns skips records", gateway: &GatewayConfig{Internal: true}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NebariConfig{DNS: public, Gateway: tt.gateway}
+			if got := cfg.RecordsDNS(); got != tt.want {
+				t.Errorf("RecordsDNS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDNSConfigValidate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -734,7 +1009,7 @@ func TestClusterConfigValidate(t *testing.T) {
 				},
 			},
 			wantErr:     true,
-			errContains: "only one cluster provider",
+			errContains: "only one cluster provider can be configured at a time, found aws, azure",
 		},
 		{
 			name: "invalid provider name",
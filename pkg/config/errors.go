@@ -0,0 +1,8 @@
+package config
+
+import "errors"
+
+// ErrInvalidConfig is wrapped by every error caused by a config file that
+// cannot be read as YAML or fails validation, so callers can tell
+// configuration mistakes apart from runtime failures with errors.Is.
+var ErrInvalidConfig = errors.New("configuration validation failed")
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// DefaultGatewayName is the Gateway every Nebari route attaches to unless an
+// additional gateway claims it.
+const DefaultGatewayName = "nebari-gateway"
+
+// GatewayRoutes are the foundational HTTPRoutes an additional gateway can
+// take over from the default gateway.
+var GatewayRoutes = []string{"argocd", "grafana", "keycloak", "longhorn"}
+
+// dnsLabelPattern is the Kubernetes DNS label rule, which Gateway,
+// listener and component names follow.
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Gateway listener protocols.
+const (
+	GatewayProtocolHTTP  = "HTTP"
+	GatewayProtocolHTTPS = "HTTPS"
+)
+
+// AdditionalGateway is a Gateway provisioned beside the default
+// nebari-gateway, with its own load balancer, e.g. an internal gateway for
+// admin UIs. DNS records are only managed for the default gateway.
+type AdditionalGateway struct {
+	// Name is the Gateway's name in envoy-gateway-system.
+	Name string `yaml:"name"`
+
+	// Internal provisions this gateway behind an internal load balancer.
+	Internal bool `yaml:"internal,omitempty"`
+
+	// LoadBalancerAnnotations are added to this gateway's LoadBalancer
+	// Service, on top of the provider's.
+	LoadBalancerAnnotations map[string]string `yaml:"load_balancer_annotations,omitempty"`
+
+	// Listeners default to "http" on port 80 and "https" on 443.
+	Listeners []GatewayListener `yaml:"listeners,omitempty"`
+
+	// TLSSecretName is a TLS secret in envoy-gateway-system served by the
+	// HTTPS listeners. Defaults to the default gateway's certificate.
+	TLSSecretName string `yaml:"tls_secret_name,omitempty"`
+
+	// Routes are foundational HTTPRoutes (see GatewayRoutes) that attach to
+	// this gateway instead of the default one. They bind to its first HTTPS
+	// listener.
+	Routes []string `yaml:"routes,omitempty"`
+}
+
+// GatewayListener is a port a gateway accepts traffic on.
+type GatewayListener struct {
+	Name     string `yaml:"name"`
+	Protocol string `yaml:"protocol"`
+	Port     int    `yaml:"port"`
+	// Hostname restricts the listener to one hostname (may start with "*.").
+	// Empty accepts any.
+	Hostname string `yaml:"hostname,omitempty"`
+}
+
+// DefaultGatewayListeners mirror the default gateway's listeners.
+func DefaultGatewayListeners() []GatewayListener {
+	return []GatewayListener{
+		{Name: "http", Protocol: GatewayProtocolHTTP, Port: 80},
+		{Name: "https", Protocol: GatewayProtocolHTTPS, Port: 443},
+	}
+}
+
+// EffectiveListeners returns the configured listeners, or the defaults.
+func (g AdditionalGateway) EffectiveListeners() []GatewayListener {
+	if len(g.Listeners) == 0 {
+		return DefaultGatewayListeners()
+	}
+	return g.Listeners
+}
+
+// RouteListener returns the name of the listener the gateway's routes bind
+// to, or "" when it has no HTTPS listener.
+func (g AdditionalGateway) RouteListener() string {
+	for _, l := range g.EffectiveListeners() {
+		if l.Protocol == GatewayProtocolHTTPS {
+			return l.Name
+		}
+	}
+	return ""
+}
+
+// validateAdditionalGateways checks names, listeners and route claims.
+func validateAdditionalGateways(gateways []AdditionalGateway) error {
+	names := map[string]bool{DefaultGatewayName: true}
+	claimed := map[string]string{}
+	for i, g := range gateways {
+		if !dnsLabelPattern.MatchString(g.Name) {
+			return fmt.Errorf("additional[%d]: invalid name %q (lowercase letters, digits and hyphens)", i, g.Name)
+		}
+		if names[g.Name] {
+			return fmt.Errorf("additional[%d]: gateway name %q is already used", i, g.Name)
+		}
+		names[g.Name] = true
+
+		listenerNames := map[string]bool{}
+		ports := map[int]bool{}
+		for j, l := range g.EffectiveListeners() {
+			if !dnsLabelPattern.MatchString(l.Name) {
+				return fmt.Errorf("additional[%d] (%s): listeners[%d]: invalid name %q", i, g.Name, j, l.Name)
+			}
+			if listenerNames[l.Name] {
+				return fmt.Errorf("additional[%d] (%s): duplicate listener %q", i, g.Name, l.Name)
+			}
+			listenerNames[l.Name] = true
+			if l.Protocol != GatewayProtocolHTTP && l.Protocol != GatewayProtocolHTTPS {
+				return fmt.Errorf("additional[%d] (%s): listener %s: protocol must be %s or %s, got %q",
+					i, g.Name, l.Name, GatewayProtocolHTTP, GatewayProtocolHTTPS, l.Protocol)
+			}
+			if l.Port < 1 || l.Port > 65535 {
+				return fmt.Errorf("additional[%d] (%s): listener %s: port %d out of range", i, g.Name, l.Name, l.Port)
+			}
+			if ports[l.Port] {
+				return fmt.Errorf("additional[%d] (%s): listener %s: port %d is used by another listener", i, g.Name, l.Name, l.Port)
+			}
+			ports[l.Port] = true
+		}
+
+		if len(g.Routes) > 0 && g.RouteListener() == "" {
+			return fmt.Errorf("additional[%d] (%s): routes need an %s listener", i, g.Name, GatewayProtocolHTTPS)
+		}
+		for _, route := range g.Routes {
+			if !slices.Contains(GatewayRoutes, route) {
+				return fmt.Errorf("additional[%d] (%s): unknown route %q (must be one of: %v)", i, g.Name, route, GatewayRoutes)
+			}
+			if other, ok := claimed[route]; ok {
+				return fmt.Errorf("additional[%d] (%s): route %q is already attached to gateway %s", i, g.Name, route, other)
+			}
+			claimed[route] = g.Name
+		}
+	}
+	return nil
+}
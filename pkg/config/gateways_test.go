@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAdditionalGateways(t *testing.T) {
+	https := func(name string, port int) GatewayListener {
+		return GatewayListener{Name: name, Protocol: GatewayProtocolHTTPS, Port: port}
+	}
+
+	tests := []struct {
+		name      string
+		gateways  []AdditionalGateway
+		errSubstr string // "" means no error expected
+	}{
+		{name: "none"},
+		{
+			name: "two gateways",
+			gateways: []AdditionalGateway{
+				{Name: "admin", Internal: true, Listeners: []GatewayListener{https("admin", 8443)}, Routes: []string{"argocd", "longhorn"}},
+				{Name: "public", Routes: []string{"keycloak"}},
+			},
+		},
+		{name: "invalid name", gateways: []AdditionalGateway{{Name: "Admin"}}, errSubstr: "invalid name"},
+		{name: "default name", gateways: []AdditionalGateway{{Name: DefaultGatewayName}}, errSubstr: "already used"},
+		{name: "duplicate name", gateways: []AdditionalGateway{{Name: "a"}, {Name: "a"}}, errSubstr: "already used"},
+		{
+			name:      "duplicate listener",
+			gateways:  []AdditionalGateway{{Name: "a", Listeners: []GatewayListener{https("web", 443), https("web", 8443)}}},
+			errSubstr: `duplicate listener "web"`,
+		},
+		{
+			name:      "duplicate port",
+			gateways:  []AdditionalGateway{{Name: "a", Listeners: []GatewayListener{https("one", 443), https("two", 443)}}},
+			errSubstr: "port 443 is used",
+		},
+		{
+			name:      "bad protocol",
+			gateways:  []AdditionalGateway{{Name: "a", Listeners: []GatewayListener{{Name: "tcp", Protocol: "TCP", Port: 22}}}},
+			errSubstr: "protocol must be",
+		},
+		{
+			name:      "bad port",
+			gateways:  []AdditionalGateway{{Name: "a", Listeners: []GatewayListener{https("web", 0)}}},
+			errSubstr: "out of range",
+		},
+		{
+			name:      "routes without https",
+			gateways:  []AdditionalGateway{{Name: "a", Listeners: []GatewayListener{{Name: "http", Protocol: GatewayProtocolHTTP, Port: 80}}, Routes: []string{"argocd"}}},
+			errSubstr: "routes need an HTTPS listener",
+		},
+		{name: "unknown route", gateways: []AdditionalGateway{{Name: "a", Routes: []string{"jupyterhub"}}}, errSubstr: `unknown route "jupyterhub"`},
+		{
+			name:      "route claimed twice",
+			gateways:  []AdditionalGateway{{Name: "a", Routes: []string{"argocd"}}, {Name: "b", Routes: []string{"argocd"}}},
+			errSubstr: "already attached to gateway a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&GatewayConfig{Additional: tt.gateways}).Validate(nil, nil, nil)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("Validate() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
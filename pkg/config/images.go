@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// imageDigestPattern matches an OCI sha256 content digest.
+var imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ImageOverride replaces the container image a foundational component's
+// Helm chart deploys, e.g. to pull it from a private registry pinned to a
+// digest.
+type ImageOverride struct {
+	// Repository is the image without tag or digest, including the
+	// registry (e.g. registry.example.com/keycloak/keycloak).
+	Repository string `yaml:"repository"`
+
+	// Tag is the image tag. Optional when Digest is set.
+	Tag string `yaml:"tag,omitempty"`
+
+	// Digest pins the image content (sha256:<64 hex characters>). It takes
+	// precedence over Tag when pulling.
+	Digest string `yaml:"digest,omitempty"`
+}
+
+// Reference returns the full image reference: repository, then :tag and
+// @digest when set.
+func (o ImageOverride) Reference() string {
+	ref := o.Repository
+	if o.Tag != "" {
+		ref += ":" + o.Tag
+	}
+	if o.Digest != "" {
+		ref += "@" + o.Digest
+	}
+	return ref
+}
+
+// Validate checks the override's fields. It does not check which components
+// can be overridden; the installer knows their charts.
+func (o ImageOverride) Validate() error {
+	// A colon before the last slash is a registry port, not a tag.
+	name := o.Repository[strings.LastIndex(o.Repository, "/")+1:]
+	switch {
+	case o.Repository == "":
+		return fmt.Errorf("repository is required")
+	case strings.Contains(o.Repository, "@") || strings.Contains(name, ":"):
+		return fmt.Errorf("repository %q must not include a tag or digest", o.Repository)
+	case o.Tag == "" && o.Digest == "":
+		return fmt.Errorf("a tag or digest is required")
+	case strings.ContainsAny(o.Tag, ":@"):
+		return fmt.Errorf("invalid tag %q", o.Tag)
+	case o.Digest != "" && !imageDigestPattern.MatchString(o.Digest):
+		return fmt.Errorf("invalid digest %q (expected sha256: followed by 64 lowercase hex characters)", o.Digest)
+	}
+	return nil
+}
+
+// validateImages validates every image override, in name order.
+func validateImages(images map[string]ImageOverride) error {
+	for _, name := range slices.Sorted(maps.Keys(images)) {
+		if err := images[name].Validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImageOverrideValidate(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	tests := []struct {
+		name      string
+		override  ImageOverride
+		errSubstr string // "" means no error expected
+	}{
+		{name: "tag", override: ImageOverride{Repository: "quay.io/keycloak/keycloak", Tag: "26.1.0"}},
+		{name: "digest", override: ImageOverride{Repository: "quay.io/keycloak/keycloak", Digest: digest}},
+		{name: "registry port", override: ImageOverride{Repository: "registry:5000/keycloak", Tag: "26.1.0", Digest: digest}},
+		{name: "no repository", override: ImageOverride{Tag: "1"}, errSubstr: "repository is required"},
+		{name: "tag in repository", override: ImageOverride{Repository: "quay.io/keycloak:26", Tag: "26"}, errSubstr: "must not include a tag"},
+		{name: "no tag or digest", override: ImageOverride{Repository: "keycloak"}, errSubstr: "tag or digest is required"},
+		{name: "digest in tag", override: ImageOverride{Repository: "keycloak", Tag: "26@" + digest}, errSubstr: "invalid tag"},
+		{name: "short digest", override: ImageOverride{Repository: "keycloak", Digest: "sha256:abc"}, errSubstr: "invalid digest"},
+		{name: "uppercase digest", override: ImageOverride{Repository: "keycloak", Digest: strings.ToUpper(digest)}, errSubstr: "invalid digest"},
+		{name: "other algorithm", override: ImageOverride{Repository: "keycloak", Digest: "sha512:" + strings.Repeat("ab", 32)}, errSubstr: "invalid digest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.override.Validate()
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("Validate() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestImageOverrideReference(t *testing.T) {
+	o := ImageOverride{Repository: "registry:5000/envoyproxy/gateway", Tag: "v1.6.2", Digest: "sha256:" + strings.Repeat("0", 64)}
+	want := "registry:5000/envoyproxy/gateway:v1.6.2@sha256:" + strings.Repeat("0", 64)
+	if got := o.Reference(); got != want {
+		t.Errorf("Reference() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/secretenv"
+)
+
+// Keycloak database modes.
+const (
+	// KeycloakDatabaseInCluster deploys PostgreSQL in the cluster for
+	// Keycloak. The default.
+	KeycloakDatabaseInCluster = "in-cluster"
+	// KeycloakDatabaseExternal points Keycloak at an existing PostgreSQL
+	// database, e.g. Amazon RDS, and deploys none.
+	KeycloakDatabaseExternal = "external"
+)
+
+// defaultPostgresPort is the port of an external database when unset.
+const defaultPostgresPort = 5432
+
+// envVarNamePattern matches a POSIX environment variable name.
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// KeycloakConfig configures the Keycloak NIC installs.
+type KeycloakConfig struct {
+	// Database selects the PostgreSQL database Keycloak stores its data in.
+	// Optional; by default an in-cluster PostgreSQL is deployed.
+	Database *KeycloakDatabaseConfig `yaml:"database,omitempty"`
+}
+
+// KeycloakDatabaseConfig selects Keycloak's PostgreSQL database.
+type KeycloakDatabaseConfig struct {
+	// Mode is in-cluster (the default) or external.
+	Mode string `yaml:"mode,omitempty"`
+
+	// Host, Port, Database and Username locate the external database.
+	// Required in external mode, except Port (default 5432).
+	Host     string `yaml:"host,omitempty"`
+	Port     int    `yaml:"port,omitempty"`
+	Database string `yaml:"database,omitempty"`
+	Username string `yaml:"username,omitempty"`
+
+	// PasswordEnv names the environment variable holding the password of
+	// Username, read at deploy time. Its _FILE variant is honoured too.
+	// Required in external mode.
+	PasswordEnv string `yaml:"password_env,omitempty"`
+}
+
+// ExternalDatabase returns the external database Keycloak uses, or nil when
+// an in-cluster PostgreSQL is deployed. Safe to call on a nil receiver.
+func (c *KeycloakConfig) ExternalDatabase() *KeycloakDatabaseConfig {
+	if c == nil || c.Database == nil || c.Database.Mode != KeycloakDatabaseExternal {
+		return nil
+	}
+	return c.Database
+}
+
+// Validate checks the database mode and, in external mode, that every field
+// needed to reach the database is set. Safe to call on a nil receiver.
+func (c *KeycloakConfig) Validate() error {
+	if c == nil || c.Database == nil {
+		return nil
+	}
+	d := c.Database
+	switch d.Mode {
+	case "", KeycloakDatabaseInCluster:
+		if d.Host != "" || d.Port != 0 || d.Database != "" || d.Username != "" || d.PasswordEnv != "" {
+			return fmt.Errorf("database: host, port, database, username and password_env require mode %q", KeycloakDatabaseExternal)
+		}
+		return nil
+	case KeycloakDatabaseExternal:
+	default:
+		return fmt.Errorf("database: invalid mode %q (must be %s or %s)", d.Mode, KeycloakDatabaseInCluster, KeycloakDatabaseExternal)
+	}
+
+	switch {
+	case d.Host == "":
+		return fmt.Errorf("database: host is required in external mode")
+	case d.Port < 0 || d.Port > 65535:
+		return fmt.Errorf("database: invalid port %d", d.Port)
+	case d.Database == "":
+		return fmt.Errorf("database: database is required in external mode")
+	case d.Username == "":
+		return fmt.Errorf("database: username is required in external mode")
+	case d.PasswordEnv == "":
+		return fmt.Errorf("database: password_env is required in external mode")
+	case !envVarNamePattern.MatchString(d.PasswordEnv):
+		return fmt.Errorf("database: invalid password_env %q", d.PasswordEnv)
+	}
+	return nil
+}
+
+// PortOrDefault returns the configured port, or 5432 when unset.
+func (d *KeycloakDatabaseConfig) PortOrDefault() int {
+	if d.Port == 0 {
+		return defaultPostgresPort
+	}
+	return d.Port
+}
+
+// ResolvePassword reads the database password from the PasswordEnv
+// environment variable or its _FILE variant. An unset or empty password is
+// an error.
+func (d *KeycloakDatabaseConfig) ResolvePassword() (string, error) {
+	password, err := secretenv.Lookup(d.PasswordEnv)
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", fmt.Errorf("%s (or %s%s) environment variable is required for the external Keycloak database", d.PasswordEnv, d.PasswordEnv, secretenv.FileSuffix)
+	}
+	return password, nil
+}
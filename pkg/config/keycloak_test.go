@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKeycloakConfigValidate(t *testing.T) {
+	external := func() *KeycloakDatabaseConfig {
+		return &KeycloakDatabaseConfig{
+			Mode:        KeycloakDatabaseExternal,
+			Host:        "keycloak.abc123.us-west-2.rds.amazonaws.com",
+			Database:    "keycloak",
+			Username:    "keycloak",
+			PasswordEnv: "KEYCLOAK_DB_PASSWORD",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *KeycloakConfig
+		modify  func(d *KeycloakDatabaseConfig)
+		wantErr string
+	}{
+		{name: "nil config", cfg: nil},
+		{name: "no database", cfg: &KeycloakConfig{}},
+		{name: "explicit in-cluster", cfg: &KeycloakConfig{Database: &KeycloakDatabaseConfig{Mode: KeycloakDatabaseInCluster}}},
+		{name: "in-cluster with host", cfg: &KeycloakConfig{Database: &KeycloakDatabaseConfig{Host: "db.example.com"}}, wantErr: `require mode "external"`},
+		{name: "invalid mode", cfg: &KeycloakConfig{Database: &KeycloakDatabaseConfig{Mode: "managed"}}, wantErr: `invalid mode "managed"`},
+		{name: "external", cfg: &KeycloakConfig{Database: external()}},
+		{name: "external with port", cfg: &KeycloakConfig{Database: external()}, modify: func(d *KeycloakDatabaseConfig) { d.Port = 6432 }},
+		{name: "external without host", cfg: &KeycloakConfig{Database: external()}, modify: func(d *KeycloakDatabaseConfig) { d.Host = "" }, wantErr: "host is required"},
+		{name: "external with invalid port", cfg: &KeycloakConfig{Database: external()}, modify: func(d *KeycloakDatabaseConfig) { d.Port = 70000 }, wantErr: "invalid port 70000"},
+		{name: "external without database", cfg: &KeycloakConfig{Database: external()}, modify: func(d *KeycloakDatabaseConfig) { d.Database = "" }, wantErr: "database is required"},
+		{name: "external without username", cfg: &KeycloakConfig{Database: external()}, modify: func(d *KeycloakDatabaseConfig) { d.Username = "" }, wantErr: "username is required"},
+		{name: "external without password_env", cfg: &KeycloakConfig{Database: external()}, modify: func(d *KeycloakDatabaseConfig) { d.PasswordEnv = "" }, wantErr: "password_env is required"},
+		{name: "external with invalid password_env", cfg: &KeycloakConfig{Database: external()}, modify: func(d *KeycloakDatabaseConfig) { d.PasswordEnv = "DB-PASSWORD" }, wantErr: `invalid password_env "DB-PASSWORD"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.modify != nil {
+				tt.modify(tt.cfg.Database)
+			}
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeycloakConfigExternalDatabase(t *testing.T) {
+	var nilCfg *KeycloakConfig
+	if nilCfg.ExternalDatabase() != nil {
+		t.Error("nil config: ExternalDatabase() != nil")
+	}
+	inCluster := &KeycloakConfig{Database: &KeycloakDatabaseConfig{Mode: KeycloakDatabaseInCluster}}
+	if inCluster.ExternalDatabase() != nil {
+		t.Error("in-cluster: ExternalDatabase() != nil")
+	}
+	db := &KeycloakDatabaseConfig{Mode: KeycloakDatabaseExternal, Host: "db.example.com"}
+	if got := (&KeycloakConfig{Database: db}).ExternalDatabase(); got != db {
+		t.Errorf("external: ExternalDatabase() = %v, want %v", got, db)
+	}
+	if got := db.PortOrDefault(); got != 5432 {
+		t.Errorf("PortOrDefault() = %d, want 5432", got)
+	}
+}
+
+func TestKeycloakDatabaseResolvePassword(t *testing.T) {
+	db := &KeycloakDatabaseConfig{Mode: KeycloakDatabaseExternal, PasswordEnv: "NIC_TEST_KEYCLOAK_DB_PASSWORD"}
+
+	t.Run("unset", func(t *testing.T) {
+		t.Setenv("NIC_TEST_KEYCLOAK_DB_PASSWORD", "")
+		if _, err := db.ResolvePassword(); err == nil || !strings.Contains(err.Error(), "NIC_TEST_KEYCLOAK_DB_PASSWORD") {
+			t.Errorf("ResolvePassword() error = %v, want it to name the variable", err)
+		}
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv("NIC_TEST_KEYCLOAK_DB_PASSWORD", "s3cret")
+		got, err := db.ResolvePassword()
+		if err != nil {
+			t.Fatalf("ResolvePassword() error = %v", err)
+		}
+		if got != "s3cret" {
+			t.Errorf("ResolvePassword() = %q, want %q", got, "s3cret")
+		}
+	})
+
+	t.Run("from file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+		if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+			t.Fatalf("write fixture: %v", err)
+		}
+		t.Setenv("NIC_TEST_KEYCLOAK_DB_PASSWORD", "")
+		t.Setenv("NIC_TEST_KEYCLOAK_DB_PASSWORD_FILE", path)
+		got, err := db.ResolvePassword()
+		if err != nil {
+			t.Fatalf("ResolvePassword() error = %v", err)
+		}
+		if got != "from-file" {
+			t.Errorf("ResolvePassword() = %q, want %q", got, "from-file")
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package config
+
+// MonitoringConfig configures the optional Prometheus and Grafana monitoring
+// stack (kube-prometheus-stack). The OpenTelemetry Collector remote-writes
+// its metrics to the Prometheus it installs, and Grafana is served at
+// grafana.<domain>.
+type MonitoringConfig struct {
+	// Enabled installs the monitoring stack. Defaults to false.
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// IsEnabled reports whether the monitoring stack is installed. Safe to call
+// on a nil receiver.
+func (c *MonitoringConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
@@ -3,7 +3,9 @@ package config
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 
 	"github.com/goccy/go-yaml"
 	"go.opentelemetry.io/otel"
@@ -19,9 +21,46 @@ func ParseConfigBytes(data []byte) (*NebariConfig, error) {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	var top map[string]any
+	if err := yaml.Unmarshal(data, &top); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if err := checkLegacyProviderKeys(top, config.Cluster); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
+// legacyProviderBlocks maps the per-cloud top-level blocks of the older
+// Nebari config schema to the cluster provider key that replaces them.
+var legacyProviderBlocks = map[string]string{
+	"amazon_web_services":   "aws",
+	"google_cloud_platform": "gcp",
+	"azure":                 "azure",
+	"local":                 "local",
+	"existing":              "existing",
+}
+
+// checkLegacyProviderKeys rejects a top-level provider selector or
+// per-provider block. Unknown keys are otherwise ignored, so without this a
+// config mixing the two schemas would deploy with whatever happens to be
+// under cluster and silently drop the rest.
+func checkLegacyProviderKeys(top map[string]any, cluster *ClusterConfig) error {
+	for _, key := range slices.Sorted(maps.Keys(legacyProviderBlocks)) {
+		if _, ok := top[key]; ok {
+			return fmt.Errorf("top-level %q block is not supported: configure the cluster provider under cluster.%s instead", key, legacyProviderBlocks[key])
+		}
+	}
+	if selected, ok := top["provider"]; ok {
+		if configured := cluster.ProviderName(); configured != "" && fmt.Sprint(selected) != configured {
+			return fmt.Errorf("top-level provider %q does not match the configured cluster provider %q; remove the provider field, the cluster block alone selects the provider", selected, configured)
+		}
+		return fmt.Errorf("top-level provider field is not supported: the cluster provider is selected by the single key under cluster (e.g. cluster.%v)", selected)
+	}
+	return nil
+}
+
 // ParseConfig reads and parses a nebari-config.yaml file.
 // This is a convenience wrapper around ParseConfigBytes that handles file I/O.
 func ParseConfig(ctx context.Context, filePath string) (*NebariConfig, error) {
@@ -40,7 +79,7 @@ func ParseConfig(ctx context.Context, filePath string) (*NebariConfig, error) {
 	config, err := ParseConfigBytes(data)
 	if err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("config file %s: %w", filePath, err)
+		return nil, fmt.Errorf("%w: config file %s: %w", ErrInvalidConfig, filePath, err)
 	}
 
 	span.SetAttributes(
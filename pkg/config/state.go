@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// State backend types accepted in state.backend.
+const (
+	StateBackendLocal = "local"
+	StateBackendS3    = "s3"
+)
+
+// StateConfig is the top-level `state:` block. It chooses where NIC keeps its
+// own deploy state (the resume checkpoint) and the lock that stops two
+// deploys of the same project running at once. The default local backend
+// keeps the checkpoint under ~/.nic and takes no lock; a remote backend lets
+// a team or CI share both.
+type StateConfig struct {
+	// Backend is StateBackendLocal (the default) or StateBackendS3.
+	Backend string `yaml:"backend,omitempty"`
+
+	// Bucket holds the state objects. Required for remote backends; NIC does
+	// not create it.
+	Bucket string `yaml:"bucket,omitempty"`
+
+	// Key is the object key prefix the state is stored under. Defaults to
+	// the project's resource name (see NebariConfig.ResourceName).
+	Key string `yaml:"key,omitempty"`
+
+	// Region is the bucket's region. Required for the s3 backend.
+	Region string `yaml:"region,omitempty"`
+}
+
+// BackendType returns the configured backend, defaulting to
+// StateBackendLocal. A nil receiver is the local backend.
+func (s *StateConfig) BackendType() string {
+	if s == nil || s.Backend == "" {
+		return StateBackendLocal
+	}
+	return s.Backend
+}
+
+// IsRemote reports whether state lives in object storage.
+func (s *StateConfig) IsRemote() bool {
+	return s.BackendType() != StateBackendLocal
+}
+
+// Validate checks the backend type and its required fields. A nil receiver
+// is valid.
+func (s *StateConfig) Validate() error {
+	if s == nil {
+		return nil
+	}
+	switch s.BackendType() {
+	case StateBackendLocal:
+		if s.Bucket != "" || s.Key != "" || s.Region != "" {
+			return fmt.Errorf("bucket, key and region only apply to remote backends")
+		}
+	case StateBackendS3:
+		if s.Bucket == "" {
+			return fmt.Errorf("bucket is required for the %s backend", StateBackendS3)
+		}
+		if s.Region == "" {
+			return fmt.Errorf("region is required for the %s backend", StateBackendS3)
+		}
+		if strings.HasPrefix(s.Key, "/") || strings.HasSuffix(s.Key, "/") {
+			return fmt.Errorf("key %q must not start or end with /", s.Key)
+		}
+	default:
+		return fmt.Errorf("unsupported backend %q (must be %s or %s)", s.Backend, StateBackendLocal, StateBackendS3)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStateConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       *StateConfig
+		errContains string
+	}{
+		{name: "nil state"},
+		{name: "explicit local", state: &StateConfig{Backend: StateBackendLocal}},
+		{name: "s3", state: &StateConfig{Backend: StateBackendS3, Bucket: "team-nic-state", Region: "us-west-2", Key: "platform/prod"}},
+		{name: "local with bucket", state: &StateConfig{Bucket: "team-nic-state"}, errContains: "only apply to remote backends"},
+		{name: "s3 without bucket", state: &StateConfig{Backend: StateBackendS3, Region: "us-west-2"}, errContains: "bucket is required"},
+		{name: "s3 without region", state: &StateConfig{Backend: StateBackendS3, Bucket: "team-nic-state"}, errContains: "region is required"},
+		{name: "key with trailing slash", state: &StateConfig{Backend: StateBackendS3, Bucket: "b", Region: "us-west-2", Key: "prod/"}, errContains: "must not start or end with /"},
+		{name: "unknown backend", state: &StateConfig{Backend: "gcs", Bucket: "b"}, errContains: `unsupported backend "gcs"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.state.Validate()
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestStateConfigBackendType(t *testing.T) {
+	var nilState *StateConfig
+	if nilState.BackendType() != StateBackendLocal || nilState.IsRemote() {
+		t.Error("nil state should be the local backend")
+	}
+	s3 := &StateConfig{Backend: StateBackendS3}
+	if !s3.IsRemote() {
+		t.Error("s3 backend should be remote")
+	}
+}
@@ -7,8 +7,12 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
 )
 
 const (
@@ -67,7 +71,10 @@ func WithPollInterval(d time.Duration) Option {
 // configured label selector and returns the load balancer endpoint once available.
 // It keeps polling for both service creation and ingress assignment until the
 // timeout expires. This handles the case where ArgoCD hasn't yet reconciled
-// the Gateway resource that triggers service creation.
+// the Gateway resource that triggers service creation. While the service has
+// no ingress, its Warning events (e.g. "Error syncing load balancer" from the
+// cloud controller) are sent as status warnings, each distinct one once, so
+// the reason a load balancer is not coming up is visible.
 func GetLoadBalancerEndpoint(ctx context.Context, client kubernetes.Interface, opts ...Option) (*LoadBalancerEndpoint, error) {
 	tracer := otel.Tracer("nebari-infrastructure-core")
 	ctx, span := tracer.Start(ctx, "endpoint.GetLoadBalancerEndpoint")
@@ -88,8 +95,10 @@ func GetLoadBalancerEndpoint(ctx context.Context, client kubernetes.Interface, o
 	ticker := time.NewTicker(cfg.pollInterval)
 	defer ticker.Stop()
 
+	reported := map[string]bool{}
+
 	// Check immediately before entering the polling loop.
-	if ep, err := checkEndpoint(ctx, client, cfg); err == nil {
+	if ep, err := checkEndpoint(ctx, client, cfg, reported); err == nil {
 		span.SetAttributes(
 			attribute.String("hostname", ep.Hostname),
 			attribute.String("ip", ep.IP),
@@ -107,7 +116,7 @@ func GetLoadBalancerEndpoint(ctx context.Context, client kubernetes.Interface, o
 			span.RecordError(err)
 			return nil, err
 		case <-ticker.C:
-			ep, err := checkEndpoint(ctx, client, cfg)
+			ep, err := checkEndpoint(ctx, client, cfg, reported)
 			if err == nil {
 				span.SetAttributes(
 					attribute.String("hostname", ep.Hostname),
@@ -119,10 +128,23 @@ func GetLoadBalancerEndpoint(ctx context.Context, client kubernetes.Interface, o
 	}
 }
 
+// LookupLoadBalancerEndpoint returns the load balancer endpoint of the
+// service matching the configured label selector as it is now, without
+// waiting. The timeout and poll interval options are ignored.
+func LookupLoadBalancerEndpoint(ctx context.Context, client kubernetes.Interface, opts ...Option) (*LoadBalancerEndpoint, error) {
+	cfg := defaultOptions()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return checkEndpoint(ctx, client, cfg, map[string]bool{})
+}
+
 // checkEndpoint performs a single attempt to find the load balancer endpoint.
 // If multiple services match the selector, the first one is used. In practice,
-// Envoy Gateway creates exactly one service per Gateway resource.
-func checkEndpoint(ctx context.Context, client kubernetes.Interface, cfg *options) (*LoadBalancerEndpoint, error) {
+// Envoy Gateway creates exactly one service per Gateway resource. While the
+// service has no ingress, its new Warning events are reported; reported holds
+// the events already sent.
+func checkEndpoint(ctx context.Context, client kubernetes.Interface, cfg *options, reported map[string]bool) (*LoadBalancerEndpoint, error) {
 	tracer := otel.Tracer("nebari-infrastructure-core")
 	ctx, span := tracer.Start(ctx, "endpoint.checkEndpoint")
 	defer span.End()
@@ -141,6 +163,7 @@ func checkEndpoint(ctx context.Context, client kubernetes.Interface, cfg *option
 	svc := services.Items[0]
 	ingress := svc.Status.LoadBalancer.Ingress
 	if len(ingress) == 0 {
+		reportServiceEvents(ctx, client, &svc, reported)
 		return nil, fmt.Errorf("load balancer not ready: no ingress entries")
 	}
 
@@ -149,3 +172,34 @@ func checkEndpoint(ctx context.Context, client kubernetes.Interface, cfg *option
 		IP:       ingress[0].IP,
 	}, nil
 }
+
+// reportServiceEvents sends a status warning for each Warning event of svc
+// not already in reported, and adds it there. Events repeat while a
+// controller retries, so they are keyed by reason and message. Failing to
+// list events is not an error: they only explain the wait.
+func reportServiceEvents(ctx context.Context, client kubernetes.Interface, svc *corev1.Service, reported map[string]bool) {
+	events, err := client.CoreV1().Events(svc.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": "Service",
+			"involvedObject.name": svc.Name,
+		}).String(),
+	})
+	if err != nil {
+		return
+	}
+	for _, ev := range events.Items {
+		if ev.Type != corev1.EventTypeWarning || ev.InvolvedObject.Kind != "Service" || ev.InvolvedObject.Name != svc.Name {
+			continue
+		}
+		key := ev.Reason + "|" + ev.Message
+		if reported[key] {
+			continue
+		}
+		reported[key] = true
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, fmt.Sprintf("Load balancer for service %s/%s: %s", svc.Namespace, svc.Name, ev.Message)).
+			WithResource("load-balancer").
+			WithAction("provisioning").
+			WithMetadata("service", svc.Name).
+			WithMetadata("reason", ev.Reason))
+	}
+}
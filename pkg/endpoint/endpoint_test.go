@@ -3,6 +3,7 @@ package endpoint
 import (
 	"context"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
 )
 
 func TestGetLoadBalancerEndpoint(t *testing.T) {
@@ -151,3 +154,109 @@ func TestGetLoadBalancerEndpoint_ContextCancelled(t *testing.T) {
 		t.Errorf("expected error containing %q, got %q", "context cancelled", err.Error())
 	}
 }
+
+func TestGetLoadBalancerEndpoint_ReportsServiceEvents(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "envoy-gateway-svc",
+			Namespace: DefaultNamespace,
+			Labels: map[string]string{
+				"gateway.envoyproxy.io/owning-gateway-name": "nebari-gateway",
+			},
+		},
+	}
+	event := func(name, svcName, eventType, reason, message string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: DefaultNamespace},
+			InvolvedObject: corev1.ObjectReference{Kind: "Service", Name: svcName, Namespace: DefaultNamespace},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        message,
+		}
+	}
+	client := fake.NewSimpleClientset(svc,
+		event("lb-error", svc.Name, corev1.EventTypeWarning, "SyncLoadBalancerFailed",
+			"Error syncing load balancer: failed to ensure load balancer: could not find any suitable subnets"),
+		event("lb-ensuring", svc.Name, corev1.EventTypeNormal, "EnsuringLoadBalancer", "Ensuring load balancer"),
+		event("other-error", "other-svc", corev1.EventTypeWarning, "SyncLoadBalancerFailed", "quota exceeded"),
+	)
+
+	var (
+		mu       sync.Mutex
+		warnings []status.Update
+	)
+	ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+		if u.Level == status.LevelWarning {
+			mu.Lock()
+			warnings = append(warnings, u)
+			mu.Unlock()
+		}
+	})
+	_, err := GetLoadBalancerEndpoint(ctx, client,
+		WithTimeout(100*time.Millisecond),
+		WithPollInterval(10*time.Millisecond),
+	)
+	cleanup()
+
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	// The event is seen on every poll but reported once.
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1: %+v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0].Message, "could not find any suitable subnets") {
+		t.Errorf("warning = %q, want the load balancer error", warnings[0].Message)
+	}
+	if warnings[0].Metadata["reason"] != "SyncLoadBalancerFailed" {
+		t.Errorf("reason = %v, want SyncLoadBalancerFailed", warnings[0].Metadata["reason"])
+	}
+}
+
+func TestLookupLoadBalancerEndpoint(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "envoy-gateway-svc",
+			Namespace: DefaultNamespace,
+			Labels: map[string]string{
+				"gateway.envoyproxy.io/owning-gateway-name": "nebari-gateway",
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(svc)
+
+	// Without an ingress the lookup fails at once instead of polling.
+	start := time.Now()
+	if _, err := LookupLoadBalancerEndpoint(context.Background(), client, WithTimeout(time.Minute)); err == nil {
+		t.Fatal("expected error for a service without ingress, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("lookup took %s, want a single check", elapsed)
+	}
+	if lists := countActions(client, "list", "services"); lists != 1 {
+		t.Errorf("services listed %d times, want 1", lists)
+	}
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}
+	if _, err := client.CoreV1().Services(DefaultNamespace).UpdateStatus(context.Background(), svc, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	ep, err := LookupLoadBalancerEndpoint(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ep.IP != "203.0.113.10" {
+		t.Errorf("ip = %q, want 203.0.113.10", ep.IP)
+	}
+}
+
+// countActions counts the recorded client actions with verb on resource.
+func countActions(client *fake.Clientset, verb, resource string) int {
+	n := 0
+	for _, a := range client.Actions() {
+		if a.GetVerb() == verb && a.GetResource().Resource == resource {
+			n++
+		}
+	}
+	return n
+}
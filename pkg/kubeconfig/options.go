@@ -0,0 +1,120 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Option rewrites a kubeconfig generated by a cluster provider before it is
+// handed to a consumer, e.g. to route it through a proxy or rename its
+// context. Options operate on the current context and the cluster and user it
+// references.
+type Option func(*clientcmdapi.Config) error
+
+// Apply parses kubeconfigBytes, applies opts in order and returns the
+// serialized result. Without options the input is returned unchanged.
+func Apply(kubeconfigBytes []byte, opts ...Option) ([]byte, error) {
+	if len(opts) == 0 {
+		return kubeconfigBytes, nil
+	}
+	config, err := clientcmd.Load(kubeconfigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, err
+		}
+	}
+	return WriteBytes(config)
+}
+
+// WithContextName renames the current context to name, so the kubeconfig can
+// be merged next to others without clashing.
+func WithContextName(name string) Option {
+	return func(config *clientcmdapi.Config) error {
+		if name == "" || name == config.CurrentContext {
+			return nil
+		}
+		current, err := currentContext(config)
+		if err != nil {
+			return err
+		}
+		if _, exists := config.Contexts[name]; exists {
+			return fmt.Errorf("context %q already exists in kubeconfig", name)
+		}
+		delete(config.Contexts, config.CurrentContext)
+		config.Contexts[name] = current
+		config.CurrentContext = name
+		return nil
+	}
+}
+
+// WithProxyURL sets the proxy used to reach the current context's API server.
+// Supported schemes are http, https and socks5.
+func WithProxyURL(proxyURL string) Option {
+	return func(config *clientcmdapi.Config) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", proxyURL)
+		}
+		cluster, err := currentCluster(config)
+		if err != nil {
+			return err
+		}
+		cluster.ProxyURL = proxyURL
+		return nil
+	}
+}
+
+// WithCABundle adds the PEM-encoded certificates in caPEM to the CAs trusted
+// for the current context's API server, e.g. the CA of a TLS-intercepting
+// proxy. The cluster's own CA is kept.
+func WithCABundle(caPEM []byte) Option {
+	return func(config *clientcmdapi.Config) error {
+		if len(caPEM) == 0 {
+			return nil
+		}
+		cluster, err := currentCluster(config)
+		if err != nil {
+			return err
+		}
+		if cluster.CertificateAuthority != "" {
+			return fmt.Errorf("cluster %q references CA file %s; cannot add a CA bundle", config.Contexts[config.CurrentContext].Cluster, cluster.CertificateAuthority)
+		}
+		data := append([]byte{}, cluster.CertificateAuthorityData...)
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			data = append(data, '\n')
+		}
+		cluster.CertificateAuthorityData = append(data, caPEM...)
+		return nil
+	}
+}
+
+func currentContext(config *clientcmdapi.Config) (*clientcmdapi.Context, error) {
+	context, exists := config.Contexts[config.CurrentContext]
+	if !exists {
+		return nil, fmt.Errorf("current context %q not found in kubeconfig", config.CurrentContext)
+	}
+	return context, nil
+}
+
+func currentCluster(config *clientcmdapi.Config) (*clientcmdapi.Cluster, error) {
+	context, err := currentContext(config)
+	if err != nil {
+		return nil, err
+	}
+	cluster, exists := config.Clusters[context.Cluster]
+	if !exists {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig", context.Cluster)
+	}
+	return cluster, nil
+}
@@ -0,0 +1,117 @@
+package kubeconfig
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func generatedKubeconfig(t *testing.T) []byte {
+	t.Helper()
+	config := clientcmdapi.NewConfig()
+	config.Clusters["demo"] = &clientcmdapi.Cluster{
+		Server:                   "https://demo.example.com",
+		CertificateAuthorityData: []byte("-----BEGIN CERTIFICATE-----\ncluster\n-----END CERTIFICATE-----\n"),
+	}
+	config.AuthInfos["demo"] = &clientcmdapi.AuthInfo{Token: "secret"}
+	config.Contexts["demo"] = &clientcmdapi.Context{Cluster: "demo", AuthInfo: "demo"}
+	config.CurrentContext = "demo"
+	b, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestApply(t *testing.T) {
+	const proxyCA = "-----BEGIN CERTIFICATE-----\nproxy\n-----END CERTIFICATE-----\n"
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		check   func(t *testing.T, config *clientcmdapi.Config)
+		wantErr string
+	}{
+		{
+			name: "rename context",
+			opts: []Option{WithContextName("nebari-prod")},
+			check: func(t *testing.T, config *clientcmdapi.Config) {
+				if config.CurrentContext != "nebari-prod" {
+					t.Errorf("current context = %q, want nebari-prod", config.CurrentContext)
+				}
+				if _, ok := config.Contexts["demo"]; ok {
+					t.Error("old context still present")
+				}
+				if ctx := config.Contexts["nebari-prod"]; ctx == nil || ctx.Cluster != "demo" || ctx.AuthInfo != "demo" {
+					t.Errorf("renamed context = %+v, want cluster and user demo", ctx)
+				}
+			},
+		},
+		{
+			name: "proxy url",
+			opts: []Option{WithProxyURL("http://proxy.corp:3128")},
+			check: func(t *testing.T, config *clientcmdapi.Config) {
+				if got := config.Clusters["demo"].ProxyURL; got != "http://proxy.corp:3128" {
+					t.Errorf("proxy-url = %q, want http://proxy.corp:3128", got)
+				}
+			},
+		},
+		{
+			name: "ca bundle appended",
+			opts: []Option{WithCABundle([]byte(proxyCA))},
+			check: func(t *testing.T, config *clientcmdapi.Config) {
+				ca := string(config.Clusters["demo"].CertificateAuthorityData)
+				if !strings.Contains(ca, "cluster") || !strings.HasSuffix(ca, proxyCA) {
+					t.Errorf("certificate-authority-data = %q, want cluster CA followed by proxy CA", ca)
+				}
+			},
+		},
+		{
+			name: "options compose",
+			opts: []Option{WithContextName("nebari-prod"), WithProxyURL("socks5://localhost:1080")},
+			check: func(t *testing.T, config *clientcmdapi.Config) {
+				if config.CurrentContext != "nebari-prod" || config.Clusters["demo"].ProxyURL != "socks5://localhost:1080" {
+					t.Errorf("got context %q proxy %q", config.CurrentContext, config.Clusters["demo"].ProxyURL)
+				}
+			},
+		},
+		{
+			name:    "unsupported proxy scheme",
+			opts:    []Option{WithProxyURL("ftp://proxy.corp")},
+			wantErr: "scheme must be http, https or socks5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Apply(generatedKubeconfig(t), tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Apply() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			config, err := clientcmd.Load(out)
+			if err != nil {
+				t.Fatalf("result does not parse: %v", err)
+			}
+			tt.check(t, config)
+		})
+	}
+}
+
+func TestApplyWithoutOptions(t *testing.T) {
+	in := generatedKubeconfig(t)
+	out, err := Apply(in)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if string(out) != string(in) {
+		t.Error("Apply() without options changed the kubeconfig")
+	}
+}
@@ -0,0 +1,91 @@
+// Package netutil provides CIDR arithmetic shared by provider config
+// validation: parsing, overlap and containment checks, and subnet splitting.
+// Functions are pure and work for both IPv4 and IPv6 prefixes.
+package netutil
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// ParseCIDR parses s as a CIDR prefix. Unlike net.ParseCIDR it rejects
+// prefixes with host bits set (e.g. "10.0.0.1/16"), since cloud APIs either
+// reject those or silently mask them and the user almost certainly meant the
+// network address.
+func ParseCIDR(s string) (netip.Prefix, error) {
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR %q: %w", s, err)
+	}
+	if p.Masked() != p {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR %q: host bits set (did you mean %s?)", s, p.Masked())
+	}
+	return p, nil
+}
+
+// Overlaps reports whether a and b share at least one address. Prefixes of
+// different address families never overlap.
+func Overlaps(a, b netip.Prefix) bool {
+	return a.Overlaps(b)
+}
+
+// Contains reports whether inner lies entirely within outer. A prefix
+// contains itself.
+func Contains(outer, inner netip.Prefix) bool {
+	if outer.Addr().Is4() != inner.Addr().Is4() {
+		return false
+	}
+	return outer.Bits() <= inner.Bits() && outer.Contains(inner.Masked().Addr())
+}
+
+// FirstOverlap returns the indexes of the first pair of overlapping prefixes
+// in ps, or ok=false when all prefixes are disjoint.
+func FirstOverlap(ps []netip.Prefix) (i, j int, ok bool) {
+	for i = range ps {
+		for j = i + 1; j < len(ps); j++ {
+			if Overlaps(ps[i], ps[j]) {
+				return i, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// Split carves the first n consecutive subnets of length newBits out of p,
+// starting at p's network address. It returns an error if newBits is shorter
+// than p, longer than the address length, or if p cannot hold n such subnets.
+func Split(p netip.Prefix, newBits, n int) ([]netip.Prefix, error) {
+	p = p.Masked()
+	if !p.IsValid() {
+		return nil, fmt.Errorf("invalid prefix")
+	}
+	if newBits < p.Bits() || newBits > p.Addr().BitLen() {
+		return nil, fmt.Errorf("cannot split %s into /%d subnets", p, newBits)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("subnet count must not be negative (got %d)", n)
+	}
+	// Only compare when the shift fits; anything wider holds more subnets than
+	// an int can count.
+	if shift := newBits - p.Bits(); shift < 62 && n > 1<<shift {
+		return nil, fmt.Errorf("%s holds %d /%d subnets, %d requested", p, 1<<shift, newBits, n)
+	}
+
+	out := make([]netip.Prefix, 0, n)
+	next := netip.PrefixFrom(p.Addr(), newBits)
+	for range n {
+		out = append(out, next)
+		next = netip.PrefixFrom(lastAddr(next).Next(), newBits)
+	}
+	return out, nil
+}
+
+// lastAddr returns the highest address in p (the broadcast address for IPv4).
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Masked().Addr().AsSlice()
+	for bit := p.Bits(); bit < len(b)*8; bit++ {
+		b[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
@@ -0,0 +1,220 @@
+package netutil
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func mustPrefix(t *testing.T, s string) netip.Prefix {
+	t.Helper()
+	p, err := ParseCIDR(s)
+	if err != nil {
+		t.Fatalf("ParseCIDR(%q): %v", s, err)
+	}
+	return p
+}
+
+func TestParseCIDR(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantErr   bool
+		wantInErr string
+	}{
+		{name: "ipv4", in: "10.0.0.0/16"},
+		{name: "ipv4 host route", in: "10.0.0.1/32"},
+		{name: "ipv6", in: "2001:db8::/56"},
+		{name: "missing prefix", in: "10.0.0.0", wantErr: true, wantInErr: "invalid CIDR"},
+		{name: "prefix too long", in: "10.0.0.0/33", wantErr: true, wantInErr: "invalid CIDR"},
+		{name: "garbage", in: "not-a-cidr", wantErr: true, wantInErr: "invalid CIDR"},
+		{name: "host bits set", in: "10.0.0.1/16", wantErr: true, wantInErr: "did you mean 10.0.0.0/16"},
+		{name: "ipv6 host bits set", in: "2001:db8::1/64", wantErr: true, wantInErr: "host bits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCIDR(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got nil", tt.in)
+				}
+				if !strings.Contains(err.Error(), tt.wantInErr) {
+					t.Errorf("error %q does not contain %q", err, tt.wantInErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "identical", a: "10.0.0.0/16", b: "10.0.0.0/16", want: true},
+		{name: "nested", a: "10.0.0.0/16", b: "10.0.4.0/22", want: true},
+		{name: "nested reversed", a: "10.0.4.0/22", b: "10.0.0.0/16", want: true},
+		{name: "adjacent", a: "10.0.0.0/24", b: "10.0.1.0/24", want: false},
+		{name: "disjoint", a: "10.0.0.0/16", b: "172.16.0.0/12", want: false},
+		{name: "ipv6 nested", a: "2001:db8::/32", b: "2001:db8:1::/48", want: true},
+		{name: "ipv6 adjacent", a: "2001:db8::/64", b: "2001:db8:0:1::/64", want: false},
+		{name: "mixed families", a: "10.0.0.0/8", b: "2001:db8::/32", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Overlaps(mustPrefix(t, tt.a), mustPrefix(t, tt.b)); got != tt.want {
+				t.Errorf("Overlaps(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		name         string
+		outer, inner string
+		want         bool
+	}{
+		{name: "exact", outer: "10.0.0.0/16", inner: "10.0.0.0/16", want: true},
+		{name: "subnet at start", outer: "10.0.0.0/16", inner: "10.0.0.0/24", want: true},
+		{name: "subnet at end", outer: "10.0.0.0/16", inner: "10.0.255.0/24", want: true},
+		{name: "supernet", outer: "10.0.0.0/24", inner: "10.0.0.0/16", want: false},
+		{name: "adjacent", outer: "10.0.0.0/24", inner: "10.0.1.0/24", want: false},
+		{name: "ipv6", outer: "2001:db8::/32", inner: "2001:db8:ffff::/48", want: true},
+		{name: "ipv6 outside", outer: "2001:db8::/32", inner: "2001:db9::/48", want: false},
+		{name: "mixed families", outer: "0.0.0.0/0", inner: "::/128", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Contains(mustPrefix(t, tt.outer), mustPrefix(t, tt.inner)); got != tt.want {
+				t.Errorf("Contains(%s, %s) = %v, want %v", tt.outer, tt.inner, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstOverlap(t *testing.T) {
+	disjoint := []netip.Prefix{
+		mustPrefix(t, "10.0.0.0/24"),
+		mustPrefix(t, "10.0.1.0/24"),
+		mustPrefix(t, "10.0.2.0/24"),
+	}
+	if i, j, ok := FirstOverlap(disjoint); ok {
+		t.Errorf("FirstOverlap(disjoint) = (%d, %d, true), want ok=false", i, j)
+	}
+
+	overlapping := append(disjoint, mustPrefix(t, "10.0.1.128/25"))
+	i, j, ok := FirstOverlap(overlapping)
+	if !ok || i != 1 || j != 3 {
+		t.Errorf("FirstOverlap(overlapping) = (%d, %d, %v), want (1, 3, true)", i, j, ok)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		newBits   int
+		n         int
+		want      []string
+		wantInErr string
+	}{
+		{
+			name:    "ipv4 into /18s",
+			cidr:    "10.0.0.0/16",
+			newBits: 18,
+			n:       4,
+			want:    []string{"10.0.0.0/18", "10.0.64.0/18", "10.0.128.0/18", "10.0.192.0/18"},
+		},
+		{
+			name:    "fewer than capacity",
+			cidr:    "10.0.0.0/16",
+			newBits: 20,
+			n:       3,
+			want:    []string{"10.0.0.0/20", "10.0.16.0/20", "10.0.32.0/20"},
+		},
+		{
+			name:    "same size",
+			cidr:    "192.168.0.0/24",
+			newBits: 24,
+			n:       1,
+			want:    []string{"192.168.0.0/24"},
+		},
+		{
+			name:    "end of address space",
+			cidr:    "255.255.255.252/30",
+			newBits: 31,
+			n:       2,
+			want:    []string{"255.255.255.252/31", "255.255.255.254/31"},
+		},
+		{
+			name:    "ipv6 into /64s",
+			cidr:    "2001:db8::/62",
+			newBits: 64,
+			n:       4,
+			want:    []string{"2001:db8::/64", "2001:db8:0:1::/64", "2001:db8:0:2::/64", "2001:db8:0:3::/64"},
+		},
+		{
+			name:    "ipv6 huge capacity",
+			cidr:    "2001:db8::/32",
+			newBits: 128,
+			n:       2,
+			want:    []string{"2001:db8::/128", "2001:db8::1/128"},
+		},
+		{
+			name:      "too many subnets",
+			cidr:      "10.0.0.0/16",
+			newBits:   18,
+			n:         5,
+			wantInErr: "holds 4 /18 subnets",
+		},
+		{
+			name:      "new prefix shorter than parent",
+			cidr:      "10.0.0.0/16",
+			newBits:   8,
+			n:         1,
+			wantInErr: "cannot split",
+		},
+		{
+			name:      "new prefix beyond address length",
+			cidr:      "10.0.0.0/16",
+			newBits:   33,
+			n:         1,
+			wantInErr: "cannot split",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Split(mustPrefix(t, tt.cidr), tt.newBits, tt.n)
+			if tt.wantInErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got %v", tt.wantInErr, got)
+				}
+				if !strings.Contains(err.Error(), tt.wantInErr) {
+					t.Errorf("error %q does not contain %q", err, tt.wantInErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Split() returned %d subnets %v, want %v", len(got), got, tt.want)
+			}
+			for i := range got {
+				if got[i].String() != tt.want[i] {
+					t.Errorf("subnet %d = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
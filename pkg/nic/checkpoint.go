@@ -0,0 +1,152 @@
+package nic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+// Stage names a top-level Deploy step whose completion is checkpointed so a
+// failed deploy can be resumed with DeployOptions.Resume.
+type Stage string
+
+const (
+	// StageInfrastructure is the cluster provider's Deploy (network, IAM,
+	// cluster and node groups, applied as a single provider operation).
+	StageInfrastructure Stage = "infrastructure"
+	// StageGitOps is the GitOps repository bootstrap.
+	StageGitOps Stage = "gitops"
+	// StageFoundational is the Argo CD install plus foundational services.
+	// Both run as one stage because they share generated OIDC client secrets.
+	StageFoundational Stage = "foundational"
+)
+
+// checkpoint records which stages of a deploy completed. It is written to the
+// state backend (by default under ~/.nic/checkpoints) after each stage and
+// removed once a deploy finishes.
+type checkpoint struct {
+	ProjectName string `json:"project_name"`
+	// ConfigDigest is a hash of the config the stages were completed with. A
+	// checkpoint whose digest does not match the current config is ignored,
+	// since skipping a stage would silently drop the config change.
+	ConfigDigest string    `json:"config_digest"`
+	Completed    []Stage   `json:"completed"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	state stateBackend
+}
+
+// checkpointPath returns the checkpoint file location for projectName.
+func checkpointPath(projectName string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".nic", "checkpoints", projectName+".json"), nil
+}
+
+// configDigest returns a stable hash of cfg used to detect config changes
+// between a failed deploy and its resume.
+func configDigest(cfg *config.NebariConfig) (string, error) {
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("marshal config: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// loadCheckpoint reads the checkpoint for cfg from state. A missing
+// checkpoint, or one written for a different config, yields an empty
+// checkpoint bound to the same backend.
+func loadCheckpoint(ctx context.Context, cfg *config.NebariConfig, state stateBackend) (*checkpoint, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.loadCheckpoint")
+	defer span.End()
+
+	digest, err := configDigest(cfg)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	fresh := &checkpoint{ProjectName: cfg.ProjectName, ConfigDigest: digest, state: state}
+
+	data, err := state.read(ctx)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fresh, nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("read checkpoint %s: %w", state.location(), err)
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("parse checkpoint %s: %w", state.location(), err)
+	}
+	if cp.ConfigDigest != digest {
+		span.SetAttributes(attribute.Bool("checkpoint.stale", true))
+		return fresh, nil
+	}
+	cp.state = state
+	span.SetAttributes(attribute.Int("checkpoint.completed", len(cp.Completed)))
+	return &cp, nil
+}
+
+// done reports whether stage completed in a previous run.
+func (cp *checkpoint) done(stage Stage) bool {
+	return cp != nil && slices.Contains(cp.Completed, stage)
+}
+
+// markComplete records stage as completed and persists the checkpoint.
+func (cp *checkpoint) markComplete(ctx context.Context, stage Stage) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.checkpoint.markComplete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("stage", string(stage)))
+
+	if !slices.Contains(cp.Completed, stage) {
+		cp.Completed = append(cp.Completed, stage)
+	}
+	cp.UpdatedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	if err := cp.state.write(ctx, data); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("write checkpoint %s: %w", cp.state.location(), err)
+	}
+	return nil
+}
+
+// clear removes the checkpoint once a deploy has run to completion.
+func (cp *checkpoint) clear(ctx context.Context) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.checkpoint.clear")
+	defer span.End()
+
+	if err := cp.state.remove(ctx); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("remove checkpoint %s: %w", cp.state.location(), err)
+	}
+	return nil
+}
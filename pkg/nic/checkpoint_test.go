@@ -0,0 +1,132 @@
+package nic
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+func checkpointTestConfig() *config.NebariConfig {
+	return &config.NebariConfig{
+		ProjectName: "resume-test",
+		Domain:      "nebari.example.com",
+		Cluster:     &config.ClusterConfig{Providers: map[string]any{"aws": map[string]any{"region": "us-west-2"}}},
+	}
+}
+
+// localState returns the local state backend for cfg under a temporary HOME.
+func localState(t *testing.T, cfg *config.NebariConfig) *fileState {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	state, err := newFileState(cfg.ResourceName())
+	if err != nil {
+		t.Fatalf("newFileState() error = %v", err)
+	}
+	return state
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	cfg := checkpointTestConfig()
+	state := localState(t, cfg)
+
+	cp, err := loadCheckpoint(ctx, cfg, state)
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	if len(cp.Completed) != 0 {
+		t.Fatalf("fresh checkpoint has completed stages: %v", cp.Completed)
+	}
+
+	for _, stage := range []Stage{StageInfrastructure, StageGitOps, StageGitOps} {
+		if err := cp.markComplete(ctx, stage); err != nil {
+			t.Fatalf("markComplete(%s) error = %v", stage, err)
+		}
+	}
+
+	reloaded, err := loadCheckpoint(ctx, cfg, state)
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	if len(reloaded.Completed) != 2 || !reloaded.done(StageInfrastructure) || !reloaded.done(StageGitOps) {
+		t.Errorf("reloaded completed = %v, want [infrastructure gitops]", reloaded.Completed)
+	}
+	if reloaded.done(StageFoundational) {
+		t.Error("foundational reported done but was never recorded")
+	}
+
+	if err := reloaded.clear(ctx); err != nil {
+		t.Fatalf("clear() error = %v", err)
+	}
+	if _, err := os.Stat(state.path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkpoint file still present after clear: %v", err)
+	}
+	// Clearing twice is a no-op.
+	if err := reloaded.clear(ctx); err != nil {
+		t.Errorf("second clear() error = %v", err)
+	}
+}
+
+func TestCheckpointIgnoredWhenConfigChanges(t *testing.T) {
+	ctx := context.Background()
+	cfg := checkpointTestConfig()
+	state := localState(t, cfg)
+
+	cp, err := loadCheckpoint(ctx, cfg, state)
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	if err := cp.markComplete(ctx, StageInfrastructure); err != nil {
+		t.Fatalf("markComplete() error = %v", err)
+	}
+
+	cfg.Domain = "other.example.com"
+	reloaded, err := loadCheckpoint(ctx, cfg, state)
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	if reloaded.done(StageInfrastructure) {
+		t.Error("checkpoint from a different config should be ignored")
+	}
+}
+
+func TestShouldSkipStage(t *testing.T) {
+	ctx := context.Background()
+	cp := &checkpoint{Completed: []Stage{StageInfrastructure, StageGitOps}}
+	ok := func(context.Context) error { return nil }
+	gone := func(context.Context) error { return errors.New("cluster not found") }
+
+	t.Run("resume jumps straight to foundational", func(t *testing.T) {
+		if !shouldSkipStage(ctx, cp, StageInfrastructure, true, ok) {
+			t.Error("infrastructure should be skipped")
+		}
+		if !shouldSkipStage(ctx, cp, StageGitOps, true, nil) {
+			t.Error("gitops should be skipped")
+		}
+		if shouldSkipStage(ctx, cp, StageFoundational, true, nil) {
+			t.Error("foundational should run")
+		}
+	})
+
+	tests := []struct {
+		name   string
+		cp     *checkpoint
+		stage  Stage
+		resume bool
+		verify func(context.Context) error
+	}{
+		{name: "without resume nothing is skipped", cp: cp, stage: StageInfrastructure, resume: false, verify: ok},
+		{name: "failed verification re-runs the stage", cp: cp, stage: StageInfrastructure, resume: true, verify: gone},
+		{name: "nil checkpoint skips nothing", cp: nil, stage: StageGitOps, resume: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if shouldSkipStage(ctx, tt.cp, tt.stage, tt.resume, tt.verify) {
+				t.Errorf("stage %s was skipped, want it to run", tt.stage)
+			}
+		})
+	}
+}
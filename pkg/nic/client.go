@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/kubeconfig"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
 )
 
@@ -11,15 +14,43 @@ import (
 // NewClient and reuse it across operations.
 type Client struct {
 	registry *registry.Registry
+
+	// stateObjects opens the object storage of a remote state backend.
+	// Nil means openStateObjects; tests substitute a fake.
+	stateObjects func(ctx context.Context, state *config.StateConfig) (objectstore.Objects, error)
+
+	// kubeconfigOptions post-process every kubeconfig the client gets from
+	// a cluster provider.
+	kubeconfigOptions []kubeconfig.Option
+
+	// lookupHost resolves hostnames for the HTTP-01 preflight. Nil means
+	// net.DefaultResolver.LookupHost; tests substitute a fake.
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+// ClientOption configures a Client built by NewClient.
+type ClientOption func(*Client)
+
+// WithKubeconfigOptions rewrites the kubeconfig returned by Kubeconfig and
+// the one the client builds its own Kubernetes clients from (preflight
+// checks, endpoint lookup), e.g. to reach the API server through a proxy.
+func WithKubeconfigOptions(opts ...kubeconfig.Option) ClientOption {
+	return func(c *Client) {
+		c.kubeconfigOptions = append(c.kubeconfigOptions, opts...)
+	}
 }
 
 // NewClient returns a new NIC client. The context governs the provider
 // registration step (currently used for trace propagation). Returns an
 // error if the default provider registry fails to build.
-func NewClient(ctx context.Context) (*Client, error) {
+func NewClient(ctx context.Context, opts ...ClientOption) (*Client, error) {
 	reg, err := defaultRegistry(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("build default registry: %w", err)
 	}
-	return &Client{registry: reg}, nil
+	c := &Client{registry: reg}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
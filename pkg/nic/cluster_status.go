@@ -0,0 +1,177 @@
+package nic
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// ClusterStatus is a point-in-time health report of a deployed cluster and
+// the foundational services NIC installs on it.
+type ClusterStatus struct {
+	Provider          string            `json:"provider"`
+	KubernetesVersion string            `json:"kubernetes_version"`
+	NodeGroups        []NodeGroupStatus `json:"node_groups"`
+	// Applications is empty for infra-only deployments, which install no
+	// Argo CD Applications.
+	Applications []argocd.ApplicationStatus `json:"applications"`
+}
+
+// NodeGroupStatus counts the ready nodes of one node group, matched by
+// cluster.NodePoolLabel. Nodes without the label are reported under an
+// empty name.
+type NodeGroupStatus struct {
+	Name  string `json:"name"`
+	Ready int    `json:"ready"`
+	Total int    `json:"total"`
+}
+
+// Healthy reports whether every node group has all of its nodes ready and
+// every Application is Healthy and Synced. A configured node group without
+// nodes counts as healthy, since it may be scaled to zero.
+func (s *ClusterStatus) Healthy() bool {
+	for _, ng := range s.NodeGroups {
+		if ng.Ready != ng.Total {
+			return false
+		}
+	}
+	for _, app := range s.Applications {
+		if !app.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// Status reads the live state of the cluster described by cfg: its
+// Kubernetes version, the readiness of each node group's nodes and the sync
+// and health status of the Argo CD Applications NIC manages. Nothing is
+// changed and nothing is waited for.
+func (c *Client) Status(ctx context.Context, cfg *config.NebariConfig) (*ClusterStatus, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.Status")
+	defer span.End()
+
+	reg := c.registry
+
+	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
+	}
+
+	providerName := cfg.Cluster.ProviderName()
+	clusterProvider, err := reg.ClusterProviders.Get(ctx, providerName)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("get cluster provider: %w", err)
+	}
+
+	kubeconfigBytes, err := c.clusterKubeconfig(ctx, cfg, clusterProvider)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigBytes)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("parse kubeconfig: %w", err)
+	}
+	k8sClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("create k8s client: %w", err)
+	}
+
+	st, err := nodeStatus(ctx, k8sClient, configuredNodeGroups(cfg))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	st.Provider = providerName
+
+	if !cfg.InfraOnly {
+		st.Applications, err = argocd.FoundationalApplicationStatuses(ctx, kubeconfigBytes)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("get Argo CD Application status: %w", err)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("kubernetes_version", st.KubernetesVersion),
+		attribute.Bool("healthy", st.Healthy()),
+	)
+	return st, nil
+}
+
+// configuredNodeGroups returns the sorted node_groups keys of the configured
+// cluster provider. Every provider declares its node groups under
+// node_groups.
+func configuredNodeGroups(cfg *config.NebariConfig) []string {
+	groups, _ := cfg.Cluster.ProviderConfig()["node_groups"].(map[string]any)
+	return slices.Sorted(maps.Keys(groups))
+}
+
+// nodeStatus returns the API server version and the node readiness of each
+// node group: the configured ones, even without nodes, followed by any other
+// node group label values found on the cluster's nodes.
+func nodeStatus(ctx context.Context, client kubernetes.Interface, nodeGroups []string) (*ClusterStatus, error) {
+	version, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("get Kubernetes version: %w", err)
+	}
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	counts := make(map[string]*NodeGroupStatus, len(nodeGroups))
+	for _, name := range nodeGroups {
+		counts[name] = &NodeGroupStatus{Name: name}
+	}
+	for _, node := range nodes.Items {
+		name := node.Labels[cluster.NodePoolLabel]
+		ng, ok := counts[name]
+		if !ok {
+			ng = &NodeGroupStatus{Name: name}
+			counts[name] = ng
+		}
+		ng.Total++
+		if nodeReady(node) {
+			ng.Ready++
+		}
+	}
+
+	st := &ClusterStatus{KubernetesVersion: version.GitVersion}
+	for _, name := range nodeGroups {
+		st.NodeGroups = append(st.NodeGroups, *counts[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		if !slices.Contains(nodeGroups, name) {
+			st.NodeGroups = append(st.NodeGroups, *counts[name])
+		}
+	}
+	return st, nil
+}
+
+// nodeReady reports whether node's Ready condition is True.
+func nodeReady(node corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
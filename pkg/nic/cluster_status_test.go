@@ -0,0 +1,92 @@
+package nic
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func statusNode(name, nodeGroup string, ready corev1.ConditionStatus) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+	if nodeGroup != "" {
+		node.Labels = map[string]string{cluster.NodePoolLabel: nodeGroup}
+	}
+	return node
+}
+
+func TestNodeStatus(t *testing.T) {
+	client := k8sfake.NewSimpleClientset(
+		statusNode("general-1", "general", corev1.ConditionTrue),
+		statusNode("general-2", "general", corev1.ConditionFalse),
+		statusNode("user-1", "user", corev1.ConditionTrue),
+		statusNode("other-1", "", corev1.ConditionTrue),
+		statusNode("extra-1", "extra", corev1.ConditionUnknown),
+	)
+	client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.31.2"}
+
+	got, err := nodeStatus(context.Background(), client, []string{"general", "user", "worker"})
+	if err != nil {
+		t.Fatalf("nodeStatus() error = %v", err)
+	}
+	if got.KubernetesVersion != "v1.31.2" {
+		t.Errorf("KubernetesVersion = %q, want v1.31.2", got.KubernetesVersion)
+	}
+	want := []NodeGroupStatus{
+		{Name: "general", Ready: 1, Total: 2},
+		{Name: "user", Ready: 1, Total: 1},
+		{Name: "worker"},
+		{Name: "", Ready: 1, Total: 1},
+		{Name: "extra", Total: 1},
+	}
+	if !reflect.DeepEqual(got.NodeGroups, want) {
+		t.Errorf("NodeGroups = %+v, want %+v", got.NodeGroups, want)
+	}
+}
+
+func TestClusterStatusHealthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ClusterStatus
+		want   bool
+	}{
+		{
+			name: "all ready",
+			status: ClusterStatus{
+				NodeGroups:   []NodeGroupStatus{{Name: "general", Ready: 2, Total: 2}, {Name: "gpu"}},
+				Applications: []argocd.ApplicationStatus{{Name: "keycloak", Health: "Healthy", Sync: "Synced"}},
+			},
+			want: true,
+		},
+		{
+			name:   "node not ready",
+			status: ClusterStatus{NodeGroups: []NodeGroupStatus{{Name: "general", Ready: 1, Total: 2}}},
+		},
+		{
+			name: "application out of sync",
+			status: ClusterStatus{
+				Applications: []argocd.ApplicationStatus{{Name: "keycloak", Health: "Healthy", Sync: "OutOfSync"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Healthy(); got != tt.want {
+				t.Errorf("Healthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package nic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// ErrClusterIDMismatch is returned by Deploy when the cluster the provider
+// finds under the project name carries a different cluster ID than the
+// deploy state, i.e. it belongs to another deployment sharing the name.
+var ErrClusterIDMismatch = errors.New("cluster ID mismatch")
+
+// liveClusterIDReader is an optional capability: providers that tag their
+// resources with the cluster ID implement it so an existing cluster's ID can
+// be adopted, and a cluster of another deployment recognised.
+type liveClusterIDReader interface {
+	// LiveClusterID returns the cluster ID tagged on the cluster named
+	// projectName, or "" when the cluster does not exist or is untagged.
+	LiveClusterID(ctx context.Context, projectName string, clusterConfig *config.ClusterConfig) (string, error)
+}
+
+// clusterIDPath returns the local cluster ID file location for projectName.
+func clusterIDPath(projectName string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".nic", "clusters", projectName+".id"), nil
+}
+
+// newClusterID returns a random (version 4) UUID read from r.
+func newClusterID(r io.Reader) (string, error) {
+	var b [16]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return "", fmt.Errorf("generate cluster ID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// ensureClusterID returns the deployment's cluster ID, assigning one on the
+// first deploy. A stored ID is checked against the live cluster, when live
+// can read it; without a stored ID the live cluster's ID is adopted (e.g. a
+// deploy from a machine with a fresh local state), and only a cluster that
+// has none gets a new ID from rand.
+func ensureClusterID(ctx context.Context, state stateBackend, live func(context.Context) (string, error), rand io.Reader) (string, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.ensureClusterID")
+	defer span.End()
+
+	stored, err := state.readClusterID(ctx)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		span.RecordError(err)
+		return "", fmt.Errorf("read cluster ID: %w", err)
+	}
+
+	var liveID string
+	if live != nil {
+		liveID, err = live(ctx)
+		if err != nil {
+			span.RecordError(err)
+			return "", fmt.Errorf("read cluster ID of the live cluster: %w", err)
+		}
+	}
+
+	if stored != "" {
+		if liveID != "" && liveID != stored {
+			err := fmt.Errorf("%w: the existing cluster has ID %s but this deployment's state has %s; it belongs to another deployment with the same project name", ErrClusterIDMismatch, liveID, stored)
+			span.RecordError(err)
+			return "", err
+		}
+		span.SetAttributes(attribute.String("cluster_id", stored))
+		return stored, nil
+	}
+
+	id := liveID
+	if id == "" {
+		if id, err = newClusterID(rand); err != nil {
+			span.RecordError(err)
+			return "", err
+		}
+	}
+	id, err = state.writeClusterID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("store cluster ID: %w", err)
+	}
+	span.SetAttributes(attribute.String("cluster_id", id))
+
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Assigned cluster ID %s", id)).
+		WithResource("cluster-id").
+		WithAction("assigned").
+		WithMetadata("cluster_id", id))
+	return id, nil
+}
+
+// storedClusterID returns the cluster ID recorded in state, or "" when none
+// has been assigned or the state cannot be read. Dry runs and destroys use it
+// so their plans match what was deployed without assigning an ID.
+func storedClusterID(ctx context.Context, state stateBackend) string {
+	id, err := state.readClusterID(ctx)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// liveClusterIDFunc returns a reader for the live cluster's ID when
+// clusterProvider supports it, or nil.
+func liveClusterIDFunc(clusterProvider cluster.Provider, cfg *config.NebariConfig) func(context.Context) (string, error) {
+	reader, ok := clusterProvider.(liveClusterIDReader)
+	if !ok {
+		return nil
+	}
+	return func(ctx context.Context) (string, error) {
+		return reader.LiveClusterID(ctx, cfg.ResourceName(), cfg.Cluster)
+	}
+}
@@ -0,0 +1,106 @@
+package nic
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewClusterID(t *testing.T) {
+	id, err := newClusterID(bytes.NewReader(make([]byte, 16)))
+	if err != nil {
+		t.Fatalf("newClusterID() error = %v", err)
+	}
+	if want := "00000000-0000-4000-8000-000000000000"; id != want {
+		t.Errorf("newClusterID() = %q, want %q", id, want)
+	}
+	if _, err := newClusterID(bytes.NewReader(nil)); err == nil {
+		t.Error("newClusterID() with an empty reader succeeded")
+	}
+}
+
+func TestEnsureClusterID(t *testing.T) {
+	liveID := func(id string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) { return id, nil }
+	}
+
+	backends := map[string]func(t *testing.T) stateBackend{
+		"local":  func(t *testing.T) stateBackend { return localState(t, checkpointTestConfig()) },
+		"object": func(t *testing.T) stateBackend { return &objectState{objects: &objectstore.Fake{}, prefix: "p"} },
+	}
+
+	for name, newState := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("assigned once", func(t *testing.T) {
+				state := newState(t)
+				first, err := ensureClusterID(ctx, state, nil, rand.Reader)
+				if err != nil {
+					t.Fatalf("ensureClusterID() error = %v", err)
+				}
+				if !uuidPattern.MatchString(first) {
+					t.Errorf("ensureClusterID() = %q, want a v4 UUID", first)
+				}
+				second, err := ensureClusterID(ctx, state, liveID(first), rand.Reader)
+				if err != nil {
+					t.Fatalf("second ensureClusterID() error = %v", err)
+				}
+				if second != first {
+					t.Errorf("second ensureClusterID() = %q, want %q", second, first)
+				}
+				if got := storedClusterID(ctx, state); got != first {
+					t.Errorf("storedClusterID() = %q, want %q", got, first)
+				}
+			})
+
+			t.Run("adopts live ID", func(t *testing.T) {
+				state := newState(t)
+				const live = "0b7e4c1e-8f7a-4c55-9b1d-2d6f3c9a1e42"
+				got, err := ensureClusterID(ctx, state, liveID(live), rand.Reader)
+				if err != nil {
+					t.Fatalf("ensureClusterID() error = %v", err)
+				}
+				if got != live || storedClusterID(ctx, state) != live {
+					t.Errorf("ensureClusterID() = %q, stored %q, want %q", got, storedClusterID(ctx, state), live)
+				}
+			})
+
+			t.Run("mismatch", func(t *testing.T) {
+				state := newState(t)
+				if _, err := ensureClusterID(ctx, state, nil, rand.Reader); err != nil {
+					t.Fatalf("ensureClusterID() error = %v", err)
+				}
+				_, err := ensureClusterID(ctx, state, liveID("0b7e4c1e-8f7a-4c55-9b1d-2d6f3c9a1e42"), rand.Reader)
+				if !errors.Is(err, ErrClusterIDMismatch) {
+					t.Errorf("ensureClusterID() error = %v, want ErrClusterIDMismatch", err)
+				}
+			})
+		})
+	}
+}
+
+func TestObjectStateWriteClusterIDKeepsExisting(t *testing.T) {
+	ctx := context.Background()
+	store := &objectstore.Fake{}
+	first := &objectState{objects: store, prefix: "p"}
+	if _, err := first.writeClusterID(ctx, "first"); err != nil {
+		t.Fatalf("writeClusterID() error = %v", err)
+	}
+
+	// A concurrent first deploy loses the race and gets the stored ID back.
+	got, err := (&objectState{objects: store, prefix: "p"}).writeClusterID(ctx, "second")
+	if err != nil {
+		t.Fatalf("writeClusterID() error = %v", err)
+	}
+	if got != "first" {
+		t.Errorf("writeClusterID() = %q, want %q", got, "first")
+	}
+}
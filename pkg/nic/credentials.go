@@ -0,0 +1,26 @@
+package nic
+
+import (
+	"context"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// credentialChecker is an optional capability: providers that call a cloud
+// API implement it so deploy and destroy can fail up front, with a message
+// saying how to configure credentials, when none are available.
+type credentialChecker interface {
+	// CheckCredentials returns an error wrapping cluster.ErrCredentials when
+	// no usable credentials are found.
+	CheckCredentials(ctx context.Context, clusterConfig *config.ClusterConfig) error
+}
+
+// checkCredentials runs clusterProvider's credential check, if it has one.
+func checkCredentials(ctx context.Context, clusterProvider cluster.Provider, cfg *config.NebariConfig) error {
+	checker, ok := clusterProvider.(credentialChecker)
+	if !ok {
+		return nil
+	}
+	return checker.CheckCredentials(ctx, cfg.Cluster)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/apitiming"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/endpoint"
@@ -23,6 +25,7 @@ import (
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/steptiming"
 )
 
 // DeployOptions configures a Deploy call.
@@ -37,6 +40,38 @@ type DeployOptions struct {
 	// RegenApps forces regeneration of ArgoCD application manifests even
 	// when the GitOps repository is already bootstrapped.
 	RegenApps bool
+
+	// Resume skips stages that a previous, failed deploy of the same config
+	// already completed (see Stage). Completed infrastructure is only
+	// skipped after confirming the cluster is still reachable.
+	Resume bool
+
+	// FailOnChanges, with DryRun, makes Deploy return an error wrapping
+	// cluster.ErrChangesPending when the infrastructure plan is not empty.
+	FailOnChanges bool
+
+	// InfraOnly deploys only the cloud cluster, node groups and networking,
+	// skipping GitOps, Argo CD, foundational services and DNS. Equivalent to
+	// infra_only in the config.
+	InfraOnly bool
+
+	// Strict turns preflight warnings into errors. Currently this covers
+	// resources that conflict with Envoy Gateway (other GatewayClasses,
+	// LoadBalancer Services on ports 80/443), nodes without enough free CPU
+	// or memory for the foundational services, and Let's Encrypt certificate
+	// names without a DNS record when no DNS provider is configured.
+	Strict bool
+
+	// RecreateFailedNodeGroups deletes node groups stuck in a failed state
+	// (such as CREATE_FAILED) before applying infrastructure, so they are
+	// created again. Providers that cannot detect failed groups ignore it.
+	RecreateFailedNodeGroups bool
+
+	// NoWait applies Argo CD and the foundational Applications without
+	// waiting for anything to become ready: the cluster nodes, the Argo CD
+	// release and deployments, or the gateway load balancer, whose address
+	// is read once and DNS provisioned only if it is already assigned.
+	NoWait bool
 }
 
 // DeployResult contains useful information from the deploy process that
@@ -54,6 +89,10 @@ type DeployResult struct {
 	// lookup succeeded. Nil when no domain is configured, during dry-run,
 	// or when the endpoint was not ready in time.
 	LBEndpoint *endpoint.LoadBalancerEndpoint
+
+	// InfraOnly is true when the deploy stopped after the infrastructure
+	// stage (see DeployOptions.InfraOnly and config infra_only).
+	InfraOnly bool
 }
 
 // Deploy creates or updates Nebari infrastructure and installs foundational
@@ -65,7 +104,10 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 	ctx, span := tracer.Start(ctx, "nic.Deploy")
 	defer span.End()
 
-	span.SetAttributes(attribute.Bool("dry_run", opts.DryRun))
+	span.SetAttributes(
+		attribute.Bool("dry_run", opts.DryRun),
+		attribute.Bool("resume", opts.Resume),
+	)
 
 	if opts.DryRun {
 		status.Info(ctx, "Starting deployment (dry-run)")
@@ -75,6 +117,19 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 
 	reg := c.registry
 
+	// Time every cloud API call made during the deploy and report the
+	// slowest ones at the end, so a slow deploy can be attributed to
+	// provider throttling or to NIC itself.
+	apiTimings := apitiming.NewRecorder()
+	ctx = apitiming.WithRecorder(ctx, apiTimings)
+	defer reportAPITimings(ctx, apiTimings)
+
+	// Likewise time each deploy step, including every resource OpenTofu
+	// applies, and report the slowest.
+	stepTimings := steptiming.NewRecorder()
+	ctx = steptiming.WithRecorder(ctx, stepTimings)
+	defer reportStepTimings(ctx, stepTimings)
+
 	// Handle context cancellation (from signal interrupt)
 	defer func() {
 		if ctx.Err() == context.Canceled {
@@ -82,25 +137,42 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 		}
 	}()
 
+	// DeployOptions.InfraOnly is equivalent to infra_only in the config. Fold
+	// it into a copy, never cfg itself, so validation and every later stage
+	// see a single setting.
+	if opts.InfraOnly && !cfg.InfraOnly {
+		infraCfg := *cfg
+		infraCfg.InfraOnly = true
+		cfg = &infraCfg
+	}
+	span.SetAttributes(attribute.Bool("infra_only", cfg.InfraOnly))
+
 	// Validate configuration with registered providers
 	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
+	}
+	if err := runRules(ctx, reg, cfg); err != nil {
+		span.RecordError(err)
+		return nil, err
 	}
 
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Configuration parsed successfully").
 		WithResource("config").
 		WithAction("validated").
 		WithMetadata("provider", cfg.Cluster.ProviderName()).
-		WithMetadata("project_name", cfg.ProjectName))
+		WithMetadata("project_name", cfg.ProjectName).
+		WithMetadata("environment", cfg.Environment))
 
 	// For user-supplied certificates sourced from files/env, validate the
 	// material is readable and a valid keypair before provisioning anything.
 	// This turns a local config error into a fast failure instead of a silently
 	// broken gateway discovered after the cluster is up.
-	if err := argocd.PreflightGatewayTLS(cfg); err != nil {
-		span.RecordError(err)
-		return nil, fmt.Errorf("gateway TLS certificate: %w", err)
+	if !cfg.InfraOnly {
+		if err := argocd.PreflightGatewayTLS(cfg); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("gateway TLS certificate: %w", err)
+		}
 	}
 
 	if opts.Timeout > 0 {
@@ -122,6 +194,11 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Provider selected").
 		WithMetadata("provider", clusterProvider.Name()))
 
+	if err := checkCredentials(ctx, clusterProvider, cfg); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	// Get provider infrastructure settings up front. InfraSettings is a pure
 	// getter, so it is safe to compute before Deploy and lets us fail fast on
 	// misconfiguration before provisioning anything.
@@ -138,6 +215,11 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 		return nil, fmt.Errorf("validate backups configuration: %w", err)
 	}
 
+	if !cfg.InfraOnly && cfg.IsInternalGateway() && len(infraSettings.InternalLoadBalancerAnnotations) == 0 && len(cfg.Gateway.LoadBalancerAnnotations) == 0 {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Provider has no internal load balancer annotation; set gateway.load_balancer_annotations or the gateway may stay public").
+			WithMetadata("provider", cfg.Cluster.ProviderName()))
+	}
+
 	// Resolve the top-level trust bundle once, here at the orchestration layer.
 	// The raw PEM feeds trust-manager via the GitOps repo (threaded into
 	// bootstrapGitOps) and its base64 form feeds the cluster provider's OS trust
@@ -153,22 +235,86 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 		caBundle = base64.StdEncoding.EncodeToString([]byte(trustPEM))
 	}
 
+	// The external Keycloak database password is read before anything is
+	// provisioned, so a missing one fails the deploy before it touches the
+	// cluster.
+	var keycloakDBPassword string
+	if db := cfg.Keycloak.ExternalDatabase(); db != nil && !cfg.InfraOnly {
+		keycloakDBPassword, err = db.ResolvePassword()
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("%w: keycloak database: %w", config.ErrInvalidConfig, err)
+		}
+	}
+
+	// Checkpoints are only kept, the state lock only taken and a cluster ID
+	// only assigned for real deploys; a dry run uses the stored ID, if any.
+	// Failing to read the checkpoint is not fatal: the deploy simply runs
+	// every stage.
+	var cp *checkpoint
+	var clusterID string
+	if !opts.DryRun {
+		var unlock func()
+		unlock, clusterID, cp, err = c.prepareDeployState(ctx, cfg, liveClusterIDFunc(clusterProvider, cfg))
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		defer unlock()
+	} else if state, err := c.openState(ctx, cfg); err == nil {
+		clusterID = storedClusterID(ctx, state)
+	}
+	span.SetAttributes(attribute.String("cluster_id", clusterID))
+
 	// Deploy infrastructure
-	if err := clusterProvider.Deploy(ctx, cfg.ProjectName, cfg.Cluster, cluster.DeployOptions{
-		DryRun:       opts.DryRun,
-		Timeout:      opts.Timeout,
-		TrustBundle:  caBundle,
-		BackupBucket: backupBucketSpec(cfg),
-	}); err != nil {
-		span.RecordError(err)
-		status.Send(ctx, status.NewUpdate(status.LevelError, "Deployment failed").
-			WithMetadata("provider", clusterProvider.Name()).
-			WithMetadata("error", err.Error()))
-		return nil, fmt.Errorf("deploy infrastructure: %w", err)
+	verifyCluster := func(ctx context.Context) error {
+		_, err := clusterProvider.GetKubeconfig(ctx, cfg.ResourceName(), cfg.Cluster)
+		return err
 	}
+	if !shouldSkipStage(ctx, cp, StageInfrastructure, opts.Resume, verifyCluster) {
+		stepCtx, endStep := steptiming.Start(ctx, "infrastructure")
+		err := clusterProvider.Deploy(stepCtx, cfg.ResourceName(), cfg.Cluster, cluster.DeployOptions{
+			DryRun:        opts.DryRun,
+			Timeout:       opts.Timeout,
+			TrustBundle:   caBundle,
+			BackupBucket:  backupBucketSpec(cfg),
+			FailOnChanges: opts.FailOnChanges,
+			Environment:   cfg.Environment,
+			ClusterID:     clusterID,
+
+			RecreateFailedNodeGroups: opts.RecreateFailedNodeGroups,
+		})
+		endStep(err)
+		if err != nil {
+			if errors.Is(err, cluster.ErrChangesPending) {
+				status.Send(ctx, status.NewUpdate(status.LevelWarning, "Infrastructure changes pending").
+					WithMetadata("provider", clusterProvider.Name()))
+				return nil, fmt.Errorf("deploy infrastructure: %w", err)
+			}
+			span.RecordError(err)
+			status.Send(ctx, status.NewUpdate(status.LevelError, "Deployment failed").
+				WithMetadata("provider", clusterProvider.Name()).
+				WithMetadata("error", err.Error()))
+			return nil, fmt.Errorf("deploy infrastructure: %w", err)
+		}
 
-	status.Send(ctx, status.NewUpdate(status.LevelSuccess, "Infrastructure deployment completed").
-		WithMetadata("provider", clusterProvider.Name()))
+		status.Send(ctx, status.NewUpdate(status.LevelSuccess, "Infrastructure deployment completed").
+			WithMetadata("provider", clusterProvider.Name()))
+		recordStage(ctx, cp, StageInfrastructure)
+	}
+
+	if cfg.InfraOnly {
+		status.Info(ctx, "Skipping GitOps, Argo CD, foundational services and DNS (infra-only mode)")
+		status.Send(ctx, status.NewUpdate(status.LevelSuccess, "Deployment completed successfully").
+			WithMetadata("provider", clusterProvider.Name()))
+		if cp.done(StageInfrastructure) {
+			if err := cp.clear(ctx); err != nil {
+				status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not remove deploy checkpoint").
+					WithMetadata("error", err.Error()))
+			}
+		}
+		return &DeployResult{InfraOnly: true}, nil
+	}
 
 	// Resolve the effective GitOps configuration. This may auto-create a
 	// local directory for providers that support it, or fall back to the
@@ -181,13 +327,17 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 			WithMetadata("error", err.Error()))
 		return nil, fmt.Errorf("resolve gitops configuration: %w", err)
 	}
-	if gitConfig != nil && !opts.DryRun {
-		if err := c.bootstrapGitOps(ctx, cfg, gitConfig, opts.RegenApps, infraSettings, trustPEM); err != nil {
+	if gitConfig != nil && !opts.DryRun && !shouldSkipStage(ctx, cp, StageGitOps, opts.Resume && !opts.RegenApps, nil) {
+		stepCtx, endStep := steptiming.Start(ctx, "gitops")
+		err := c.bootstrapGitOps(stepCtx, cfg, gitConfig, opts.RegenApps, infraSettings, trustPEM)
+		endStep(err)
+		if err != nil {
 			span.RecordError(err)
 			status.Send(ctx, status.NewUpdate(status.LevelError, "GitOps bootstrap failed").
 				WithMetadata("error", err.Error()))
 			return nil, fmt.Errorf("bootstrap gitops: %w", err)
 		}
+		recordStage(ctx, cp, StageGitOps)
 	}
 
 	status.Send(ctx, status.NewUpdate(status.LevelSuccess, "Deployment completed successfully").
@@ -196,7 +346,29 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 	result := &DeployResult{}
 
 	// Install Argo CD (skip in dry-run mode)
-	if !opts.DryRun {
+	if !opts.DryRun && shouldSkipStage(ctx, cp, StageFoundational, opts.Resume, nil) {
+		result.ArgoCDInstalled = true
+		result.KeycloakInstalled = true
+	} else if !opts.DryRun {
+		if err := c.preflightGateway(ctx, cfg, clusterProvider, opts.Strict); err != nil {
+			span.RecordError(err)
+			status.Send(ctx, status.NewUpdate(status.LevelError, "Gateway preflight failed").
+				WithMetadata("error", err.Error()))
+			return nil, err
+		}
+		if err := c.preflightCapacity(ctx, cfg, clusterProvider, infraSettings, trustPEM != "", opts.Strict); err != nil {
+			span.RecordError(err)
+			status.Send(ctx, status.NewUpdate(status.LevelError, "Capacity preflight failed").
+				WithMetadata("error", err.Error()))
+			return nil, err
+		}
+		if err := c.preflightHTTP01(ctx, cfg, infraSettings, opts.Strict); err != nil {
+			span.RecordError(err)
+			status.Send(ctx, status.NewUpdate(status.LevelError, "Certificate preflight failed").
+				WithMetadata("error", err.Error()))
+			return nil, err
+		}
+
 		status.Progress(ctx, "Installing Argo CD on cluster")
 
 		// Generate OIDC client secret upfront - needed by both ArgoCD Helm values
@@ -211,8 +383,12 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 
 		// Build ArgoCD config with Keycloak OIDC SSO
 		argoCDConfig := argocd.ConfigWithOIDC(cfg.Domain, infraSettings.KeycloakBasePath, argoCDClientSecret)
+		argoCDConfig.NoWait = opts.NoWait
 
-		if err := argocd.Install(ctx, cfg, clusterProvider, gitConfig, trustPEM, argoCDConfig); err != nil {
+		stepCtx, endStep := steptiming.Start(ctx, "argocd")
+		err = argocd.Install(stepCtx, cfg, clusterProvider, gitConfig, trustPEM, argoCDConfig)
+		endStep(err)
+		if err != nil {
 			// Log error but don't fail deployment
 			status.Send(ctx, status.NewUpdate(status.LevelWarning, "Failed to install Argo CD").
 				WithMetadata("error", err.Error()))
@@ -246,6 +422,19 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 				}
 			}
 
+			// Generate the Grafana admin password only when the monitoring
+			// stack is enabled; an empty password skips its secret.
+			var grafanaAdminPassword string
+			if cfg.Monitoring.IsEnabled() {
+				grafanaAdminPassword, err = generateSecurePassword(rand.Reader)
+				if err != nil {
+					span.RecordError(err)
+					status.Send(ctx, status.NewUpdate(status.LevelError, "Failed to generate Grafana admin password").
+						WithMetadata("error", err.Error()))
+					return nil, fmt.Errorf("generate Grafana admin password: %w", err)
+				}
+			}
+
 			foundationalCfg := argocd.FoundationalConfig{
 				Keycloak: argocd.KeycloakConfig{
 					Enabled:               true,
@@ -272,31 +461,87 @@ func (c *Client) Deploy(ctx context.Context, cfg *config.NebariConfig, opts Depl
 					Enabled:     infraSettings.NeedsMetalLB,
 					AddressPool: infraSettings.MetalLBAddressPool,
 				},
+				Monitoring: argocd.MonitoringConfig{
+					GrafanaAdminPassword: grafanaAdminPassword,
+				},
 				Backups:       cfg.Backups.LonghornConfig(),
 				BackupRoleARN: resolveBackupRoleARN(ctx, cfg, clusterProvider),
+				DNS01APIToken: resolveDNS01APIToken(ctx, cfg, reg),
+			}
+			if keycloakDBPassword != "" {
+				foundationalCfg.Keycloak.DBPassword = keycloakDBPassword
+				foundationalCfg.Keycloak.ExternalDatabase = true
 			}
 
-			if err := argocd.InstallFoundationalServices(ctx, cfg, clusterProvider, gitConfig, foundationalCfg); err != nil {
+			stepCtx, endStep := steptiming.Start(ctx, "foundational_services")
+			err = argocd.InstallFoundationalServices(stepCtx, cfg, clusterProvider, gitConfig, foundationalCfg)
+			endStep(err)
+			if err != nil {
 				// Log warning but don't fail deployment
 				status.Send(ctx, status.NewUpdate(status.LevelWarning, "Failed to install foundational services").
 					WithMetadata("error", err.Error()))
 			} else {
 				status.Success(ctx, "Foundational services installed successfully")
 				result.KeycloakInstalled = true
+				recordStage(ctx, cp, StageFoundational)
 			}
 		}
 	} else {
-		status.Info(ctx, "Would install Argo CD and foundational services (dry-run mode)")
+		planFoundationalServices(ctx, cfg, clusterProvider, gitConfig)
 	}
 
 	// Look up LB endpoint and provision DNS records if configured
 	if cfg.Domain != "" && !opts.DryRun {
-		result.LBEndpoint = c.lookupEndpointAndProvisionDNS(ctx, cfg, clusterProvider, reg)
+		stepCtx, endStep := steptiming.Start(ctx, "dns")
+		result.LBEndpoint = c.lookupEndpointAndProvisionDNS(stepCtx, cfg, clusterProvider, reg, opts.NoWait)
+		endStep(nil)
+	}
+
+	// Every stage completed, so there is nothing left to resume.
+	if cp.done(StageFoundational) {
+		if err := cp.clear(ctx); err != nil {
+			status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not remove deploy checkpoint").
+				WithMetadata("error", err.Error()))
+		}
 	}
 
 	return result, nil
 }
 
+// shouldSkipStage reports whether stage can be skipped on a resumed deploy:
+// resume is requested, the checkpoint records the stage as completed, and
+// verify (when non-nil) confirms its resources still exist. A failed
+// verification re-runs the stage.
+func shouldSkipStage(ctx context.Context, cp *checkpoint, stage Stage, resume bool, verify func(context.Context) error) bool {
+	if !resume || !cp.done(stage) {
+		return false
+	}
+	if verify != nil {
+		if err := verify(ctx); err != nil {
+			status.Send(ctx, status.NewUpdate(status.LevelWarning, "Checkpointed stage could not be verified, re-running it").
+				WithMetadata("stage", string(stage)).
+				WithMetadata("error", err.Error()))
+			return false
+		}
+	}
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Skipping stage completed by a previous deploy (--resume)").
+		WithMetadata("stage", string(stage)))
+	return true
+}
+
+// recordStage checkpoints a completed stage. Checkpointing is best effort; a
+// write failure only costs the ability to resume past this stage.
+func recordStage(ctx context.Context, cp *checkpoint, stage Stage) {
+	if cp == nil {
+		return
+	}
+	if err := cp.markComplete(ctx, stage); err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not record deploy checkpoint").
+			WithMetadata("stage", string(stage)).
+			WithMetadata("error", err.Error()))
+	}
+}
+
 // defaultGitConfig returns a default local git configuration for development workflows.
 // This is a pure function with no side effects — directory creation happens separately.
 func defaultGitConfig(projectName string) *git.Config {
@@ -325,7 +570,7 @@ func (c *Client) getOrCreateGitConfig(ctx context.Context, cfg *config.NebariCon
 		return nil, nil
 	}
 
-	gitCfg := defaultGitConfig(cfg.ProjectName)
+	gitCfg := defaultGitConfig(cfg.ResourceName())
 	localPath, err := gitCfg.GetLocalPath()
 	if err != nil {
 		return nil, fmt.Errorf("invalid local path in auto-generated git config: %w", err)
@@ -341,11 +586,60 @@ func (c *Client) getOrCreateGitConfig(ctx context.Context, cfg *config.NebariCon
 	return gitCfg, nil
 }
 
+// slowestAPIOperations is how many operations reportAPITimings lists.
+const slowestAPIOperations = 5
+
+// reportAPITimings sends one status update per slowest cloud API operation
+// recorded during the run. Nothing is sent when no API calls were recorded.
+func reportAPITimings(ctx context.Context, r *apitiming.Recorder) {
+	slowest := r.Slowest(slowestAPIOperations)
+	if len(slowest) == 0 {
+		return
+	}
+	status.Info(ctx, "Slowest cloud API operations")
+	for _, op := range slowest {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("%s: %s total over %d call(s)", op.Name(), op.Total.Round(time.Millisecond), op.Calls)).
+			WithResource("cloud_api").
+			WithAction("timing").
+			WithMetadata("operation", op.Name()).
+			WithMetadata("calls", op.Calls).
+			WithMetadata("errors", op.Errors).
+			WithMetadata("total", op.Total.String()).
+			WithMetadata("max", op.Max.String()))
+	}
+}
+
+// slowestSteps is how many steps reportStepTimings lists.
+const slowestSteps = 10
+
+// reportStepTimings sends a "slowest steps" summary: one status update per
+// step, slowest first. Nothing is sent when no steps were recorded.
+func reportStepTimings(ctx context.Context, r *steptiming.Recorder) {
+	slowest := r.Slowest(slowestSteps)
+	if len(slowest) == 0 {
+		return
+	}
+	status.Info(ctx, "Slowest deploy steps")
+	for i, step := range slowest {
+		msg := fmt.Sprintf("%2d. %-12s %s", i+1, step.Duration.Round(time.Millisecond), step.Name)
+		if step.Failed {
+			msg += " (failed)"
+		}
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, msg).
+			WithResource("deploy_step").
+			WithAction("timing").
+			WithMetadata("step", step.Name).
+			WithMetadata("duration", step.Duration.String()).
+			WithMetadata("failed", step.Failed))
+	}
+}
+
 // lookupEndpointAndProvisionDNS gets the load balancer endpoint from the cluster
-// and provisions DNS records if a DNS provider is configured. Returns the LB
-// endpoint for use in manual DNS guidance (may be nil if lookup failed).
-func (c *Client) lookupEndpointAndProvisionDNS(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, reg *registry.Registry) *endpoint.LoadBalancerEndpoint {
-	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ProjectName, cfg.Cluster)
+// and provisions DNS records if a DNS provider is configured. With noWait the
+// endpoint is read once instead of waited for. Returns the LB endpoint for use
+// in manual DNS guidance (may be nil if lookup failed).
+func (c *Client) lookupEndpointAndProvisionDNS(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, reg *registry.Registry, noWait bool) *endpoint.LoadBalancerEndpoint {
+	kubeconfigBytes, err := c.clusterKubeconfig(ctx, cfg, clusterProvider)
 	if err != nil {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not get kubeconfig for endpoint lookup").
 			WithMetadata("error", err.Error()))
@@ -366,16 +660,27 @@ func (c *Client) lookupEndpointAndProvisionDNS(ctx context.Context, cfg *config.
 		return nil
 	}
 
-	status.Progress(ctx, "Waiting for load balancer endpoint...")
-	lbEndpoint, err := endpoint.GetLoadBalancerEndpoint(ctx, k8sClient)
+	lookup := endpoint.GetLoadBalancerEndpoint
+	if noWait {
+		lookup = endpoint.LookupLoadBalancerEndpoint
+	} else {
+		status.Progress(ctx, "Waiting for load balancer endpoint...")
+	}
+	lbEndpoint, err := lookup(ctx, k8sClient)
 	if err != nil {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not retrieve load balancer endpoint").
 			WithMetadata("error", err.Error()))
 		return nil
 	}
 
-	// Provision DNS records if a provider is configured
-	if cfg.DNS == nil {
+	// Provision DNS records if a provider is configured. An internal gateway
+	// only gets records in its private DNS zone, never in the public one.
+	dnsCfg := cfg.RecordsDNS()
+	if cfg.IsInternalGateway() && cfg.DNS != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Skipping public DNS provisioning for internal gateway").
+			WithMetadata("provider", cfg.DNS.ProviderName()))
+	}
+	if dnsCfg == nil {
 		return lbEndpoint
 	}
 
@@ -393,10 +698,10 @@ func (c *Client) lookupEndpointAndProvisionDNS(ctx context.Context, cfg *config.
 		return lbEndpoint
 	}
 
-	dnsProvider, err := reg.DNSProviders.Get(ctx, cfg.DNS.ProviderName())
+	dnsProvider, err := reg.DNSProviders.Get(ctx, dnsCfg.ProviderName())
 	if err != nil {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning, "DNS provider not found, skipping DNS provisioning").
-			WithMetadata("provider", cfg.DNS.ProviderName()).
+			WithMetadata("provider", dnsCfg.ProviderName()).
 			WithMetadata("error", err.Error()))
 		return lbEndpoint
 	}
@@ -404,9 +709,9 @@ func (c *Client) lookupEndpointAndProvisionDNS(ctx context.Context, cfg *config.
 	status.Send(ctx, status.NewUpdate(status.LevelProgress, "Provisioning DNS records").
 		WithResource("dns").
 		WithAction("provisioning").
-		WithMetadata("provider", cfg.DNS.ProviderName()).
+		WithMetadata("provider", dnsCfg.ProviderName()).
 		WithMetadata("domain", cfg.Domain))
-	if err := dnsProvider.ProvisionRecords(ctx, cfg.Domain, cfg.DNS.ProviderConfig(), lbEndpointStr); err != nil {
+	if err := dnsProvider.ProvisionRecords(ctx, cfg.Domain, dnsCfg.ProviderConfig(), lbEndpointStr); err != nil {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Failed to provision DNS records").
 			WithMetadata("error", err.Error()))
 		status.Warning(ctx, "You can configure DNS manually - see instructions below")
@@ -608,7 +913,7 @@ func resolveBackupRoleARN(ctx context.Context, cfg *config.NebariConfig, cluster
 	if !ok {
 		return ""
 	}
-	arn, err := resolver.BackupPodIdentityRoleARN(ctx, cfg.ProjectName, cfg.Cluster)
+	arn, err := resolver.BackupPodIdentityRoleARN(ctx, cfg.ResourceName(), cfg.Cluster)
 	if err != nil {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not resolve Longhorn backup IAM role; keyless backups will not authenticate").
 			WithMetadata("error", err.Error()))
@@ -620,6 +925,43 @@ func resolveBackupRoleARN(ctx context.Context, cfg *config.NebariConfig, cluster
 	return arn
 }
 
+// dns01TokenProvider is an optional capability: DNS providers that
+// cert-manager can solve ACME DNS-01 challenges with implement it to hand
+// over the API token the solver authenticates with.
+type dns01TokenProvider interface {
+	DNS01APIToken() (string, error)
+}
+
+// resolveDNS01APIToken returns the DNS provider API token for a Let's
+// Encrypt issuer solved over DNS-01, or "" when certificates use another
+// solver. A missing token is surfaced as a warning: the gateway certificate
+// then stays pending until the token is supplied and nic deploy re-run.
+func resolveDNS01APIToken(ctx context.Context, cfg *config.NebariConfig, reg *registry.Registry) string {
+	if argocd.ACMEDNS01Solver(cfg) == "" {
+		return ""
+	}
+	name := cfg.ACMEDNS().ProviderName()
+	provider, err := reg.DNSProviders.Get(ctx, name)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "DNS provider not found; Let's Encrypt DNS-01 challenges will fail").
+			WithMetadata("provider", name).
+			WithMetadata("error", err.Error()))
+		return ""
+	}
+	tokens, ok := provider.(dns01TokenProvider)
+	if !ok {
+		return ""
+	}
+	token, err := tokens.DNS01APIToken()
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not read the DNS provider API token; Let's Encrypt DNS-01 challenges will fail").
+			WithMetadata("provider", name).
+			WithMetadata("error", err.Error()))
+		return ""
+	}
+	return token
+}
+
 // backupBucketSpec derives the provider bucket-provisioning request from config.
 // Returns nil unless the module has work to do: creating a cloud-native
 // bucket/container (create_bucket/create_container set and no external endpoint)
@@ -700,3 +1042,25 @@ func generateFoundationalSecrets(r io.Reader) (foundationalSecrets, error) {
 	}
 	return s, nil
 }
+
+// planFoundationalServices reports, in dry-run mode, which foundational
+// manifests a deploy would create or change. A cluster that cannot be reached
+// yet (the usual first dry run) is reported as a plain "would install".
+func planFoundationalServices(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, gitConfig *git.Config) {
+	changes, err := argocd.PlanFoundationalServices(ctx, cfg, clusterProvider, gitConfig)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Would install Argo CD and foundational services (dry-run mode)").
+			WithMetadata("reason", err.Error()))
+		return
+	}
+
+	var pending int
+	for _, change := range changes {
+		if change.Action != argocd.ChangeUnchanged {
+			pending++
+		}
+	}
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Foundational services: %d of %d manifests would change (dry-run mode)", pending, len(changes))).
+		WithResource("foundational").
+		WithAction("plan"))
+}
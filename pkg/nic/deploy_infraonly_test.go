@@ -0,0 +1,102 @@
+package nic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
+)
+
+// recordingDeployProvider is a cluster.Provider that records Deploy and
+// GetKubeconfig calls. Every post-infrastructure step (gateway preflight,
+// Argo CD, foundational services, DNS) starts by fetching the kubeconfig, so
+// kubeconfigCalls tells whether the platform layer was attempted. Methods
+// Deploy does not reach are left to the embedded nil interface.
+type recordingDeployProvider struct {
+	cluster.Provider
+
+	deployed        bool
+	kubeconfigCalls int
+}
+
+func (p *recordingDeployProvider) Name() string { return "aws" }
+
+func (p *recordingDeployProvider) InfraSettings(*config.ClusterConfig) cluster.InfraSettings {
+	return cluster.InfraSettings{}
+}
+
+func (p *recordingDeployProvider) Deploy(context.Context, string, *config.ClusterConfig, cluster.DeployOptions) error {
+	p.deployed = true
+	return nil
+}
+
+func (p *recordingDeployProvider) GetKubeconfig(context.Context, string, *config.ClusterConfig) ([]byte, error) {
+	p.kubeconfigCalls++
+	return nil, errors.New("no cluster in tests")
+}
+
+func TestDeploy_InfraOnly(t *testing.T) {
+	tests := []struct {
+		name          string
+		configFlag    bool
+		optionFlag    bool
+		wantInfraOnly bool
+	}{
+		{name: "full deploy attempts foundational install"},
+		{name: "infra_only in config", configFlag: true, wantInfraOnly: true},
+		{name: "InfraOnly option", optionFlag: true, wantInfraOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			ctx := context.Background()
+			provider := &recordingDeployProvider{}
+			reg := registry.NewRegistry()
+			if err := reg.ClusterProviders.Register(ctx, "aws", provider); err != nil {
+				t.Fatal(err)
+			}
+			client := &Client{registry: reg}
+
+			cfg := &config.NebariConfig{
+				ProjectName: "demo",
+				InfraOnly:   tt.configFlag,
+				Cluster:     &config.ClusterConfig{Providers: map[string]any{"aws": map[string]any{}}},
+				// Invalid for a full deploy; an infra-only deploy must not
+				// validate the platform-layer certificate block.
+				Certificate: &config.CertificateConfig{Type: "bogus"},
+			}
+			if !tt.wantInfraOnly {
+				cfg.Certificate = nil
+			}
+
+			result, err := client.Deploy(ctx, cfg, DeployOptions{InfraOnly: tt.optionFlag})
+			if err != nil {
+				t.Fatalf("Deploy() unexpected error: %v", err)
+			}
+			if !provider.deployed {
+				t.Error("infrastructure was not deployed")
+			}
+			if result.InfraOnly != tt.wantInfraOnly {
+				t.Errorf("DeployResult.InfraOnly = %v, want %v", result.InfraOnly, tt.wantInfraOnly)
+			}
+
+			if tt.wantInfraOnly {
+				if provider.kubeconfigCalls != 0 {
+					t.Errorf("infra-only deploy fetched the kubeconfig %d time(s); foundational install must be skipped", provider.kubeconfigCalls)
+				}
+				if result.ArgoCDInstalled || result.KeycloakInstalled {
+					t.Errorf("infra-only result reports platform installs: %+v", result)
+				}
+				if cfg.InfraOnly != tt.configFlag {
+					t.Error("Deploy mutated the caller's config")
+				}
+			} else if provider.kubeconfigCalls == 0 {
+				t.Error("full deploy never attempted the foundational install")
+			}
+		})
+	}
+}
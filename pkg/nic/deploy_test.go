@@ -2,13 +2,19 @@ package nic
 
 import (
 	"bytes"
+	"context"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/apitiming"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/git"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/steptiming"
 )
 
 func TestGenerateSecurePassword(t *testing.T) {
@@ -277,3 +283,74 @@ func TestScrubbedConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestReportAPITimings(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		updates []status.Update
+	)
+	ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+		if u.Resource == "cloud_api" {
+			mu.Lock()
+			updates = append(updates, u)
+			mu.Unlock()
+		}
+	})
+
+	recorder := apitiming.NewRecorder()
+	recCtx := apitiming.WithRecorder(ctx, recorder)
+	apitiming.Record(recCtx, "EC2", "DescribeVpcs", 20*time.Millisecond, nil)
+	apitiming.Record(recCtx, "EKS", "DescribeCluster", 2*time.Second, nil)
+	reportAPITimings(ctx, recorder)
+
+	// An empty recorder reports nothing.
+	reportAPITimings(ctx, apitiming.NewRecorder())
+	cleanup()
+
+	if len(updates) != 2 {
+		t.Fatalf("got %d cloud_api updates, want 2: %+v", len(updates), updates)
+	}
+	if updates[0].Metadata["operation"] != "EKS.DescribeCluster" || updates[0].Metadata["total"] != "2s" {
+		t.Errorf("first update = %+v, want EKS.DescribeCluster with total 2s", updates[0].Metadata)
+	}
+	if updates[1].Metadata["operation"] != "EC2.DescribeVpcs" {
+		t.Errorf("second update = %+v, want EC2.DescribeVpcs", updates[1].Metadata)
+	}
+}
+
+func TestReportStepTimings(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		updates []status.Update
+	)
+	ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+		if u.Resource == "deploy_step" {
+			mu.Lock()
+			updates = append(updates, u)
+			mu.Unlock()
+		}
+	})
+
+	recorder := steptiming.NewRecorder()
+	recCtx := steptiming.WithRecorder(ctx, recorder)
+	steptiming.Record(recCtx, "gitops", 3*time.Second, false)
+	steptiming.Record(recCtx, "infrastructure", 14*time.Minute, true)
+	reportStepTimings(ctx, recorder)
+
+	// An empty recorder reports nothing.
+	reportStepTimings(ctx, steptiming.NewRecorder())
+	cleanup()
+
+	if len(updates) != 2 {
+		t.Fatalf("got %d deploy_step updates, want 2: %+v", len(updates), updates)
+	}
+	if updates[0].Metadata["step"] != "infrastructure" || updates[0].Metadata["failed"] != true {
+		t.Errorf("first update = %+v, want the failed infrastructure step", updates[0].Metadata)
+	}
+	if !strings.HasSuffix(updates[0].Message, "infrastructure (failed)") {
+		t.Errorf("first message = %q, want it to flag the failure", updates[0].Message)
+	}
+	if updates[1].Metadata["step"] != "gitops" || updates[1].Metadata["duration"] != "3s" {
+		t.Errorf("second update = %+v, want gitops with duration 3s", updates[1].Metadata)
+	}
+}
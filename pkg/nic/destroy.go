@@ -6,17 +6,23 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
 )
 
+// ErrAborted is returned by Destroy when DestroyOptions.Confirm declines.
+var ErrAborted = errors.New("aborted by user")
+
 // DestroySummary describes the infrastructure a Destroy is about to tear
 // down. It is passed to DestroyOptions.Confirm so callers can render a
 // confirmation prompt without reaching into provider internals themselves.
@@ -27,6 +33,9 @@ type DestroySummary struct {
 	// ProjectName is the Nebari project name from the config.
 	ProjectName string
 
+	// Environment is the config's environment, empty when unset.
+	Environment string
+
 	// Details is the provider-specific key/value summary returned by
 	// Provider.Summary (region, cluster name, node group sizes, etc.).
 	Details map[string]string
@@ -47,10 +56,23 @@ type DestroyOptions struct {
 
 	// Confirm, when non-nil, is invoked after the provider has been resolved
 	// but before any destructive call. Returning a non-nil error aborts
-	// Destroy with that error, allowing callers to implement interactive
+	// Destroy with that error wrapped in ErrAborted, allowing callers to implement interactive
 	// confirmation prompts or policy checks. Skipped when DryRun is true.
 	// Leave nil for programmatic callers that do not need a prompt.
 	Confirm func(ctx context.Context, summary DestroySummary) error
+
+	// Environment must equal the config's environment when it is protected
+	// (see config.NebariConfig.IsProtectedEnvironment); otherwise Destroy
+	// returns an error wrapping ErrAborted before touching any resource.
+	// Ignored for unprotected environments and when DryRun is true.
+	Environment string
+
+	// ApplicationCascade, when set, deletes the foundational Argo CD
+	// Applications before the cluster is torn down, using one of
+	// argocd.CascadeModes. Cascading lets Argo CD remove the cloud resources
+	// its apps created (load balancers, volumes) so they do not outlive the
+	// cluster. Empty leaves the Applications to go with the cluster.
+	ApplicationCascade string
 }
 
 // Destroy tears down the cluster described by cfg and cleans up any DNS
@@ -58,10 +80,11 @@ type DestroyOptions struct {
 // prompt via opts.Confirm; Destroy assumes consent has already been
 // granted by the time it is invoked.
 //
-// When cfg.DNS is set, DNS records are cleaned up before the cluster is
-// torn down. DNS cleanup failures are logged but do not abort the destroy,
-// since orphaned DNS records are cheaper to fix manually than a
-// half-destroyed cluster. Provider errors abort the run unless Force is
+// When cfg.RecordsDNS() is set, DNS records are cleaned up before the cluster is
+// torn down, followed by the Argo CD Applications when ApplicationCascade is
+// set. DNS and Application cleanup failures are logged but do not abort the
+// destroy, since orphaned records and resources are cheaper to fix manually
+// than a half-destroyed cluster. Provider errors abort the run unless Force is
 // true, in which case they are logged and execution continues.
 func (c *Client) Destroy(ctx context.Context, cfg *config.NebariConfig, opts DestroyOptions) error {
 	tracer := otel.Tracer("nebari-infrastructure-core")
@@ -75,6 +98,15 @@ func (c *Client) Destroy(ctx context.Context, cfg *config.NebariConfig, opts Des
 	if opts.Timeout > 0 {
 		span.SetAttributes(attribute.String("timeout", opts.Timeout.String()))
 	}
+	if opts.ApplicationCascade != "" {
+		span.SetAttributes(attribute.String("application_cascade", opts.ApplicationCascade))
+		if !slices.Contains(argocd.CascadeModes, opts.ApplicationCascade) {
+			err := fmt.Errorf("invalid application cascade mode %q (must be one of: %s)",
+				opts.ApplicationCascade, strings.Join(argocd.CascadeModes, ", "))
+			span.RecordError(err)
+			return err
+		}
+	}
 
 	if opts.DryRun {
 		status.Info(ctx, "Starting destruction (dry-run)")
@@ -86,14 +118,21 @@ func (c *Client) Destroy(ctx context.Context, cfg *config.NebariConfig, opts Des
 
 	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
 	}
 
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Configuration validated").
 		WithResource("config").
 		WithAction("validated").
 		WithMetadata("provider", cfg.Cluster.ProviderName()).
-		WithMetadata("project_name", cfg.ProjectName))
+		WithMetadata("project_name", cfg.ProjectName).
+		WithMetadata("environment", cfg.Environment))
+
+	if cfg.IsProtectedEnvironment() && !opts.DryRun && opts.Environment != cfg.Environment {
+		err := fmt.Errorf("%w: destroying the %q environment requires confirming it by name", ErrAborted, cfg.Environment)
+		span.RecordError(err)
+		return err
+	}
 
 	clusterProvider, err := reg.ClusterProviders.Get(ctx, cfg.Cluster.ProviderName())
 	if err != nil {
@@ -104,26 +143,58 @@ func (c *Client) Destroy(ctx context.Context, cfg *config.NebariConfig, opts Des
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Provider selected").
 		WithMetadata("provider", clusterProvider.Name()))
 
+	if err := checkCredentials(ctx, clusterProvider, cfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	if opts.Confirm != nil && !opts.DryRun {
 		summary := DestroySummary{
 			Provider:    cfg.Cluster.ProviderName(),
 			ProjectName: cfg.ProjectName,
+			Environment: cfg.Environment,
 			Details:     clusterProvider.Summary(cfg.Cluster),
 		}
 		if err := opts.Confirm(ctx, summary); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("%w: %w", ErrAborted, err)
+		}
+	}
+
+	// Hold the state lock so a destroy cannot run alongside a deploy of the
+	// same project sharing a remote state backend.
+	var clusterID string
+	if !opts.DryRun {
+		state, err := c.openState(ctx, cfg)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		unlock, err := lockState(ctx, state, "destroy")
+		if err != nil {
 			span.RecordError(err)
 			return err
 		}
+		defer unlock()
+		clusterID = storedClusterID(ctx, state)
 	}
 
-	if cfg.DNS != nil {
-		if err := c.destroyDNS(ctx, cfg, reg, opts.DryRun); err != nil {
+	if dnsCfg := cfg.RecordsDNS(); dnsCfg != nil {
+		if err := c.destroyDNS(ctx, cfg.Domain, dnsCfg, reg, opts.DryRun); err != nil {
 			status.Send(ctx, status.NewUpdate(status.LevelWarning, "Failed to clean up DNS records").
 				WithMetadata("error", err.Error()))
 			status.Warning(ctx, "You may need to manually remove DNS records from your provider")
 		}
 	}
 
+	if opts.ApplicationCascade != "" && !cfg.InfraOnly {
+		if err := deleteApplications(ctx, cfg, clusterProvider, opts); err != nil {
+			status.Send(ctx, status.NewUpdate(status.LevelWarning, "Failed to delete Argo CD Applications").
+				WithMetadata("error", err.Error()))
+			status.Warning(ctx, "Cloud resources created by in-cluster apps (load balancers, volumes) may need manual cleanup")
+		}
+	}
+
 	// Re-resolve the bundle so the destroy plan matches what was deployed. The
 	// applied value already lives in TF state, so a source PEM that was deleted
 	// or moved after deploy must not block teardown: downgrade a missing file to
@@ -140,12 +211,14 @@ func (c *Client) Destroy(ctx context.Context, cfg *config.NebariConfig, opts Des
 		return fmt.Errorf("resolve trust_bundle: %w", err)
 	}
 
-	if err := clusterProvider.Destroy(ctx, cfg.ProjectName, cfg.Cluster, cluster.DestroyOptions{
+	if err := clusterProvider.Destroy(ctx, cfg.ResourceName(), cfg.Cluster, cluster.DestroyOptions{
 		DryRun:       opts.DryRun,
 		Force:        opts.Force,
 		Timeout:      opts.Timeout,
 		TrustBundle:  caBundle,
 		BackupBucket: backupBucketSpec(cfg),
+		Environment:  cfg.Environment,
+		ClusterID:    clusterID,
 	}); err != nil {
 		span.RecordError(err)
 		if opts.Force {
@@ -166,6 +239,38 @@ func (c *Client) Destroy(ctx context.Context, cfg *config.NebariConfig, opts Des
 	return nil
 }
 
+// deleteApplications deletes the foundational Argo CD Applications with the
+// configured cascade mode and waits for them to be gone, so cascaded cloud
+// resources are released before the cluster (and its network) is destroyed.
+// Like DNS cleanup, failures are reported to the caller to log rather than
+// abort the destroy.
+func deleteApplications(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, opts DestroyOptions) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.deleteApplications")
+	defer span.End()
+
+	if opts.DryRun {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Would delete Argo CD Applications").
+			WithResource("argocd-application").
+			WithMetadata("cascade", opts.ApplicationCascade))
+		return nil
+	}
+
+	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ResourceName(), cfg.Cluster)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("get kubeconfig: %w", err)
+	}
+
+	if err := argocd.DeleteFoundationalApplications(ctx, kubeconfigBytes, argocd.DeleteApplicationsOptions{
+		Cascade: opts.ApplicationCascade,
+	}); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
 // reportRetainedGitOpsDir logs a reminder that the local GitOps directory is
 // left in place after a destroy so the user knows it exists and where to find
 // it. Cluster teardown does not remove this directory: it may hold local
@@ -185,7 +290,7 @@ func reportRetainedGitOpsDir(ctx context.Context, cfg *config.NebariConfig, clus
 		if !clusterProvider.InfraSettings(cfg.Cluster).SupportsLocalGitOps {
 			return
 		}
-		gitConfig = defaultGitConfig(cfg.ProjectName)
+		gitConfig = defaultGitConfig(cfg.ResourceName())
 	}
 
 	if !gitConfig.IsLocalPath() {
@@ -217,29 +322,29 @@ func reportRetainedGitOpsDir(ctx context.Context, cfg *config.NebariConfig, clus
 // destroyDNS removes DNS records associated with the cluster's domain.
 // Split from Destroy so failures here can be warned about without aborting
 // the cluster teardown.
-func (c *Client) destroyDNS(ctx context.Context, cfg *config.NebariConfig, reg *registry.Registry, dryRun bool) error {
+func (c *Client) destroyDNS(ctx context.Context, domain string, dnsCfg *config.DNSConfig, reg *registry.Registry, dryRun bool) error {
 	if dryRun {
 		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Would clean up DNS records (dry-run)").
-			WithMetadata("provider", cfg.DNS.ProviderName()).
-			WithMetadata("domain", cfg.Domain))
+			WithMetadata("provider", dnsCfg.ProviderName()).
+			WithMetadata("domain", domain))
 		return nil
 	}
 
-	dnsProvider, err := reg.DNSProviders.Get(ctx, cfg.DNS.ProviderName())
+	dnsProvider, err := reg.DNSProviders.Get(ctx, dnsCfg.ProviderName())
 	if err != nil {
 		return err
 	}
 	status.Send(ctx, status.NewUpdate(status.LevelProgress, "Cleaning up DNS records").
 		WithResource("dns").
 		WithAction("destroying").
-		WithMetadata("provider", cfg.DNS.ProviderName()).
-		WithMetadata("domain", cfg.Domain))
+		WithMetadata("provider", dnsCfg.ProviderName()).
+		WithMetadata("domain", domain))
 
-	if err := dnsProvider.DestroyRecords(ctx, cfg.Domain, cfg.DNS.ProviderConfig()); err != nil {
+	if err := dnsProvider.DestroyRecords(ctx, domain, dnsCfg.ProviderConfig()); err != nil {
 		return err
 	}
 
 	status.Send(ctx, status.NewUpdate(status.LevelSuccess, "DNS records cleaned up successfully").
-		WithMetadata("domain", cfg.Domain))
+		WithMetadata("domain", domain))
 	return nil
 }
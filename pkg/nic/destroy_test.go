@@ -0,0 +1,123 @@
+package nic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
+)
+
+// recordingDestroyProvider is a cluster.Provider that records Destroy calls.
+// Methods Destroy does not reach are left to the embedded nil interface.
+type recordingDestroyProvider struct {
+	cluster.Provider
+
+	destroyedName string
+	destroyOpts   cluster.DestroyOptions
+}
+
+func (p *recordingDestroyProvider) Name() string { return "aws" }
+
+func (p *recordingDestroyProvider) Summary(*config.ClusterConfig) map[string]string { return nil }
+
+func (p *recordingDestroyProvider) InfraSettings(*config.ClusterConfig) cluster.InfraSettings {
+	return cluster.InfraSettings{}
+}
+
+func (p *recordingDestroyProvider) Destroy(_ context.Context, projectName string, _ *config.ClusterConfig, opts cluster.DestroyOptions) error {
+	p.destroyedName = projectName
+	p.destroyOpts = opts
+	return nil
+}
+
+func TestDestroy_ProtectedEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		opts        DestroyOptions
+		wantAborted bool
+	}{
+		{name: "unprotected environment needs no confirmation", environment: "dev"},
+		{name: "prod without environment confirmation", environment: "prod", wantAborted: true},
+		{name: "prod with mismatched confirmation", environment: "prod", opts: DestroyOptions{Environment: "staging"}, wantAborted: true},
+		{name: "prod with matching confirmation", environment: "prod", opts: DestroyOptions{Environment: "prod"}},
+		{name: "prod dry run needs no confirmation", environment: "prod", opts: DestroyOptions{DryRun: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			provider := &recordingDestroyProvider{}
+			reg := registry.NewRegistry()
+			if err := reg.ClusterProviders.Register(ctx, "aws", provider); err != nil {
+				t.Fatal(err)
+			}
+			client := &Client{registry: reg}
+
+			cfg := &config.NebariConfig{
+				ProjectName: "demo",
+				Environment: tt.environment,
+				Cluster:     &config.ClusterConfig{Providers: map[string]any{"aws": map[string]any{}}},
+			}
+
+			err := client.Destroy(ctx, cfg, tt.opts)
+			if tt.wantAborted {
+				if !errors.Is(err, ErrAborted) {
+					t.Fatalf("Destroy() error = %v, want ErrAborted", err)
+				}
+				if provider.destroyedName != "" {
+					t.Error("provider Destroy was called despite the missing confirmation")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Destroy() unexpected error: %v", err)
+			}
+			if want := "demo-" + tt.environment; provider.destroyedName != want {
+				t.Errorf("provider destroyed %q, want %q", provider.destroyedName, want)
+			}
+			if provider.destroyOpts.Environment != tt.environment {
+				t.Errorf("DestroyOptions.Environment = %q, want %q", provider.destroyOpts.Environment, tt.environment)
+			}
+		})
+	}
+}
+
+// noCredentialsProvider fails the up-front credential check.
+type noCredentialsProvider struct {
+	recordingDestroyProvider
+}
+
+func (p *noCredentialsProvider) CheckCredentials(context.Context, *config.ClusterConfig) error {
+	return fmt.Errorf("%w: no AWS credentials found", cluster.ErrCredentials)
+}
+
+func TestDestroy_MissingCredentials(t *testing.T) {
+	ctx := context.Background()
+	provider := &noCredentialsProvider{}
+	reg := registry.NewRegistry()
+	if err := reg.ClusterProviders.Register(ctx, "aws", provider); err != nil {
+		t.Fatal(err)
+	}
+	client := &Client{registry: reg}
+
+	confirmed := false
+	cfg := &config.NebariConfig{
+		ProjectName: "demo",
+		Cluster:     &config.ClusterConfig{Providers: map[string]any{"aws": map[string]any{}}},
+	}
+	err := client.Destroy(ctx, cfg, DestroyOptions{Confirm: func(context.Context, DestroySummary) error {
+		confirmed = true
+		return nil
+	}})
+	if !errors.Is(err, cluster.ErrCredentials) {
+		t.Fatalf("Destroy() error = %v, want ErrCredentials", err)
+	}
+	if confirmed || provider.destroyedName != "" {
+		t.Error("destroy continued past the failed credential check")
+	}
+}
@@ -0,0 +1,71 @@
+package nic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/redact"
+)
+
+// Output formats for EffectiveConfig.
+const (
+	ConfigFormatYAML = "yaml"
+	ConfigFormatJSON = "json"
+)
+
+// EffectiveConfig validates cfg and renders it in format (ConfigFormatYAML or
+// ConfigFormatJSON) with secret-bearing values replaced by redact.Placeholder,
+// so users can confirm what NIC will act on. cfg should already carry any
+// CLI overrides. Values NIC only references by name (environment variables,
+// files, Kubernetes secrets) are shown as-is.
+func (c *Client) EffectiveConfig(ctx context.Context, cfg *config.NebariConfig, format string) ([]byte, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.EffectiveConfig")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("format", format))
+
+	if format != ConfigFormatYAML && format != ConfigFormatJSON {
+		err := fmt.Errorf("unsupported config format %q (must be %s or %s)", format, ConfigFormatYAML, ConfigFormatJSON)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := c.Validate(ctx, cfg); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Round-trip through YAML to get a generic tree keyed by the config's
+	// own field names, which the redaction pass walks.
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	var tree map[string]any
+	if err := yaml.Unmarshal(raw, &tree); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	redacted := redact.Value(tree)
+
+	var out []byte
+	if format == ConfigFormatJSON {
+		out, err = json.MarshalIndent(redacted, "", "  ")
+		out = append(out, '\n')
+	} else {
+		out, err = yaml.Marshal(redacted)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("render config as %s: %w", format, err)
+	}
+	return out, nil
+}
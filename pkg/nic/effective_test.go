@@ -0,0 +1,80 @@
+package nic
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/dns/cloudflare"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/redact"
+)
+
+const effectiveSourceConfig = `
+project_name: effective-test
+domain: nebari.example.com
+cluster:
+  local:
+    kube_context: kind-nebari
+dns:
+  cloudflare:
+    zone_name: example.com
+    api_token: cf-super-secret
+    api_token_env: CLOUDFLARE_API_TOKEN
+`
+
+func TestEffectiveConfig(t *testing.T) {
+	ctx := context.Background()
+	client := newExportTestClient(t)
+	if err := client.registry.DNSProviders.Register(ctx, "cloudflare", cloudflare.NewProvider()); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.ParseConfigBytes([]byte(effectiveSourceConfig))
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	// Simulate a CLI override applied after parsing.
+	cfg.InfraOnly = true
+
+	for _, format := range []string{ConfigFormatYAML, ConfigFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			out, err := client.EffectiveConfig(ctx, cfg, format)
+			if err != nil {
+				t.Fatalf("EffectiveConfig() error = %v", err)
+			}
+			text := string(out)
+
+			if strings.Contains(text, "cf-super-secret") {
+				t.Errorf("secret value leaked:\n%s", text)
+			}
+			for _, want := range []string{redact.Placeholder, "CLOUDFLARE_API_TOKEN", "effective-test", "kind-nebari", "infra_only"} {
+				if !strings.Contains(text, want) {
+					t.Errorf("output missing %q:\n%s", want, text)
+				}
+			}
+			if format == ConfigFormatJSON && !json.Valid(out) {
+				t.Errorf("output is not valid JSON:\n%s", text)
+			}
+		})
+	}
+}
+
+func TestEffectiveConfig_Errors(t *testing.T) {
+	ctx := context.Background()
+	client := newExportTestClient(t)
+
+	cfg, err := config.ParseConfigBytes([]byte(effectiveSourceConfig))
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+
+	if _, err := client.EffectiveConfig(ctx, cfg, "toml"); err == nil || !strings.Contains(err.Error(), "unsupported config format") {
+		t.Errorf("EffectiveConfig(toml) error = %v, want unsupported format", err)
+	}
+	cfg.ProjectName = ""
+	if _, err := client.EffectiveConfig(ctx, cfg, ConfigFormatYAML); err == nil {
+		t.Error("EffectiveConfig() with an invalid config should fail before printing")
+	}
+}
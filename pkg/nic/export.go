@@ -0,0 +1,119 @@
+package nic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// ExportFormatVersion identifies the layout of documents produced by Export.
+// Bump it when the header or body changes in a way consumers must handle.
+const ExportFormatVersion = 1
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// Region, when set, rewrites the cluster config for a rebuild in another
+	// region. Providers that cannot relocate return an error.
+	Region string
+}
+
+// regionRelocator is an optional capability: providers whose config can be
+// rewritten for another region implement it so Export can produce a document
+// for a disaster-recovery rebuild. Only the AWS provider implements it today.
+type regionRelocator interface {
+	RelocateRegion(clusterConfig *config.ClusterConfig, region string) (*config.ClusterConfig, error)
+}
+
+// Export returns a portable YAML description of the deployment described by
+// cfg: the effective config, preceded by a comment header recording the
+// export format version and the provider's summary of that config. The live
+// cluster is not queried, so the document holds only what the config
+// describes. The body is a regular NIC config, so the document can be passed
+// straight to `nic deploy`.
+func (c *Client) Export(ctx context.Context, cfg *config.NebariConfig, opts ExportOptions) ([]byte, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.Export")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project_name", cfg.ProjectName),
+		attribute.String("export.region", opts.Region),
+	)
+
+	reg := c.registry
+
+	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
+	}
+
+	providerName := cfg.Cluster.ProviderName()
+	clusterProvider, err := reg.ClusterProviders.Get(ctx, providerName)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("get cluster provider: %w", err)
+	}
+
+	summary := clusterProvider.Summary(cfg.Cluster)
+
+	out := *cfg
+	if opts.Region != "" {
+		relocator, ok := clusterProvider.(regionRelocator)
+		if !ok {
+			err := fmt.Errorf("cluster provider %q does not support exporting to another region", providerName)
+			span.RecordError(err)
+			return nil, err
+		}
+		relocated, err := relocator.RelocateRegion(cfg.Cluster, opts.Region)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("relocate cluster config to %s: %w", opts.Region, err)
+		}
+		out.Cluster = relocated
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Rewrote cluster config for target region").
+			WithResource("config").
+			WithAction("relocating").
+			WithMetadata("region", opts.Region))
+	}
+
+	doc, err := exportDocument(&out, providerName, summary, opts.Region, time.Now().UTC())
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return doc, nil
+}
+
+// exportDocument renders cfg as YAML behind a comment header. Keeping the
+// metadata in comments means the document parses as a plain NebariConfig.
+func exportDocument(cfg *config.NebariConfig, providerName string, summary map[string]string, region string, now time.Time) ([]byte, error) {
+	body, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config to YAML: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# NIC export format version %d\n", ExportFormatVersion)
+	fmt.Fprintf(&buf, "# Exported at: %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&buf, "# Source provider: %s\n", providerName)
+	for _, k := range slices.Sorted(maps.Keys(summary)) {
+		fmt.Fprintf(&buf, "# Source %s: %s\n", k, summary[k])
+	}
+	if region != "" {
+		fmt.Fprintf(&buf, "# Target region: %s\n", region)
+		buf.WriteString("# Region-scoped references (zones, existing network IDs, KMS keys) were\n")
+		buf.WriteString("# removed; review before running `nic deploy`.\n")
+	}
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
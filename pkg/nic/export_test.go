@@ -0,0 +1,147 @@
+package nic
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster/aws"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster/local"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
+)
+
+const exportSourceConfig = `
+project_name: dr-test
+domain: nebari.example.com
+cluster:
+  aws:
+    region: us-west-2
+    kubernetes_version: "1.34"
+    availability_zones: [us-west-2a, us-west-2b]
+    existing_vpc_id: vpc-0123456789abcdef0
+    eks_kms_arn: arn:aws:kms:us-west-2:123456789012:key/abc
+    existing_node_role_arn: arn:aws:iam::123456789012:role/nodes
+    node_groups:
+      general:
+        instance: m7i.xlarge
+        min_nodes: 1
+        max_nodes: 3
+`
+
+func newExportTestClient(t *testing.T) *Client {
+	t.Helper()
+	ctx := context.Background()
+	reg := registry.NewRegistry()
+	if err := reg.ClusterProviders.Register(ctx, "aws", aws.NewProvider()); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.ClusterProviders.Register(ctx, "local", local.NewProvider()); err != nil {
+		t.Fatal(err)
+	}
+	return &Client{registry: reg}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	client := newExportTestClient(t)
+
+	src, err := config.ParseConfigBytes([]byte(exportSourceConfig))
+	if err != nil {
+		t.Fatalf("parse source config: %v", err)
+	}
+
+	doc, err := client.Export(ctx, src, ExportOptions{Region: "eu-west-1"})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	text := string(doc)
+	for _, want := range []string{"# NIC export format version 1", "# Source Region: us-west-2", "# Target region: eu-west-1"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("export header missing %q:\n%s", want, text)
+		}
+	}
+
+	got, err := config.ParseConfigBytes(doc)
+	if err != nil {
+		t.Fatalf("exported document does not parse: %v\n%s", err, text)
+	}
+	if err := got.Validate(validateOptions(ctx, client.registry)); err != nil {
+		t.Fatalf("exported document does not validate: %v", err)
+	}
+
+	if got.ProjectName != "dr-test" || got.Domain != "nebari.example.com" {
+		t.Errorf("project/domain = %q/%q, want dr-test/nebari.example.com", got.ProjectName, got.Domain)
+	}
+	awsCfg := got.Cluster.ProviderConfig()
+	if awsCfg["region"] != "eu-west-1" {
+		t.Errorf("region = %v, want eu-west-1", awsCfg["region"])
+	}
+	for _, key := range []string{"availability_zones", "existing_vpc_id", "eks_kms_arn"} {
+		if _, ok := awsCfg[key]; ok {
+			t.Errorf("region-scoped key %q carried into export", key)
+		}
+	}
+	if awsCfg["existing_node_role_arn"] != "arn:aws:iam::123456789012:role/nodes" {
+		t.Errorf("existing_node_role_arn = %v, want it preserved", awsCfg["existing_node_role_arn"])
+	}
+	if _, ok := awsCfg["node_groups"].(map[string]any)["general"]; !ok {
+		t.Error("node group general missing from export")
+	}
+
+	if src.Cluster.ProviderConfig()["region"] != "us-west-2" {
+		t.Error("Export mutated the source config")
+	}
+}
+
+func TestExportWithoutRegionKeepsConfig(t *testing.T) {
+	client := newExportTestClient(t)
+	src, err := config.ParseConfigBytes([]byte(exportSourceConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := client.Export(context.Background(), src, ExportOptions{})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	got, err := config.ParseConfigBytes(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Cluster.ProviderConfig()["availability_zones"]; !ok {
+		t.Error("availability_zones dropped without --region")
+	}
+	if strings.Contains(string(doc), "Target region") {
+		t.Error("header mentions a target region without --region")
+	}
+}
+
+func TestExportRegionUnsupported(t *testing.T) {
+	client := newExportTestClient(t)
+	src, err := config.ParseConfigBytes([]byte("project_name: local-test\ncluster:\n  local: {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Export(context.Background(), src, ExportOptions{Region: "eu-west-1"})
+	if err == nil || !strings.Contains(err.Error(), "does not support exporting to another region") {
+		t.Fatalf("Export() error = %v, want unsupported-region error", err)
+	}
+}
+
+func TestExportDocumentHeader(t *testing.T) {
+	cfg := &config.NebariConfig{ProjectName: "p"}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	doc, err := exportDocument(cfg, "aws", map[string]string{"Region": "us-east-1"}, "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# NIC export format version 1\n# Exported at: 2026-01-02T03:04:05Z\n# Source provider: aws\n# Source Region: us-east-1\nproject_name: p\n"
+	if string(doc) != want {
+		t.Errorf("exportDocument() =\n%s\nwant\n%s", doc, want)
+	}
+}
@@ -0,0 +1,36 @@
+package nic
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+// AccessInfo returns the URLs, admin users and admin password secrets of the
+// foundational services deployed for cfg. It is derived from the config and
+// the provider's infrastructure settings only and makes no cloud or cluster
+// calls, so it can be printed before DNS or the services are ready.
+func (c *Client) AccessInfo(ctx context.Context, cfg *config.NebariConfig) ([]argocd.ServiceAccess, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.AccessInfo")
+	defer span.End()
+
+	reg := c.registry
+
+	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
+	}
+
+	clusterProvider, err := reg.ClusterProviders.Get(ctx, cfg.Cluster.ProviderName())
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("get cluster provider: %w", err)
+	}
+
+	return argocd.AccessInfo(cfg, clusterProvider.InfraSettings(cfg.Cluster)), nil
+}
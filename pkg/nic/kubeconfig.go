@@ -3,17 +3,21 @@ package nic
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"go.opentelemetry.io/otel"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/kubeconfig"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
 )
 
 // Kubeconfig returns the raw kubeconfig bytes for the cluster described by
 // cfg. The caller decides where to write them (stdout, file, or merge into
-// an existing kubeconfig).
-func (c *Client) Kubeconfig(ctx context.Context, cfg *config.NebariConfig) ([]byte, error) {
+// an existing kubeconfig). The client's kubeconfig options are applied
+// first, then opts.
+func (c *Client) Kubeconfig(ctx context.Context, cfg *config.NebariConfig, opts ...kubeconfig.Option) ([]byte, error) {
 	tracer := otel.Tracer("nebari-infrastructure-core")
 	ctx, span := tracer.Start(ctx, "nic.Kubeconfig")
 	defer span.End()
@@ -22,14 +26,15 @@ func (c *Client) Kubeconfig(ctx context.Context, cfg *config.NebariConfig) ([]by
 
 	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
 	}
 
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Configuration validated").
 		WithResource("config").
 		WithAction("validated").
 		WithMetadata("provider", cfg.Cluster.ProviderName()).
-		WithMetadata("project_name", cfg.ProjectName))
+		WithMetadata("project_name", cfg.ProjectName).
+		WithMetadata("environment", cfg.Environment))
 
 	clusterProvider, err := reg.ClusterProviders.Get(ctx, cfg.Cluster.ProviderName())
 	if err != nil {
@@ -37,11 +42,25 @@ func (c *Client) Kubeconfig(ctx context.Context, cfg *config.NebariConfig) ([]by
 		return nil, fmt.Errorf("get cluster provider: %w", err)
 	}
 
-	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ProjectName, cfg.Cluster)
+	kubeconfigBytes, err := c.clusterKubeconfig(ctx, cfg, clusterProvider, opts...)
 	if err != nil {
 		span.RecordError(err)
-		return nil, fmt.Errorf("get kubeconfig: %w", err)
+		return nil, err
 	}
 
 	return kubeconfigBytes, nil
 }
+
+// clusterKubeconfig gets the kubeconfig from clusterProvider and applies the
+// client's kubeconfig options followed by opts.
+func (c *Client) clusterKubeconfig(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, opts ...kubeconfig.Option) ([]byte, error) {
+	kubeconfigBytes, err := clusterProvider.GetKubeconfig(ctx, cfg.ResourceName(), cfg.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("get kubeconfig: %w", err)
+	}
+	kubeconfigBytes, err = kubeconfig.Apply(kubeconfigBytes, append(slices.Clone(c.kubeconfigOptions), opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("post-process kubeconfig: %w", err)
+	}
+	return kubeconfigBytes, nil
+}
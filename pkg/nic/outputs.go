@@ -0,0 +1,89 @@
+package nic
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/endpoint"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/kubeconfig"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// Outputs is what a deployed cluster exposes to its users: the kubeconfig
+// to reach it, the URLs of the foundational services and the gateway load
+// balancer's address.
+type Outputs struct {
+	Kubeconfig []byte
+	Services   []argocd.ServiceAccess
+	// LoadBalancer is nil when the gateway Service has no external address
+	// yet.
+	LoadBalancer *endpoint.LoadBalancerEndpoint
+}
+
+// Outputs returns the outputs of the already deployed cluster described by
+// cfg. It only reads: the kubeconfig comes from the provider and the load
+// balancer address from the gateway Service as it is now, without waiting.
+// The client's kubeconfig options are applied first, then opts.
+func (c *Client) Outputs(ctx context.Context, cfg *config.NebariConfig, opts ...kubeconfig.Option) (*Outputs, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.Outputs")
+	defer span.End()
+
+	reg := c.registry
+
+	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
+	}
+
+	clusterProvider, err := reg.ClusterProviders.Get(ctx, cfg.Cluster.ProviderName())
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("get cluster provider: %w", err)
+	}
+
+	kubeconfigBytes, err := c.clusterKubeconfig(ctx, cfg, clusterProvider, opts...)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	out := &Outputs{Kubeconfig: kubeconfigBytes}
+	if cfg.InfraOnly {
+		return out, nil
+	}
+
+	out.Services = argocd.AccessInfo(cfg, clusterProvider.InfraSettings(cfg.Cluster))
+
+	// The kubeconfig itself is usable without the gateway, so failing to
+	// read its address is only a warning.
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigBytes)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("parse kubeconfig: %w", err)
+	}
+	k8sClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("create k8s client: %w", err)
+	}
+	lb, err := endpoint.LookupLoadBalancerEndpoint(ctx, k8sClient)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Gateway load balancer address not available").
+			WithResource("load-balancer").
+			WithMetadata("error", err.Error()))
+		return out, nil
+	}
+	out.LoadBalancer = lb
+	span.SetAttributes(
+		attribute.String("hostname", lb.Hostname),
+		attribute.String("ip", lb.IP),
+	)
+	return out, nil
+}
@@ -0,0 +1,189 @@
+package nic
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// preflightGateway checks the cluster for Gateway API implementations or
+// LoadBalancer Services that would clash with Envoy Gateway before it is
+// installed. Failing to inspect the cluster is only a warning: the install
+// itself will surface a cluster that is genuinely unreachable.
+func (c *Client) preflightGateway(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, strict bool) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.preflightGateway")
+	defer span.End()
+
+	kubeconfigBytes, err := c.clusterKubeconfig(ctx, cfg, clusterProvider)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not get kubeconfig for gateway preflight").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigBytes)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not parse kubeconfig for gateway preflight").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+	k8sClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not create k8s client for gateway preflight").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not create dynamic client for gateway preflight").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+
+	if err := reportGatewayConflicts(ctx, dynamicClient, k8sClient, strict); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// reportGatewayConflicts sends a warning for every conflict found by
+// argocd.DetectGatewayConflicts. With strict set, any conflict is returned as
+// an error instead of letting the deploy continue.
+func reportGatewayConflicts(ctx context.Context, dynamicClient dynamic.Interface, k8sClient kubernetes.Interface, strict bool) error {
+	conflicts, err := argocd.DetectGatewayConflicts(ctx, dynamicClient, k8sClient)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not check the cluster for gateway conflicts").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		descriptions = append(descriptions, conflict.String())
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Possible conflict with Envoy Gateway").
+			WithResource(strings.ToLower(conflict.Kind)).
+			WithAction("preflight").
+			WithMetadata("name", conflict.Name).
+			WithMetadata("reason", conflict.Reason))
+	}
+
+	if strict && len(conflicts) > 0 {
+		return fmt.Errorf("gateway preflight found %d conflict(s): %s", len(conflicts), strings.Join(descriptions, "; "))
+	}
+	return nil
+}
+
+// preflightCapacity warns when the schedulable nodes of the cluster do not
+// have enough free CPU or memory for the requests of the foundational
+// components. Like preflightGateway, failing to inspect the cluster is only a
+// warning.
+func (c *Client) preflightCapacity(ctx context.Context, cfg *config.NebariConfig, clusterProvider cluster.Provider, settings cluster.InfraSettings, trustManager, strict bool) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.preflightCapacity")
+	defer span.End()
+
+	kubeconfigBytes, err := c.clusterKubeconfig(ctx, cfg, clusterProvider)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not get kubeconfig for capacity preflight").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigBytes)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not parse kubeconfig for capacity preflight").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+	k8sClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not create k8s client for capacity preflight").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+
+	if err := reportCapacityShortfall(ctx, k8sClient, argocd.FoundationalRequests(cfg, settings, trustManager), strict); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// reportCapacityShortfall sends a warning for every resource
+// argocd.DetectCapacityShortfall finds short, naming the components that
+// request the most of it. With strict set, a shortfall is returned as an
+// error instead of letting the deploy continue.
+func reportCapacityShortfall(ctx context.Context, k8sClient kubernetes.Interface, requests []argocd.ComponentRequest, strict bool) error {
+	shortfalls, err := argocd.DetectCapacityShortfall(ctx, k8sClient, requests)
+	if err != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not check the cluster capacity for the foundational services").
+			WithMetadata("error", err.Error()))
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(shortfalls))
+	for _, s := range shortfalls {
+		descriptions = append(descriptions, s.String())
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Cluster may be too small for the foundational services; add or enlarge nodes, or set infra_only to skip them").
+			WithResource("node").
+			WithAction("preflight").
+			WithMetadata("resource", string(s.Resource)).
+			WithMetadata("requested", s.Requested.String()).
+			WithMetadata("available", s.Available.String()).
+			WithMetadata("largest_components", strings.Join(s.Largest, ", ")))
+	}
+
+	if strict && len(shortfalls) > 0 {
+		return fmt.Errorf("capacity preflight: cluster is too small for the foundational services (%s)", strings.Join(descriptions, "; "))
+	}
+	return nil
+}
+
+// preflightHTTP01 warns about Let's Encrypt certificate names that do not
+// resolve yet when no DNS provider manages the gateway records: their HTTP-01
+// challenges cannot reach the gateway until the records exist. With a DNS
+// provider the records are created after the load balancer comes up. With
+// strict set, unresolvable names are an error.
+func (c *Client) preflightHTTP01(ctx context.Context, cfg *config.NebariConfig, settings cluster.InfraSettings, strict bool) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.preflightHTTP01")
+	defer span.End()
+
+	if cfg.RecordsDNS() != nil {
+		return nil
+	}
+	hostnames, err := argocd.HTTP01Hostnames(cfg, settings)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	lookup := c.lookupHost
+	if lookup == nil {
+		lookup = net.DefaultResolver.LookupHost
+	}
+	missing := argocd.UnresolvableHostnames(ctx, hostnames, lookup)
+	for _, host := range missing {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Certificate name has no DNS record; its HTTP-01 challenge will fail until one points at the gateway").
+			WithResource("certificate").
+			WithAction("preflight").
+			WithMetadata("hostname", host))
+	}
+
+	if strict && len(missing) > 0 {
+		err := fmt.Errorf("HTTP-01 preflight: %s do not resolve and no DNS provider is configured to create them", strings.Join(missing, ", "))
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
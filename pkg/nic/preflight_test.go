@@ -0,0 +1,196 @@
+package nic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+func TestReportGatewayConflicts(t *testing.T) {
+	istio := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "GatewayClass",
+		"metadata":   map[string]any{"name": "istio"},
+		"spec":       map[string]any{"controllerName": "istio.io/gateway-controller"},
+	}}
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses"}: "GatewayClassList",
+	}
+
+	tests := []struct {
+		name    string
+		strict  bool
+		wantErr bool
+	}{
+		{name: "conflict is a warning by default"},
+		{name: "conflict fails with strict", strict: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				warnings []status.Update
+			)
+			ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+				if u.Level == status.LevelWarning {
+					mu.Lock()
+					warnings = append(warnings, u)
+					mu.Unlock()
+				}
+			})
+
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, istio)
+			err := reportGatewayConflicts(ctx, dynamicClient, k8sfake.NewSimpleClientset(), tt.strict)
+			cleanup()
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "GatewayClass istio") {
+					t.Fatalf("error = %v, want the istio GatewayClass conflict", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(warnings) != 1 || warnings[0].Metadata["name"] != "istio" {
+				t.Errorf("warnings = %+v, want one for GatewayClass istio", warnings)
+			}
+		})
+	}
+}
+
+func TestReportCapacityShortfall(t *testing.T) {
+	small := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	requests := argocd.FoundationalRequests(&config.NebariConfig{}, cluster.InfraSettings{NeedsMetalLB: true}, false)
+
+	tests := []struct {
+		name    string
+		strict  bool
+		wantErr bool
+	}{
+		{name: "small cluster is a warning by default"},
+		{name: "small cluster fails with strict", strict: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				warnings []status.Update
+			)
+			ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+				if u.Level == status.LevelWarning {
+					mu.Lock()
+					warnings = append(warnings, u)
+					mu.Unlock()
+				}
+			})
+
+			err := reportCapacityShortfall(ctx, k8sfake.NewSimpleClientset(small), requests, tt.strict)
+			cleanup()
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "too small") {
+					t.Fatalf("error = %v, want a capacity error", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(warnings) != 2 {
+				t.Fatalf("warnings = %+v, want one for cpu and one for memory", warnings)
+			}
+			if warnings[0].Metadata["resource"] != "cpu" || warnings[0].Metadata["available"] != "1" {
+				t.Errorf("cpu warning metadata = %v", warnings[0].Metadata)
+			}
+			if !strings.HasPrefix(warnings[1].Metadata["largest_components"].(string), "keycloak") {
+				t.Errorf("memory warning metadata = %v, want keycloak first", warnings[1].Metadata)
+			}
+		})
+	}
+}
+
+func TestPreflightHTTP01(t *testing.T) {
+	letsencrypt := &config.CertificateConfig{Type: config.CertificateTypeLetsEncrypt, ACME: &config.ACMEConfig{Email: "admin@example.com"}}
+	resolvesApexOnly := func(_ context.Context, host string) ([]string, error) {
+		if host == "example.com" {
+			return []string{"203.0.113.10"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	tests := []struct {
+		name         string
+		cert         *config.CertificateConfig
+		dns          *config.DNSConfig
+		strict       bool
+		wantWarnings int
+		wantErr      bool
+	}{
+		{name: "selfsigned is skipped"},
+		{name: "missing records warn", cert: letsencrypt, wantWarnings: 2},
+		{name: "missing records fail with strict", cert: letsencrypt, strict: true, wantWarnings: 2, wantErr: true},
+		{
+			name: "dns provider creates the records",
+			cert: letsencrypt,
+			dns:  &config.DNSConfig{Providers: map[string]any{"cloudflare": map[string]any{"zone_name": "example.com"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				warnings []status.Update
+			)
+			ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+				if u.Level == status.LevelWarning {
+					mu.Lock()
+					warnings = append(warnings, u)
+					mu.Unlock()
+				}
+			})
+
+			c := &Client{lookupHost: resolvesApexOnly}
+			cfg := &config.NebariConfig{Domain: "example.com", Certificate: tt.cert, DNS: tt.dns}
+			err := c.preflightHTTP01(ctx, cfg, cluster.InfraSettings{}, tt.strict)
+			cleanup()
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "keycloak.example.com, argocd.example.com") {
+					t.Fatalf("error = %v, want the unresolvable names", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("got %d warnings, want %d: %+v", len(warnings), tt.wantWarnings, warnings)
+			}
+		})
+	}
+}
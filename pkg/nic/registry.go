@@ -67,6 +67,10 @@ func defaultRegistry(ctx context.Context) (*registry.Registry, error) {
 		return nil, fmt.Errorf("register cloudflare dns provider: %w", err)
 	}
 
+	if err := r.RegisterRules(ctx); err != nil {
+		return nil, fmt.Errorf("register provider validation rules: %w", err)
+	}
+
 	return r, nil
 }
 
@@ -0,0 +1,76 @@
+package nic
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// nodeGroupScaler is an optional capability: providers that can resize a
+// node group without a full deploy implement it. Providers without it are
+// reported as unsupported by Scale.
+type nodeGroupScaler interface {
+	ScaleNodeGroup(ctx context.Context, projectName string, clusterConfig *config.ClusterConfig, nodeGroup string, scaling cluster.NodeGroupScaling) error
+}
+
+// Scale resizes a single node group of the cluster described by cfg without
+// running a deploy. The config file is not modified, so the next deploy
+// restores the configured sizes.
+func (c *Client) Scale(ctx context.Context, cfg *config.NebariConfig, nodeGroup string, scaling cluster.NodeGroupScaling) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.Scale")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("project_name", cfg.ProjectName),
+		attribute.String("node_group", nodeGroup),
+	)
+
+	if nodeGroup == "" {
+		err := fmt.Errorf("node group name is required")
+		span.RecordError(err)
+		return err
+	}
+	if err := scaling.Validate(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("invalid scaling for node group %s: %w", nodeGroup, err)
+	}
+
+	reg := c.registry
+
+	if err := cfg.Validate(validateOptions(ctx, reg)); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
+	}
+
+	providerName := cfg.Cluster.ProviderName()
+	clusterProvider, err := reg.ClusterProviders.Get(ctx, providerName)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("get cluster provider: %w", err)
+	}
+
+	scaler, ok := clusterProvider.(nodeGroupScaler)
+	if !ok {
+		err := fmt.Errorf("cluster provider %q does not support scaling node groups outside of deploy", providerName)
+		span.RecordError(err)
+		return err
+	}
+
+	if err := scaler.ScaleNodeGroup(ctx, cfg.ResourceName(), cfg.Cluster, nodeGroup, scaling); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("scale node group %s: %w", nodeGroup, err)
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelSuccess, fmt.Sprintf("Node group %s scaling updated", nodeGroup)).
+		WithResource("node-group").
+		WithAction("scaled").
+		WithMetadata("provider", providerName))
+	return nil
+}
@@ -0,0 +1,338 @@
+package nic
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore/s3store"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/steptiming"
+)
+
+// ErrStateLocked is returned by Deploy and Destroy when another run holds the
+// deploy lock of a remote state backend.
+var ErrStateLocked = errors.New("deploy state is locked")
+
+// Object names under a remote backend's key prefix.
+const (
+	stateCheckpointObject = "checkpoint.json"
+	stateLockObject       = "deploy.lock"
+	stateClusterIDObject  = "cluster-id"
+)
+
+// stateBackend stores a project's deploy checkpoint and the lock that keeps
+// two runs from changing the same deployment at once. It is selected by the
+// config's state block (see Client.openState).
+type stateBackend interface {
+	// read returns the stored checkpoint, or an error wrapping
+	// fs.ErrNotExist when there is none.
+	read(ctx context.Context) ([]byte, error)
+	// write replaces the stored checkpoint.
+	write(ctx context.Context, data []byte) error
+	// remove deletes the stored checkpoint; a missing one is not an error.
+	remove(ctx context.Context) error
+	// lock takes the deploy lock for operation and returns the function
+	// releasing it, or an error wrapping ErrStateLocked.
+	lock(ctx context.Context, operation string) (unlock func(context.Context) error, err error)
+	// readClusterID returns the stored cluster ID, or an error wrapping
+	// fs.ErrNotExist when none has been assigned.
+	readClusterID(ctx context.Context) (string, error)
+	// writeClusterID stores id unless an ID is already stored, and returns
+	// the ID that is stored afterwards. Unlike the checkpoint it is never
+	// removed.
+	writeClusterID(ctx context.Context, id string) (string, error)
+	// location describes where the checkpoint lives, for messages.
+	location() string
+}
+
+// fileState is the local backend: a checkpoint file and a cluster ID file
+// under ~/.nic. It takes no lock, since the files are never shared between
+// machines.
+type fileState struct {
+	path   string
+	idPath string
+}
+
+func newFileState(resourceName string) (*fileState, error) {
+	path, err := checkpointPath(resourceName)
+	if err != nil {
+		return nil, err
+	}
+	idPath, err := clusterIDPath(resourceName)
+	if err != nil {
+		return nil, err
+	}
+	return &fileState{path: path, idPath: idPath}, nil
+}
+
+func (s *fileState) read(_ context.Context) ([]byte, error) {
+	return os.ReadFile(s.path) //nolint:gosec // path is derived from the validated project name
+}
+
+func (s *fileState) write(_ context.Context, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create checkpoint directory: %w", err)
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+func (s *fileState) remove(_ context.Context) error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *fileState) lock(context.Context, string) (func(context.Context) error, error) {
+	return func(context.Context) error { return nil }, nil
+}
+
+func (s *fileState) location() string { return s.path }
+
+func (s *fileState) readClusterID(_ context.Context) (string, error) {
+	data, err := os.ReadFile(s.idPath) //nolint:gosec // path is derived from the validated project name
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (s *fileState) writeClusterID(ctx context.Context, id string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(s.idPath), 0o700); err != nil {
+		return "", fmt.Errorf("create cluster ID directory: %w", err)
+	}
+	f, err := os.OpenFile(s.idPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) //nolint:gosec // path is derived from the validated project name
+	if errors.Is(err, fs.ErrExist) {
+		return s.readClusterID(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(id + "\n"); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return id, f.Close()
+}
+
+// objectState is a remote backend keeping the checkpoint and the lock as
+// objects under prefix. Every write is conditional on the version last read
+// or written, so a concurrent writer surfaces as objectstore.ErrConflict
+// instead of silently overwriting the other run's checkpoint.
+type objectState struct {
+	objects objectstore.Objects
+	prefix  string
+
+	// version is the checkpoint object's version as last seen by this run;
+	// empty when it did not exist.
+	version string
+}
+
+// stateLockInfo is the content of the lock object.
+type stateLockInfo struct {
+	Operation  string    `json:"operation"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+func (s *objectState) key(name string) string {
+	return s.prefix + "/" + name
+}
+
+func (s *objectState) read(ctx context.Context) ([]byte, error) {
+	data, version, err := s.objects.Get(ctx, s.key(stateCheckpointObject))
+	if errors.Is(err, objectstore.ErrNotExist) {
+		s.version = ""
+		return nil, fmt.Errorf("%s: %w", s.location(), fs.ErrNotExist)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.version = version
+	return data, nil
+}
+
+func (s *objectState) write(ctx context.Context, data []byte) error {
+	version, err := s.objects.Put(ctx, s.key(stateCheckpointObject), data, s.version)
+	if errors.Is(err, objectstore.ErrConflict) {
+		return fmt.Errorf("%s was changed by another run: %w", s.location(), err)
+	}
+	if err != nil {
+		return err
+	}
+	s.version = version
+	return nil
+}
+
+func (s *objectState) remove(ctx context.Context) error {
+	if err := s.objects.Delete(ctx, s.key(stateCheckpointObject), s.version); err != nil {
+		if errors.Is(err, objectstore.ErrConflict) {
+			return fmt.Errorf("%s was changed by another run: %w", s.location(), err)
+		}
+		return err
+	}
+	s.version = ""
+	return nil
+}
+
+func (s *objectState) lock(ctx context.Context, operation string) (func(context.Context) error, error) {
+	info := stateLockInfo{Operation: operation, Holder: lockHolder(), AcquiredAt: time.Now().UTC()}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("marshal state lock: %w", err)
+	}
+
+	lockKey := s.key(stateLockObject)
+	version, err := s.objects.Put(ctx, lockKey, data, "")
+	if errors.Is(err, objectstore.ErrConflict) {
+		return nil, s.lockedError(ctx, lockKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("acquire state lock: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		return s.objects.Delete(ctx, lockKey, version)
+	}, nil
+}
+
+// lockedError describes the run holding lockKey, as far as it can be read.
+func (s *objectState) lockedError(ctx context.Context, lockKey string) error {
+	where := s.objects.Location(lockKey)
+	data, _, err := s.objects.Get(ctx, lockKey)
+	if err != nil {
+		return fmt.Errorf("%w (%s)", ErrStateLocked, where)
+	}
+	var held stateLockInfo
+	if err := json.Unmarshal(data, &held); err != nil {
+		return fmt.Errorf("%w (%s)", ErrStateLocked, where)
+	}
+	return fmt.Errorf("%w: %s by %s since %s; if that run is no longer active, delete %s",
+		ErrStateLocked, held.Operation, held.Holder, held.AcquiredAt.Format(time.RFC3339), where)
+}
+
+func (s *objectState) readClusterID(ctx context.Context) (string, error) {
+	data, _, err := s.objects.Get(ctx, s.key(stateClusterIDObject))
+	if errors.Is(err, objectstore.ErrNotExist) {
+		return "", fmt.Errorf("%s: %w", s.objects.Location(s.key(stateClusterIDObject)), fs.ErrNotExist)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (s *objectState) writeClusterID(ctx context.Context, id string) (string, error) {
+	_, err := s.objects.Put(ctx, s.key(stateClusterIDObject), []byte(id), "")
+	if errors.Is(err, objectstore.ErrConflict) {
+		// Another run assigned an ID first; it wins.
+		return s.readClusterID(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (s *objectState) location() string {
+	return s.objects.Location(s.key(stateCheckpointObject))
+}
+
+// lockHolder identifies this process in a lock object.
+func lockHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown-host"
+	}
+	return fmt.Sprintf("%s (pid %d)", host, os.Getpid())
+}
+
+// openState returns the state backend cfg.State selects.
+func (c *Client) openState(ctx context.Context, cfg *config.NebariConfig) (stateBackend, error) {
+	if !cfg.State.IsRemote() {
+		return newFileState(cfg.ResourceName())
+	}
+
+	open := c.stateObjects
+	if open == nil {
+		open = openStateObjects
+	}
+	objects, err := open(ctx, cfg.State)
+	if err != nil {
+		return nil, fmt.Errorf("open %s state backend: %w", cfg.State.BackendType(), err)
+	}
+
+	prefix := cfg.State.Key
+	if prefix == "" {
+		prefix = cfg.ResourceName()
+	}
+	return &objectState{objects: objects, prefix: prefix}, nil
+}
+
+// openStateObjects connects to the object storage of a remote state backend.
+func openStateObjects(ctx context.Context, state *config.StateConfig) (objectstore.Objects, error) {
+	switch state.BackendType() {
+	case config.StateBackendS3:
+		return s3store.New(ctx, state.Bucket, state.Region)
+	default:
+		return nil, fmt.Errorf("unsupported state backend %q", state.BackendType())
+	}
+}
+
+// prepareDeployState opens the state backend, takes the deploy lock, ensures
+// the cluster ID and loads the checkpoint. It runs as the "state" step so the
+// time spent on a remote backend shows up in the deploy's step timings.
+// Failing to read the checkpoint is not fatal: it is reported and cp is nil,
+// so the deploy simply runs every stage. The caller must call unlock.
+func (c *Client) prepareDeployState(ctx context.Context, cfg *config.NebariConfig, liveClusterID func(context.Context) (string, error)) (unlock func(), clusterID string, cp *checkpoint, err error) {
+	ctx, endStep := steptiming.Start(ctx, "state")
+	defer func() { endStep(err) }()
+
+	state, err := c.openState(ctx, cfg)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	unlock, err = lockState(ctx, state, "deploy")
+	if err != nil {
+		return nil, "", nil, err
+	}
+	clusterID, err = ensureClusterID(ctx, state, liveClusterID, rand.Reader)
+	if err != nil {
+		unlock()
+		return nil, "", nil, err
+	}
+
+	cp, loadErr := loadCheckpoint(ctx, cfg, state)
+	if loadErr != nil {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not load deploy checkpoint, running all stages").
+			WithMetadata("error", loadErr.Error()))
+	}
+	return unlock, clusterID, cp, nil
+}
+
+// lockState takes the deploy lock of state for operation. The returned
+// function releases it and only warns on failure, since a stale lock can be
+// removed by hand; it ignores cancellation of ctx so an interrupted run still
+// unlocks.
+func lockState(ctx context.Context, state stateBackend, operation string) (func(), error) {
+	unlock, err := state.lock(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		if err := unlock(context.WithoutCancel(ctx)); err != nil {
+			status.Send(ctx, status.NewUpdate(status.LevelWarning, "Could not release the deploy state lock; remove it by hand before the next run").
+				WithMetadata("error", err.Error()))
+		}
+	}, nil
+}
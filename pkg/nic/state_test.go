@@ -0,0 +1,191 @@
+package nic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/steptiming"
+)
+
+func TestObjectStateCheckpointRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	cfg := checkpointTestConfig()
+	store := &objectstore.Fake{}
+	state := &objectState{objects: store, prefix: "team/resume-test"}
+
+	cp, err := loadCheckpoint(ctx, cfg, state)
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	for _, stage := range []Stage{StageInfrastructure, StageGitOps} {
+		if err := cp.markComplete(ctx, stage); err != nil {
+			t.Fatalf("markComplete(%s) error = %v", stage, err)
+		}
+	}
+	if _, _, err := store.Get(ctx, "team/resume-test/checkpoint.json"); err != nil {
+		t.Fatalf("checkpoint object not written: %v", err)
+	}
+
+	// A second run sharing the bucket sees the first run's progress.
+	other := &objectState{objects: store, prefix: "team/resume-test"}
+	reloaded, err := loadCheckpoint(ctx, cfg, other)
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	if !reloaded.done(StageInfrastructure) || !reloaded.done(StageGitOps) {
+		t.Errorf("reloaded completed = %v, want [infrastructure gitops]", reloaded.Completed)
+	}
+
+	if err := reloaded.clear(ctx); err != nil {
+		t.Fatalf("clear() error = %v", err)
+	}
+	if _, _, err := store.Get(ctx, "team/resume-test/checkpoint.json"); !errors.Is(err, objectstore.ErrNotExist) {
+		t.Errorf("checkpoint object still present after clear: %v", err)
+	}
+}
+
+func TestObjectStateRejectsConcurrentWrite(t *testing.T) {
+	ctx := context.Background()
+	cfg := checkpointTestConfig()
+	store := &objectstore.Fake{}
+
+	first, err := loadCheckpoint(ctx, cfg, &objectState{objects: store, prefix: "p"})
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	second, err := loadCheckpoint(ctx, cfg, &objectState{objects: store, prefix: "p"})
+	if err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+
+	if err := first.markComplete(ctx, StageInfrastructure); err != nil {
+		t.Fatalf("first markComplete() error = %v", err)
+	}
+	err = second.markComplete(ctx, StageInfrastructure)
+	if !errors.Is(err, objectstore.ErrConflict) {
+		t.Fatalf("second markComplete() error = %v, want ErrConflict", err)
+	}
+	if err := second.clear(ctx); !errors.Is(err, objectstore.ErrConflict) {
+		t.Errorf("clear() with a stale version error = %v, want ErrConflict", err)
+	}
+}
+
+func TestObjectStateLock(t *testing.T) {
+	ctx := context.Background()
+	store := &objectstore.Fake{}
+	state := &objectState{objects: store, prefix: "p"}
+
+	unlock, err := state.lock(ctx, "deploy")
+	if err != nil {
+		t.Fatalf("lock() error = %v", err)
+	}
+
+	_, err = (&objectState{objects: store, prefix: "p"}).lock(ctx, "destroy")
+	if !errors.Is(err, ErrStateLocked) {
+		t.Fatalf("second lock() error = %v, want ErrStateLocked", err)
+	}
+	for _, want := range []string{"deploy by", "fake://p/deploy.lock"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("lock error %q does not mention %q", err, want)
+		}
+	}
+
+	// Locks are per prefix.
+	if _, err := (&objectState{objects: store, prefix: "q"}).lock(ctx, "deploy"); err != nil {
+		t.Errorf("lock() on another prefix error = %v", err)
+	}
+
+	if err := unlock(ctx); err != nil {
+		t.Fatalf("unlock() error = %v", err)
+	}
+	if _, err := state.lock(ctx, "deploy"); err != nil {
+		t.Errorf("lock() after unlock error = %v", err)
+	}
+}
+
+func TestOpenState(t *testing.T) {
+	ctx := context.Background()
+	store := &objectstore.Fake{}
+	var opened *config.StateConfig
+	client := &Client{stateObjects: func(_ context.Context, s *config.StateConfig) (objectstore.Objects, error) {
+		opened = s
+		return store, nil
+	}}
+
+	cfg := checkpointTestConfig()
+	cfg.Environment = "dev"
+	cfg.State = &config.StateConfig{Backend: config.StateBackendS3, Bucket: "team-state", Region: "us-west-2"}
+	state, err := client.openState(ctx, cfg)
+	if err != nil {
+		t.Fatalf("openState() error = %v", err)
+	}
+	if opened != cfg.State {
+		t.Error("remote backend opened without the state config")
+	}
+	if got, ok := state.(*objectState); !ok || got.prefix != "resume-test-dev" {
+		t.Errorf("openState() = %#v, want objectState with the resource name as prefix", state)
+	}
+
+	cfg.State.Key = "platform/dev"
+	state, _ = client.openState(ctx, cfg)
+	if got := state.(*objectState).prefix; got != "platform/dev" {
+		t.Errorf("prefix = %q, want the configured key", got)
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	cfg.State = nil
+	state, err = client.openState(ctx, cfg)
+	if err != nil {
+		t.Fatalf("openState() error = %v", err)
+	}
+	if _, ok := state.(*fileState); !ok {
+		t.Errorf("openState() without a state block = %T, want *fileState", state)
+	}
+}
+
+// Remote state access runs as its own deploy step, so a slow state backend
+// shows up in the step timings.
+func TestPrepareDeployStateIsTimed(t *testing.T) {
+	store := &objectstore.Fake{}
+	client := &Client{stateObjects: func(_ context.Context, _ *config.StateConfig) (objectstore.Objects, error) {
+		return store, nil
+	}}
+	cfg := checkpointTestConfig()
+	cfg.State = &config.StateConfig{Backend: config.StateBackendS3, Bucket: "team-state", Region: "us-west-2"}
+
+	recorder := steptiming.NewRecorder()
+	ctx := steptiming.WithRecorder(context.Background(), recorder)
+	unlock, clusterID, _, err := client.prepareDeployState(ctx, cfg, nil)
+	if err != nil {
+		t.Fatalf("prepareDeployState() error = %v", err)
+	}
+	if clusterID == "" {
+		t.Error("prepareDeployState() assigned no cluster ID")
+	}
+
+	if _, _, _, err := client.prepareDeployState(ctx, cfg, nil); !errors.Is(err, ErrStateLocked) {
+		t.Fatalf("prepareDeployState() while locked error = %v, want ErrStateLocked", err)
+	}
+	unlock()
+
+	steps := recorder.Slowest(5)
+	if len(steps) != 2 {
+		t.Fatalf("recorded %d steps, want 2: %+v", len(steps), steps)
+	}
+	failed := 0
+	for _, s := range steps {
+		if s.Name != "state" {
+			t.Errorf("step name = %q, want state", s.Name)
+		}
+		if s.Failed {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("%d failed state steps, want 1 (the locked attempt)", failed)
+	}
+}
@@ -13,7 +13,10 @@ import (
 // to status.StartHandler. For plain slog-only integration, use StartSlogHandler.
 func SlogHandler(logger *slog.Logger) status.Handler {
 	return func(update status.Update) {
-		attrs := make([]slog.Attr, 0, 2+len(update.Metadata))
+		attrs := make([]slog.Attr, 0, 3+len(update.Metadata))
+		// slog levels cannot express progress or success; carry the
+		// status level so handlers can render it.
+		attrs = append(attrs, slog.String("status", string(update.Level)))
 		if update.Resource != "" {
 			attrs = append(attrs, slog.String("resource", update.Resource))
 		}
@@ -46,8 +49,8 @@ func mapSlogLevel(l status.Level) slog.Level {
 	default:
 		// Info, Progress, Success, and any future levels render at info —
 		// the level enum is carried by slog.Level, the semantic distinction
-		// stays as the Update.Level value (and can be re-derived from the
-		// status.Level attr if a handler wants it).
+		// stays as the Update.Level value, which SlogHandler emits as the
+		// "status" attr.
 		return slog.LevelInfo
 	}
 }
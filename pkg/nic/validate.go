@@ -6,7 +6,11 @@ import (
 
 	"go.opentelemetry.io/otel"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/argocd"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/validation"
 )
 
 // Validate checks that cfg is well-formed and references providers that are
@@ -19,7 +23,7 @@ func (c *Client) Validate(ctx context.Context, cfg *config.NebariConfig) error {
 
 	if err := cfg.Validate(validateOptions(ctx, c.registry)); err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
 	}
 
 	// Reject Longhorn backups on a cluster whose storage layer is not Longhorn.
@@ -33,8 +37,49 @@ func (c *Client) Validate(ctx context.Context, cfg *config.NebariConfig) error {
 	}
 	if err := ensureBackupsHaveLonghorn(cfg, clusterProvider.InfraSettings(cfg.Cluster).StorageClass); err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
 	}
 
+	// Component dependencies and image overrides are checked against the
+	// foundational Applications, which the config package does not know
+	// about.
+	if !cfg.InfraOnly {
+		if _, err := argocd.ComponentWaves(cfg.Components); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("%w: invalid components: %w", config.ErrInvalidConfig, err)
+		}
+		if err := argocd.CheckImageOverrides(cfg.Images); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("%w: invalid images: %w", config.ErrInvalidConfig, err)
+		}
+		if err := argocd.CheckValueOverrides(cfg.Overrides); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("%w: invalid overrides: %w", config.ErrInvalidConfig, err)
+		}
+	}
+
+	if err := runRules(ctx, c.registry, cfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// runRules checks cfg against the provider-registered rules. Warnings are
+// reported through status; errors fail with config.ErrInvalidConfig.
+func runRules(ctx context.Context, reg *registry.Registry, cfg *config.NebariConfig) error {
+	findings := reg.Rules.Run(ctx, cfg)
+	for _, f := range findings {
+		if f.Severity == validation.SeverityWarning {
+			status.Send(ctx, status.NewUpdate(status.LevelWarning, f.Err.Error()).
+				WithResource("config").
+				WithAction("validate").
+				WithMetadata("rule", f.Rule))
+		}
+	}
+	if err := validation.Errors(findings); err != nil {
+		return fmt.Errorf("%w: %w", config.ErrInvalidConfig, err)
+	}
 	return nil
 }
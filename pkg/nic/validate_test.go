@@ -0,0 +1,143 @@
+package nic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster/local"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/registry"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/validation"
+)
+
+func TestValidateRunsRegisteredRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity validation.Severity
+		wantErr  bool
+	}{
+		{name: "warning is reported", severity: validation.SeverityWarning},
+		{name: "error fails", severity: validation.SeverityError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := registry.NewRegistry()
+			if err := reg.ClusterProviders.Register(context.Background(), "local", local.NewProvider()); err != nil {
+				t.Fatal(err)
+			}
+			ran := false
+			rule := validation.Rule{Name: "always", Severity: tt.severity, Check: func(context.Context, *config.NebariConfig) error {
+				ran = true
+				return errors.New("rule tripped")
+			}}
+			if err := reg.Rules.Register(context.Background(), validation.ClusterScope("local"), rule); err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				mu       sync.Mutex
+				warnings []status.Update
+			)
+			ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+				if u.Level == status.LevelWarning {
+					mu.Lock()
+					warnings = append(warnings, u)
+					mu.Unlock()
+				}
+			})
+			cfg := &config.NebariConfig{
+				ProjectName: "test",
+				Cluster:     &config.ClusterConfig{Providers: map[string]any{"local": map[string]any{}}},
+			}
+			err := (&Client{registry: reg}).Validate(ctx, cfg)
+			cleanup()
+
+			if !ran {
+				t.Fatal("registered rule did not run")
+			}
+			if tt.wantErr {
+				if !errors.Is(err, config.ErrInvalidConfig) || !strings.Contains(err.Error(), "cluster/local/always: rule tripped") {
+					t.Fatalf("Validate() error = %v, want the rule's error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v, want warnings not to fail", err)
+			}
+			if len(warnings) != 1 || warnings[0].Metadata["rule"] != "cluster/local/always" {
+				t.Errorf("warnings = %+v, want one for the rule", warnings)
+			}
+		})
+	}
+}
+
+func TestDeployRunsRegisteredRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity validation.Severity
+		wantErr  bool
+	}{
+		{name: "warning is reported and deploy continues", severity: validation.SeverityWarning},
+		{name: "error stops the deploy", severity: validation.SeverityError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			provider := &recordingDeployProvider{}
+			reg := registry.NewRegistry()
+			if err := reg.ClusterProviders.Register(context.Background(), "aws", provider); err != nil {
+				t.Fatal(err)
+			}
+			rule := validation.Rule{Name: "always", Severity: tt.severity, Check: func(context.Context, *config.NebariConfig) error {
+				return errors.New("rule tripped")
+			}}
+			if err := reg.Rules.Register(context.Background(), validation.ClusterScope("aws"), rule); err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				mu       sync.Mutex
+				warnings []status.Update
+			)
+			ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+				if u.Level == status.LevelWarning && u.Metadata["rule"] != nil {
+					mu.Lock()
+					warnings = append(warnings, u)
+					mu.Unlock()
+				}
+			})
+			cfg := &config.NebariConfig{
+				ProjectName: "demo",
+				InfraOnly:   true,
+				Cluster:     &config.ClusterConfig{Providers: map[string]any{"aws": map[string]any{}}},
+			}
+			_, err := (&Client{registry: reg}).Deploy(ctx, cfg, DeployOptions{})
+			cleanup()
+
+			if tt.wantErr {
+				if !errors.Is(err, config.ErrInvalidConfig) || !strings.Contains(err.Error(), "cluster/aws/always: rule tripped") {
+					t.Fatalf("Deploy() error = %v, want the rule's error", err)
+				}
+				if provider.deployed {
+					t.Error("infrastructure was deployed despite a failing rule")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Deploy() error = %v, want warnings not to fail", err)
+			}
+			if !provider.deployed {
+				t.Error("infrastructure was not deployed")
+			}
+			if len(warnings) != 1 || warnings[0].Metadata["rule"] != "cluster/aws/always" {
+				t.Errorf("warnings = %+v, want one for the rule", warnings)
+			}
+		})
+	}
+}
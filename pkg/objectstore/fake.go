@@ -0,0 +1,122 @@
+package objectstore
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Fake is an in-memory Store and Objects for tests. Object versions are
+// assigned from a counter, so every write yields a new version. The zero
+// value is ready to use.
+type Fake struct {
+	mu      sync.Mutex
+	buckets map[string]BucketSpec
+	objects map[string]fakeObject
+	seq     int
+
+	// Err, when set, is returned by every call.
+	Err error
+
+	// Creates counts buckets EnsureBucket actually created.
+	Creates int
+}
+
+type fakeObject struct {
+	data    []byte
+	version string
+}
+
+var (
+	_ Store   = (*Fake)(nil)
+	_ Objects = (*Fake)(nil)
+)
+
+// EnsureBucket records spec unless a bucket of the same name already exists.
+func (f *Fake) EnsureBucket(_ context.Context, spec BucketSpec) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return false, f.Err
+	}
+	if _, ok := f.buckets[spec.Name]; ok {
+		return false, nil
+	}
+	if f.buckets == nil {
+		f.buckets = map[string]BucketSpec{}
+	}
+	spec.Tags = maps.Clone(spec.Tags)
+	f.buckets[spec.Name] = spec
+	f.Creates++
+	return true, nil
+}
+
+// Bucket returns the spec a bucket was created with.
+func (f *Fake) Bucket(name string) (BucketSpec, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	spec, ok := f.buckets[name]
+	return spec, ok
+}
+
+// Get returns a copy of the object stored under key.
+func (f *Fake) Get(_ context.Context, key string) ([]byte, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return nil, "", f.Err
+	}
+	obj, ok := f.objects[key]
+	if !ok {
+		return nil, "", ErrNotExist
+	}
+	return slices.Clone(obj.data), obj.version, nil
+}
+
+// Put stores data under key if the object is still at ifVersion.
+func (f *Fake) Put(_ context.Context, key string, data []byte, ifVersion string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return "", f.Err
+	}
+	if f.objects[key].version != ifVersion {
+		return "", ErrConflict
+	}
+	if f.objects == nil {
+		f.objects = map[string]fakeObject{}
+	}
+	f.seq++
+	version := strconv.Itoa(f.seq)
+	f.objects[key] = fakeObject{data: slices.Clone(data), version: version}
+	return version, nil
+}
+
+// Delete removes key if the object is still at ifVersion.
+func (f *Fake) Delete(_ context.Context, key, ifVersion string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return f.Err
+	}
+	obj, ok := f.objects[key]
+	if !ok {
+		return nil
+	}
+	if obj.version != ifVersion {
+		return ErrConflict
+	}
+	delete(f.objects, key)
+	return nil
+}
+
+// Location returns a fake:// URL for key.
+func (f *Fake) Location(key string) string {
+	return "fake://" + key
+}
@@ -0,0 +1,37 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotExist is returned when an object does not exist.
+	ErrNotExist = errors.New("object does not exist")
+
+	// ErrConflict is returned when a conditional write or delete loses a race:
+	// the object was created, changed or removed since the caller last read
+	// it.
+	ErrConflict = errors.New("object was modified concurrently")
+)
+
+// Objects reads and writes single objects in one bucket with optimistic
+// concurrency. Each object carries an opaque version (an ETag on S3) that
+// changes on every write; writes and deletes name the version they expect to
+// replace and fail with ErrConflict if another writer got there first.
+type Objects interface {
+	// Get returns the object's content and current version, or ErrNotExist.
+	Get(ctx context.Context, key string) (data []byte, version string, err error)
+
+	// Put writes data if the object is still at ifVersion, and returns the
+	// new version. An empty ifVersion means the object must not exist yet.
+	Put(ctx context.Context, key string, data []byte, ifVersion string) (version string, err error)
+
+	// Delete removes the object if it is still at ifVersion. Deleting an
+	// object that does not exist is not an error.
+	Delete(ctx context.Context, key, ifVersion string) error
+
+	// Location describes where key is stored, for messages (e.g.
+	// "s3://bucket/key").
+	Location(key string) string
+}
@@ -0,0 +1,43 @@
+// Package objectstore is the cross-provider abstraction NIC uses to provision
+// object-storage buckets it owns, such as the OpenTofu state bucket. Each
+// cluster provider supplies a Store for its cloud (an S3 bucket, an Azure
+// Blob container, ...); callers only ever ask for a bucket to exist.
+package objectstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+)
+
+// BucketSpec describes a bucket NIC should own.
+type BucketSpec struct {
+	// Name is the bucket (or container) name. Callers are responsible for
+	// making it valid and, where the cloud requires it, globally unique; see
+	// Suffix.
+	Name string
+
+	// Region is where the bucket is created. Stores whose location is fixed
+	// by an enclosing resource (an Azure storage account) ignore it.
+	Region string
+
+	// Tags are applied to a newly created bucket alongside the store's own
+	// NIC managed-by marker.
+	Tags map[string]string
+}
+
+// Store creates NIC-managed buckets on one cloud.
+type Store interface {
+	// EnsureBucket creates the bucket described by spec if it does not
+	// already exist and reports whether it did. It is idempotent: an existing
+	// bucket is left as-is, including its tags, and yields (false, nil).
+	EnsureBucket(ctx context.Context, spec BucketSpec) (created bool, err error)
+}
+
+// Suffix returns the first n hex characters of SHA-256(seed). Bucket names
+// that must be globally unique (S3 buckets, Azure storage accounts) append it
+// to a fixed prefix, seeded with the account or subscription ID, so the name
+// is stable across runs without exposing the ID itself. n is capped at 64.
+func Suffix(seed string, n int) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(seed)))[:min(n, sha256.Size*2)]
+}
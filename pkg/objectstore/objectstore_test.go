@@ -0,0 +1,88 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSuffix(t *testing.T) {
+	a := Suffix("123456789012", 8)
+	if len(a) != 8 {
+		t.Fatalf("Suffix() = %q, want 8 characters", a)
+	}
+	if a != Suffix("123456789012", 8) {
+		t.Error("Suffix() is not deterministic")
+	}
+	if a == Suffix("210987654321", 8) {
+		t.Error("Suffix() collides for distinct seeds")
+	}
+	if got := Suffix("x", 100); len(got) != 64 {
+		t.Errorf("Suffix(n=100) length = %d, want 64", len(got))
+	}
+}
+
+func TestFakeEnsureBucketIdempotent(t *testing.T) {
+	ctx := context.Background()
+	var store Fake
+
+	created, err := store.EnsureBucket(ctx, BucketSpec{Name: "state", Tags: map[string]string{"team": "data"}})
+	if err != nil || !created {
+		t.Fatalf("first EnsureBucket() = %v, %v; want true, nil", created, err)
+	}
+	created, err = store.EnsureBucket(ctx, BucketSpec{Name: "state", Tags: map[string]string{"team": "ml"}})
+	if err != nil || created {
+		t.Fatalf("second EnsureBucket() = %v, %v; want false, nil", created, err)
+	}
+	if store.Creates != 1 {
+		t.Errorf("Creates = %d, want 1", store.Creates)
+	}
+	if spec, _ := store.Bucket("state"); spec.Tags["team"] != "data" {
+		t.Errorf("existing bucket tags changed to %v", spec.Tags)
+	}
+
+	store.Err = errors.New("boom")
+	if _, err := store.EnsureBucket(ctx, BucketSpec{Name: "other"}); err == nil {
+		t.Error("EnsureBucket() with Err set returned nil error")
+	}
+}
+
+func TestFakeObjectsConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	var store Fake
+
+	if _, _, err := store.Get(ctx, "state.json"); !errors.Is(err, ErrNotExist) {
+		t.Fatalf("Get() on missing object error = %v, want ErrNotExist", err)
+	}
+
+	v1, err := store.Put(ctx, "state.json", []byte("one"), "")
+	if err != nil {
+		t.Fatalf("create Put() error = %v", err)
+	}
+	if _, err := store.Put(ctx, "state.json", []byte("again"), ""); !errors.Is(err, ErrConflict) {
+		t.Errorf("create-only Put() over existing object error = %v, want ErrConflict", err)
+	}
+
+	v2, err := store.Put(ctx, "state.json", []byte("two"), v1)
+	if err != nil {
+		t.Fatalf("conditional Put() error = %v", err)
+	}
+	if _, err := store.Put(ctx, "state.json", []byte("stale"), v1); !errors.Is(err, ErrConflict) {
+		t.Errorf("Put() with stale version error = %v, want ErrConflict", err)
+	}
+
+	data, version, err := store.Get(ctx, "state.json")
+	if err != nil || string(data) != "two" || version != v2 {
+		t.Errorf("Get() = %q, %q, %v; want %q, %q, nil", data, version, err, "two", v2)
+	}
+
+	if err := store.Delete(ctx, "state.json", v1); !errors.Is(err, ErrConflict) {
+		t.Errorf("Delete() with stale version error = %v, want ErrConflict", err)
+	}
+	if err := store.Delete(ctx, "state.json", v2); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "state.json", v2); err != nil {
+		t.Errorf("Delete() of missing object error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,171 @@
+// Package s3store implements objectstore.Objects on Amazon S3, using S3
+// conditional writes (If-Match / If-None-Match on the object's ETag) for
+// optimistic concurrency.
+package s3store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+)
+
+// API is the subset of the S3 client Objects uses.
+type API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// Objects stores objects in a single S3 bucket. The version of an object is
+// its ETag.
+type Objects struct {
+	client API
+	bucket string
+}
+
+var _ objectstore.Objects = (*Objects)(nil)
+
+// New returns Objects for bucket in region using the default AWS credential
+// chain and the shared SDK configuration, so its calls are timed like every
+// other AWS call. The bucket must already exist.
+func New(ctx context.Context, bucket, region string) (*Objects, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return NewWithClient(s3.NewFromConfig(cfg), bucket), nil
+}
+
+// NewWithClient returns Objects for bucket backed by client.
+func NewWithClient(client API, bucket string) *Objects {
+	return &Objects{client: client, bucket: bucket}
+}
+
+// Get returns the object's content and ETag.
+func (o *Objects) Get(ctx context.Context, key string) ([]byte, string, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "s3store.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bucket", o.bucket), attribute.String("key", key))
+
+	out, err := o.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(o.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, "", objectstore.ErrNotExist
+		}
+		span.RecordError(err)
+		return nil, "", fmt.Errorf("get %s: %w", o.Location(key), err)
+	}
+	defer func() { _ = out.Body.Close() }()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		span.RecordError(err)
+		return nil, "", fmt.Errorf("read %s: %w", o.Location(key), err)
+	}
+	return data, aws.ToString(out.ETag), nil
+}
+
+// Put writes data with If-Match: ifVersion, or If-None-Match: * when
+// ifVersion is empty.
+func (o *Objects) Put(ctx context.Context, key string, data []byte, ifVersion string) (string, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "s3store.Put")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bucket", o.bucket), attribute.String("key", key))
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(o.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	}
+	if ifVersion == "" {
+		input.IfNoneMatch = aws.String("*")
+	} else {
+		input.IfMatch = aws.String(ifVersion)
+	}
+
+	out, err := o.client.PutObject(ctx, input)
+	if err != nil {
+		if isConflict(err) {
+			return "", objectstore.ErrConflict
+		}
+		span.RecordError(err)
+		return "", fmt.Errorf("put %s: %w", o.Location(key), err)
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+// Delete removes the object with If-Match: ifVersion.
+func (o *Objects) Delete(ctx context.Context, key, ifVersion string) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "s3store.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bucket", o.bucket), attribute.String("key", key))
+
+	input := &s3.DeleteObjectInput{
+		Bucket: aws.String(o.bucket),
+		Key:    aws.String(key),
+	}
+	if ifVersion != "" {
+		input.IfMatch = aws.String(ifVersion)
+	}
+
+	if _, err := o.client.DeleteObject(ctx, input); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		if isConflict(err) {
+			return objectstore.ErrConflict
+		}
+		span.RecordError(err)
+		return fmt.Errorf("delete %s: %w", o.Location(key), err)
+	}
+	return nil
+}
+
+// Location returns the s3:// URL of key.
+func (o *Objects) Location(key string) string {
+	return fmt.Sprintf("s3://%s/%s", o.bucket, key)
+}
+
+// isNotFound reports whether err means the object does not exist.
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	return errors.As(err, &noSuchKey) || errors.As(err, &notFound)
+}
+
+// isConflict reports whether err is a failed S3 conditional request: 412
+// PreconditionFailed when the ETag no longer matches, or 409
+// ConditionalRequestConflict when a concurrent write to the same key won.
+func isConflict(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "PreconditionFailed", "ConditionalRequestConflict":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package s3store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+)
+
+// mockAPI records the last PutObject and DeleteObject inputs and returns err
+// from every call when set.
+type mockAPI struct {
+	err        error
+	lastPut    *s3.PutObjectInput
+	lastDelete *s3.DeleteObjectInput
+}
+
+func (m *mockAPI) GetObject(_ context.Context, _ *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("{}")), ETag: aws.String(`"abc"`)}, nil
+}
+
+func (m *mockAPI) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	m.lastPut = params
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &s3.PutObjectOutput{ETag: aws.String(`"def"`)}, nil
+}
+
+func (m *mockAPI) DeleteObject(_ context.Context, params *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	m.lastDelete = params
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestObjectsConditionalHeaders(t *testing.T) {
+	ctx := context.Background()
+	api := &mockAPI{}
+	objects := NewWithClient(api, "team-state")
+
+	if _, err := objects.Put(ctx, "prod/checkpoint.json", []byte("{}"), ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if aws.ToString(api.lastPut.IfNoneMatch) != "*" || api.lastPut.IfMatch != nil {
+		t.Errorf("create Put sent If-None-Match=%v If-Match=%v, want * and none", api.lastPut.IfNoneMatch, api.lastPut.IfMatch)
+	}
+
+	version, err := objects.Put(ctx, "prod/checkpoint.json", []byte("{}"), `"abc"`)
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if aws.ToString(api.lastPut.IfMatch) != `"abc"` || api.lastPut.IfNoneMatch != nil {
+		t.Errorf("update Put sent If-Match=%v If-None-Match=%v, want \"abc\" and none", api.lastPut.IfMatch, api.lastPut.IfNoneMatch)
+	}
+	if version != `"def"` {
+		t.Errorf("Put() version = %q, want the returned ETag", version)
+	}
+
+	if err := objects.Delete(ctx, "prod/deploy.lock", `"def"`); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if aws.ToString(api.lastDelete.IfMatch) != `"def"` {
+		t.Errorf("Delete sent If-Match=%v, want \"def\"", api.lastDelete.IfMatch)
+	}
+}
+
+func TestObjectsErrorMapping(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "precondition failed", err: &smithy.GenericAPIError{Code: "PreconditionFailed"}, want: objectstore.ErrConflict},
+		{name: "conditional request conflict", err: &smithy.GenericAPIError{Code: "ConditionalRequestConflict"}, want: objectstore.ErrConflict},
+		{name: "access denied", err: &smithy.GenericAPIError{Code: "AccessDenied"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := NewWithClient(&mockAPI{err: tt.err}, "team-state")
+			_, err := objects.Put(ctx, "k", nil, "")
+			if tt.want != nil {
+				if !errors.Is(err, tt.want) {
+					t.Errorf("Put() error = %v, want %v", err, tt.want)
+				}
+				return
+			}
+			if err == nil || errors.Is(err, objectstore.ErrConflict) {
+				t.Errorf("Put() error = %v, want a wrapped non-conflict error", err)
+			}
+		})
+	}
+
+	objects := NewWithClient(&mockAPI{err: &types.NoSuchKey{}}, "team-state")
+	if _, _, err := objects.Get(ctx, "k"); !errors.Is(err, objectstore.ErrNotExist) {
+		t.Errorf("Get() of missing key error = %v, want ErrNotExist", err)
+	}
+	if err := objects.Delete(ctx, "k", `"abc"`); err != nil {
+		t.Errorf("Delete() of missing key error = %v, want nil", err)
+	}
+}
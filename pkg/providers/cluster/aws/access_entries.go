@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// principalARNPattern matches the IAM role and user ARNs EKS access entries
+// accept.
+var principalARNPattern = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::\d{12}:(role|user)/[\w+=,.@/-]+$`)
+
+// accessPolicies maps the access_entries policy names to the EKS access
+// policies they associate.
+var accessPolicies = map[string]string{
+	"cluster-admin": "AmazonEKSClusterAdminPolicy",
+	"admin":         "AmazonEKSAdminPolicy",
+	"edit":          "AmazonEKSEditPolicy",
+	"view":          "AmazonEKSViewPolicy",
+}
+
+const defaultAccessPolicy = "cluster-admin"
+
+// AccessEntry grants an IAM role or user kubectl access to the cluster
+// through an EKS access entry.
+type AccessEntry struct {
+	PrincipalARN string `yaml:"principal_arn"`
+	// Policy is cluster-admin (the default), admin, edit or view, after the
+	// EKS access policies of the same names.
+	Policy string `yaml:"policy,omitempty"`
+	// Namespaces limits Policy to these namespaces. Unset grants it
+	// cluster-wide.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+}
+
+// accessEntryVar is the OpenTofu shape of an AccessEntry, keyed by its
+// principal ARN.
+type accessEntryVar struct {
+	Policy     string   `json:"policy"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// validateAccessEntries checks the access_entries block.
+func validateAccessEntries(c *Config) error {
+	seen := map[string]bool{}
+	for i, entry := range c.AccessEntries {
+		if !principalARNPattern.MatchString(entry.PrincipalARN) {
+			return fmt.Errorf("access_entries[%d]: %q is not an IAM role or user ARN", i, entry.PrincipalARN)
+		}
+		if seen[entry.PrincipalARN] {
+			return fmt.Errorf("access_entries[%d]: %s is listed more than once", i, entry.PrincipalARN)
+		}
+		seen[entry.PrincipalARN] = true
+		if entry.Policy != "" {
+			if _, ok := accessPolicies[entry.Policy]; !ok {
+				return fmt.Errorf("access_entries[%d]: invalid policy %q (must be cluster-admin, admin, edit or view)", i, entry.Policy)
+			}
+		}
+		if slices.Contains(entry.Namespaces, "") {
+			return fmt.Errorf("access_entries[%d]: namespaces must not be empty", i)
+		}
+	}
+	return nil
+}
+
+// accessEntryVars converts the configured access entries to their OpenTofu
+// variable form.
+func (c *Config) accessEntryVars() map[string]accessEntryVar {
+	if len(c.AccessEntries) == 0 {
+		return nil
+	}
+	vars := make(map[string]accessEntryVar, len(c.AccessEntries))
+	for _, entry := range c.AccessEntries {
+		policy := entry.Policy
+		if policy == "" {
+			policy = defaultAccessPolicy
+		}
+		namespaces := entry.Namespaces
+		if namespaces == nil {
+			namespaces = []string{}
+		}
+		vars[entry.PrincipalARN] = accessEntryVar{Policy: accessPolicies[policy], Namespaces: namespaces}
+	}
+	return vars
+}
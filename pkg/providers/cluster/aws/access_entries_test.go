@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateAccessEntries(t *testing.T) {
+	const admin = "arn:aws:iam::123456789012:role/platform-admins"
+
+	tests := []struct {
+		name      string
+		entries   []AccessEntry
+		errSubstr string // "" means no error expected
+	}{
+		{name: "none configured"},
+		{
+			name: "role and user",
+			entries: []AccessEntry{
+				{PrincipalARN: admin},
+				{PrincipalARN: "arn:aws-us-gov:iam::123456789012:user/path/alice", Policy: "view", Namespaces: []string{"dev"}},
+			},
+		},
+		{
+			name:      "assumed role session",
+			entries:   []AccessEntry{{PrincipalARN: "arn:aws:sts::123456789012:assumed-role/platform-admins/alice"}},
+			errSubstr: "not an IAM role or user ARN",
+		},
+		{
+			name:      "duplicate principal",
+			entries:   []AccessEntry{{PrincipalARN: admin}, {PrincipalARN: admin, Policy: "view"}},
+			errSubstr: "more than once",
+		},
+		{
+			name:      "unknown policy",
+			entries:   []AccessEntry{{PrincipalARN: admin, Policy: "AmazonEKSViewPolicy"}},
+			errSubstr: "invalid policy",
+		},
+		{
+			name:      "empty namespace",
+			entries:   []AccessEntry{{PrincipalARN: admin, Policy: "edit", Namespaces: []string{""}}},
+			errSubstr: "namespaces must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAccessEntries(&Config{AccessEntries: tt.entries})
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("expected error containing %q, got %v", tt.errSubstr, err)
+			}
+		})
+	}
+}
+
+func TestToTFVarsAccessEntries(t *testing.T) {
+	cfg := &Config{
+		Region: "us-west-2",
+		AccessEntries: []AccessEntry{
+			{PrincipalARN: "arn:aws:iam::123456789012:role/platform-admins"},
+			{PrincipalARN: "arn:aws:iam::123456789012:user/alice", Policy: "edit", Namespaces: []string{"dev", "staging"}},
+		},
+	}
+	got := cfg.toTFVars("proj", "", nil).AccessEntries
+	want := map[string]accessEntryVar{
+		"arn:aws:iam::123456789012:role/platform-admins": {Policy: "AmazonEKSClusterAdminPolicy", Namespaces: []string{}},
+		"arn:aws:iam::123456789012:user/alice":           {Policy: "AmazonEKSEditPolicy", Namespaces: []string{"dev", "staging"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("access_entries = %+v, want %+v", got, want)
+	}
+
+	data, err := json.Marshal((&Config{Region: "us-west-2"}).toTFVars("proj", "", nil))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(data), "access_entries") {
+		t.Errorf("expected access_entries to be omitted when unset:\n%s", data)
+	}
+}
@@ -0,0 +1,274 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/mod/semver"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// addonVersionLatest selects the newest addon version for the cluster.
+const addonVersionLatest = "latest"
+
+// supportedAddons are the EKS managed addons NIC reconciles.
+var supportedAddons = []string{"vpc-cni", "coredns", "kube-proxy", "aws-ebs-csi-driver"}
+
+// AddonClient defines the EKS operations needed to reconcile managed addons.
+type AddonClient interface {
+	DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error)
+	DescribeAddon(ctx context.Context, params *eks.DescribeAddonInput, optFns ...func(*eks.Options)) (*eks.DescribeAddonOutput, error)
+	DescribeAddonVersions(ctx context.Context, params *eks.DescribeAddonVersionsInput, optFns ...func(*eks.Options)) (*eks.DescribeAddonVersionsOutput, error)
+	CreateAddon(ctx context.Context, params *eks.CreateAddonInput, optFns ...func(*eks.Options)) (*eks.CreateAddonOutput, error)
+	UpdateAddon(ctx context.Context, params *eks.UpdateAddonInput, optFns ...func(*eks.Options)) (*eks.UpdateAddonOutput, error)
+}
+
+func newAddonClient(ctx context.Context, region string) (AddonClient, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return eks.NewFromConfig(cfg), nil
+}
+
+// addonPollInterval is how often an addon being created or updated is checked.
+var addonPollInterval = 10 * time.Second
+
+// addonTimeout bounds the wait for one addon to settle.
+const addonTimeout = 20 * time.Minute
+
+// validateAddons checks the addons block without calling AWS.
+func validateAddons(addons map[string]Addon) error {
+	for _, name := range slices.Sorted(maps.Keys(addons)) {
+		if !slices.Contains(supportedAddons, name) {
+			return fmt.Errorf("unsupported addon %q (expected one of %s)", name, strings.Join(supportedAddons, ", "))
+		}
+		addon := addons[name]
+		if v := addon.Version; v != "" && v != addonVersionLatest && !semver.IsValid(v) {
+			return fmt.Errorf("addon %s: invalid version %q (expected e.g. v1.19.0-eksbuild.1 or %q)", name, v, addonVersionLatest)
+		}
+		if arn := addon.ServiceAccountRoleARN; arn != "" && !strings.HasPrefix(arn, "arn:") {
+			return fmt.Errorf("addon %s: invalid service_account_role_arn %q", name, arn)
+		}
+	}
+	return nil
+}
+
+// reconcileAddons creates or updates each addon in addons so it runs the
+// configured version and service account role, and waits for it to settle.
+// An unset version or role is left as it is on the live addon; an addon
+// created without a version gets the EKS default for the cluster.
+// An addon that ends up DEGRADED is reported as a warning, since EKS keeps
+// retrying and the cluster is usable meanwhile; a failed create or update is
+// an error. With dryRun, the pending changes are only reported. A cluster
+// that does not exist yet has nothing to reconcile. Returns whether any addon
+// needed a change.
+func reconcileAddons(ctx context.Context, client AddonClient, clusterName string, addons map[string]Addon, dryRun bool) (bool, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.reconcileAddons")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cluster_name", clusterName),
+		attribute.Int("addons", len(addons)),
+		attribute.Bool("dry_run", dryRun),
+	)
+
+	if len(addons) == 0 {
+		return false, nil
+	}
+	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		var notFound *ekstypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to describe EKS cluster %s: %w", clusterName, err)
+	}
+	kubernetesVersion := aws.ToString(out.Cluster.Version)
+
+	changed := false
+	for _, name := range slices.Sorted(maps.Keys(addons)) {
+		c, err := reconcileAddon(ctx, client, clusterName, kubernetesVersion, name, addons[name], dryRun)
+		if err != nil {
+			span.RecordError(err)
+			return changed, err
+		}
+		changed = changed || c
+	}
+	return changed, nil
+}
+
+// reconcileAddon brings a single addon in line with want.
+func reconcileAddon(ctx context.Context, client AddonClient, clusterName, kubernetesVersion, name string, want Addon, dryRun bool) (bool, error) {
+	var live *ekstypes.Addon
+	out, err := client.DescribeAddon(ctx, &eks.DescribeAddonInput{ClusterName: aws.String(clusterName), AddonName: aws.String(name)})
+	if err != nil {
+		var notFound *ekstypes.ResourceNotFoundException
+		if !errors.As(err, &notFound) {
+			return false, fmt.Errorf("failed to describe addon %s: %w", name, err)
+		}
+	} else {
+		live = out.Addon
+	}
+
+	version := want.Version
+	switch {
+	case version == addonVersionLatest:
+		if version, err = latestAddonVersion(ctx, client, kubernetesVersion, name); err != nil {
+			return false, err
+		}
+	case version == "" && live != nil:
+		version = aws.ToString(live.AddonVersion)
+	}
+
+	verb := ""
+	switch {
+	case live == nil:
+		verb = "create"
+	case aws.ToString(live.AddonVersion) != version:
+		verb = "update"
+	case want.ServiceAccountRoleARN != "" && aws.ToString(live.ServiceAccountRoleArn) != want.ServiceAccountRoleARN:
+		verb = "update"
+	}
+	if verb == "" {
+		return false, nil
+	}
+
+	target := version
+	if target == "" {
+		target = "the EKS default version"
+	}
+	level, action := status.LevelProgress, verb[:len(verb)-1]+"ing"
+	if dryRun {
+		level, action = status.LevelInfo, "drifted"
+	}
+	update := status.NewUpdate(level, fmt.Sprintf("EKS addon %s: %s to %s", name, verb, target)).
+		WithResource("eks-addon").
+		WithAction(action).
+		WithMetadata("cluster_name", clusterName).
+		WithMetadata("addon", name).
+		WithMetadata("version", version)
+	if live != nil {
+		update = update.WithMetadata("live_version", aws.ToString(live.AddonVersion))
+	}
+	status.Send(ctx, update)
+	if dryRun {
+		return true, nil
+	}
+
+	var versionArg, role *string
+	if version != "" {
+		versionArg = aws.String(version)
+	}
+	if want.ServiceAccountRoleARN != "" {
+		role = aws.String(want.ServiceAccountRoleARN)
+	}
+	if live == nil {
+		_, err = client.CreateAddon(ctx, &eks.CreateAddonInput{
+			ClusterName:           aws.String(clusterName),
+			AddonName:             aws.String(name),
+			AddonVersion:          versionArg,
+			ServiceAccountRoleArn: role,
+			ResolveConflicts:      ekstypes.ResolveConflictsOverwrite,
+		})
+	} else {
+		_, err = client.UpdateAddon(ctx, &eks.UpdateAddonInput{
+			ClusterName:           aws.String(clusterName),
+			AddonName:             aws.String(name),
+			AddonVersion:          aws.String(version),
+			ServiceAccountRoleArn: role,
+			ResolveConflicts:      ekstypes.ResolveConflictsOverwrite,
+		})
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to %s addon %s: %w", verb, name, err)
+	}
+	return true, waitForAddon(ctx, client, clusterName, name)
+}
+
+// latestAddonVersion returns the highest version of addon name EKS offers
+// for kubernetesVersion.
+func latestAddonVersion(ctx context.Context, client AddonClient, kubernetesVersion, name string) (string, error) {
+	out, err := client.DescribeAddonVersions(ctx, &eks.DescribeAddonVersionsInput{
+		AddonName:         aws.String(name),
+		KubernetesVersion: aws.String(kubernetesVersion),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list versions of addon %s: %w", name, err)
+	}
+	latest := ""
+	for _, info := range out.Addons {
+		for _, v := range info.AddonVersions {
+			if version := aws.ToString(v.AddonVersion); latest == "" || semver.Compare(version, latest) > 0 {
+				latest = version
+			}
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no version of addon %s is available for Kubernetes %s", name, kubernetesVersion)
+	}
+	return latest, nil
+}
+
+// waitForAddon polls the addon until it is ACTIVE or DEGRADED, reporting
+// DEGRADED as a warning with the health issues EKS lists.
+func waitForAddon(ctx context.Context, client AddonClient, clusterName, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, addonTimeout)
+	defer cancel()
+	for {
+		out, err := client.DescribeAddon(ctx, &eks.DescribeAddonInput{ClusterName: aws.String(clusterName), AddonName: aws.String(name)})
+		if err != nil {
+			return fmt.Errorf("failed to describe addon %s: %w", name, err)
+		}
+		addon := out.Addon
+		switch addon.Status {
+		case ekstypes.AddonStatusActive:
+			status.Send(ctx, status.NewUpdate(status.LevelSuccess, fmt.Sprintf("EKS addon %s is active", name)).
+				WithResource("eks-addon").
+				WithAction("ready").
+				WithMetadata("addon", name).
+				WithMetadata("version", aws.ToString(addon.AddonVersion)))
+			return nil
+		case ekstypes.AddonStatusDegraded:
+			status.Send(ctx, status.NewUpdate(status.LevelWarning, fmt.Sprintf("EKS addon %s is degraded: %s", name, addonIssues(addon))).
+				WithResource("eks-addon").
+				WithAction("degraded").
+				WithMetadata("addon", name).
+				WithMetadata("version", aws.ToString(addon.AddonVersion)))
+			return nil
+		case ekstypes.AddonStatusCreateFailed, ekstypes.AddonStatusUpdateFailed:
+			return fmt.Errorf("EKS addon %s %s: %s", name, strings.ToLower(string(addon.Status)), addonIssues(addon))
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for EKS addon %s (status %s): %w", name, addon.Status, ctx.Err())
+		case <-time.After(addonPollInterval):
+		}
+	}
+}
+
+// addonIssues summarizes the health issues of addon.
+func addonIssues(addon *ekstypes.Addon) string {
+	if addon.Health == nil || len(addon.Health.Issues) == 0 {
+		return "no health issues reported"
+	}
+	var issues []string
+	for _, i := range addon.Health.Issues {
+		issues = append(issues, fmt.Sprintf("%s: %s", i.Code, aws.ToString(i.Message)))
+	}
+	return strings.Join(issues, "; ")
+}
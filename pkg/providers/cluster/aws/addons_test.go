@@ -0,0 +1,243 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// mockAddonClient implements AddonClient for testing. Created and updated
+// addons settle immediately in settleStatus (ACTIVE when unset).
+type mockAddonClient struct {
+	noCluster    bool
+	addons       map[string]*ekstypes.Addon
+	versions     []string
+	settleStatus ekstypes.AddonStatus
+
+	created []*eks.CreateAddonInput
+	updated []*eks.UpdateAddonInput
+}
+
+func (m *mockAddonClient) DescribeCluster(_ context.Context, _ *eks.DescribeClusterInput, _ ...func(*eks.Options)) (*eks.DescribeClusterOutput, error) {
+	if m.noCluster {
+		return nil, &ekstypes.ResourceNotFoundException{Message: aws.String("not found")}
+	}
+	return &eks.DescribeClusterOutput{Cluster: &ekstypes.Cluster{Version: aws.String("1.34")}}, nil
+}
+
+func (m *mockAddonClient) DescribeAddon(_ context.Context, params *eks.DescribeAddonInput, _ ...func(*eks.Options)) (*eks.DescribeAddonOutput, error) {
+	addon, ok := m.addons[aws.ToString(params.AddonName)]
+	if !ok {
+		return nil, &ekstypes.ResourceNotFoundException{Message: aws.String("not found")}
+	}
+	return &eks.DescribeAddonOutput{Addon: addon}, nil
+}
+
+func (m *mockAddonClient) DescribeAddonVersions(_ context.Context, _ *eks.DescribeAddonVersionsInput, _ ...func(*eks.Options)) (*eks.DescribeAddonVersionsOutput, error) {
+	info := ekstypes.AddonInfo{}
+	for _, v := range m.versions {
+		info.AddonVersions = append(info.AddonVersions, ekstypes.AddonVersionInfo{AddonVersion: aws.String(v)})
+	}
+	return &eks.DescribeAddonVersionsOutput{Addons: []ekstypes.AddonInfo{info}}, nil
+}
+
+func (m *mockAddonClient) settle(name string, version, role *string) {
+	st := m.settleStatus
+	if st == "" {
+		st = ekstypes.AddonStatusActive
+	}
+	addon := &ekstypes.Addon{AddonName: aws.String(name), AddonVersion: version, ServiceAccountRoleArn: role, Status: st}
+	if st != ekstypes.AddonStatusActive {
+		addon.Health = &ekstypes.AddonHealth{Issues: []ekstypes.AddonIssue{{Code: ekstypes.AddonIssueCodeInsufficientNumberOfReplicas, Message: aws.String("no nodes")}}}
+	}
+	if m.addons == nil {
+		m.addons = map[string]*ekstypes.Addon{}
+	}
+	m.addons[name] = addon
+}
+
+func (m *mockAddonClient) CreateAddon(_ context.Context, params *eks.CreateAddonInput, _ ...func(*eks.Options)) (*eks.CreateAddonOutput, error) {
+	m.created = append(m.created, params)
+	m.settle(aws.ToString(params.AddonName), params.AddonVersion, params.ServiceAccountRoleArn)
+	return &eks.CreateAddonOutput{}, nil
+}
+
+func (m *mockAddonClient) UpdateAddon(_ context.Context, params *eks.UpdateAddonInput, _ ...func(*eks.Options)) (*eks.UpdateAddonOutput, error) {
+	m.updated = append(m.updated, params)
+	m.settle(aws.ToString(params.AddonName), params.AddonVersion, params.ServiceAccountRoleArn)
+	return &eks.UpdateAddonOutput{}, nil
+}
+
+func TestReconcileAddons(t *testing.T) {
+	const ebsRole = "arn:aws:iam::123456789012:role/ebs-csi"
+	active := func(version string) *ekstypes.Addon {
+		return &ekstypes.Addon{AddonVersion: aws.String(version), Status: ekstypes.AddonStatusActive}
+	}
+
+	tests := []struct {
+		name        string
+		client      *mockAddonClient
+		addons      map[string]Addon
+		dryRun      bool
+		wantChanged bool
+		wantCreated []string // addon@version
+		wantUpdated []string
+		errSubstr   string
+	}{
+		{
+			name:   "no addons configured",
+			client: &mockAddonClient{},
+		},
+		{
+			name:   "cluster not created yet",
+			client: &mockAddonClient{noCluster: true},
+			addons: map[string]Addon{"coredns": {}},
+		},
+		{
+			name:        "create latest",
+			client:      &mockAddonClient{versions: []string{"v1.11.4-eksbuild.2", "v1.11.4-eksbuild.10", "v1.10.1-eksbuild.1"}},
+			addons:      map[string]Addon{"coredns": {Version: "latest"}},
+			wantChanged: true,
+			wantCreated: []string{"coredns@v1.11.4-eksbuild.10"},
+		},
+		{
+			name:        "ebs csi with role",
+			client:      &mockAddonClient{},
+			addons:      map[string]Addon{"aws-ebs-csi-driver": {Version: "v1.40.0-eksbuild.1", ServiceAccountRoleARN: ebsRole}},
+			wantChanged: true,
+			wantCreated: []string{"aws-ebs-csi-driver@v1.40.0-eksbuild.1"},
+		},
+		{
+			name:   "in sync",
+			client: &mockAddonClient{addons: map[string]*ekstypes.Addon{"vpc-cni": active("v1.19.0-eksbuild.1")}},
+			addons: map[string]Addon{"vpc-cni": {Version: "v1.19.0-eksbuild.1"}},
+		},
+		{
+			name:        "version drift updated",
+			client:      &mockAddonClient{addons: map[string]*ekstypes.Addon{"vpc-cni": active("v1.18.0-eksbuild.1")}},
+			addons:      map[string]Addon{"vpc-cni": {Version: "v1.19.0-eksbuild.1"}},
+			wantChanged: true,
+			wantUpdated: []string{"vpc-cni@v1.19.0-eksbuild.1"},
+		},
+		{
+			name:   "unset version keeps live version",
+			client: &mockAddonClient{addons: map[string]*ekstypes.Addon{"vpc-cni": active("v1.18.0-eksbuild.1")}, versions: []string{"v1.19.0-eksbuild.1"}},
+			addons: map[string]Addon{"vpc-cni": {}},
+		},
+		{
+			name:        "unset version creates EKS default",
+			client:      &mockAddonClient{versions: []string{"v1.19.0-eksbuild.1"}},
+			addons:      map[string]Addon{"kube-proxy": {}},
+			wantChanged: true,
+			wantCreated: []string{"kube-proxy@"},
+		},
+		{
+			name: "unset role keeps live role",
+			client: &mockAddonClient{addons: map[string]*ekstypes.Addon{"aws-ebs-csi-driver": {
+				AddonVersion: aws.String("v1.40.0-eksbuild.1"), ServiceAccountRoleArn: aws.String(ebsRole), Status: ekstypes.AddonStatusActive,
+			}}},
+			addons: map[string]Addon{"aws-ebs-csi-driver": {Version: "v1.40.0-eksbuild.1"}},
+		},
+		{
+			name: "role drift updated",
+			client: &mockAddonClient{addons: map[string]*ekstypes.Addon{"aws-ebs-csi-driver": {
+				AddonVersion: aws.String("v1.40.0-eksbuild.1"), ServiceAccountRoleArn: aws.String("arn:aws:iam::123456789012:role/old"), Status: ekstypes.AddonStatusActive,
+			}}},
+			addons:      map[string]Addon{"aws-ebs-csi-driver": {Version: "v1.40.0-eksbuild.1", ServiceAccountRoleARN: ebsRole}},
+			wantChanged: true,
+			wantUpdated: []string{"aws-ebs-csi-driver@v1.40.0-eksbuild.1"},
+		},
+		{
+			name:        "dry run only reports",
+			client:      &mockAddonClient{addons: map[string]*ekstypes.Addon{"vpc-cni": active("v1.18.0-eksbuild.1")}},
+			addons:      map[string]Addon{"vpc-cni": {Version: "v1.19.0-eksbuild.1"}, "kube-proxy": {Version: "v1.34.0-eksbuild.1"}},
+			dryRun:      true,
+			wantChanged: true,
+		},
+		{
+			name:        "degraded is not fatal",
+			client:      &mockAddonClient{settleStatus: ekstypes.AddonStatusDegraded},
+			addons:      map[string]Addon{"coredns": {Version: "v1.11.4-eksbuild.2"}},
+			wantChanged: true,
+			wantCreated: []string{"coredns@v1.11.4-eksbuild.2"},
+		},
+		{
+			name:      "create failed",
+			client:    &mockAddonClient{settleStatus: ekstypes.AddonStatusCreateFailed},
+			addons:    map[string]Addon{"coredns": {Version: "v1.11.4-eksbuild.2"}},
+			errSubstr: "EKS addon coredns create_failed: InsufficientNumberOfReplicas: no nodes",
+		},
+		{
+			name:      "no version available",
+			client:    &mockAddonClient{},
+			addons:    map[string]Addon{"coredns": {Version: "latest"}},
+			errSubstr: "no version of addon coredns is available for Kubernetes 1.34",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := reconcileAddons(context.Background(), tt.client, "demo", tt.addons, tt.dryRun)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("reconcileAddons() error = %v, want containing %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reconcileAddons() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("reconcileAddons() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			var created, updated []string
+			for _, in := range tt.client.created {
+				created = append(created, aws.ToString(in.AddonName)+"@"+aws.ToString(in.AddonVersion))
+				if aws.ToString(in.AddonName) == "aws-ebs-csi-driver" && aws.ToString(in.ServiceAccountRoleArn) != ebsRole {
+					t.Errorf("aws-ebs-csi-driver created with role %q, want %q", aws.ToString(in.ServiceAccountRoleArn), ebsRole)
+				}
+			}
+			for _, in := range tt.client.updated {
+				updated = append(updated, aws.ToString(in.AddonName)+"@"+aws.ToString(in.AddonVersion))
+			}
+			if strings.Join(created, ",") != strings.Join(tt.wantCreated, ",") {
+				t.Errorf("created = %v, want %v", created, tt.wantCreated)
+			}
+			if strings.Join(updated, ",") != strings.Join(tt.wantUpdated, ",") {
+				t.Errorf("updated = %v, want %v", updated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestValidateAddons(t *testing.T) {
+	tests := []struct {
+		name      string
+		addons    map[string]Addon
+		errSubstr string
+	}{
+		{name: "none"},
+		{name: "valid", addons: map[string]Addon{"vpc-cni": {Version: "v1.19.0-eksbuild.1"}, "coredns": {Version: "latest"}, "kube-proxy": {}}},
+		{name: "unknown addon", addons: map[string]Addon{"metrics-server": {}}, errSubstr: `unsupported addon "metrics-server"`},
+		{name: "bad version", addons: map[string]Addon{"coredns": {Version: "1.11"}}, errSubstr: `addon coredns: invalid version "1.11"`},
+		{name: "bad role", addons: map[string]Addon{"aws-ebs-csi-driver": {ServiceAccountRoleARN: "ebs-csi"}}, errSubstr: "invalid service_account_role_arn"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAddons(tt.addons)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("validateAddons() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("validateAddons() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
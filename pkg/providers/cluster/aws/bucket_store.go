@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+)
+
+// s3BucketStore is the objectstore.Store for S3. Buckets it creates are
+// versioned, block all public access, and carry the NIC managed-by tag.
+type s3BucketStore struct {
+	client S3Client
+}
+
+var _ objectstore.Store = (*s3BucketStore)(nil)
+
+func newS3BucketStore(client S3Client) *s3BucketStore {
+	return &s3BucketStore{client: client}
+}
+
+// EnsureBucket creates spec.Name in spec.Region unless it already exists.
+func (s *s3BucketStore) EnsureBucket(ctx context.Context, spec objectstore.BucketSpec) (bool, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.EnsureBucket")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bucket_name", spec.Name),
+		attribute.String(attrKeyRegion, spec.Region),
+	)
+
+	created, err := s.ensureBucket(ctx, spec)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.SetAttributes(attribute.Bool("bucket_created", created))
+	return created, err
+}
+
+func (s *s3BucketStore) ensureBucket(ctx context.Context, spec objectstore.BucketSpec) (bool, error) {
+	exists, err := stateBucketExists(ctx, s.client, spec.Name)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	createInput := &s3.CreateBucketInput{
+		Bucket: aws.String(spec.Name),
+	}
+	// us-east-1 is special-cased in S3: CreateBucket rejects a LocationConstraint
+	// of "us-east-1" because that region is the default.
+	if spec.Region != "us-east-1" {
+		createInput.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(spec.Region),
+		}
+	}
+
+	if _, err := s.client.CreateBucket(ctx, createInput); err != nil {
+		return false, fmt.Errorf("failed to create bucket %q: %w", spec.Name, err)
+	}
+
+	_, err = s.client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
+		Bucket: aws.String(spec.Name),
+		VersioningConfiguration: &types.VersioningConfiguration{
+			Status: types.BucketVersioningStatusEnabled,
+		},
+	})
+	if err != nil {
+		return true, fmt.Errorf("failed to enable bucket versioning: %w", err)
+	}
+
+	_, err = s.client.PutPublicAccessBlock(ctx, &s3.PutPublicAccessBlockInput{
+		Bucket: aws.String(spec.Name),
+		PublicAccessBlockConfiguration: &types.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(true),
+			BlockPublicPolicy:     aws.Bool(true),
+			IgnorePublicAcls:      aws.Bool(true),
+			RestrictPublicBuckets: aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return true, fmt.Errorf("failed to block public access: %w", err)
+	}
+
+	_, err = s.client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(spec.Name),
+		Tagging: &types.Tagging{TagSet: bucketTagSet(spec.Tags)},
+	})
+	if err != nil {
+		return true, fmt.Errorf("failed to tag bucket: %w", err)
+	}
+
+	return true, nil
+}
+
+// bucketTagSet returns tags plus the NIC managed-by tag, sorted by key.
+func bucketTagSet(tags map[string]string) []types.Tag {
+	all := maps.Clone(tags)
+	if all == nil {
+		all = map[string]string{}
+	}
+	all[tagKeyManagedBy] = tagValueNIC
+
+	set := make([]types.Tag, 0, len(all))
+	for _, k := range slices.Sorted(maps.Keys(all)) {
+		set = append(set, types.Tag{Key: aws.String(k), Value: aws.String(all[k])})
+	}
+	return set
+}
@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+)
+
+// statefulS3Client returns a mockS3Client backed by an in-memory bucket set,
+// so repeated EnsureBucket calls see each other's effects.
+func statefulS3Client(buckets map[string][]types.Tag, creates *int) *mockS3Client {
+	return &mockS3Client{
+		HeadBucketFunc: func(_ context.Context, params *s3.HeadBucketInput, _ ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+			if _, ok := buckets[aws.ToString(params.Bucket)]; !ok {
+				return nil, &types.NotFound{}
+			}
+			return &s3.HeadBucketOutput{}, nil
+		},
+		CreateBucketFunc: func(_ context.Context, params *s3.CreateBucketInput, _ ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+			buckets[aws.ToString(params.Bucket)] = nil
+			*creates++
+			return &s3.CreateBucketOutput{}, nil
+		},
+		PutBucketTaggingFunc: func(_ context.Context, params *s3.PutBucketTaggingInput, _ ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+			buckets[aws.ToString(params.Bucket)] = params.Tagging.TagSet
+			return &s3.PutBucketTaggingOutput{}, nil
+		},
+	}
+}
+
+func TestS3BucketStoreEnsureBucketIdempotent(t *testing.T) {
+	buckets := map[string][]types.Tag{}
+	var creates int
+	store := newS3BucketStore(statefulS3Client(buckets, &creates))
+	spec := objectstore.BucketSpec{Name: "nic-backups-abc12345", Region: "us-west-2", Tags: map[string]string{"team": "data"}}
+
+	for i, want := range []bool{true, false} {
+		created, err := store.EnsureBucket(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("EnsureBucket() call %d error = %v", i+1, err)
+		}
+		if created != want {
+			t.Errorf("EnsureBucket() call %d created = %v, want %v", i+1, created, want)
+		}
+	}
+	if creates != 1 {
+		t.Errorf("CreateBucket called %d times, want 1", creates)
+	}
+
+	got := map[string]string{}
+	for _, tag := range buckets[spec.Name] {
+		got[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	if got[tagKeyManagedBy] != tagValueNIC || got["team"] != "data" {
+		t.Errorf("bucket tags = %v, want managed-by marker and team=data", got)
+	}
+}
@@ -0,0 +1,367 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/netutil"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// ClusterConfigClient defines the EKS operations needed to reconcile
+// cluster-level settings.
+type ClusterConfigClient interface {
+	DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error)
+	UpdateClusterConfig(ctx context.Context, params *eks.UpdateClusterConfigInput, optFns ...func(*eks.Options)) (*eks.UpdateClusterConfigOutput, error)
+	DescribeUpdate(ctx context.Context, params *eks.DescribeUpdateInput, optFns ...func(*eks.Options)) (*eks.DescribeUpdateOutput, error)
+	TagResource(ctx context.Context, params *eks.TagResourceInput, optFns ...func(*eks.Options)) (*eks.TagResourceOutput, error)
+}
+
+func newClusterConfigClient(ctx context.Context, region string) (ClusterConfigClient, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return eks.NewFromConfig(cfg), nil
+}
+
+// allClusterLogTypes are the EKS control plane log types. Types not enabled
+// in the config are explicitly disabled.
+var allClusterLogTypes = []string{"api", "audit", "authenticator", "controllerManager", "scheduler"}
+
+// clusterUpdatePollInterval is how often an in-flight cluster update is
+// checked. EKS rejects a second update while one is in progress.
+var clusterUpdatePollInterval = 15 * time.Second
+
+// clusterUpdateTimeout bounds the wait for a single cluster update.
+const clusterUpdateTimeout = 30 * time.Minute
+
+// validateEndpointPublicAccessCIDRs checks endpoint_public_access_cidrs:
+// valid IPv4 CIDRs, only with a public endpoint.
+func validateEndpointPublicAccessCIDRs(c *Config) error {
+	if len(c.EndpointPublicAccessCIDRs) == 0 {
+		return nil
+	}
+	if !c.EndpointPublicAccess {
+		return fmt.Errorf("endpoint_public_access_cidrs requires endpoint_public_access: true")
+	}
+	for i, s := range c.EndpointPublicAccessCIDRs {
+		p, err := netutil.ParseCIDR(s)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint_public_access_cidrs[%d]: %w", i, err)
+		}
+		if !p.Addr().Is4() {
+			return fmt.Errorf("invalid endpoint_public_access_cidrs[%d] %q: must be IPv4", i, s)
+		}
+	}
+	return nil
+}
+
+// clusterSettings are the cluster-level settings NIC reconciles after
+// creation.
+type clusterSettings struct {
+	Tags                  map[string]string
+	LogTypes              []string
+	EndpointPrivateAccess bool
+	EndpointPublicAccess  bool
+	// PublicAccessCIDRs is compared only when set; EKS reports 0.0.0.0/0
+	// for an unrestricted public endpoint.
+	PublicAccessCIDRs []string
+}
+
+// clusterSettingChange is one drifted setting.
+type clusterSettingChange struct {
+	Setting string
+	Live    string
+	Desired string
+}
+
+// desiredClusterSettings returns the cluster-level settings c asks for.
+func desiredClusterSettings(c *Config, environment, clusterID string) clusterSettings {
+	return clusterSettings{
+		Tags:                  withClusterIDTag(withEnvironmentTag(c.Tags, environment), clusterID),
+		LogTypes:              sortedCopy(c.EnabledLogTypes),
+		EndpointPrivateAccess: c.EndpointPrivateAccess,
+		EndpointPublicAccess:  c.EndpointPublicAccess,
+		PublicAccessCIDRs:     sortedCopy(c.EndpointPublicAccessCIDRs),
+	}
+}
+
+// sortedCopy returns a sorted copy of s, or nil when s is empty.
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return slices.Sorted(slices.Values(s))
+}
+
+// enabledLogTypes returns the sorted log types enabled on a live cluster.
+func enabledLogTypes(logging *ekstypes.Logging) []string {
+	var types []string
+	if logging == nil {
+		return nil
+	}
+	for _, setup := range logging.ClusterLogging {
+		if aws.ToBool(setup.Enabled) {
+			for _, t := range setup.Types {
+				types = append(types, string(t))
+			}
+		}
+	}
+	return sortedCopy(types)
+}
+
+// loggingDrift reports whether the enabled log types differ.
+func loggingDrift(live *ekstypes.Cluster, desired clusterSettings) (clusterSettingChange, bool) {
+	got := enabledLogTypes(live.Logging)
+	if slices.Equal(got, desired.LogTypes) {
+		return clusterSettingChange{}, false
+	}
+	return clusterSettingChange{Setting: "enabled_log_types", Live: formatList(got), Desired: formatList(desired.LogTypes)}, true
+}
+
+// endpointDrift returns the drifted endpoint access settings.
+func endpointDrift(live *ekstypes.Cluster, desired clusterSettings) []clusterSettingChange {
+	var changes []clusterSettingChange
+	vpc := live.ResourcesVpcConfig
+	if vpc == nil {
+		vpc = &ekstypes.VpcConfigResponse{}
+	}
+	if vpc.EndpointPrivateAccess != desired.EndpointPrivateAccess {
+		changes = append(changes, clusterSettingChange{"endpoint_private_access", fmt.Sprint(vpc.EndpointPrivateAccess), fmt.Sprint(desired.EndpointPrivateAccess)})
+	}
+	if vpc.EndpointPublicAccess != desired.EndpointPublicAccess {
+		changes = append(changes, clusterSettingChange{"endpoint_public_access", fmt.Sprint(vpc.EndpointPublicAccess), fmt.Sprint(desired.EndpointPublicAccess)})
+	}
+	if len(desired.PublicAccessCIDRs) > 0 {
+		if got := sortedCopy(vpc.PublicAccessCidrs); !slices.Equal(got, desired.PublicAccessCIDRs) {
+			changes = append(changes, clusterSettingChange{"endpoint_public_access_cidrs", formatList(got), formatList(desired.PublicAccessCIDRs)})
+		}
+	}
+	return changes
+}
+
+// formatList renders a setting list for status metadata.
+func formatList(s []string) string {
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, ",")
+}
+
+// LiveClusterID returns the cluster ID tagged on the EKS cluster named
+// projectName, or "" when the cluster does not exist yet or predates cluster
+// IDs.
+func (p *Provider) LiveClusterID(ctx context.Context, projectName string, clusterConfig *config.ClusterConfig) (string, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.LiveClusterID")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("provider", ProviderName),
+		attribute.String("project_name", projectName),
+	)
+
+	awsCfg, err := extractAWSConfig(ctx, clusterConfig)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	client, err := newClusterConfigClient(ctx, awsCfg.Region)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	id, err := liveClusterID(ctx, client, projectName)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	return id, nil
+}
+
+// liveClusterID reads the cluster ID tag of the EKS cluster clusterName.
+func liveClusterID(ctx context.Context, client ClusterConfigClient, clusterName string) (string, error) {
+	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		var notFound *ekstypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to describe EKS cluster %s: %w", clusterName, err)
+	}
+	return out.Cluster.Tags[tagKeyClusterID], nil
+}
+
+// reconcileClusterConfig compares the live cluster's tags, control plane
+// logging, endpoint access and public access CIDRs with desired, reports each
+// drifted setting, and unless dryRun corrects them with TagResource and
+// UpdateClusterConfig. Logging and endpoint access are separate updates
+// because EKS accepts one kind per call and only one in flight at a time.
+// A cluster that does not exist yet has nothing to reconcile. Returns whether
+// any drift was found.
+func reconcileClusterConfig(ctx context.Context, client ClusterConfigClient, clusterName string, desired clusterSettings, dryRun bool) (bool, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.reconcileClusterConfig")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cluster_name", clusterName),
+		attribute.Bool("dry_run", dryRun),
+	)
+
+	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		var notFound *ekstypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to describe EKS cluster %s: %w", clusterName, err)
+	}
+	live := out.Cluster
+
+	// As with the other NIC tag reconcilers, tags added out-of-band are kept.
+	tags := tagChanges(live.Tags, desired.Tags)
+	logging, loggingDrifted := loggingDrift(live, desired)
+	endpoint := endpointDrift(live, desired)
+
+	var changes []clusterSettingChange
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		changes = append(changes, clusterSettingChange{Setting: "tag " + k, Live: live.Tags[k], Desired: tags[k]})
+	}
+	if loggingDrifted {
+		changes = append(changes, logging)
+	}
+	changes = append(changes, endpoint...)
+
+	span.SetAttributes(attribute.Int("drifted_settings", len(changes)))
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	action := "updating"
+	if dryRun {
+		action = "drifted"
+	}
+	for _, c := range changes {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, fmt.Sprintf("EKS cluster setting %s drifted", c.Setting)).
+			WithResource("eks-cluster").
+			WithAction(action).
+			WithMetadata("cluster_name", clusterName).
+			WithMetadata("setting", c.Setting).
+			WithMetadata("live", c.Live).
+			WithMetadata("desired", c.Desired))
+	}
+	if dryRun {
+		return true, nil
+	}
+
+	if len(tags) > 0 {
+		if _, err := client.TagResource(ctx, &eks.TagResourceInput{ResourceArn: live.Arn, Tags: tags}); err != nil {
+			span.RecordError(err)
+			return true, fmt.Errorf("failed to tag EKS cluster %s: %w", clusterName, err)
+		}
+	}
+
+	if loggingDrifted {
+		if err := updateClusterConfig(ctx, client, clusterName, &eks.UpdateClusterConfigInput{
+			Name:    aws.String(clusterName),
+			Logging: clusterLogging(desired.LogTypes),
+		}); err != nil {
+			span.RecordError(err)
+			return true, err
+		}
+	}
+
+	if len(endpoint) > 0 {
+		vpc := &ekstypes.VpcConfigRequest{
+			EndpointPrivateAccess: aws.Bool(desired.EndpointPrivateAccess),
+			EndpointPublicAccess:  aws.Bool(desired.EndpointPublicAccess),
+		}
+		if len(desired.PublicAccessCIDRs) > 0 {
+			vpc.PublicAccessCidrs = desired.PublicAccessCIDRs
+		}
+		if err := updateClusterConfig(ctx, client, clusterName, &eks.UpdateClusterConfigInput{
+			Name:               aws.String(clusterName),
+			ResourcesVpcConfig: vpc,
+		}); err != nil {
+			span.RecordError(err)
+			return true, err
+		}
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelSuccess, "EKS cluster settings reconciled").
+		WithResource("eks-cluster").
+		WithAction("updated").
+		WithMetadata("cluster_name", clusterName).
+		WithMetadata("changes", len(changes)))
+	return true, nil
+}
+
+// clusterLogging builds the logging request enabling exactly types.
+func clusterLogging(types []string) *ekstypes.Logging {
+	var enabled, disabled []ekstypes.LogType
+	for _, t := range allClusterLogTypes {
+		if slices.Contains(types, t) {
+			enabled = append(enabled, ekstypes.LogType(t))
+		} else {
+			disabled = append(disabled, ekstypes.LogType(t))
+		}
+	}
+	var setups []ekstypes.LogSetup
+	if len(enabled) > 0 {
+		setups = append(setups, ekstypes.LogSetup{Enabled: aws.Bool(true), Types: enabled})
+	}
+	if len(disabled) > 0 {
+		setups = append(setups, ekstypes.LogSetup{Enabled: aws.Bool(false), Types: disabled})
+	}
+	return &ekstypes.Logging{ClusterLogging: setups}
+}
+
+// updateClusterConfig starts a cluster update and waits for it to finish, so
+// the next update is not rejected.
+func updateClusterConfig(ctx context.Context, client ClusterConfigClient, clusterName string, input *eks.UpdateClusterConfigInput) error {
+	out, err := client.UpdateClusterConfig(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update EKS cluster %s config: %w", clusterName, err)
+	}
+	updateID := aws.ToString(out.Update.Id)
+
+	ctx, cancel := context.WithTimeout(ctx, clusterUpdateTimeout)
+	defer cancel()
+	for {
+		upd, err := client.DescribeUpdate(ctx, &eks.DescribeUpdateInput{Name: aws.String(clusterName), UpdateId: aws.String(updateID)})
+		if err != nil {
+			return fmt.Errorf("failed to describe EKS cluster %s update %s: %w", clusterName, updateID, err)
+		}
+		switch upd.Update.Status {
+		case ekstypes.UpdateStatusSuccessful:
+			return nil
+		case ekstypes.UpdateStatusFailed, ekstypes.UpdateStatusCancelled:
+			var reasons []string
+			for _, e := range upd.Update.Errors {
+				reasons = append(reasons, aws.ToString(e.ErrorMessage))
+			}
+			return fmt.Errorf("EKS cluster %s update %s %s: %s", clusterName, updateID, strings.ToLower(string(upd.Update.Status)), strings.Join(reasons, "; "))
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for EKS cluster %s update %s: %w", clusterName, updateID, ctx.Err())
+		case <-time.After(clusterUpdatePollInterval):
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package aws
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// mockClusterConfigClient implements ClusterConfigClient for testing. Updates
+// complete immediately.
+type mockClusterConfigClient struct {
+	cluster *ekstypes.Cluster
+
+	tagged  []*eks.TagResourceInput
+	updates []*eks.UpdateClusterConfigInput
+}
+
+func (m *mockClusterConfigClient) DescribeCluster(_ context.Context, _ *eks.DescribeClusterInput, _ ...func(*eks.Options)) (*eks.DescribeClusterOutput, error) {
+	if m.cluster == nil {
+		return nil, &ekstypes.ResourceNotFoundException{Message: aws.String("not found")}
+	}
+	return &eks.DescribeClusterOutput{Cluster: m.cluster}, nil
+}
+
+func (m *mockClusterConfigClient) UpdateClusterConfig(_ context.Context, params *eks.UpdateClusterConfigInput, _ ...func(*eks.Options)) (*eks.UpdateClusterConfigOutput, error) {
+	m.updates = append(m.updates, params)
+	return &eks.UpdateClusterConfigOutput{Update: &ekstypes.Update{Id: aws.String("upd-1")}}, nil
+}
+
+func (m *mockClusterConfigClient) DescribeUpdate(_ context.Context, _ *eks.DescribeUpdateInput, _ ...func(*eks.Options)) (*eks.DescribeUpdateOutput, error) {
+	return &eks.DescribeUpdateOutput{Update: &ekstypes.Update{Status: ekstypes.UpdateStatusSuccessful}}, nil
+}
+
+func (m *mockClusterConfigClient) TagResource(_ context.Context, params *eks.TagResourceInput, _ ...func(*eks.Options)) (*eks.TagResourceOutput, error) {
+	m.tagged = append(m.tagged, params)
+	return &eks.TagResourceOutput{}, nil
+}
+
+// liveCluster returns a cluster matching desiredClusterSettings(baseConfig).
+func liveCluster() *ekstypes.Cluster {
+	return &ekstypes.Cluster{
+		Arn:  aws.String("arn:aws:eks:us-west-2:123456789012:cluster/demo"),
+		Tags: map[string]string{"team": "data", "owner": "secops"},
+		Logging: &ekstypes.Logging{ClusterLogging: []ekstypes.LogSetup{
+			{Enabled: aws.Bool(true), Types: []ekstypes.LogType{ekstypes.LogTypeAudit, ekstypes.LogTypeApi}},
+			{Enabled: aws.Bool(false), Types: []ekstypes.LogType{ekstypes.LogTypeScheduler}},
+		}},
+		ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+			EndpointPrivateAccess: true,
+			EndpointPublicAccess:  true,
+			PublicAccessCidrs:     []string{"0.0.0.0/0"},
+		},
+	}
+}
+
+func baseConfig() *Config {
+	return &Config{
+		Tags:                  map[string]string{"team": "data"},
+		EnabledLogTypes:       []string{"api", "audit"},
+		EndpointPrivateAccess: true,
+		EndpointPublicAccess:  true,
+	}
+}
+
+func TestReconcileClusterConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         func(*Config)
+		cluster     func(*ekstypes.Cluster)
+		environment string
+		clusterID   string
+		dryRun      bool
+		wantDrift   bool
+		wantTags    map[string]string
+		wantLogging []string // enabled types in the logging update, nil for none
+		wantVPC     *ekstypes.VpcConfigRequest
+	}{
+		{name: "in sync"},
+		{
+			name:      "tag drift writes only changed tags",
+			cluster:   func(c *ekstypes.Cluster) { c.Tags["team"] = "ml" },
+			wantDrift: true,
+			wantTags:  map[string]string{"team": "data"},
+		},
+		{
+			name:        "missing environment tag",
+			environment: "prod",
+			wantDrift:   true,
+			wantTags:    map[string]string{tagKeyEnvironment: "prod"},
+		},
+		{
+			name:      "missing cluster ID tag",
+			clusterID: "0b7e4c1e-8f7a-4c55-9b1d-2d6f3c9a1e42",
+			wantDrift: true,
+			wantTags:  map[string]string{tagKeyClusterID: "0b7e4c1e-8f7a-4c55-9b1d-2d6f3c9a1e42"},
+		},
+		{
+			name:      "endpoint access drift",
+			cluster:   func(c *ekstypes.Cluster) { c.ResourcesVpcConfig.EndpointPublicAccess = false },
+			wantDrift: true,
+			wantVPC:   &ekstypes.VpcConfigRequest{EndpointPrivateAccess: aws.Bool(true), EndpointPublicAccess: aws.Bool(true)},
+		},
+		{
+			name:      "public access cidrs drift",
+			cfg:       func(c *Config) { c.EndpointPublicAccessCIDRs = []string{"203.0.113.0/24"} },
+			wantDrift: true,
+			wantVPC: &ekstypes.VpcConfigRequest{
+				EndpointPrivateAccess: aws.Bool(true), EndpointPublicAccess: aws.Bool(true),
+				PublicAccessCidrs: []string{"203.0.113.0/24"},
+			},
+		},
+		{
+			name:        "logging drift",
+			cfg:         func(c *Config) { c.EnabledLogTypes = []string{"api", "audit", "authenticator"} },
+			wantDrift:   true,
+			wantLogging: []string{"api", "audit", "authenticator"},
+		},
+		{
+			name:      "dry run only reports",
+			cluster:   func(c *ekstypes.Cluster) { c.Tags["team"] = "ml"; c.ResourcesVpcConfig.EndpointPublicAccess = false },
+			dryRun:    true,
+			wantDrift: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			if tt.cfg != nil {
+				tt.cfg(cfg)
+			}
+			cluster := liveCluster()
+			if tt.cluster != nil {
+				tt.cluster(cluster)
+			}
+			client := &mockClusterConfigClient{cluster: cluster}
+
+			drifted, err := reconcileClusterConfig(context.Background(), client, "demo", desiredClusterSettings(cfg, tt.environment, tt.clusterID), tt.dryRun)
+			if err != nil {
+				t.Fatalf("reconcileClusterConfig() error = %v", err)
+			}
+			if drifted != tt.wantDrift {
+				t.Errorf("drifted = %v, want %v", drifted, tt.wantDrift)
+			}
+
+			if tt.wantTags == nil {
+				if len(client.tagged) != 0 {
+					t.Errorf("unexpected TagResource calls: %+v", client.tagged)
+				}
+			} else if len(client.tagged) != 1 || !maps.Equal(client.tagged[0].Tags, tt.wantTags) {
+				t.Errorf("TagResource calls = %+v, want one with %v", client.tagged, tt.wantTags)
+			}
+
+			var logging, vpc []*eks.UpdateClusterConfigInput
+			for _, u := range client.updates {
+				if u.Logging != nil {
+					logging = append(logging, u)
+				}
+				if u.ResourcesVpcConfig != nil {
+					vpc = append(vpc, u)
+				}
+				if u.Logging != nil && u.ResourcesVpcConfig != nil {
+					t.Error("logging and endpoint access must be separate updates")
+				}
+			}
+
+			if tt.wantLogging == nil {
+				if len(logging) != 0 {
+					t.Errorf("unexpected logging update: %+v", logging[0].Logging)
+				}
+			} else {
+				if len(logging) != 1 {
+					t.Fatalf("got %d logging updates, want 1", len(logging))
+				}
+				var enabled []string
+				for _, setup := range logging[0].Logging.ClusterLogging {
+					if aws.ToBool(setup.Enabled) {
+						for _, lt := range setup.Types {
+							enabled = append(enabled, string(lt))
+						}
+					}
+				}
+				if !slices.Equal(enabled, tt.wantLogging) {
+					t.Errorf("enabled log types = %v, want %v", enabled, tt.wantLogging)
+				}
+			}
+
+			if tt.wantVPC == nil {
+				if len(vpc) != 0 {
+					t.Errorf("unexpected endpoint update: %+v", vpc[0].ResourcesVpcConfig)
+				}
+			} else {
+				if len(vpc) != 1 {
+					t.Fatalf("got %d endpoint updates, want 1", len(vpc))
+				}
+				got := vpc[0].ResourcesVpcConfig
+				if aws.ToBool(got.EndpointPrivateAccess) != aws.ToBool(tt.wantVPC.EndpointPrivateAccess) ||
+					aws.ToBool(got.EndpointPublicAccess) != aws.ToBool(tt.wantVPC.EndpointPublicAccess) ||
+					!slices.Equal(got.PublicAccessCidrs, tt.wantVPC.PublicAccessCidrs) {
+					t.Errorf("endpoint update = %+v, want %+v", got, tt.wantVPC)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileClusterConfig_ClusterNotCreated(t *testing.T) {
+	client := &mockClusterConfigClient{}
+	drifted, err := reconcileClusterConfig(context.Background(), client, "demo", desiredClusterSettings(baseConfig(), "", ""), true)
+	if err != nil || drifted {
+		t.Errorf("reconcileClusterConfig() = %v, %v; want no drift and no error before the cluster exists", drifted, err)
+	}
+}
+
+func TestValidateEndpointPublicAccessCIDRs(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		errSubstr string
+	}{
+		{name: "unset", cfg: Config{}},
+		{name: "valid", cfg: Config{EndpointPublicAccess: true, EndpointPublicAccessCIDRs: []string{"203.0.113.0/24"}}},
+		{name: "private endpoint only", cfg: Config{EndpointPublicAccessCIDRs: []string{"203.0.113.0/24"}}, errSubstr: "requires endpoint_public_access"},
+		{name: "host bits", cfg: Config{EndpointPublicAccess: true, EndpointPublicAccessCIDRs: []string{"203.0.113.1/24"}}, errSubstr: "endpoint_public_access_cidrs[0]"},
+		{name: "ipv6", cfg: Config{EndpointPublicAccess: true, EndpointPublicAccessCIDRs: []string{"2001:db8::/32"}}, errSubstr: "must be IPv4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEndpointPublicAccessCIDRs(&tt.cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestLiveClusterID(t *testing.T) {
+	tagged := liveCluster()
+	tagged.Tags[tagKeyClusterID] = "0b7e4c1e-8f7a-4c55-9b1d-2d6f3c9a1e42"
+
+	tests := []struct {
+		name    string
+		cluster *ekstypes.Cluster
+		want    string
+	}{
+		{name: "tagged", cluster: tagged, want: "0b7e4c1e-8f7a-4c55-9b1d-2d6f3c9a1e42"},
+		{name: "untagged", cluster: liveCluster()},
+		{name: "not created"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := liveClusterID(context.Background(), &mockClusterConfigClient{cluster: tt.cluster}, "demo")
+			if err != nil {
+				t.Fatalf("liveClusterID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("liveClusterID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
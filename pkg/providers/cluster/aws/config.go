@@ -10,7 +10,7 @@ import (
 type Config struct {
 	Region                    string                           `yaml:"region"`
 	StateBucket               string                           `yaml:"state_bucket,omitempty"`
-	AvailabilityZones         []string                         `yaml:"availability_zones,omitempty"`
+	AvailabilityZones         []string                         `yaml:"availability_zones,omitempty"` // order fixes the subnet-to-AZ mapping
 	VPCCIDRBlock              string                           `yaml:"vpc_cidr_block,omitempty"`
 	ExistingVPCID             string                           `yaml:"existing_vpc_id,omitempty"`
 	ExistingPrivateSubnetIDs  []string                         `yaml:"existing_private_subnet_ids,omitempty"`
@@ -36,6 +36,78 @@ type Config struct {
 	// when the VPC cannot resolve oidc.eks.<region>.amazonaws.com (a fully
 	// private deployment with no public DNS resolution for AWS hostnames).
 	EnableIRSA *bool `yaml:"enable_irsa,omitempty"`
+	// AdditionalNodePolicyARNs are IAM managed policies attached to the node
+	// role on top of the EKS baseline policies. An ARN removed from this list
+	// is detached on the next deploy; the baseline policies are never touched.
+	AdditionalNodePolicyARNs []string `yaml:"additional_node_policy_arns,omitempty"`
+	// AdditionalClusterPolicyARNs is the cluster role equivalent of
+	// AdditionalNodePolicyARNs.
+	AdditionalClusterPolicyARNs []string `yaml:"additional_cluster_policy_arns,omitempty"`
+	// NodeInlinePolicies maps inline policy names to IAM policy JSON documents
+	// put on the node role. A policy removed from this map is deleted from the
+	// role on the next deploy.
+	NodeInlinePolicies map[string]string `yaml:"node_inline_policies,omitempty"`
+	// ClusterInlinePolicies is the cluster role equivalent of
+	// NodeInlinePolicies.
+	ClusterInlinePolicies map[string]string `yaml:"cluster_inline_policies,omitempty"`
+	// PeeredCIDRs lists networks the cluster VPC will be peered or routed to
+	// (on-prem ranges, VPCs in other accounts). vpc_cidr_block must not
+	// overlap any of them.
+	PeeredCIDRs []string `yaml:"peered_cidrs,omitempty"`
+	// CheckVPCOverlap opts in to a pre-flight that lists the VPCs in the
+	// account and region and fails validation when vpc_cidr_block overlaps
+	// any of them. Ignored when existing_vpc_id is set.
+	CheckVPCOverlap bool `yaml:"check_vpc_overlap,omitempty"`
+	// AllowPublicVPCCIDR permits a vpc_cidr_block outside the RFC 1918
+	// private ranges, for organisations that route their own public space.
+	AllowPublicVPCCIDR bool `yaml:"allow_public_vpc_cidr,omitempty"`
+	// RestrictDefaultSecurityGroup tags the default security group of the
+	// NIC-created VPC and revokes all of its rules after each deploy, so
+	// compliance scanners stop flagging its allow-all defaults. Nothing in
+	// the cluster uses the default group.
+	RestrictDefaultSecurityGroup bool `yaml:"restrict_default_security_group,omitempty"`
+	// LaunchProfiles are named node bootstrap settings that node groups share
+	// by referencing them from NodeGroup.LaunchProfile.
+	LaunchProfiles map[string]LaunchProfile `yaml:"launch_profiles,omitempty"`
+	// EndpointPublicAccessCIDRs restricts the public API endpoint to these
+	// source ranges. Requires endpoint_public_access. Unset leaves the
+	// endpoint open to all addresses.
+	EndpointPublicAccessCIDRs []string `yaml:"endpoint_public_access_cidrs,omitempty"`
+	// Addons maps EKS managed addon names (vpc-cni, coredns, kube-proxy,
+	// aws-ebs-csi-driver) to the version NIC installs and keeps them at
+	// after each deploy. Addons left out are not touched.
+	Addons map[string]Addon `yaml:"addons,omitempty"`
+	// ServiceAccountRoles are IAM roles for Kubernetes service accounts
+	// (IRSA), keyed by a short name used in the role name. Each role trusts
+	// only its own service account through the cluster's OIDC provider, so
+	// enable_irsa must not be false. The roles are removed on destroy.
+	ServiceAccountRoles map[string]ServiceAccountRole `yaml:"service_account_roles,omitempty"`
+	// SubnetRoles assigns load balancer roles to the subnets of an existing
+	// VPC. NIC tags the listed subnets for internal or internet-facing load
+	// balancers and marks the remaining existing_private_subnet_ids
+	// node-only. Requires existing_vpc_id; the tags are removed on destroy.
+	SubnetRoles *SubnetRolesConfig `yaml:"subnet_roles,omitempty"`
+	// AccessEntries grant IAM roles and users kubectl access to the cluster
+	// through EKS access entries, on top of the identity that created it.
+	// An entry removed from this list is deleted on the next deploy.
+	AccessEntries []AccessEntry `yaml:"access_entries,omitempty"`
+}
+
+// Addon pins an EKS managed addon.
+type Addon struct {
+	// Version is an addon version such as v1.19.0-eksbuild.1, or "latest"
+	// for the newest version EKS offers for the cluster's Kubernetes version.
+	// Unset leaves the live addon's version alone; a new addon gets the EKS
+	// default version.
+	Version string `yaml:"version,omitempty"`
+	// ServiceAccountRoleARN is the IAM role (IRSA) the addon's service
+	// account assumes. aws-ebs-csi-driver needs one with the
+	// AmazonEBSCSIDriverPolicy unless that policy is on the node role. Unset
+	// leaves the live addon's role alone.
+	ServiceAccountRoleARN string `yaml:"service_account_role_arn,omitempty"`
+	// ServiceAccountRole names a service_account_roles entry whose ARN is
+	// used instead of ServiceAccountRoleARN.
+	ServiceAccountRole string `yaml:"service_account_role,omitempty"`
 }
 
 const (
@@ -48,6 +120,18 @@ var validLoadBalancerSchemes = []string{
 	loadBalancerSchemeInternal,
 }
 
+// createsVPC reports whether NIC creates the VPC. Supplying an existing VPC
+// or existing private subnets opts out.
+func (c *Config) createsVPC() bool {
+	return c.ExistingVPCID == "" && len(c.ExistingPrivateSubnetIDs) == 0
+}
+
+// createsIAMRoles reports whether NIC creates the cluster and node IAM roles.
+// Supplying either existing role ARN opts out of creating both.
+func (c *Config) createsIAMRoles() bool {
+	return c.ExistingClusterRoleArn == "" && c.ExistingNodeRoleArn == ""
+}
+
 // LoadBalancerSchemeOrDefault returns the configured AWS load balancer scheme,
 // defaulting to "internet-facing" when unset. Values are validated at config
 // load time, so callers can trust the result is one of the supported schemes.
@@ -159,6 +243,22 @@ type NodeGroup struct {
 	DiskSize *int              `yaml:"disk_size,omitempty" json:"disk_size,omitempty"`
 	Labels   map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
 	Taints   []Taint           `yaml:"taints,omitempty" json:"taints,omitempty"`
+	// LaunchProfile names an entry of Config.LaunchProfiles whose settings
+	// fill in whatever this node group leaves unset.
+	LaunchProfile string `yaml:"launch_profile,omitempty" json:"-"`
+}
+
+// LaunchProfile is a set of node bootstrap settings defined once and shared
+// by every node group that references it, so those groups launch with the
+// same AMI, disk and node configuration. Settings on the node group itself
+// take precedence: AMIType and DiskSize apply only when the group leaves them
+// unset, Labels are merged under the group's own, and Taints are added unless
+// the group already has a taint with the same key.
+type LaunchProfile struct {
+	AMIType  *string           `yaml:"ami_type,omitempty"`
+	DiskSize *int              `yaml:"disk_size,omitempty"`
+	Labels   map[string]string `yaml:"labels,omitempty"`
+	Taints   []Taint           `yaml:"taints,omitempty"`
 }
 
 type Taint struct {
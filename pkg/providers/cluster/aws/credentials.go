@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"go.opentelemetry.io/otel"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// credentialsHint tells the user how to make credentials available to the
+// default AWS credential chain.
+const credentialsHint = "set AWS_PROFILE (run `aws sso login` for an SSO profile), or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY"
+
+// rejectedCredentialCodes are the STS error codes returned for credentials
+// that were found but are expired or invalid.
+var rejectedCredentialCodes = []string{
+	"ExpiredToken",
+	"ExpiredTokenException",
+	"InvalidClientTokenId",
+	"SignatureDoesNotMatch",
+	"UnrecognizedClientException",
+}
+
+// CheckCredentials verifies that the default credential chain yields AWS
+// credentials and that STS accepts them, so a missing or expired profile is
+// reported before deploy or destroy starts rather than deep inside it.
+func (p *Provider) CheckCredentials(ctx context.Context, clusterConfig *config.ClusterConfig) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.CheckCredentials")
+	defer span.End()
+
+	awsCfg, err := extractAWSConfig(ctx, clusterConfig)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	sdkCfg, err := awssdk.LoadConfig(ctx, awsCfg.Region)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: failed to load AWS config (check AWS_PROFILE and ~/.aws/config): %w", cluster.ErrCredentials, err)
+	}
+	if err := checkCredentials(ctx, sdkCfg.Credentials, sts.NewFromConfig(sdkCfg)); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// checkCredentials retrieves credentials from creds and confirms them with
+// GetCallerIdentity, which needs no IAM permissions.
+func checkCredentials(ctx context.Context, creds aws.CredentialsProvider, client STSClient) error {
+	if creds == nil {
+		return noCredentialsError(errors.New("no credential provider configured"))
+	}
+	if _, err := creds.Retrieve(ctx); err != nil {
+		return noCredentialsError(err)
+	}
+	if _, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && slices.Contains(rejectedCredentialCodes, apiErr.ErrorCode()) {
+			return fmt.Errorf("%w: AWS rejected the configured credentials (%s); refresh them or %s: %w", cluster.ErrCredentials, apiErr.ErrorCode(), credentialsHint, err)
+		}
+		return fmt.Errorf("failed to verify AWS credentials: %w", err)
+	}
+	return nil
+}
+
+// noCredentialsError wraps a credential chain failure in the user-facing
+// "no credentials" message.
+func noCredentialsError(err error) error {
+	return fmt.Errorf("%w: no AWS credentials found; %s: %w", cluster.ErrCredentials, credentialsHint, err)
+}
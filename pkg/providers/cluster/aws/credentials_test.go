@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+func TestCheckCredentials(t *testing.T) {
+	staticCreds := aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+	})
+	missingCreds := aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{}, errors.New("failed to refresh cached credentials, no EC2 IMDS role found")
+	})
+	identityErr := func(err error) *mockSTSClient {
+		return &mockSTSClient{GetCallerIdentityFunc: func(context.Context, *sts.GetCallerIdentityInput, ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+			return nil, err
+		}}
+	}
+
+	tests := []struct {
+		name            string
+		creds           aws.CredentialsProvider
+		client          STSClient
+		wantErr         string
+		wantCredentials bool
+	}{
+		{name: "valid", creds: staticCreds, client: &mockSTSClient{}},
+		{
+			name:            "no credentials",
+			creds:           missingCreds,
+			client:          &mockSTSClient{},
+			wantErr:         "no AWS credentials found; set AWS_PROFILE",
+			wantCredentials: true,
+		},
+		{
+			name:            "no credential provider",
+			client:          &mockSTSClient{},
+			wantErr:         "no AWS credentials found",
+			wantCredentials: true,
+		},
+		{
+			name:            "expired",
+			creds:           staticCreds,
+			client:          identityErr(&smithy.GenericAPIError{Code: "ExpiredToken", Message: "The security token included in the request is expired"}),
+			wantErr:         "AWS rejected the configured credentials (ExpiredToken)",
+			wantCredentials: true,
+		},
+		{
+			name:    "sts unreachable",
+			creds:   staticCreds,
+			client:  identityErr(errors.New("dial tcp: lookup sts.us-west-2.amazonaws.com: no such host")),
+			wantErr: "failed to verify AWS credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCredentials(context.Background(), tt.creds, tt.client)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkCredentials() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkCredentials() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if got := errors.Is(err, cluster.ErrCredentials); got != tt.wantCredentials {
+				t.Errorf("errors.Is(err, ErrCredentials) = %v, want %v", got, tt.wantCredentials)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// DefaultSecurityGroupClient defines the EC2 operations needed to lock down
+// a VPC's default security group.
+type DefaultSecurityGroupClient interface {
+	DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
+	CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
+	RevokeSecurityGroupIngress(ctx context.Context, params *ec2.RevokeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error)
+	RevokeSecurityGroupEgress(ctx context.Context, params *ec2.RevokeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error)
+}
+
+func newDefaultSecurityGroupClient(ctx context.Context, region string) (DefaultSecurityGroupClient, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return ec2.NewFromConfig(cfg), nil
+}
+
+// restrictDefaultSecurityGroup tags the default security group of vpcID as
+// NIC-managed and revokes every ingress and egress rule on it. It is
+// idempotent: a group with no rules left only has drifted tags corrected. The
+// group itself is deleted along with the VPC, so Destroy needs no matching
+// step.
+func restrictDefaultSecurityGroup(ctx context.Context, client DefaultSecurityGroupClient, clusterName, environment, clusterID, vpcID string, tags map[string]string) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.restrictDefaultSecurityGroup")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cluster_name", clusterName),
+		attribute.String("vpc_id", vpcID),
+	)
+
+	out, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("vpc-id"), Values: []string{vpcID}},
+			{Name: aws.String("group-name"), Values: []string{"default"}},
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to describe default security group of VPC %s: %w", vpcID, err)
+	}
+	if len(out.SecurityGroups) == 0 {
+		err := fmt.Errorf("default security group of VPC %s not found", vpcID)
+		span.RecordError(err)
+		return err
+	}
+	sg := out.SecurityGroups[0]
+	groupID := aws.ToString(sg.GroupId)
+	span.SetAttributes(attribute.String("security_group_id", groupID))
+
+	// CreateTags only adds or overwrites, so tags added to the group
+	// out-of-band survive; only missing or drifted tags are written.
+	if changes := tagChanges(ec2TagMap(sg.Tags), nicTags(clusterName, environment, clusterID, tags)); len(changes) > 0 {
+		if _, err := client.CreateTags(ctx, &ec2.CreateTagsInput{
+			Resources: []string{groupID},
+			Tags:      ec2Tags(changes),
+		}); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to tag default security group %s: %w", groupID, err)
+		}
+	}
+
+	if len(sg.IpPermissions) > 0 {
+		if _, err := client.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       &groupID,
+			IpPermissions: sg.IpPermissions,
+		}); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to revoke ingress rules on default security group %s: %w", groupID, err)
+		}
+	}
+	if len(sg.IpPermissionsEgress) > 0 {
+		if _, err := client.RevokeSecurityGroupEgress(ctx, &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:       &groupID,
+			IpPermissions: sg.IpPermissionsEgress,
+		}); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to revoke egress rules on default security group %s: %w", groupID, err)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("ingress_rules_revoked", len(sg.IpPermissions)),
+		attribute.Int("egress_rules_revoked", len(sg.IpPermissionsEgress)),
+	)
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Restricted default security group %s", groupID)).
+		WithResource("security-group").
+		WithAction("restricted").
+		WithMetadata("vpc_id", vpcID))
+	return nil
+}
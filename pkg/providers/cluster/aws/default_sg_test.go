@@ -0,0 +1,147 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// mockDefaultSGClient implements DefaultSecurityGroupClient and records the
+// calls made against it.
+type mockDefaultSGClient struct {
+	groups      []ec2types.SecurityGroup
+	describeErr error
+
+	describeInput *ec2.DescribeSecurityGroupsInput
+	tagged        *ec2.CreateTagsInput
+	ingress       *ec2.RevokeSecurityGroupIngressInput
+	egress        *ec2.RevokeSecurityGroupEgressInput
+}
+
+func (m *mockDefaultSGClient) DescribeSecurityGroups(_ context.Context, params *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	m.describeInput = params
+	if m.describeErr != nil {
+		return nil, m.describeErr
+	}
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: m.groups}, nil
+}
+
+func (m *mockDefaultSGClient) CreateTags(_ context.Context, params *ec2.CreateTagsInput, _ ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	m.tagged = params
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func (m *mockDefaultSGClient) RevokeSecurityGroupIngress(_ context.Context, params *ec2.RevokeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	m.ingress = params
+	return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+}
+
+func (m *mockDefaultSGClient) RevokeSecurityGroupEgress(_ context.Context, params *ec2.RevokeSecurityGroupEgressInput, _ ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	m.egress = params
+	return &ec2.RevokeSecurityGroupEgressOutput{}, nil
+}
+
+func TestRestrictDefaultSecurityGroup(t *testing.T) {
+	t.Run("tags the group and revokes its default rules", func(t *testing.T) {
+		selfIngress := ec2types.IpPermission{
+			IpProtocol:       aws.String("-1"),
+			UserIdGroupPairs: []ec2types.UserIdGroupPair{{GroupId: aws.String("sg-default")}},
+		}
+		allEgress := ec2types.IpPermission{
+			IpProtocol: aws.String("-1"),
+			IpRanges:   []ec2types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+		}
+		client := &mockDefaultSGClient{groups: []ec2types.SecurityGroup{{
+			GroupId:             aws.String("sg-default"),
+			IpPermissions:       []ec2types.IpPermission{selfIngress},
+			IpPermissionsEgress: []ec2types.IpPermission{allEgress},
+		}}}
+
+		err := restrictDefaultSecurityGroup(context.Background(), client, "demo", "", "", "vpc-123", map[string]string{"team": "data"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := client.describeInput.Filters[0].Values; len(got) != 1 || got[0] != "vpc-123" {
+			t.Errorf("describe filtered on VPC %v, want vpc-123", got)
+		}
+
+		if client.tagged == nil || client.tagged.Resources[0] != "sg-default" {
+			t.Fatalf("expected sg-default to be tagged, got %+v", client.tagged)
+		}
+		tags := map[string]string{}
+		for _, tag := range client.tagged.Tags {
+			tags[*tag.Key] = *tag.Value
+		}
+		want := map[string]string{tagKeyManagedBy: tagValueNIC, tagKeyCluster: "demo", "team": "data"}
+		for k, v := range want {
+			if tags[k] != v {
+				t.Errorf("tag %s = %q, want %q", k, tags[k], v)
+			}
+		}
+
+		if client.ingress == nil || *client.ingress.GroupId != "sg-default" || len(client.ingress.IpPermissions) != 1 {
+			t.Errorf("ingress rules not revoked: %+v", client.ingress)
+		}
+		if client.egress == nil || *client.egress.GroupId != "sg-default" || len(client.egress.IpPermissions) != 1 {
+			t.Errorf("egress rules not revoked: %+v", client.egress)
+		}
+	})
+
+	t.Run("already restricted group is only re-tagged", func(t *testing.T) {
+		client := &mockDefaultSGClient{groups: []ec2types.SecurityGroup{{GroupId: aws.String("sg-default")}}}
+
+		if err := restrictDefaultSecurityGroup(context.Background(), client, "demo", "", "", "vpc-123", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.tagged == nil {
+			t.Error("expected tags to be refreshed")
+		}
+		if client.ingress != nil || client.egress != nil {
+			t.Errorf("expected no revoke calls, got ingress=%+v egress=%+v", client.ingress, client.egress)
+		}
+	})
+
+	t.Run("out-of-band tags survive while drifted NIC tags are corrected", func(t *testing.T) {
+		client := &mockDefaultSGClient{groups: []ec2types.SecurityGroup{{
+			GroupId: aws.String("sg-default"),
+			Tags: []ec2types.Tag{
+				{Key: aws.String("owner"), Value: aws.String("secops")},
+				{Key: aws.String(tagKeyManagedBy), Value: aws.String(tagValueNIC)},
+				{Key: aws.String(tagKeyCluster), Value: aws.String("stale")},
+			},
+		}}}
+
+		if err := restrictDefaultSecurityGroup(context.Background(), client, "demo", "", "", "vpc-123", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.tagged == nil {
+			t.Fatal("expected the drifted cluster tag to be written")
+		}
+		written := ec2TagMap(client.tagged.Tags)
+		if len(written) != 1 || written[tagKeyCluster] != "demo" {
+			t.Errorf("written tags = %v, want only %s=demo", written, tagKeyCluster)
+		}
+	})
+
+	t.Run("missing group", func(t *testing.T) {
+		client := &mockDefaultSGClient{}
+		err := restrictDefaultSecurityGroup(context.Background(), client, "demo", "", "", "vpc-123", nil)
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("error = %v, want not found", err)
+		}
+	})
+
+	t.Run("describe error", func(t *testing.T) {
+		client := &mockDefaultSGClient{describeErr: errors.New("throttled")}
+		err := restrictDefaultSecurityGroup(context.Background(), client, "demo", "", "", "vpc-123", nil)
+		if err == nil || !strings.Contains(err.Error(), "throttled") {
+			t.Fatalf("error = %v, want wrapped throttled", err)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -91,6 +92,8 @@ func createEFSStorageClassWithClient(ctx context.Context, client kubernetes.Inte
 	case err != nil:
 		span.RecordError(err)
 		return fmt.Errorf("failed to get EFS StorageClass: %w", err)
+	case storageClassMatches(existing, sc):
+		// Already as desired; skip the no-op Update.
 	default:
 		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Updating existing EFS StorageClass").
 			WithResource("efs-storageclass").
@@ -110,3 +113,12 @@ func createEFSStorageClassWithClient(ctx context.Context, client kubernetes.Inte
 
 	return nil
 }
+
+// storageClassMatches reports whether live already carries every field of
+// desired that NIC manages.
+func storageClassMatches(live, desired *storagev1.StorageClass) bool {
+	return live.Provisioner == desired.Provisioner &&
+		maps.Equal(live.Parameters, desired.Parameters) &&
+		live.ReclaimPolicy != nil && *live.ReclaimPolicy == *desired.ReclaimPolicy &&
+		live.VolumeBindingMode != nil && *live.VolumeBindingMode == *desired.VolumeBindingMode
+}
@@ -166,3 +166,23 @@ func TestCreateEFSStorageClassWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateEFSStorageClassWithClient_Unchanged(t *testing.T) {
+	cfg := &Config{EFS: &EFSConfig{Enabled: true}}
+	client := fake.NewSimpleClientset()
+	ctx := context.Background()
+
+	if err := createEFSStorageClassWithClient(ctx, client, cfg, "fs-12345678"); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	client.ClearActions()
+
+	if err := createEFSStorageClassWithClient(ctx, client, cfg, "fs-12345678"); err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() != "get" {
+			t.Errorf("unexpected %s on an unchanged StorageClass", action.GetVerb())
+		}
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"errors"
 	"fmt"
 
-	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/storage/longhorn"
 )
 
@@ -28,7 +28,7 @@ func newEKSClient(ctx context.Context, region string) (EKSClient, error) {
 	defer span.End()
 	span.SetAttributes(attribute.String(attrKeyRegion, region))
 
-	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	cfg, err := awssdk.LoadConfig(ctx, region)
 	if err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
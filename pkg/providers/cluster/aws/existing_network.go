@@ -0,0 +1,227 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// Subnet role tags the AWS Load Balancer Controller uses to discover where to
+// place internal and internet-facing load balancers.
+const (
+	subnetTagInternalELB = "kubernetes.io/role/internal-elb"
+	subnetTagELB         = "kubernetes.io/role/elb"
+)
+
+// ExistingNetworkClient defines the EC2 operations needed to check a VPC and
+// private subnets supplied with existing_vpc_id and existing_private_subnet_ids.
+type ExistingNetworkClient interface {
+	DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
+	DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error)
+}
+
+func newExistingNetworkClient(ctx context.Context, region string) (ExistingNetworkClient, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return ec2.NewFromConfig(cfg), nil
+}
+
+// validateExistingNetwork checks existing_vpc_id and
+// existing_private_subnet_ids without calling AWS: the module needs both to
+// place the cluster in a VPC it does not create.
+func validateExistingNetwork(c *Config) error {
+	if c.createsVPC() {
+		return nil
+	}
+	if c.ExistingVPCID == "" {
+		return fmt.Errorf("existing_private_subnet_ids requires existing_vpc_id")
+	}
+	if !strings.HasPrefix(c.ExistingVPCID, "vpc-") {
+		return fmt.Errorf("invalid existing_vpc_id %q (expected vpc- followed by the VPC ID)", c.ExistingVPCID)
+	}
+	if len(c.ExistingPrivateSubnetIDs) == 0 {
+		return fmt.Errorf("existing_vpc_id requires existing_private_subnet_ids, the private subnets to run the cluster in")
+	}
+	for i, id := range c.ExistingPrivateSubnetIDs {
+		if !strings.HasPrefix(id, "subnet-") {
+			return fmt.Errorf("invalid existing_private_subnet_ids[%d] %q (expected subnet- followed by the subnet ID)", i, id)
+		}
+		if j := slices.Index(c.ExistingPrivateSubnetIDs, id); j < i {
+			return fmt.Errorf("existing_private_subnet_ids[%d] duplicates existing_private_subnet_ids[%d] %q", i, j, id)
+		}
+	}
+	return nil
+}
+
+// checkExistingNetwork verifies the supplied private subnets against the live
+// account: they must belong to vpcID, span at least two availability zones,
+// carry the internal load balancer role tag and send internet-bound traffic
+// through a NAT or transit gateway, since nodes pull images and reach the EKS
+// API from them. A VPC without subnets tagged for internet-facing load
+// balancers only produces a warning, as private-only deployments need none.
+// When roles is set NIC applies the role tags itself, so the tag checks are
+// replaced by checking the load balancer subnets belong to vpcID.
+func checkExistingNetwork(ctx context.Context, client ExistingNetworkClient, vpcID string, subnetIDs []string, roles *SubnetRolesConfig) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.checkExistingNetwork")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("vpc_id", vpcID),
+		attribute.StringSlice("subnet_ids", subnetIDs),
+	)
+
+	out, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: subnetIDs})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to describe existing_private_subnet_ids %v: %w", subnetIDs, err)
+	}
+	subnets := make(map[string]ec2types.Subnet, len(out.Subnets))
+	for _, s := range out.Subnets {
+		subnets[aws.ToString(s.SubnetId)] = s
+	}
+
+	var azs, untagged []string
+	for _, id := range subnetIDs {
+		s, ok := subnets[id]
+		if !ok {
+			err := fmt.Errorf("existing private subnet %s not found in region", id)
+			span.RecordError(err)
+			return err
+		}
+		if got := aws.ToString(s.VpcId); got != vpcID {
+			err := fmt.Errorf("existing private subnet %s is in VPC %s, not existing_vpc_id %s", id, got, vpcID)
+			span.RecordError(err)
+			return err
+		}
+		if az := aws.ToString(s.AvailabilityZone); !slices.Contains(azs, az) {
+			azs = append(azs, az)
+		}
+		if !hasTag(s.Tags, subnetTagInternalELB) {
+			untagged = append(untagged, id)
+		}
+	}
+	if len(azs) < minControlPlaneAZs {
+		err := fmt.Errorf("existing_private_subnet_ids span %d availability zone(s) %v; EKS requires subnets in at least %d", len(azs), azs, minControlPlaneAZs)
+		span.RecordError(err)
+		return err
+	}
+	if len(untagged) > 0 && roles == nil {
+		err := fmt.Errorf("existing private subnets %v lack the %s=1 tag the AWS Load Balancer Controller uses to place internal load balancers", untagged, subnetTagInternalELB)
+		span.RecordError(err)
+		return err
+	}
+
+	noEgress, err := subnetsWithoutPrivateEgress(ctx, client, vpcID, subnetIDs)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if len(noEgress) > 0 {
+		err := fmt.Errorf("existing private subnets %v have no default route (0.0.0.0/0) through a NAT gateway or transit gateway; nodes could not pull images or join the cluster", noEgress)
+		span.RecordError(err)
+		return err
+	}
+
+	if roles != nil {
+		if err := checkSubnetRoleSubnets(ctx, client, vpcID, roles); err != nil {
+			span.RecordError(err)
+			return err
+		}
+		span.SetAttributes(attribute.StringSlice("availability_zones", azs))
+		return nil
+	}
+
+	public, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{Filters: []ec2types.Filter{
+		{Name: aws.String("vpc-id"), Values: []string{vpcID}},
+		{Name: aws.String("tag-key"), Values: []string{subnetTagELB}},
+	}})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to list public subnets of VPC %s: %w", vpcID, err)
+	}
+	if len(public.Subnets) == 0 {
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, fmt.Sprintf("VPC %s has no subnets tagged %s; internet-facing load balancers cannot be created", vpcID, subnetTagELB)).
+			WithResource("vpc").
+			WithAction("preflight").
+			WithMetadata("vpc_id", vpcID))
+	}
+
+	span.SetAttributes(attribute.StringSlice("availability_zones", azs))
+	return nil
+}
+
+// subnetsWithoutPrivateEgress returns the subnets in subnetIDs whose route
+// table (the explicitly associated one, or else the VPC's main table) has no
+// default route through a NAT or transit gateway.
+func subnetsWithoutPrivateEgress(ctx context.Context, client ExistingNetworkClient, vpcID string, subnetIDs []string) ([]string, error) {
+	var main *ec2types.RouteTable
+	associated := make(map[string]ec2types.RouteTable)
+
+	paginator := ec2.NewDescribeRouteTablesPaginator(client, &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("vpc-id"), Values: []string{vpcID}}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe route tables of VPC %s: %w", vpcID, err)
+		}
+		for _, rt := range page.RouteTables {
+			for _, assoc := range rt.Associations {
+				if aws.ToBool(assoc.Main) {
+					main = &rt
+				}
+				if assoc.SubnetId != nil {
+					associated[*assoc.SubnetId] = rt
+				}
+			}
+		}
+	}
+
+	var missing []string
+	for _, id := range subnetIDs {
+		rt, ok := associated[id]
+		if !ok {
+			if main == nil {
+				missing = append(missing, id)
+				continue
+			}
+			rt = *main
+		}
+		if !hasPrivateDefaultRoute(rt) {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// hasPrivateDefaultRoute reports whether rt sends 0.0.0.0/0 to a NAT gateway
+// or transit gateway, as opposed to an internet gateway or nowhere.
+func hasPrivateDefaultRoute(rt ec2types.RouteTable) bool {
+	for _, r := range rt.Routes {
+		if aws.ToString(r.DestinationCidrBlock) != "0.0.0.0/0" || r.State == ec2types.RouteStateBlackhole {
+			continue
+		}
+		if r.NatGatewayId != nil || r.TransitGatewayId != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTag reports whether tags contain key.
+func hasTag(tags []ec2types.Tag, key string) bool {
+	return slices.ContainsFunc(tags, func(t ec2types.Tag) bool { return aws.ToString(t.Key) == key })
+}
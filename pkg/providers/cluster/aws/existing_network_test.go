@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// mockExistingNetworkClient serves fixed subnets and route tables. Filtered
+// DescribeSubnets calls return the subnets carrying the filtered tag key.
+type mockExistingNetworkClient struct {
+	subnets     []ec2types.Subnet
+	routeTables []ec2types.RouteTable
+}
+
+func (m *mockExistingNetworkClient) DescribeSubnets(_ context.Context, params *ec2.DescribeSubnetsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	out := &ec2.DescribeSubnetsOutput{}
+	for _, s := range m.subnets {
+		if len(params.SubnetIds) > 0 && slices.Contains(params.SubnetIds, aws.ToString(s.SubnetId)) {
+			out.Subnets = append(out.Subnets, s)
+		}
+		for _, f := range params.Filters {
+			if aws.ToString(f.Name) == "tag-key" && hasTag(s.Tags, f.Values[0]) {
+				out.Subnets = append(out.Subnets, s)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockExistingNetworkClient) DescribeRouteTables(_ context.Context, _ *ec2.DescribeRouteTablesInput, _ ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	return &ec2.DescribeRouteTablesOutput{RouteTables: m.routeTables}, nil
+}
+
+func testSubnet(id, vpc, az string, tags ...string) ec2types.Subnet {
+	s := ec2types.Subnet{SubnetId: aws.String(id), VpcId: aws.String(vpc), AvailabilityZone: aws.String(az)}
+	for _, k := range tags {
+		s.Tags = append(s.Tags, ec2types.Tag{Key: aws.String(k), Value: aws.String("1")})
+	}
+	return s
+}
+
+func testRouteTable(main bool, target ec2types.Route, subnets ...string) ec2types.RouteTable {
+	rt := ec2types.RouteTable{Routes: []ec2types.Route{target}}
+	if main {
+		rt.Associations = append(rt.Associations, ec2types.RouteTableAssociation{Main: aws.Bool(true)})
+	}
+	for _, id := range subnets {
+		rt.Associations = append(rt.Associations, ec2types.RouteTableAssociation{SubnetId: aws.String(id)})
+	}
+	return rt
+}
+
+func newMockExistingNetwork() *mockExistingNetworkClient {
+	natRoute := ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}
+	igwRoute := ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}
+	return &mockExistingNetworkClient{
+		subnets: []ec2types.Subnet{
+			testSubnet("subnet-a", "vpc-1", "us-west-2a", subnetTagInternalELB),
+			testSubnet("subnet-b", "vpc-1", "us-west-2b", subnetTagInternalELB),
+			testSubnet("subnet-c", "vpc-1", "us-west-2a", subnetTagInternalELB),
+			testSubnet("subnet-untagged", "vpc-1", "us-west-2c"),
+			testSubnet("subnet-public", "vpc-1", "us-west-2a", subnetTagELB),
+			testSubnet("subnet-other", "vpc-2", "us-west-2c", subnetTagInternalELB),
+		},
+		routeTables: []ec2types.RouteTable{
+			testRouteTable(true, natRoute),
+			testRouteTable(false, natRoute, "subnet-a"),
+			testRouteTable(false, igwRoute, "subnet-c", "subnet-public"),
+		},
+	}
+}
+
+func TestCheckExistingNetwork(t *testing.T) {
+	tests := []struct {
+		name      string
+		subnets   []string
+		roles     *SubnetRolesConfig
+		errSubstr string
+	}{
+		// subnet-b has no explicit association and uses the main table.
+		{name: "valid", subnets: []string{"subnet-a", "subnet-b"}},
+		{name: "unknown subnet", subnets: []string{"subnet-a", "subnet-missing"}, errSubstr: "subnet-missing not found"},
+		{name: "other vpc", subnets: []string{"subnet-a", "subnet-other"}, errSubstr: "is in VPC vpc-2, not existing_vpc_id vpc-1"},
+		{name: "single az", subnets: []string{"subnet-a"}, errSubstr: "span 1 availability zone(s)"},
+		{name: "missing role tag", subnets: []string{"subnet-a", "subnet-untagged"}, errSubstr: "[subnet-untagged] lack the kubernetes.io/role/internal-elb=1 tag"},
+		{name: "public route", subnets: []string{"subnet-b", "subnet-c"}, errSubstr: "[subnet-c] have no default route"},
+		{
+			name:    "role tags applied by NIC",
+			subnets: []string{"subnet-a", "subnet-untagged"},
+			roles:   &SubnetRolesConfig{InternalLoadBalancerSubnetIDs: []string{"subnet-a"}, PublicLoadBalancerSubnetIDs: []string{"subnet-public"}},
+		},
+		{
+			name:      "role subnet in other vpc",
+			subnets:   []string{"subnet-a", "subnet-b"},
+			roles:     &SubnetRolesConfig{InternalLoadBalancerSubnetIDs: []string{"subnet-a"}, PublicLoadBalancerSubnetIDs: []string{"subnet-other"}},
+			errSubstr: "subnet_roles subnet subnet-other is in VPC vpc-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkExistingNetwork(context.Background(), newMockExistingNetwork(), "vpc-1", tt.subnets, tt.roles)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("checkExistingNetwork() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("checkExistingNetwork() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestValidateExistingNetwork(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		errSubstr string
+	}{
+		{name: "nic-created vpc", cfg: Config{}},
+		{name: "valid", cfg: Config{ExistingVPCID: "vpc-1", ExistingPrivateSubnetIDs: []string{"subnet-a", "subnet-b"}}},
+		{name: "subnets without vpc", cfg: Config{ExistingPrivateSubnetIDs: []string{"subnet-a"}}, errSubstr: "requires existing_vpc_id"},
+		{name: "vpc without subnets", cfg: Config{ExistingVPCID: "vpc-1"}, errSubstr: "requires existing_private_subnet_ids"},
+		{name: "malformed vpc id", cfg: Config{ExistingVPCID: "1234", ExistingPrivateSubnetIDs: []string{"subnet-a"}}, errSubstr: "invalid existing_vpc_id"},
+		{name: "malformed subnet id", cfg: Config{ExistingVPCID: "vpc-1", ExistingPrivateSubnetIDs: []string{"subnet-a", "sn-b"}}, errSubstr: "invalid existing_private_subnet_ids[1]"},
+		{name: "duplicate subnet", cfg: Config{ExistingVPCID: "vpc-1", ExistingPrivateSubnetIDs: []string{"subnet-a", "subnet-a"}}, errSubstr: "duplicates existing_private_subnet_ids[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExistingNetwork(&tt.cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("validateExistingNetwork() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("validateExistingNetwork() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+)
+
+// policyARNPattern matches IAM managed policy ARNs, both AWS-managed
+// (arn:aws:iam::aws:policy/...) and customer-managed (12-digit account ID),
+// in any partition.
+var policyARNPattern = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::(aws|\d{12}):policy/[\w+=,.@/-]+$`)
+
+// inlinePolicyNamePattern is the IAM character and length rule for inline
+// policy names.
+var inlinePolicyNamePattern = regexp.MustCompile(`^[\w+=,.@-]{1,128}$`)
+
+// validPolicyVersions are the IAM policy language versions AWS accepts.
+var validPolicyVersions = []string{"2012-10-17", "2008-10-17"}
+
+// requireManagedRoles returns an error naming field when the node and
+// cluster roles are not created by NIC. Policies NIC puts on a role are
+// reconciled by OpenTofu, which would fight with whoever owns a
+// bring-your-own role.
+func requireManagedRoles(c *Config, field string) error {
+	if c.createsIAMRoles() {
+		return nil
+	}
+	return fmt.Errorf("%s cannot be used with existing_cluster_role_arn or existing_node_role_arn; attach policies to the existing roles directly", field)
+}
+
+// validatePolicyARNs checks the additional managed policy ARNs configured for
+// the node and cluster roles.
+func validatePolicyARNs(c *Config) error {
+	for _, f := range []struct {
+		field string
+		arns  []string
+	}{
+		{"additional_node_policy_arns", c.AdditionalNodePolicyARNs},
+		{"additional_cluster_policy_arns", c.AdditionalClusterPolicyARNs},
+	} {
+		field, arns := f.field, f.arns
+		if len(arns) > 0 {
+			if err := requireManagedRoles(c, field); err != nil {
+				return err
+			}
+		}
+		seen := make(map[string]bool, len(arns))
+		for i, arn := range arns {
+			if !policyARNPattern.MatchString(arn) {
+				return fmt.Errorf("%s[%d]: %q is not an IAM managed policy ARN", field, i, arn)
+			}
+			if seen[arn] {
+				return fmt.Errorf("%s[%d]: duplicate policy %q", field, i, arn)
+			}
+			seen[arn] = true
+		}
+	}
+	return nil
+}
+
+// validateInlinePolicies checks the inline policies configured for the node
+// and cluster roles: names follow IAM rules and each document is a
+// well-formed policy with a Statement.
+func validateInlinePolicies(c *Config) error {
+	for _, f := range []struct {
+		field    string
+		policies map[string]string
+	}{
+		{"node_inline_policies", c.NodeInlinePolicies},
+		{"cluster_inline_policies", c.ClusterInlinePolicies},
+	} {
+		if len(f.policies) > 0 {
+			if err := requireManagedRoles(c, f.field); err != nil {
+				return err
+			}
+		}
+		names := slices.Sorted(maps.Keys(f.policies))
+		for _, name := range names {
+			if !inlinePolicyNamePattern.MatchString(name) {
+				return fmt.Errorf("%s: invalid policy name %q (1-128 characters of letters, digits, and +=,.@_-)", f.field, name)
+			}
+			if err := validatePolicyDocument(f.policies[name]); err != nil {
+				return fmt.Errorf("%s.%s: %w", f.field, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validatePolicyDocument checks that doc is a JSON IAM policy document with a
+// supported Version and a non-empty Statement. It does not evaluate the
+// statements themselves; IAM rejects semantically invalid ones at apply time.
+func validatePolicyDocument(doc string) error {
+	var policy struct {
+		Version   string          `json:"Version"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return fmt.Errorf("policy document is not valid JSON: %w", err)
+	}
+	if policy.Version != "" && !slices.Contains(validPolicyVersions, policy.Version) {
+		return fmt.Errorf("unsupported policy Version %q (must be one of: %v)", policy.Version, validPolicyVersions)
+	}
+
+	var statements []map[string]any
+	switch {
+	case len(policy.Statement) == 0:
+		return fmt.Errorf("policy document has no Statement")
+	case policy.Statement[0] == '[':
+		if err := json.Unmarshal(policy.Statement, &statements); err != nil {
+			return fmt.Errorf("policy Statement must be an object or list of objects: %w", err)
+		}
+	default:
+		var statement map[string]any
+		if err := json.Unmarshal(policy.Statement, &statement); err != nil {
+			return fmt.Errorf("policy Statement must be an object or list of objects: %w", err)
+		}
+		statements = append(statements, statement)
+	}
+	if len(statements) == 0 {
+		return fmt.Errorf("policy document has no Statement")
+	}
+	for i, st := range statements {
+		if _, ok := st["Effect"]; !ok {
+			return fmt.Errorf("policy Statement[%d] is missing Effect", i)
+		}
+	}
+	return nil
+}
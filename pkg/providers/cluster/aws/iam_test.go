@@ -0,0 +1,203 @@
+package aws
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidatePolicyARNs(t *testing.T) {
+	const s3Read = "arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"
+	const custom = "arn:aws:iam::123456789012:policy/team/data-pipeline"
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		errSubstr string // "" means no error expected
+	}{
+		{name: "none configured", cfg: Config{}},
+		{
+			name: "aws and customer managed",
+			cfg:  Config{AdditionalNodePolicyARNs: []string{s3Read, custom}, AdditionalClusterPolicyARNs: []string{custom}},
+		},
+		{
+			name: "govcloud partition",
+			cfg:  Config{AdditionalNodePolicyARNs: []string{"arn:aws-us-gov:iam::aws:policy/AmazonS3ReadOnlyAccess"}},
+		},
+		{
+			name:      "role arn rejected",
+			cfg:       Config{AdditionalNodePolicyARNs: []string{"arn:aws:iam::123456789012:role/foo"}},
+			errSubstr: "additional_node_policy_arns[0]",
+		},
+		{
+			name:      "bare name rejected",
+			cfg:       Config{AdditionalClusterPolicyARNs: []string{"AmazonS3ReadOnlyAccess"}},
+			errSubstr: "additional_cluster_policy_arns[0]",
+		},
+		{
+			name:      "duplicate rejected",
+			cfg:       Config{AdditionalNodePolicyARNs: []string{s3Read, s3Read}},
+			errSubstr: "duplicate policy",
+		},
+		{
+			name:      "existing node role",
+			cfg:       Config{ExistingNodeRoleArn: "arn:aws:iam::123456789012:role/nodes", AdditionalNodePolicyARNs: []string{s3Read}},
+			errSubstr: "cannot be used with existing_cluster_role_arn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePolicyARNs(&tt.cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestToTFVarsAdditionalPolicyARNs(t *testing.T) {
+	const s3Read = "arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"
+	const custom = "arn:aws:iam::123456789012:policy/data-pipeline"
+
+	cfg := Config{
+		Region:                      "us-west-2",
+		KubernetesVersion:           "1.34",
+		NodeGroups:                  map[string]NodeGroup{"general": {Instance: "m5.large"}},
+		AdditionalNodePolicyARNs:    []string{s3Read, custom},
+		AdditionalClusterPolicyARNs: []string{custom},
+	}
+
+	vars := cfg.toTFVars("test", "", nil)
+	if !slices.Equal(vars.AdditionalNodePolicyARNs, []string{s3Read, custom}) {
+		t.Errorf("AdditionalNodePolicyARNs = %v, want [%s %s]", vars.AdditionalNodePolicyARNs, s3Read, custom)
+	}
+	if !slices.Equal(vars.AdditionalClusterPolicyARNs, []string{custom}) {
+		t.Errorf("AdditionalClusterPolicyARNs = %v, want [%s]", vars.AdditionalClusterPolicyARNs, custom)
+	}
+
+	// OpenTofu detaches any attachment no longer in the variable, so removing
+	// an ARN from config must remove it from the rendered vars.
+	cfg.AdditionalNodePolicyARNs = []string{s3Read}
+	cfg.AdditionalClusterPolicyARNs = nil
+	vars = cfg.toTFVars("test", "", nil)
+	if !slices.Equal(vars.AdditionalNodePolicyARNs, []string{s3Read}) {
+		t.Errorf("after removal AdditionalNodePolicyARNs = %v, want [%s]", vars.AdditionalNodePolicyARNs, s3Read)
+	}
+	if vars.AdditionalClusterPolicyARNs != nil {
+		t.Errorf("after removal AdditionalClusterPolicyARNs = %v, want nil", vars.AdditionalClusterPolicyARNs)
+	}
+}
+
+func TestValidateInlinePolicies(t *testing.T) {
+	const s3Doc = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}]}`
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		errSubstr string // "" means no error expected
+	}{
+		{name: "none configured", cfg: Config{}},
+		{
+			name: "node and cluster policies",
+			cfg: Config{
+				NodeInlinePolicies:    map[string]string{"s3-read": s3Doc},
+				ClusterInlinePolicies: map[string]string{"single": `{"Statement":{"Effect":"Allow","Action":"ec2:Describe*","Resource":"*"}}`},
+			},
+		},
+		{
+			name:      "invalid json",
+			cfg:       Config{NodeInlinePolicies: map[string]string{"broken": `{"Statement":`}},
+			errSubstr: "node_inline_policies.broken: policy document is not valid JSON",
+		},
+		{
+			name:      "missing statement",
+			cfg:       Config{NodeInlinePolicies: map[string]string{"empty": `{"Version":"2012-10-17"}`}},
+			errSubstr: "no Statement",
+		},
+		{
+			name:      "empty statement list",
+			cfg:       Config{NodeInlinePolicies: map[string]string{"empty": `{"Statement":[]}`}},
+			errSubstr: "no Statement",
+		},
+		{
+			name:      "statement without effect",
+			cfg:       Config{ClusterInlinePolicies: map[string]string{"noeffect": `{"Statement":[{"Action":"*","Resource":"*"}]}`}},
+			errSubstr: "Statement[0] is missing Effect",
+		},
+		{
+			name:      "unsupported version",
+			cfg:       Config{NodeInlinePolicies: map[string]string{"old": `{"Version":"2020-01-01","Statement":[{"Effect":"Allow"}]}`}},
+			errSubstr: "unsupported policy Version",
+		},
+		{
+			name:      "invalid name",
+			cfg:       Config{NodeInlinePolicies: map[string]string{"has space": s3Doc}},
+			errSubstr: "invalid policy name",
+		},
+		{
+			name:      "existing roles",
+			cfg:       Config{ExistingClusterRoleArn: "arn:aws:iam::123456789012:role/cluster", ClusterInlinePolicies: map[string]string{"s3-read": s3Doc}},
+			errSubstr: "cannot be used with existing_cluster_role_arn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInlinePolicies(&tt.cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestToTFVarsInlinePolicies(t *testing.T) {
+	const doc = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+
+	cfg := Config{
+		Region:                "us-west-2",
+		KubernetesVersion:     "1.34",
+		NodeGroups:            map[string]NodeGroup{"general": {Instance: "m5.large"}},
+		NodeInlinePolicies:    map[string]string{"s3-read": doc, "s3-write": doc},
+		ClusterInlinePolicies: map[string]string{"describe": doc},
+	}
+
+	vars := cfg.toTFVars("test", "", nil)
+	if len(vars.NodeInlinePolicies) != 2 || vars.NodeInlinePolicies["s3-read"] != doc {
+		t.Errorf("NodeInlinePolicies = %v, want s3-read and s3-write", vars.NodeInlinePolicies)
+	}
+	if len(vars.ClusterInlinePolicies) != 1 {
+		t.Errorf("ClusterInlinePolicies = %v, want describe", vars.ClusterInlinePolicies)
+	}
+
+	// OpenTofu deletes inline policies that drop out of the variable, so a
+	// policy removed from config must be absent from the rendered vars.
+	cfg.NodeInlinePolicies = map[string]string{"s3-read": doc}
+	cfg.ClusterInlinePolicies = nil
+	vars = cfg.toTFVars("test", "", nil)
+	if _, ok := vars.NodeInlinePolicies["s3-write"]; ok {
+		t.Error("removed node inline policy s3-write still rendered")
+	}
+	if vars.ClusterInlinePolicies != nil {
+		t.Errorf("ClusterInlinePolicies = %v, want nil after removal", vars.ClusterInlinePolicies)
+	}
+}
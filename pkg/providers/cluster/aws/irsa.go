@@ -0,0 +1,146 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/tofu"
+)
+
+// serviceAccountRoleARNsOutput is the OpenTofu output mapping each
+// service_account_roles key to the ARN of the role created for it.
+const serviceAccountRoleARNsOutput = "service_account_role_arns"
+
+// serviceAccountRoleKeyPattern limits role keys to short DNS-style labels.
+// The key becomes part of the IAM role name (<project>-irsa-<key>), which
+// IAM caps at 64 characters.
+var serviceAccountRoleKeyPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,30}[a-z0-9])?$`)
+
+// ServiceAccountRole is an IAM role assumable by a single Kubernetes service
+// account through the cluster's OIDC provider (IRSA).
+type ServiceAccountRole struct {
+	Namespace      string   `yaml:"namespace"`
+	ServiceAccount string   `yaml:"service_account"`
+	PolicyARNs     []string `yaml:"policy_arns"`
+}
+
+// serviceAccountRoleVar is the OpenTofu shape of a ServiceAccountRole.
+type serviceAccountRoleVar struct {
+	Namespace      string   `json:"namespace"`
+	ServiceAccount string   `json:"service_account"`
+	PolicyARNs     []string `json:"policy_arns"`
+}
+
+// validateServiceAccountRoles checks the service_account_roles block and the
+// addons that reference it.
+func validateServiceAccountRoles(c *Config) error {
+	if len(c.ServiceAccountRoles) > 0 && c.EnableIRSA != nil && !*c.EnableIRSA {
+		return fmt.Errorf("service_account_roles requires enable_irsa; the roles are trusted through the cluster's OIDC provider")
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.ServiceAccountRoles)) {
+		role := c.ServiceAccountRoles[key]
+		if !serviceAccountRoleKeyPattern.MatchString(key) {
+			return fmt.Errorf("service_account_roles: invalid key %q (1-32 lowercase letters, digits and hyphens)", key)
+		}
+		if role.Namespace == "" || role.ServiceAccount == "" {
+			return fmt.Errorf("service_account_roles.%s: namespace and service_account are required", key)
+		}
+		if len(role.PolicyARNs) == 0 {
+			return fmt.Errorf("service_account_roles.%s: at least one policy_arns entry is required", key)
+		}
+		for i, arn := range role.PolicyARNs {
+			if !policyARNPattern.MatchString(arn) {
+				return fmt.Errorf("service_account_roles.%s.policy_arns[%d]: %q is not an IAM managed policy ARN", key, i, arn)
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Addons)) {
+		addon := c.Addons[name]
+		if addon.ServiceAccountRole == "" {
+			continue
+		}
+		if addon.ServiceAccountRoleARN != "" {
+			return fmt.Errorf("addon %s: set service_account_role or service_account_role_arn, not both", name)
+		}
+		if _, ok := c.ServiceAccountRoles[addon.ServiceAccountRole]; !ok {
+			return fmt.Errorf("addon %s: service_account_role %q is not defined in service_account_roles", name, addon.ServiceAccountRole)
+		}
+	}
+	return nil
+}
+
+// serviceAccountRoleVars converts the configured roles to their OpenTofu
+// variable form.
+func (c *Config) serviceAccountRoleVars() map[string]serviceAccountRoleVar {
+	if len(c.ServiceAccountRoles) == 0 {
+		return nil
+	}
+	vars := make(map[string]serviceAccountRoleVar, len(c.ServiceAccountRoles))
+	for key, role := range c.ServiceAccountRoles {
+		vars[key] = serviceAccountRoleVar(role)
+	}
+	return vars
+}
+
+// addonsUseServiceAccountRoles reports whether any addon takes its role ARN
+// from service_account_roles.
+func addonsUseServiceAccountRoles(addons map[string]Addon) bool {
+	for _, addon := range addons {
+		if addon.ServiceAccountRole != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseServiceAccountRoleARNs reads the role ARNs from the OpenTofu outputs.
+// A missing output (no state yet) yields an empty map.
+func parseServiceAccountRoleARNs(outputs map[string]tfexec.OutputMeta) (map[string]string, error) {
+	arns := map[string]string{}
+	out, ok := outputs[serviceAccountRoleARNsOutput]
+	if !ok {
+		return arns, nil
+	}
+	if err := json.Unmarshal(out.Value, &arns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", serviceAccountRoleARNsOutput, err)
+	}
+	return arns, nil
+}
+
+// resolveAddonRoles returns a copy of addons with each service_account_role
+// reference replaced by the ARN of the role OpenTofu created. A role not yet
+// created leaves the ARN empty.
+func resolveAddonRoles(addons map[string]Addon, arns map[string]string) map[string]Addon {
+	resolved := make(map[string]Addon, len(addons))
+	for name, addon := range addons {
+		if addon.ServiceAccountRole != "" {
+			addon.ServiceAccountRoleARN = arns[addon.ServiceAccountRole]
+		}
+		resolved[name] = addon
+	}
+	return resolved
+}
+
+// resolveAddons fills in the role ARNs of addons that reference
+// service_account_roles from the OpenTofu outputs. Outputs are only read
+// when some addon needs them.
+func resolveAddons(ctx context.Context, tf *tofu.TerraformExecutor, addons map[string]Addon) (map[string]Addon, error) {
+	if !addonsUseServiceAccountRoles(addons) {
+		return addons, nil
+	}
+	outputs, err := tf.Output(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get terraform outputs for service account roles: %w", err)
+	}
+	arns, err := parseServiceAccountRoleARNs(outputs)
+	if err != nil {
+		return nil, err
+	}
+	return resolveAddonRoles(addons, arns), nil
+}
@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+func TestValidateServiceAccountRoles(t *testing.T) {
+	const ebsPolicy = "arn:aws:iam::aws:policy/service-role/AmazonEBSCSIDriverPolicy"
+	ebsRole := ServiceAccountRole{Namespace: "kube-system", ServiceAccount: "ebs-csi-controller-sa", PolicyARNs: []string{ebsPolicy}}
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		errSubstr string // "" means no error expected
+	}{
+		{name: "none configured", cfg: Config{}},
+		{
+			name: "role referenced by addon",
+			cfg: Config{
+				ServiceAccountRoles: map[string]ServiceAccountRole{"ebs-csi": ebsRole},
+				Addons:              map[string]Addon{"aws-ebs-csi-driver": {ServiceAccountRole: "ebs-csi"}},
+			},
+		},
+		{
+			name:      "irsa disabled",
+			cfg:       Config{EnableIRSA: boolPtr(false), ServiceAccountRoles: map[string]ServiceAccountRole{"ebs-csi": ebsRole}},
+			errSubstr: "requires enable_irsa",
+		},
+		{
+			name:      "invalid key",
+			cfg:       Config{ServiceAccountRoles: map[string]ServiceAccountRole{"EBS_CSI": ebsRole}},
+			errSubstr: "invalid key",
+		},
+		{
+			name:      "missing service account",
+			cfg:       Config{ServiceAccountRoles: map[string]ServiceAccountRole{"ebs-csi": {Namespace: "kube-system", PolicyARNs: []string{ebsPolicy}}}},
+			errSubstr: "namespace and service_account are required",
+		},
+		{
+			name:      "no policies",
+			cfg:       Config{ServiceAccountRoles: map[string]ServiceAccountRole{"ebs-csi": {Namespace: "kube-system", ServiceAccount: "sa"}}},
+			errSubstr: "at least one policy_arns",
+		},
+		{
+			name:      "role arn as policy",
+			cfg:       Config{ServiceAccountRoles: map[string]ServiceAccountRole{"ebs-csi": {Namespace: "kube-system", ServiceAccount: "sa", PolicyARNs: []string{"arn:aws:iam::123456789012:role/foo"}}}},
+			errSubstr: "policy_arns[0]",
+		},
+		{
+			name:      "undefined role",
+			cfg:       Config{Addons: map[string]Addon{"aws-ebs-csi-driver": {ServiceAccountRole: "ebs-csi"}}},
+			errSubstr: "not defined in service_account_roles",
+		},
+		{
+			name: "role and arn",
+			cfg: Config{
+				ServiceAccountRoles: map[string]ServiceAccountRole{"ebs-csi": ebsRole},
+				Addons:              map[string]Addon{"aws-ebs-csi-driver": {ServiceAccountRole: "ebs-csi", ServiceAccountRoleARN: "arn:aws:iam::123456789012:role/ebs"}},
+			},
+			errSubstr: "not both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateServiceAccountRoles(&tt.cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("expected error containing %q, got %q", tt.errSubstr, err.Error())
+			}
+		})
+	}
+}
+
+func TestToTFVarsServiceAccountRoles(t *testing.T) {
+	cfg := &Config{
+		Region: "us-west-2",
+		ServiceAccountRoles: map[string]ServiceAccountRole{
+			"external-dns": {Namespace: "external-dns", ServiceAccount: "external-dns", PolicyARNs: []string{"arn:aws:iam::123456789012:policy/route53"}},
+		},
+	}
+	data, err := json.Marshal(cfg.toTFVars("proj", "", nil))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `"service_account_roles":{"external-dns":{"namespace":"external-dns","service_account":"external-dns","policy_arns":["arn:aws:iam::123456789012:policy/route53"]}}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("tfvars missing %s:\n%s", want, data)
+	}
+
+	data, err = json.Marshal((&Config{Region: "us-west-2"}).toTFVars("proj", "", nil))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(data), "service_account_roles") {
+		t.Errorf("expected service_account_roles to be omitted when unset:\n%s", data)
+	}
+}
+
+func TestResolveAddonRoles(t *testing.T) {
+	const arn = "arn:aws:iam::123456789012:role/proj-irsa-ebs-csi"
+	outputs := map[string]tfexec.OutputMeta{
+		serviceAccountRoleARNsOutput: {Value: json.RawMessage(`{"ebs-csi":"` + arn + `"}`)},
+	}
+	arns, err := parseServiceAccountRoleARNs(outputs)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	addons := map[string]Addon{
+		"aws-ebs-csi-driver": {ServiceAccountRole: "ebs-csi"},
+		"vpc-cni":            {ServiceAccountRoleARN: "arn:aws:iam::123456789012:role/cni"},
+		"coredns":            {},
+	}
+	resolved := resolveAddonRoles(addons, arns)
+	if got := resolved["aws-ebs-csi-driver"].ServiceAccountRoleARN; got != arn {
+		t.Errorf("aws-ebs-csi-driver role = %q, want %q", got, arn)
+	}
+	if got := resolved["vpc-cni"].ServiceAccountRoleARN; got != "arn:aws:iam::123456789012:role/cni" {
+		t.Errorf("vpc-cni role = %q, want explicit ARN kept", got)
+	}
+	if addons["aws-ebs-csi-driver"].ServiceAccountRoleARN != "" {
+		t.Error("resolveAddonRoles mutated its input")
+	}
+
+	arns, err = parseServiceAccountRoleARNs(map[string]tfexec.OutputMeta{})
+	if err != nil || len(arns) != 0 {
+		t.Errorf("missing output: got %v, %v; want empty map", arns, err)
+	}
+}
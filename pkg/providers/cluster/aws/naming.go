@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// See https://docs.aws.amazon.com/eks/latest/APIReference/API_CreateCluster.html
+const maxClusterNameLength = 100
+
+// nameHashLength is the number of hex characters of the full name's hash kept
+// when shortenName truncates a name.
+const nameHashLength = 8
+
+// clusterNamePattern is the EKS cluster name character rule. The cluster is
+// named after project_name, so this is checked before any resource is created.
+var clusterNamePattern = regexp.MustCompile(`^[0-9A-Za-z][A-Za-z0-9_-]*$`)
+
+// validateClusterName checks projectName against the EKS cluster name rules.
+func validateClusterName(projectName string) error {
+	if len(projectName) > maxClusterNameLength {
+		return fmt.Errorf("project_name %q is %d characters; EKS cluster names are limited to %d", projectName, len(projectName), maxClusterNameLength)
+	}
+	if !clusterNamePattern.MatchString(projectName) {
+		return fmt.Errorf("project_name %q is not a valid EKS cluster name (must start with alphanumeric and contain only alphanumeric, hyphens, or underscores)", projectName)
+	}
+	return nil
+}
+
+// shortenName returns name unchanged if it fits in maxLen, and otherwise
+// truncates it and appends a hash of the full name. The result is
+// deterministic, and two long names sharing a prefix still get distinct
+// results.
+func shortenName(name string, maxLen int) string {
+	if len(name) <= maxLen {
+		return name
+	}
+	sum := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:nameHashLength]
+	keep := maxLen - nameHashLength - 1
+	if keep <= 0 {
+		return sum[:min(maxLen, nameHashLength)]
+	}
+	return strings.TrimRight(name[:keep], "-_") + "-" + sum
+}
+
+// bucketNameComponent normalizes s for use inside an S3 bucket name, which
+// allows only lowercase letters, digits, and hyphens.
+func bucketNameComponent(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "_", "-")
+}
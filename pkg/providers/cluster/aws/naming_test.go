@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateClusterName(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectName string
+		errSubstr   string // "" means no error expected
+	}{
+		{name: "simple", projectName: "nebari"},
+		{name: "hyphens and underscores", projectName: "my_nebari-prod"},
+		{name: "at limit", projectName: strings.Repeat("a", maxClusterNameLength)},
+		{name: "over limit", projectName: strings.Repeat("a", maxClusterNameLength+1), errSubstr: "limited to 100"},
+		{name: "leading hyphen", projectName: "-nebari", errSubstr: "not a valid EKS cluster name"},
+		{name: "dot", projectName: "nebari.prod", errSubstr: "not a valid EKS cluster name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateClusterName(tt.projectName)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestShortenName(t *testing.T) {
+	long := strings.Repeat("project-", 20)
+
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		want   string // "" means only length and determinism are checked
+	}{
+		{name: "fits unchanged", input: "nebari", maxLen: 63, want: "nebari"},
+		{name: "exactly at limit", input: "abcdef", maxLen: 6, want: "abcdef"},
+		{name: "long name truncated", input: long, maxLen: 30},
+		{name: "limit smaller than hash", input: long, maxLen: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shortenName(tt.input, tt.maxLen)
+			if len(got) > tt.maxLen {
+				t.Errorf("shortenName() = %q (%d chars), want <= %d", got, len(got), tt.maxLen)
+			}
+			if tt.want != "" && got != tt.want {
+				t.Errorf("shortenName() = %q, want %q", got, tt.want)
+			}
+			if again := shortenName(tt.input, tt.maxLen); again != got {
+				t.Errorf("shortenName() not deterministic: %q != %q", got, again)
+			}
+		})
+	}
+
+	t.Run("shared prefixes do not collide", func(t *testing.T) {
+		a := shortenName(long+"a", 30)
+		b := shortenName(long+"b", 30)
+		if a == b {
+			t.Errorf("shortenName() collided for distinct inputs: %q", a)
+		}
+	})
+
+	t.Run("no trailing separator before hash", func(t *testing.T) {
+		got := shortenName(long, 30)
+		if strings.Contains(got, "--") {
+			t.Errorf("shortenName() = %q, should not contain a doubled separator", got)
+		}
+	})
+}
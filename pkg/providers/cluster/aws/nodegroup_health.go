@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// nodegroupDeleteTimeout bounds how long deploy waits for a failed node group
+// to be deleted before OpenTofu recreates it.
+const nodegroupDeleteTimeout = 20 * time.Minute
+
+// NodegroupHealthClient defines the EKS operations needed to find node groups
+// in a failed state and delete them so the next apply recreates them.
+type NodegroupHealthClient interface {
+	ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error)
+	DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error)
+	DeleteNodegroup(ctx context.Context, params *eks.DeleteNodegroupInput, optFns ...func(*eks.Options)) (*eks.DeleteNodegroupOutput, error)
+}
+
+func newNodegroupHealthClient(ctx context.Context, region string) (NodegroupHealthClient, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return eks.NewFromConfig(cfg), nil
+}
+
+// failedNodegroup is a node group EKS reports as CREATE_FAILED or DEGRADED.
+type failedNodegroup struct {
+	Name   string
+	Status ekstypes.NodegroupStatus
+	Issues []string
+}
+
+// isFailedNodegroupStatus reports whether s is a state OpenTofu cannot
+// recover from on its own: the group exists in state, so apply leaves it be.
+func isFailedNodegroupStatus(s ekstypes.NodegroupStatus) bool {
+	return s == ekstypes.NodegroupStatusCreateFailed || s == ekstypes.NodegroupStatusDegraded
+}
+
+// findFailedNodegroups lists the cluster's node groups and returns those in a
+// failed state together with their health issues. A cluster that does not
+// exist yet has no node groups.
+func findFailedNodegroups(ctx context.Context, client NodegroupHealthClient, clusterName string) ([]failedNodegroup, error) {
+	var failed []failedNodegroup
+	var nextToken *string
+	for {
+		out, err := client.ListNodegroups(ctx, &eks.ListNodegroupsInput{ClusterName: &clusterName, NextToken: nextToken})
+		if err != nil {
+			var notFound *ekstypes.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("list node groups for cluster %s: %w", clusterName, err)
+		}
+		for _, name := range out.Nodegroups {
+			desc, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{ClusterName: &clusterName, NodegroupName: &name})
+			if err != nil {
+				return nil, fmt.Errorf("describe node group %s: %w", name, err)
+			}
+			ng := desc.Nodegroup
+			if ng == nil || !isFailedNodegroupStatus(ng.Status) {
+				continue
+			}
+			failed = append(failed, failedNodegroup{Name: name, Status: ng.Status, Issues: healthIssues(ng.Health)})
+		}
+		if out.NextToken == nil {
+			break
+		}
+		nextToken = out.NextToken
+	}
+	slices.SortFunc(failed, func(a, b failedNodegroup) int { return strings.Compare(a.Name, b.Name) })
+	return failed, nil
+}
+
+// healthIssues renders a node group's health issues as "CODE: message".
+func healthIssues(health *ekstypes.NodegroupHealth) []string {
+	if health == nil {
+		return nil
+	}
+	issues := make([]string, 0, len(health.Issues))
+	for _, issue := range health.Issues {
+		issues = append(issues, fmt.Sprintf("%s: %s", issue.Code, aws.ToString(issue.Message)))
+	}
+	return issues
+}
+
+// checkNodegroupHealth reports node groups stuck in CREATE_FAILED or
+// DEGRADED. With recreate (and not dryRun) it deletes them and waits for the
+// deletion, so the following apply creates them again from config. It
+// returns the names of the failed node groups.
+func checkNodegroupHealth(ctx context.Context, client NodegroupHealthClient, clusterName string, recreate, dryRun bool) ([]string, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.checkNodegroupHealth")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cluster_name", clusterName),
+		attribute.Bool("recreate", recreate),
+		attribute.Bool("dry_run", dryRun),
+	)
+
+	failed, err := findFailedNodegroups(ctx, client, clusterName)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("failed_count", len(failed)))
+
+	names := make([]string, 0, len(failed))
+	for _, ng := range failed {
+		names = append(names, ng.Name)
+		msg := fmt.Sprintf("Node group %s is %s", ng.Name, ng.Status)
+		if !recreate {
+			msg += "; rerun deploy with --recreate-failed-nodegroups to replace it"
+		}
+		status.Send(ctx, status.NewUpdate(status.LevelWarning, msg).
+			WithResource("node-group").
+			WithAction("health-check").
+			WithMetadata("node_group", ng.Name).
+			WithMetadata("status", string(ng.Status)).
+			WithMetadata("issues", ng.Issues))
+	}
+
+	if !recreate || dryRun {
+		return names, nil
+	}
+
+	for _, ng := range failed {
+		if err := deleteFailedNodegroup(ctx, client, clusterName, ng.Name); err != nil {
+			span.RecordError(err)
+			return names, err
+		}
+	}
+	return names, nil
+}
+
+// deleteFailedNodegroup deletes the node group name and waits until EKS has
+// removed it.
+func deleteFailedNodegroup(ctx context.Context, client NodegroupHealthClient, clusterName, name string) error {
+	status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Deleting failed node group %s so it can be recreated", name)).
+		WithResource("node-group").
+		WithAction("deleting").
+		WithMetadata("node_group", name))
+
+	if _, err := client.DeleteNodegroup(ctx, &eks.DeleteNodegroupInput{ClusterName: &clusterName, NodegroupName: &name}); err != nil {
+		return fmt.Errorf("delete failed node group %s: %w", name, err)
+	}
+
+	waiter := eks.NewNodegroupDeletedWaiter(client)
+	if err := waiter.Wait(ctx, &eks.DescribeNodegroupInput{ClusterName: &clusterName, NodegroupName: &name}, nodegroupDeleteTimeout); err != nil {
+		return fmt.Errorf("wait for node group %s deletion: %w", name, err)
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Deleted failed node group %s", name)).
+		WithResource("node-group").
+		WithAction("deleted").
+		WithMetadata("node_group", name))
+	return nil
+}
@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// mockNodegroupHealthClient serves a fixed set of node groups; deleted groups
+// disappear from DescribeNodegroup.
+type mockNodegroupHealthClient struct {
+	nodegroups map[string]*ekstypes.Nodegroup
+	noCluster  bool
+	deleted    []string
+}
+
+func (m *mockNodegroupHealthClient) ListNodegroups(_ context.Context, _ *eks.ListNodegroupsInput, _ ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error) {
+	if m.noCluster {
+		return nil, &ekstypes.ResourceNotFoundException{Message: aws.String("cluster not found")}
+	}
+	out := &eks.ListNodegroupsOutput{}
+	for name := range m.nodegroups {
+		out.Nodegroups = append(out.Nodegroups, name)
+	}
+	return out, nil
+}
+
+func (m *mockNodegroupHealthClient) DescribeNodegroup(_ context.Context, params *eks.DescribeNodegroupInput, _ ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error) {
+	ng, ok := m.nodegroups[*params.NodegroupName]
+	if !ok {
+		return nil, &ekstypes.ResourceNotFoundException{Message: aws.String("node group not found")}
+	}
+	return &eks.DescribeNodegroupOutput{Nodegroup: ng}, nil
+}
+
+func (m *mockNodegroupHealthClient) DeleteNodegroup(_ context.Context, params *eks.DeleteNodegroupInput, _ ...func(*eks.Options)) (*eks.DeleteNodegroupOutput, error) {
+	m.deleted = append(m.deleted, *params.NodegroupName)
+	delete(m.nodegroups, *params.NodegroupName)
+	return &eks.DeleteNodegroupOutput{}, nil
+}
+
+func newMockNodegroupHealth() *mockNodegroupHealthClient {
+	return &mockNodegroupHealthClient{nodegroups: map[string]*ekstypes.Nodegroup{
+		"general": {NodegroupName: aws.String("general"), Status: ekstypes.NodegroupStatusActive},
+		"gpu": {
+			NodegroupName: aws.String("gpu"),
+			Status:        ekstypes.NodegroupStatusCreateFailed,
+			Health: &ekstypes.NodegroupHealth{Issues: []ekstypes.Issue{{
+				Code:    ekstypes.NodegroupIssueCodeAsgInstanceLaunchFailures,
+				Message: aws.String("We currently do not have sufficient g5.12xlarge capacity"),
+			}}},
+		},
+	}}
+}
+
+func TestCheckNodegroupHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		recreate    bool
+		dryRun      bool
+		noCluster   bool
+		wantFailed  []string
+		wantDeleted []string
+	}{
+		{name: "reports failed group", wantFailed: []string{"gpu"}},
+		{name: "recreate deletes failed group", recreate: true, wantFailed: []string{"gpu"}, wantDeleted: []string{"gpu"}},
+		{name: "dry run never deletes", recreate: true, dryRun: true, wantFailed: []string{"gpu"}},
+		{name: "cluster not created yet", noCluster: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				updates []status.Update
+			)
+			ctx, cleanup := status.StartHandler(context.Background(), func(u status.Update) {
+				mu.Lock()
+				updates = append(updates, u)
+				mu.Unlock()
+			})
+
+			client := newMockNodegroupHealth()
+			client.noCluster = tt.noCluster
+			failed, err := checkNodegroupHealth(ctx, client, "nebari", tt.recreate, tt.dryRun)
+			cleanup()
+			if err != nil {
+				t.Fatalf("checkNodegroupHealth() error = %v", err)
+			}
+
+			if !slices.Equal(failed, tt.wantFailed) {
+				t.Errorf("failed = %v, want %v", failed, tt.wantFailed)
+			}
+			if !slices.Equal(client.deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", client.deleted, tt.wantDeleted)
+			}
+
+			if len(tt.wantFailed) == 0 {
+				return
+			}
+			var warning *status.Update
+			for i := range updates {
+				if updates[i].Action == "health-check" {
+					warning = &updates[i]
+				}
+			}
+			if warning == nil || warning.Level != status.LevelWarning {
+				t.Fatalf("no health-check warning among updates: %+v", updates)
+			}
+			issues, _ := warning.Metadata["issues"].([]string)
+			want := "AsgInstanceLaunchFailures: We currently do not have sufficient g5.12xlarge capacity"
+			if !slices.Equal(issues, []string{want}) {
+				t.Errorf("issues = %v, want [%s]", warning.Metadata["issues"], want)
+			}
+			if warning.Metadata["status"] != string(ekstypes.NodegroupStatusCreateFailed) {
+				t.Errorf("status = %v, want CREATE_FAILED", warning.Metadata["status"])
+			}
+		})
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
 	"sync"
 	"time"
 
-	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/hashicorp/terraform-exec/tfexec"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/netutil"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/storage/longhorn"
@@ -90,6 +94,58 @@ func validateTaints(nodeGroupName string, taints []Taint) error {
 	return nil
 }
 
+// validateLaunchProfiles checks that every node group's LaunchProfile
+// reference names a defined profile.
+func validateLaunchProfiles(c *Config) error {
+	for name, group := range c.NodeGroups {
+		if group.LaunchProfile == "" {
+			continue
+		}
+		if _, ok := c.LaunchProfiles[group.LaunchProfile]; !ok {
+			return fmt.Errorf("node group %s: launch_profile %q is not defined in launch_profiles (have: %v)",
+				name, group.LaunchProfile, slices.Sorted(maps.Keys(c.LaunchProfiles)))
+		}
+	}
+	return nil
+}
+
+const (
+	// minVPCPrefixBits and maxVPCPrefixBits bound the VPC size NIC accepts:
+	// AWS allows /16 to /28, but anything smaller than /24 cannot hold a
+	// usable EKS cluster.
+	minVPCPrefixBits = 16
+	maxVPCPrefixBits = 24
+)
+
+// privateIPv4Ranges are the RFC 1918 private address blocks.
+var privateIPv4Ranges = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+}
+
+// validateVPCCIDR checks that the VPC CIDR block is a well-formed IPv4
+// network address in an RFC 1918 range (unless allowPublic is set) and
+// between /16 and /24. How the block is divided into subnets is up to the EKS
+// module, so it is not checked here; a block too small for the configured
+// availability zones fails at plan time.
+func validateVPCCIDR(cidr string, allowPublic bool) error {
+	prefix, err := netutil.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid vpc_cidr_block: %w", err)
+	}
+	if !prefix.Addr().Is4() {
+		return fmt.Errorf("invalid vpc_cidr_block %q: the primary VPC CIDR must be IPv4", cidr)
+	}
+	if !allowPublic && !slices.ContainsFunc(privateIPv4Ranges, func(r netip.Prefix) bool { return netutil.Contains(r, prefix) }) {
+		return fmt.Errorf("invalid vpc_cidr_block %q: must be within a private range (10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16); set allow_public_vpc_cidr to use a public range", cidr)
+	}
+	if prefix.Bits() < minVPCPrefixBits || prefix.Bits() > maxVPCPrefixBits {
+		return fmt.Errorf("invalid vpc_cidr_block %q: prefix length must be between /%d and /%d", cidr, minVPCPrefixBits, maxVPCPrefixBits)
+	}
+	return nil
+}
+
 // containsSubstring checks if any string in the slice contains the substring
 func containsSubstring(slice []string, substr string) bool {
 	for _, s := range slice {
@@ -153,6 +209,11 @@ func (p *Provider) Validate(ctx context.Context, projectName string, clusterConf
 		return err
 	}
 
+	if err := validateClusterName(projectName); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	// Validate required fields
 	if awsCfg.Region == "" {
 		err := fmt.Errorf("AWS region is required")
@@ -160,6 +221,16 @@ func (p *Provider) Validate(ctx context.Context, projectName string, clusterConf
 		return err
 	}
 
+	if err := validateAvailabilityZones(awsCfg.Region, awsCfg.AvailabilityZones); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := validateTags(awsCfg.Tags); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	// Validate Kubernetes version format
 	if awsCfg.KubernetesVersion != "" {
 		// Basic validation - should be like "1.34", "1.29", etc.
@@ -172,12 +243,55 @@ func (p *Provider) Validate(ctx context.Context, projectName string, clusterConf
 
 	// Validate VPC CIDR block if specified
 	if awsCfg.VPCCIDRBlock != "" {
-		// Basic CIDR validation
-		if !containsSubstring([]string{awsCfg.VPCCIDRBlock}, "/") {
-			err := fmt.Errorf("invalid VPC CIDR block format: %s (must include /prefix)", awsCfg.VPCCIDRBlock)
+		if err := validateVPCCIDR(awsCfg.VPCCIDRBlock, awsCfg.AllowPublicVPCCIDR); err != nil {
 			span.RecordError(err)
 			return err
 		}
+		if err := validatePeeredCIDRs(awsCfg.VPCCIDRBlock, awsCfg.PeeredCIDRs); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	} else if len(awsCfg.PeeredCIDRs) > 0 {
+		err := fmt.Errorf("peered_cidrs requires vpc_cidr_block to be set so overlaps can be checked")
+		span.RecordError(err)
+		return err
+	}
+
+	if err := validateExistingNetwork(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := validateSubnetRoles(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := validateEndpointPublicAccessCIDRs(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := validateAddons(awsCfg.Addons); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := validateServiceAccountRoles(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := validateAccessEntries(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := validatePolicyARNs(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := validateInlinePolicies(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
 	}
 
 	// Validate load_balancer_scheme if specified
@@ -195,7 +309,14 @@ func (p *Provider) Validate(ctx context.Context, projectName string, clusterConf
 		return err
 	}
 
-	for nodeGroupName, nodeGroup := range awsCfg.NodeGroups {
+	if err := validateLaunchProfiles(awsCfg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	// Validate node groups as deployed, with their launch profiles applied,
+	// so shared taints are checked too.
+	for nodeGroupName, nodeGroup := range applyLaunchProfiles(awsCfg.NodeGroups, awsCfg.LaunchProfiles) {
 		// Validate instance type is specified
 		if nodeGroup.Instance == "" {
 			err := fmt.Errorf("node group %s: instance type is required", nodeGroupName)
@@ -230,14 +351,33 @@ func (p *Provider) Validate(ctx context.Context, projectName string, clusterConf
 	}
 
 	// Validate AWS credentials
-	sdkCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(awsCfg.Region))
+	sdkCfg, err := awssdk.LoadConfig(ctx, awsCfg.Region)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to load AWS config: %w", err)
 	}
 	if _, err := sdkCfg.Credentials.Retrieve(ctx); err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+		return noCredentialsError(err)
+	}
+
+	if !awsCfg.createsVPC() {
+		client, err := newExistingNetworkClient(ctx, awsCfg.Region)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		if err := checkExistingNetwork(ctx, client, awsCfg.ExistingVPCID, awsCfg.ExistingPrivateSubnetIDs, awsCfg.SubnetRoles); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	if awsCfg.CheckVPCOverlap && awsCfg.createsVPC() && awsCfg.VPCCIDRBlock != "" {
+		if err := p.preflightVPCOverlap(ctx, projectName, awsCfg); err != nil {
+			span.RecordError(err)
+			return err
+		}
 	}
 
 	span.SetAttributes(
@@ -306,6 +446,7 @@ func (p *Provider) Deploy(ctx context.Context, projectName string, clusterConfig
 	}
 
 	tfVars := awsCfg.toTFVars(projectName, opts.TrustBundle, opts.BackupBucket)
+	tfVars.Tags = withClusterIDTag(withEnvironmentTag(tfVars.Tags, opts.Environment), opts.ClusterID)
 	tf, err := tofu.Setup(ctx, tofuTemplates, tfVars)
 	if err != nil {
 		span.RecordError(err)
@@ -338,12 +479,56 @@ func (p *Provider) Deploy(ctx context.Context, projectName string, clusterConfig
 		return err
 	}
 
+	clusterCfgClient, err := newClusterConfigClient(ctx, region)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	desiredSettings := desiredClusterSettings(awsCfg, opts.Environment, opts.ClusterID)
+	addonClient, err := newAddonClient(ctx, region)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	// A node group stuck in CREATE_FAILED or DEGRADED stays in OpenTofu state,
+	// so apply would never replace it; surface it and optionally delete it.
+	ngHealthClient, err := newNodegroupHealthClient(ctx, region)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, err := checkNodegroupHealth(ctx, ngHealthClient, projectName, opts.RecreateFailedNodeGroups, opts.DryRun); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	if opts.DryRun {
-		_, err = tf.Plan(ctx)
+		hasChanges, err := tf.Plan(ctx)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		// Report cluster-level drift the plan may not surface, such as
+		// public access CIDRs, which are reconciled outside OpenTofu.
+		drifted, err := reconcileClusterConfig(ctx, clusterCfgClient, projectName, desiredSettings, true)
 		if err != nil {
 			span.RecordError(err)
 			return err
 		}
+		addons, err := resolveAddons(ctx, tf, awsCfg.Addons)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		addonsChanged, err := reconcileAddons(ctx, addonClient, projectName, addons, true)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		if (hasChanges || drifted || addonsChanged) && opts.FailOnChanges {
+			return cluster.ErrChangesPending
+		}
 		return nil
 	}
 
@@ -353,6 +538,55 @@ func (p *Provider) Deploy(ctx context.Context, projectName string, clusterConfig
 		return err
 	}
 
+	if _, err := reconcileClusterConfig(ctx, clusterCfgClient, projectName, desiredSettings, false); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	// Managed addons come after the node groups so coredns and the EBS CSI
+	// controller have somewhere to run.
+	addons, err := resolveAddons(ctx, tf, awsCfg.Addons)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, err := reconcileAddons(ctx, addonClient, projectName, addons, false); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	// Lock down the default security group of a NIC-created VPC if requested.
+	if awsCfg.RestrictDefaultSecurityGroup && awsCfg.createsVPC() {
+		outputs, err := tf.Output(ctx)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to get terraform outputs for default security group: %w", err)
+		}
+
+		vpcIDOutput, ok := outputs["vpc_id"]
+		if !ok {
+			err := fmt.Errorf("vpc_id not found in terraform outputs")
+			span.RecordError(err)
+			return err
+		}
+
+		var vpcID string
+		if err := json.Unmarshal(vpcIDOutput.Value, &vpcID); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to unmarshal vpc_id: %w", err)
+		}
+
+		sgClient, err := newDefaultSecurityGroupClient(ctx, region)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		if err := restrictDefaultSecurityGroup(ctx, sgClient, projectName, opts.Environment, opts.ClusterID, vpcID, awsCfg.Tags); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
 	// Install Longhorn storage if enabled
 	if awsCfg.LonghornEnabled() {
 		kubeconfigBytes, err := p.GetKubeconfig(ctx, projectName, clusterConfig)
@@ -542,6 +776,7 @@ func (p *Provider) Destroy(ctx context.Context, projectName string, clusterConfi
 	}
 
 	tfVars := awsCfg.toTFVars(projectName, opts.TrustBundle, nil)
+	tfVars.Tags = withClusterIDTag(withEnvironmentTag(tfVars.Tags, opts.Environment), opts.ClusterID)
 	tf, err := tofu.Setup(ctx, tofuTemplates, tfVars)
 	if err != nil {
 		span.RecordError(err)
@@ -801,7 +1036,8 @@ func (p *Provider) Summary(clusterConfig *config.ClusterConfig) map[string]strin
 // Controller (type=external) and request an IP-targeted NLB. The
 // aws-load-balancer-scheme annotation defaults to "internet-facing" so the NLB
 // is reachable from outside the VPC; operators with private-only VPCs can
-// override this to "internal" via cluster.aws.load_balancer_scheme.
+// override this to "internal" via cluster.aws.load_balancer_scheme, or use
+// gateway.internal, which applies InternalLoadBalancerAnnotations.
 func (p *Provider) InfraSettings(clusterConfig *config.ClusterConfig) cluster.InfraSettings {
 	sc := longhorn.StorageClassName
 	longhornEnabled := true // AWS default — see Config.LonghornEnabled
@@ -833,5 +1069,8 @@ func (p *Provider) InfraSettings(clusterConfig *config.ClusterConfig) cluster.In
 			"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "ip",
 			"service.beta.kubernetes.io/aws-load-balancer-scheme":          lbScheme,
 		},
+		InternalLoadBalancerAnnotations: map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-scheme": loadBalancerSchemeInternal,
+		},
 	}
 }
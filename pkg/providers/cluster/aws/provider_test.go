@@ -166,6 +166,84 @@ func TestValidateTaints(t *testing.T) {
 	}
 }
 
+func TestValidateLaunchProfiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		errSubstr string // "" means no error expected
+	}{
+		{name: "no references", cfg: Config{NodeGroups: map[string]NodeGroup{"general": {Instance: "m5.large"}}}},
+		{
+			name: "defined profile",
+			cfg: Config{
+				LaunchProfiles: map[string]LaunchProfile{"standard": {}},
+				NodeGroups:     map[string]NodeGroup{"general": {Instance: "m5.large", LaunchProfile: "standard"}},
+			},
+		},
+		{
+			name:      "undefined profile",
+			cfg:       Config{NodeGroups: map[string]NodeGroup{"general": {Instance: "m5.large", LaunchProfile: "missing"}}},
+			errSubstr: `node group general: launch_profile "missing" is not defined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLaunchProfiles(&tt.cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestValidateVPCCIDR(t *testing.T) {
+	tests := []struct {
+		name        string
+		cidr        string
+		allowPublic bool
+		errSubstr   string // "" means no error expected
+	}{
+		{name: "valid /16", cidr: "10.0.0.0/16"},
+		{name: "missing prefix", cidr: "10.0.0.0", errSubstr: "invalid vpc_cidr_block"},
+		{name: "slash but not a CIDR", cidr: "foo/bar", errSubstr: "invalid vpc_cidr_block"},
+		{name: "host bits set", cidr: "10.0.0.1/16", errSubstr: "host bits"},
+		{name: "ipv6 rejected", cidr: "2001:db8::/56", errSubstr: "must be IPv4"},
+		{name: "172.16/12 private range", cidr: "172.20.0.0/16"},
+		{name: "192.168/16 private range", cidr: "192.168.0.0/20"},
+		{name: "public range rejected", cidr: "54.10.0.0/16", errSubstr: "must be within a private range"},
+		{name: "straddles private range", cidr: "172.0.0.0/8", errSubstr: "must be within a private range"},
+		{name: "public range allowed", cidr: "54.10.0.0/16", allowPublic: true},
+		{name: "larger than /16", cidr: "10.0.0.0/12", errSubstr: "between /16 and /24"},
+		{name: "smaller than /24", cidr: "10.0.0.0/25", errSubstr: "between /16 and /24"},
+		{name: "valid /24", cidr: "10.0.0.0/24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVPCCIDR(tt.cidr, tt.allowPublic)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
 func TestInfraSettings_LoadBalancerScheme(t *testing.T) {
 	const schemeKey = "service.beta.kubernetes.io/aws-load-balancer-scheme"
 
@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+// regionBoundKeys are cluster.aws settings that reference resources living in
+// a single region. They cannot be carried over to a rebuild elsewhere, so
+// RelocateRegion drops them and lets NIC create fresh ones (or fail
+// validation where a replacement must be supplied).
+var regionBoundKeys = []string{
+	"availability_zones",
+	"existing_vpc_id",
+	"existing_private_subnet_ids",
+	"existing_security_group_id",
+	"subnet_roles",
+	"eks_kms_arn",
+	"state_bucket",
+}
+
+// RelocateRegion returns a copy of clusterConfig rewritten for a rebuild in
+// region. Region-scoped references (availability zones, existing network
+// IDs, KMS keys, an explicit state bucket) are removed; everything else,
+// including IAM role ARNs, carries over unchanged. The input is not modified.
+func (p *Provider) RelocateRegion(clusterConfig *config.ClusterConfig, region string) (*config.ClusterConfig, error) {
+	if region == "" {
+		return nil, fmt.Errorf("target region is required")
+	}
+	raw := clusterConfig.ProviderConfig()
+	if raw == nil {
+		return nil, fmt.Errorf("AWS configuration is required")
+	}
+
+	out := maps.Clone(raw)
+	out["region"] = region
+	for _, key := range regionBoundKeys {
+		delete(out, key)
+	}
+	if efs, ok := out["efs"].(map[string]any); ok {
+		efs = maps.Clone(efs)
+		delete(efs, "kms_key_arn")
+		out["efs"] = efs
+	}
+
+	return &config.ClusterConfig{Providers: map[string]any{ProviderName: out}}, nil
+}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+func TestRelocateRegion(t *testing.T) {
+	src := &config.ClusterConfig{Providers: map[string]any{
+		"aws": map[string]any{
+			"region":                 "us-west-2",
+			"availability_zones":     []any{"us-west-2a"},
+			"existing_vpc_id":        "vpc-1",
+			"state_bucket":           "my-state",
+			"existing_node_role_arn": "arn:aws:iam::123456789012:role/nodes",
+			"efs":                    map[string]any{"enabled": true, "kms_key_arn": "arn:aws:kms:us-west-2:1:key/x"},
+		},
+	}}
+
+	got, err := NewProvider().RelocateRegion(src, "eu-west-1")
+	if err != nil {
+		t.Fatalf("RelocateRegion() error = %v", err)
+	}
+	out := got.ProviderConfig()
+	if out["region"] != "eu-west-1" {
+		t.Errorf("region = %v, want eu-west-1", out["region"])
+	}
+	for _, key := range []string{"availability_zones", "existing_vpc_id", "state_bucket"} {
+		if _, ok := out[key]; ok {
+			t.Errorf("%s should be dropped", key)
+		}
+	}
+	if out["existing_node_role_arn"] == nil {
+		t.Error("IAM role ARN should carry over")
+	}
+	efs := out["efs"].(map[string]any)
+	if _, ok := efs["kms_key_arn"]; ok {
+		t.Error("efs.kms_key_arn should be dropped")
+	}
+	if efs["enabled"] != true {
+		t.Error("efs.enabled should carry over")
+	}
+
+	srcCfg := src.ProviderConfig()
+	if srcCfg["region"] != "us-west-2" || srcCfg["existing_vpc_id"] == nil {
+		t.Error("RelocateRegion mutated the input config")
+	}
+	if _, ok := srcCfg["efs"].(map[string]any)["kms_key_arn"]; !ok {
+		t.Error("RelocateRegion mutated the input efs config")
+	}
+
+	if _, err := NewProvider().RelocateRegion(src, ""); err == nil {
+		t.Error("RelocateRegion() with empty region should fail")
+	}
+}
@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/validation"
+)
+
+// ValidationRules implements validation.Source.
+func (p *Provider) ValidationRules() []validation.Rule {
+	return []validation.Rule{
+		{Name: "node-group-scaling", Severity: validation.SeverityWarning, Check: checkNodeGroupScaling},
+	}
+}
+
+// checkNodeGroupScaling flags node groups with a min/max range when the
+// cluster autoscaler is disabled: nothing would ever resize them.
+func checkNodeGroupScaling(ctx context.Context, cfg *config.NebariConfig) error {
+	awsCfg, err := extractAWSConfig(ctx, cfg.Cluster)
+	if err != nil {
+		return nil // a malformed aws block is reported by Provider.Validate
+	}
+	if awsCfg.ClusterAutoscalerEnabled() {
+		return nil
+	}
+	var ranged []string
+	for _, name := range slices.Sorted(maps.Keys(awsCfg.NodeGroups)) {
+		if ng := awsCfg.NodeGroups[name]; ng.MaxNodes > ng.MinNodes {
+			ranged = append(ranged, name)
+		}
+	}
+	if len(ranged) > 0 {
+		return fmt.Errorf("node groups %s have max_nodes above min_nodes, but cluster_autoscaler is disabled, so they stay at their initial size",
+			strings.Join(ranged, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+func TestCheckNodeGroupScaling(t *testing.T) {
+	tests := []struct {
+		name      string
+		aws       map[string]any
+		errSubstr string // "" means the rule passes
+	}{
+		{
+			name: "autoscaler enabled by default",
+			aws:  map[string]any{"node_groups": map[string]any{"general": map[string]any{"min_nodes": 1, "max_nodes": 5}}},
+		},
+		{
+			name: "autoscaler disabled, fixed size",
+			aws: map[string]any{
+				"cluster_autoscaler": map[string]any{"enabled": false},
+				"node_groups":        map[string]any{"general": map[string]any{"min_nodes": 2, "max_nodes": 2}},
+			},
+		},
+		{
+			name: "autoscaler disabled, ranged",
+			aws: map[string]any{
+				"cluster_autoscaler": map[string]any{"enabled": false},
+				"node_groups": map[string]any{
+					"user":    map[string]any{"min_nodes": 0, "max_nodes": 3},
+					"general": map[string]any{"min_nodes": 1, "max_nodes": 5},
+				},
+			},
+			errSubstr: "node groups general, user have max_nodes above min_nodes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NebariConfig{Cluster: &config.ClusterConfig{Providers: map[string]any{"aws": tt.aws}}}
+			err := checkNodeGroupScaling(context.Background(), cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("checkNodeGroupScaling() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("checkNodeGroupScaling() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+)
+
+// NodegroupClient defines the EKS operations needed to resize a managed node
+// group outside of a deploy.
+type NodegroupClient interface {
+	ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error)
+	DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error)
+	UpdateNodegroupConfig(ctx context.Context, params *eks.UpdateNodegroupConfigInput, optFns ...func(*eks.Options)) (*eks.UpdateNodegroupConfigOutput, error)
+}
+
+func newNodegroupClient(ctx context.Context, region string) (NodegroupClient, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return eks.NewFromConfig(cfg), nil
+}
+
+// ScaleNodeGroup resizes the node group named nodeGroup (the key under
+// cluster.aws.node_groups) with UpdateNodegroupConfig. The change is not
+// written back to the config, so the next deploy restores the configured
+// min_nodes/max_nodes.
+func (p *Provider) ScaleNodeGroup(ctx context.Context, projectName string, clusterConfig *config.ClusterConfig, nodeGroup string, scaling cluster.NodeGroupScaling) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.ScaleNodeGroup")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("provider", ProviderName),
+		attribute.String("project_name", projectName),
+		attribute.String("node_group", nodeGroup),
+	)
+
+	awsCfg, err := extractAWSConfig(ctx, clusterConfig)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if _, ok := awsCfg.NodeGroups[nodeGroup]; !ok {
+		err := fmt.Errorf("node group %q is not defined in cluster.aws.node_groups (have: %v)", nodeGroup, slices.Sorted(maps.Keys(awsCfg.NodeGroups)))
+		span.RecordError(err)
+		return err
+	}
+
+	client, err := newNodegroupClient(ctx, awsCfg.Region)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := scaleNodeGroup(ctx, client, projectName, nodeGroup, scaling); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// scaleNodeGroup finds the EKS node group for nodeGroup, merges scaling into
+// its current sizes and applies the result.
+func scaleNodeGroup(ctx context.Context, client NodegroupClient, clusterName, nodeGroup string, scaling cluster.NodeGroupScaling) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.scaleNodeGroup")
+	defer span.End()
+
+	if err := scaling.Validate(); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	ng, err := findNodegroup(ctx, client, clusterName, nodeGroup)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	target, err := mergeScaling(ng.ScalingConfig, scaling)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("node group %s: %w", nodeGroup, err)
+	}
+	span.SetAttributes(
+		attribute.String("eks.nodegroup", *ng.NodegroupName),
+		attribute.Int("scaling.min", int(*target.MinSize)),
+		attribute.Int("scaling.max", int(*target.MaxSize)),
+		attribute.Int("scaling.desired", int(*target.DesiredSize)),
+	)
+
+	if scalingEqual(ng.ScalingConfig, target) {
+		span.SetAttributes(attribute.Bool("no_op", true))
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, fmt.Sprintf("Node group %s is already at the requested sizes", nodeGroup)).
+			WithResource("node-group").
+			WithAction("scaling"))
+		return nil
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Scaling node group %s", nodeGroup)).
+		WithResource("node-group").
+		WithAction("scaling").
+		WithMetadata("min", *target.MinSize).
+		WithMetadata("max", *target.MaxSize).
+		WithMetadata("desired", *target.DesiredSize))
+
+	if _, err := client.UpdateNodegroupConfig(ctx, &eks.UpdateNodegroupConfigInput{
+		ClusterName:   &clusterName,
+		NodegroupName: ng.NodegroupName,
+		ScalingConfig: target,
+	}); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("update node group %s scaling: %w", *ng.NodegroupName, err)
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelWarning, fmt.Sprintf("Node group %s scaled outside of config; the next deploy restores its configured sizes", nodeGroup)).
+		WithResource("node-group").
+		WithAction("scaled"))
+	return nil
+}
+
+// findNodegroup returns the EKS node group NIC created for the config key
+// nodeGroup. Groups are matched by the node-pool label NIC applies, falling
+// back to an exact name match for groups created before the label existed.
+func findNodegroup(ctx context.Context, client NodegroupClient, clusterName, nodeGroup string) (*ekstypes.Nodegroup, error) {
+	var fallback *ekstypes.Nodegroup
+	var nextToken *string
+	for {
+		out, err := client.ListNodegroups(ctx, &eks.ListNodegroupsInput{ClusterName: &clusterName, NextToken: nextToken})
+		if err != nil {
+			return nil, fmt.Errorf("list node groups for cluster %s: %w", clusterName, err)
+		}
+		for _, name := range out.Nodegroups {
+			desc, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{ClusterName: &clusterName, NodegroupName: &name})
+			if err != nil {
+				return nil, fmt.Errorf("describe node group %s: %w", name, err)
+			}
+			if desc.Nodegroup.Labels[cluster.NodePoolLabel] == nodeGroup {
+				return desc.Nodegroup, nil
+			}
+			if name == nodeGroup {
+				fallback = desc.Nodegroup
+			}
+		}
+		if out.NextToken == nil {
+			break
+		}
+		nextToken = out.NextToken
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, fmt.Errorf("no EKS node group found for %q in cluster %s: run 'deploy' first", nodeGroup, clusterName)
+}
+
+// scalingEqual reports whether the live scaling config already matches target.
+func scalingEqual(live, target *ekstypes.NodegroupScalingConfig) bool {
+	return live != nil &&
+		aws.ToInt32(live.MinSize) == aws.ToInt32(target.MinSize) &&
+		aws.ToInt32(live.MaxSize) == aws.ToInt32(target.MaxSize) &&
+		aws.ToInt32(live.DesiredSize) == aws.ToInt32(target.DesiredSize)
+}
+
+// mergeScaling applies the requested sizes on top of current. Scaling to
+// zero keeps the current maximum because EKS requires maxSize >= 1.
+func mergeScaling(current *ekstypes.NodegroupScalingConfig, scaling cluster.NodeGroupScaling) (*ekstypes.NodegroupScalingConfig, error) {
+	var minSize, maxSize, desired int32
+	if current != nil {
+		minSize, maxSize, desired = aws.ToInt32(current.MinSize), aws.ToInt32(current.MaxSize), aws.ToInt32(current.DesiredSize)
+	}
+
+	if scaling.ScaleToZero() {
+		minSize, desired = 0, 0
+	} else {
+		if scaling.Min != nil {
+			minSize = int32(*scaling.Min) //nolint:gosec // validated non-negative; node counts are small
+		}
+		if scaling.Max != nil {
+			maxSize = int32(*scaling.Max) //nolint:gosec // validated non-negative; node counts are small
+		}
+		if scaling.Desired != nil {
+			desired = int32(*scaling.Desired) //nolint:gosec // validated non-negative; node counts are small
+		} else {
+			desired = min(max(desired, minSize), maxSize)
+		}
+	}
+
+	if maxSize < 1 {
+		return nil, fmt.Errorf("EKS requires a max of at least 1")
+	}
+	if minSize > maxSize {
+		return nil, fmt.Errorf("min (%d) cannot be greater than max (%d)", minSize, maxSize)
+	}
+	if desired < minSize || desired > maxSize {
+		return nil, fmt.Errorf("desired (%d) must be between min (%d) and max (%d)", desired, minSize, maxSize)
+	}
+
+	return &ekstypes.NodegroupScalingConfig{MinSize: &minSize, MaxSize: &maxSize, DesiredSize: &desired}, nil
+}
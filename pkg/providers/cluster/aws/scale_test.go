@@ -0,0 +1,158 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// mockNodegroupClient serves a fixed set of node groups and records updates.
+type mockNodegroupClient struct {
+	nodegroups map[string]*ekstypes.Nodegroup
+	updates    []*eks.UpdateNodegroupConfigInput
+}
+
+func (m *mockNodegroupClient) ListNodegroups(_ context.Context, _ *eks.ListNodegroupsInput, _ ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error) {
+	out := &eks.ListNodegroupsOutput{}
+	for name := range m.nodegroups {
+		out.Nodegroups = append(out.Nodegroups, name)
+	}
+	return out, nil
+}
+
+func (m *mockNodegroupClient) DescribeNodegroup(_ context.Context, params *eks.DescribeNodegroupInput, _ ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error) {
+	return &eks.DescribeNodegroupOutput{Nodegroup: m.nodegroups[*params.NodegroupName]}, nil
+}
+
+func (m *mockNodegroupClient) UpdateNodegroupConfig(_ context.Context, params *eks.UpdateNodegroupConfigInput, _ ...func(*eks.Options)) (*eks.UpdateNodegroupConfigOutput, error) {
+	m.updates = append(m.updates, params)
+	return &eks.UpdateNodegroupConfigOutput{}, nil
+}
+
+func newMockNodegroups() *mockNodegroupClient {
+	return &mockNodegroupClient{nodegroups: map[string]*ekstypes.Nodegroup{
+		"nebari-user-20240101": {
+			NodegroupName: aws.String("nebari-user-20240101"),
+			Labels:        map[string]string{cluster.NodePoolLabel: "user"},
+			ScalingConfig: &ekstypes.NodegroupScalingConfig{MinSize: aws.Int32(1), MaxSize: aws.Int32(5), DesiredSize: aws.Int32(2)},
+		},
+		"general": {
+			NodegroupName: aws.String("general"),
+			ScalingConfig: &ekstypes.NodegroupScalingConfig{MinSize: aws.Int32(1), MaxSize: aws.Int32(1), DesiredSize: aws.Int32(1)},
+		},
+	}}
+}
+
+func TestScaleNodeGroup(t *testing.T) {
+	n := func(v int) *int { return &v }
+
+	tests := []struct {
+		name        string
+		nodeGroup   string
+		scaling     cluster.NodeGroupScaling
+		wantEKSName string
+		wantMin     int32
+		wantMax     int32
+		wantDesired int32
+		errSubstr   string
+	}{
+		{
+			name:        "scale to zero keeps max for EKS",
+			nodeGroup:   "user",
+			scaling:     cluster.NodeGroupScaling{Min: n(0), Max: n(0)},
+			wantEKSName: "nebari-user-20240101",
+			wantMin:     0, wantMax: 5, wantDesired: 0,
+		},
+		{
+			name:        "explicit range",
+			nodeGroup:   "user",
+			scaling:     cluster.NodeGroupScaling{Min: n(2), Max: n(8), Desired: n(4)},
+			wantEKSName: "nebari-user-20240101",
+			wantMin:     2, wantMax: 8, wantDesired: 4,
+		},
+		{
+			name:        "desired clamped into new range",
+			nodeGroup:   "user",
+			scaling:     cluster.NodeGroupScaling{Min: n(3)},
+			wantEKSName: "nebari-user-20240101",
+			wantMin:     3, wantMax: 5, wantDesired: 3,
+		},
+		{
+			name:        "unlabelled group matched by name",
+			nodeGroup:   "general",
+			scaling:     cluster.NodeGroupScaling{Max: n(3)},
+			wantEKSName: "general",
+			wantMin:     1, wantMax: 3, wantDesired: 1,
+		},
+		{
+			name:      "min greater than max rejected",
+			nodeGroup: "user",
+			scaling:   cluster.NodeGroupScaling{Min: n(4), Max: n(2)},
+			errSubstr: "min (4) cannot be greater than max (2)",
+		},
+		{
+			name:      "min above current max rejected after merge",
+			nodeGroup: "general",
+			scaling:   cluster.NodeGroupScaling{Min: n(3)},
+			errSubstr: "min (3) cannot be greater than max (1)",
+		},
+		{
+			name:      "unknown group",
+			nodeGroup: "gpu",
+			scaling:   cluster.NodeGroupScaling{Max: n(0)},
+			errSubstr: "no EKS node group found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockNodegroups()
+			err := scaleNodeGroup(context.Background(), client, "nebari", tt.nodeGroup, tt.scaling)
+
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.errSubstr)
+				}
+				if len(client.updates) != 0 {
+					t.Errorf("UpdateNodegroupConfig called %d times on error", len(client.updates))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(client.updates) != 1 {
+				t.Fatalf("UpdateNodegroupConfig called %d times, want 1", len(client.updates))
+			}
+			got := client.updates[0]
+			if aws.ToString(got.NodegroupName) != tt.wantEKSName || aws.ToString(got.ClusterName) != "nebari" {
+				t.Errorf("updated %s/%s, want nebari/%s", aws.ToString(got.ClusterName), aws.ToString(got.NodegroupName), tt.wantEKSName)
+			}
+			sc := got.ScalingConfig
+			if aws.ToInt32(sc.MinSize) != tt.wantMin || aws.ToInt32(sc.MaxSize) != tt.wantMax || aws.ToInt32(sc.DesiredSize) != tt.wantDesired {
+				t.Errorf("scaling = min %d max %d desired %d, want min %d max %d desired %d",
+					aws.ToInt32(sc.MinSize), aws.ToInt32(sc.MaxSize), aws.ToInt32(sc.DesiredSize), tt.wantMin, tt.wantMax, tt.wantDesired)
+			}
+		})
+	}
+}
+
+func TestScaleNodeGroup_NoChange(t *testing.T) {
+	n := func(v int) *int { return &v }
+	client := newMockNodegroups()
+
+	// nebari-user-20240101 is already at min 1, max 5, desired 2.
+	err := scaleNodeGroup(context.Background(), client, "nebari", "user", cluster.NodeGroupScaling{Min: n(1), Max: n(5), Desired: n(2)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.updates) != 0 {
+		t.Errorf("UpdateNodegroupConfig called %d times for an unchanged node group, want 0", len(client.updates))
+	}
+}
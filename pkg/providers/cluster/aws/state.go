@@ -2,17 +2,19 @@ package aws
 
 import (
 	"context"
-	"crypto/sha256"
 	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 )
 
 // See https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html
@@ -24,6 +26,7 @@ type S3Client interface {
 	CreateBucket(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error)
 	PutBucketVersioning(ctx context.Context, params *s3.PutBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error)
 	PutPublicAccessBlock(ctx context.Context, params *s3.PutPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.PutPublicAccessBlockOutput, error)
+	PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error)
 	ListObjectVersions(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
 	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
 	DeleteBucket(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
@@ -35,7 +38,7 @@ type STSClient interface {
 }
 
 func newS3Client(ctx context.Context, region string) (S3Client, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	cfg, err := awssdk.LoadConfig(ctx, region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
@@ -43,7 +46,7 @@ func newS3Client(ctx context.Context, region string) (S3Client, error) {
 }
 
 func newSTSClient(ctx context.Context, region string) (STSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	cfg, err := awssdk.LoadConfig(ctx, region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
@@ -51,15 +54,21 @@ func newSTSClient(ctx context.Context, region string) (STSClient, error) {
 }
 
 // generateBucketName creates a deterministic bucket name from account ID, region, and project name.
-// The account ID is hashed to avoid exposing it directly in the bucket name.
+// The account ID is hashed to avoid exposing it directly in the bucket name. The project
+// name is lowercased and, if the result would exceed the S3 limit, shortened with a
+// stable hash suffix.
 func generateBucketName(accountID, region, projectName string) (string, error) {
-	hash := sha256.Sum256([]byte(accountID))
-	suffix := fmt.Sprintf("%x", hash[:4]) // 8 hex chars
-	name := fmt.Sprintf("nic-tfstate-%s-%s-%s", projectName, region, suffix)
-	if len(name) > maxBucketNameLength {
-		return "", fmt.Errorf("bucket name %q exceeds %d chars: consider a shorter project name", name, maxBucketNameLength)
+	suffix := objectstore.Suffix(accountID, 8)
+	prefix := "nic-tfstate-"
+	tail := fmt.Sprintf("-%s-%s", region, suffix)
+
+	budget := maxBucketNameLength - len(prefix) - len(tail)
+	if budget <= nameHashLength {
+		return "", fmt.Errorf("region %q leaves no room for the project name in the state bucket name", region)
 	}
-	return name, nil
+	project := shortenName(bucketNameComponent(projectName), budget)
+
+	return prefix + project + tail, nil
 }
 
 func stateKey(projectName string) string {
@@ -70,7 +79,7 @@ func stateKey(projectName string) string {
 func getStateBucketName(ctx context.Context, client STSClient, region, projectName string) (string, error) {
 	output, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
-		return "", fmt.Errorf("failed to get AWS account ID: %w", err)
+		return "", fmt.Errorf("%w: failed to get AWS account ID: %w", cluster.ErrCredentials, err)
 	}
 	accountID := aws.ToString(output.Account)
 
@@ -98,7 +107,7 @@ func stateBucketExists(ctx context.Context, client S3Client, bucketName string)
 // The caller is responsible for providing the bucket name (via getStateBucketName or config override).
 func ensureStateBucket(ctx context.Context, client S3Client, region, bucketName string) error {
 	tracer := otel.Tracer("nebari-infrastructure-core")
-	_, span := tracer.Start(ctx, "aws.EnsureStateBucket")
+	ctx, span := tracer.Start(ctx, "aws.EnsureStateBucket")
 	defer span.End()
 
 	span.SetAttributes(
@@ -106,60 +115,12 @@ func ensureStateBucket(ctx context.Context, client S3Client, region, bucketName
 		attribute.String(attrKeyRegion, region),
 	)
 
-	exists, err := stateBucketExists(ctx, client, bucketName)
+	created, err := newS3BucketStore(client).EnsureBucket(ctx, objectstore.BucketSpec{Name: bucketName, Region: region})
 	if err != nil {
 		span.RecordError(err)
 		return err
 	}
-	if exists {
-		span.SetAttributes(attribute.Bool("bucket_created", false))
-		return nil
-	}
-
-	createInput := &s3.CreateBucketInput{
-		Bucket: aws.String(bucketName),
-	}
-	// us-east-1 is special-cased in S3: CreateBucket rejects a LocationConstraint
-	// of "us-east-1" because that region is the default.
-	if region != "us-east-1" {
-		createInput.CreateBucketConfiguration = &types.CreateBucketConfiguration{
-			LocationConstraint: types.BucketLocationConstraint(region),
-		}
-	}
-
-	if _, err := client.CreateBucket(ctx, createInput); err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to create state bucket: %w", err)
-	}
-
-	// Enable versioning
-	_, err = client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
-		Bucket: aws.String(bucketName),
-		VersioningConfiguration: &types.VersioningConfiguration{
-			Status: types.BucketVersioningStatusEnabled,
-		},
-	})
-	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to enable bucket versioning: %w", err)
-	}
-
-	// Block public access
-	_, err = client.PutPublicAccessBlock(ctx, &s3.PutPublicAccessBlockInput{
-		Bucket: aws.String(bucketName),
-		PublicAccessBlockConfiguration: &types.PublicAccessBlockConfiguration{
-			BlockPublicAcls:       aws.Bool(true),
-			BlockPublicPolicy:     aws.Bool(true),
-			IgnorePublicAcls:      aws.Bool(true),
-			RestrictPublicBuckets: aws.Bool(true),
-		},
-	})
-	if err != nil {
-		span.RecordError(err)
-		return fmt.Errorf("failed to block public access: %w", err)
-	}
-
-	span.SetAttributes(attribute.Bool("bucket_created", true))
+	span.SetAttributes(attribute.Bool("bucket_created", created))
 	return nil
 }
 
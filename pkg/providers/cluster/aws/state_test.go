@@ -18,6 +18,7 @@ type mockS3Client struct {
 	CreateBucketFunc         func(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error)
 	PutBucketVersioningFunc  func(ctx context.Context, params *s3.PutBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error)
 	PutPublicAccessBlockFunc func(ctx context.Context, params *s3.PutPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.PutPublicAccessBlockOutput, error)
+	PutBucketTaggingFunc     func(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error)
 	ListObjectVersionsFunc   func(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
 	DeleteObjectsFunc        func(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
 	DeleteBucketFunc         func(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
@@ -51,6 +52,13 @@ func (m *mockS3Client) PutPublicAccessBlock(ctx context.Context, params *s3.PutP
 	return &s3.PutPublicAccessBlockOutput{}, nil
 }
 
+func (m *mockS3Client) PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+	if m.PutBucketTaggingFunc != nil {
+		return m.PutBucketTaggingFunc(ctx, params, optFns...)
+	}
+	return &s3.PutBucketTaggingOutput{}, nil
+}
+
 func (m *mockS3Client) ListObjectVersions(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
 	if m.ListObjectVersionsFunc != nil {
 		return m.ListObjectVersionsFunc(ctx, params, optFns...)
@@ -126,11 +134,38 @@ func TestGenerateBucketName(t *testing.T) {
 		}
 	})
 
-	t.Run("returns error for name exceeding max length", func(t *testing.T) {
-		longProjectName := "this-is-a-very-long-project-name-that-will-exceed-the-limit"
-		_, err := generateBucketName("123456789012", "us-east-1", longProjectName)
-		if err == nil {
-			t.Error("generateBucketName() expected error for long name, got nil")
+	t.Run("shortens long project names deterministically", func(t *testing.T) {
+		long1 := "this-is-a-very-long-project-name-that-will-exceed-the-limit"
+		long2 := "this-is-a-very-long-project-name-that-will-exceed-the-limit-too"
+
+		name1, err := generateBucketName("123456789012", "ap-southeast-2", long1)
+		if err != nil {
+			t.Fatalf("generateBucketName() error = %v", err)
+		}
+		again, _ := generateBucketName("123456789012", "ap-southeast-2", long1)
+		name2, _ := generateBucketName("123456789012", "ap-southeast-2", long2)
+
+		if len(name1) > maxBucketNameLength {
+			t.Errorf("generateBucketName() = %q (%d chars), want <= %d", name1, len(name1), maxBucketNameLength)
+		}
+		if name1 != again {
+			t.Errorf("generateBucketName() not deterministic: %q != %q", name1, again)
+		}
+		if name1 == name2 {
+			t.Errorf("distinct long project names collided: %q", name1)
+		}
+		if !strings.HasSuffix(name1, "-ap-southeast-2-"+name1[len(name1)-8:]) {
+			t.Errorf("generateBucketName() = %q, should keep region and account suffix", name1)
+		}
+	})
+
+	t.Run("normalizes project name to bucket charset", func(t *testing.T) {
+		name, err := generateBucketName("123456789012", "us-east-1", "My_Project")
+		if err != nil {
+			t.Fatalf("generateBucketName() error = %v", err)
+		}
+		if !strings.HasPrefix(name, "nic-tfstate-my-project-") {
+			t.Errorf("generateBucketName() = %q, want lowercase project without underscores", name)
 		}
 	})
 }
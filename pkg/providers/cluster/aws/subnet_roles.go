@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// Values of the subnet role tags. The AWS Load Balancer Controller places
+// load balancers in subnets tagged "1" (or with an empty value) and skips
+// subnets where the tag is "0".
+const (
+	subnetRoleEligible   = "1"
+	subnetRoleIneligible = "0"
+)
+
+// SubnetRolesConfig lists which subnets of an existing VPC may hold load
+// balancers. Private subnets that are not listed are node-only.
+type SubnetRolesConfig struct {
+	// InternalLoadBalancerSubnetIDs are tagged kubernetes.io/role/internal-elb=1.
+	InternalLoadBalancerSubnetIDs []string `yaml:"internal_load_balancer_subnet_ids"`
+	// PublicLoadBalancerSubnetIDs are tagged kubernetes.io/role/elb=1. They
+	// must route to an internet gateway, so they cannot also be node subnets.
+	PublicLoadBalancerSubnetIDs []string `yaml:"public_load_balancer_subnet_ids"`
+}
+
+// subnetRoleTag is one tag applied to an existing subnet, in the shape of
+// the subnet_role_tags variable.
+type subnetRoleTag struct {
+	SubnetID string `json:"subnet_id"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+// validateSubnetRoles checks subnet_roles without calling AWS: it needs an
+// existing VPC and at least one internal and one public load balancer
+// subnet, and no subnet may take two roles.
+func validateSubnetRoles(c *Config) error {
+	r := c.SubnetRoles
+	if r == nil {
+		return nil
+	}
+	if c.ExistingVPCID == "" {
+		return fmt.Errorf("subnet_roles requires existing_vpc_id; subnets of a NIC-created VPC are tagged by NIC")
+	}
+	if len(r.InternalLoadBalancerSubnetIDs) == 0 {
+		return fmt.Errorf("subnet_roles.internal_load_balancer_subnet_ids must list at least one subnet")
+	}
+	if len(r.PublicLoadBalancerSubnetIDs) == 0 {
+		return fmt.Errorf("subnet_roles.public_load_balancer_subnet_ids must list at least one subnet")
+	}
+	for _, f := range []struct {
+		field string
+		ids   []string
+	}{
+		{"internal_load_balancer_subnet_ids", r.InternalLoadBalancerSubnetIDs},
+		{"public_load_balancer_subnet_ids", r.PublicLoadBalancerSubnetIDs},
+	} {
+		for i, id := range f.ids {
+			if !strings.HasPrefix(id, "subnet-") {
+				return fmt.Errorf("invalid subnet_roles.%s[%d] %q (expected subnet- followed by the subnet ID)", f.field, i, id)
+			}
+			if j := slices.Index(f.ids, id); j < i {
+				return fmt.Errorf("subnet_roles.%s[%d] duplicates subnet_roles.%s[%d] %q", f.field, i, f.field, j, id)
+			}
+		}
+	}
+	for _, id := range r.PublicLoadBalancerSubnetIDs {
+		if slices.Contains(r.InternalLoadBalancerSubnetIDs, id) {
+			return fmt.Errorf("subnet %s is listed for both internal and public load balancers", id)
+		}
+		if slices.Contains(c.ExistingPrivateSubnetIDs, id) {
+			return fmt.Errorf("subnet %s is in existing_private_subnet_ids and cannot also hold public load balancers", id)
+		}
+	}
+	return nil
+}
+
+// subnetRoleTags returns the role tags to apply for subnet_roles, keyed by
+// "<subnet>|<tag key>". Internal and public load balancer subnets are
+// eligible for their own role only; node-only private subnets are marked
+// ineligible for both. Returns nil when subnet_roles is unset.
+func (c *Config) subnetRoleTags() map[string]subnetRoleTag {
+	r := c.SubnetRoles
+	if r == nil {
+		return nil
+	}
+	tags := make(map[string]subnetRoleTag)
+	add := func(id, key, value string) {
+		tags[id+"|"+key] = subnetRoleTag{SubnetID: id, Key: key, Value: value}
+	}
+	for _, id := range r.InternalLoadBalancerSubnetIDs {
+		add(id, subnetTagInternalELB, subnetRoleEligible)
+		add(id, subnetTagELB, subnetRoleIneligible)
+	}
+	for _, id := range r.PublicLoadBalancerSubnetIDs {
+		add(id, subnetTagELB, subnetRoleEligible)
+		add(id, subnetTagInternalELB, subnetRoleIneligible)
+	}
+	for _, id := range c.ExistingPrivateSubnetIDs {
+		if slices.Contains(r.InternalLoadBalancerSubnetIDs, id) {
+			continue
+		}
+		add(id, subnetTagInternalELB, subnetRoleIneligible)
+		add(id, subnetTagELB, subnetRoleIneligible)
+	}
+	return tags
+}
+
+// checkSubnetRoleSubnets verifies the subnet_roles load balancer subnets exist
+// in vpcID.
+func checkSubnetRoleSubnets(ctx context.Context, client ExistingNetworkClient, vpcID string, r *SubnetRolesConfig) error {
+	ids := slices.Concat(r.InternalLoadBalancerSubnetIDs, r.PublicLoadBalancerSubnetIDs)
+	out, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: ids})
+	if err != nil {
+		return fmt.Errorf("failed to describe subnet_roles subnets %v: %w", ids, err)
+	}
+	found := make(map[string]string, len(out.Subnets))
+	for _, s := range out.Subnets {
+		found[aws.ToString(s.SubnetId)] = aws.ToString(s.VpcId)
+	}
+	for _, id := range ids {
+		vpc, ok := found[id]
+		if !ok {
+			return fmt.Errorf("subnet_roles subnet %s not found in region", id)
+		}
+		if vpc != vpcID {
+			return fmt.Errorf("subnet_roles subnet %s is in VPC %s, not existing_vpc_id %s", id, vpc, vpcID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestSubnetRoleTags(t *testing.T) {
+	c := &Config{
+		ExistingVPCID:            "vpc-1",
+		ExistingPrivateSubnetIDs: []string{"subnet-lb-a", "subnet-node-a", "subnet-node-b"},
+		SubnetRoles: &SubnetRolesConfig{
+			InternalLoadBalancerSubnetIDs: []string{"subnet-lb-a", "subnet-lb-b"},
+			PublicLoadBalancerSubnetIDs:   []string{"subnet-pub-a"},
+		},
+	}
+
+	// Each subnet type carries exactly these role tags.
+	want := map[string]map[string]string{
+		// Internal load balancer subnets, whether or not nodes also run there.
+		"subnet-lb-a": {subnetTagInternalELB: "1", subnetTagELB: "0"},
+		"subnet-lb-b": {subnetTagInternalELB: "1", subnetTagELB: "0"},
+		// Public load balancer subnets.
+		"subnet-pub-a": {subnetTagELB: "1", subnetTagInternalELB: "0"},
+		// Node-only subnets.
+		"subnet-node-a": {subnetTagInternalELB: "0", subnetTagELB: "0"},
+		"subnet-node-b": {subnetTagInternalELB: "0", subnetTagELB: "0"},
+	}
+
+	got := map[string]map[string]string{}
+	for key, tag := range c.subnetRoleTags() {
+		if key != tag.SubnetID+"|"+tag.Key {
+			t.Errorf("tag %+v has key %q", tag, key)
+		}
+		if got[tag.SubnetID] == nil {
+			got[tag.SubnetID] = map[string]string{}
+		}
+		got[tag.SubnetID][tag.Key] = tag.Value
+	}
+	if len(got) != len(want) {
+		t.Errorf("tagged subnets = %v, want %v", got, want)
+	}
+	for id, tags := range want {
+		if !maps.Equal(got[id], tags) {
+			t.Errorf("%s tags = %v, want %v", id, got[id], tags)
+		}
+	}
+
+	if tags := (&Config{}).subnetRoleTags(); tags != nil {
+		t.Errorf("subnetRoleTags() without subnet_roles = %v, want nil", tags)
+	}
+}
+
+func TestValidateSubnetRoles(t *testing.T) {
+	roles := func(internal, public []string) *SubnetRolesConfig {
+		return &SubnetRolesConfig{InternalLoadBalancerSubnetIDs: internal, PublicLoadBalancerSubnetIDs: public}
+	}
+	existing := func(r *SubnetRolesConfig) Config {
+		return Config{ExistingVPCID: "vpc-1", ExistingPrivateSubnetIDs: []string{"subnet-a", "subnet-b"}, SubnetRoles: r}
+	}
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		errSubstr string // "" means no error expected
+	}{
+		{name: "unset", cfg: Config{}},
+		{name: "valid", cfg: existing(roles([]string{"subnet-a"}, []string{"subnet-p"}))},
+		{name: "dedicated internal subnets", cfg: existing(roles([]string{"subnet-lb"}, []string{"subnet-p"}))},
+		{
+			name:      "created vpc",
+			cfg:       Config{SubnetRoles: roles([]string{"subnet-a"}, []string{"subnet-p"})},
+			errSubstr: "subnet_roles requires existing_vpc_id",
+		},
+		{name: "no internal", cfg: existing(roles(nil, []string{"subnet-p"})), errSubstr: "internal_load_balancer_subnet_ids must list at least one subnet"},
+		{name: "no public", cfg: existing(roles([]string{"subnet-a"}, nil)), errSubstr: "public_load_balancer_subnet_ids must list at least one subnet"},
+		{name: "bad id", cfg: existing(roles([]string{"sn-a"}, []string{"subnet-p"})), errSubstr: `invalid subnet_roles.internal_load_balancer_subnet_ids[0] "sn-a"`},
+		{
+			name:      "duplicate",
+			cfg:       existing(roles([]string{"subnet-a"}, []string{"subnet-p", "subnet-p"})),
+			errSubstr: "public_load_balancer_subnet_ids[1] duplicates",
+		},
+		{name: "both roles", cfg: existing(roles([]string{"subnet-x"}, []string{"subnet-x"})), errSubstr: "both internal and public"},
+		{name: "public node subnet", cfg: existing(roles([]string{"subnet-a"}, []string{"subnet-b"})), errSubstr: "subnet-b is in existing_private_subnet_ids"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSubnetRoles(&tt.cfg)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("validateSubnetRoles() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("validateSubnetRoles() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
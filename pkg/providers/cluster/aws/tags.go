@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// nicTagPrefix is the tag namespace reserved for NIC. Tags under it are
+	// authoritative: NIC overwrites any out-of-band value on reconcile, and
+	// users cannot set them through the tags config.
+	nicTagPrefix = "nic.nebari.dev/"
+
+	// tagKeyManagedBy and tagKeyCluster mark AWS resources NIC modifies
+	// outside of OpenTofu.
+	tagKeyManagedBy = nicTagPrefix + "managed-by"
+	tagKeyCluster   = nicTagPrefix + "cluster"
+	tagValueNIC     = "nebari-infrastructure-core"
+
+	// tagKeyEnvironment records the config's environment on every tagged
+	// resource, both through OpenTofu and outside of it.
+	tagKeyEnvironment = nicTagPrefix + "environment"
+
+	// tagKeyClusterID records the deployment's cluster ID on every tagged
+	// resource, for cost attribution and to tell apart deployments that
+	// share a project name.
+	tagKeyClusterID = nicTagPrefix + "cluster-id"
+)
+
+// isNICTag reports whether key is in the NIC-reserved tag namespace.
+func isNICTag(key string) bool {
+	return strings.HasPrefix(key, nicTagPrefix)
+}
+
+// validateTags rejects user tags in the NIC-reserved namespace, which would
+// otherwise be silently overwritten on every deploy.
+func validateTags(tags map[string]string) error {
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		if isNICTag(k) {
+			return fmt.Errorf("invalid tag %q: the %s prefix is reserved for NIC", k, nicTagPrefix)
+		}
+	}
+	return nil
+}
+
+// nicTags returns the tags NIC wants on a resource it manages for
+// clusterName: the user's configured tags plus the NIC markers, including the
+// environment and cluster ID when set.
+func nicTags(clusterName, environment, clusterID string, userTags map[string]string) map[string]string {
+	desired := make(map[string]string, len(userTags)+4)
+	for k, v := range userTags {
+		if !isNICTag(k) {
+			desired[k] = v
+		}
+	}
+	desired[tagKeyManagedBy] = tagValueNIC
+	desired[tagKeyCluster] = clusterName
+	if environment != "" {
+		desired[tagKeyEnvironment] = environment
+	}
+	if clusterID != "" {
+		desired[tagKeyClusterID] = clusterID
+	}
+	return desired
+}
+
+// withEnvironmentTag returns userTags plus the environment tag, for the tags
+// OpenTofu applies to every resource. The input map is never mutated, and
+// userTags is returned as-is when environment is empty so the tofu variables
+// of configs without an environment are unchanged.
+func withEnvironmentTag(userTags map[string]string, environment string) map[string]string {
+	if environment == "" {
+		return userTags
+	}
+	tags := make(map[string]string, len(userTags)+1)
+	maps.Copy(tags, userTags)
+	tags[tagKeyEnvironment] = environment
+	return tags
+}
+
+// withClusterIDTag returns tags plus the cluster ID tag, like
+// withEnvironmentTag: the input is never mutated and is returned as-is when
+// clusterID is empty.
+func withClusterIDTag(tags map[string]string, clusterID string) map[string]string {
+	if clusterID == "" {
+		return tags
+	}
+	out := make(map[string]string, len(tags)+1)
+	maps.Copy(out, tags)
+	out[tagKeyClusterID] = clusterID
+	return out
+}
+
+// mergeTags reconciles the tags live on a resource with desired. Every key in
+// desired wins, and tags that only exist on the live resource (added
+// out-of-band by users or other tooling) are kept. NIC never removes a tag it
+// does not own.
+func mergeTags(live, desired map[string]string) map[string]string {
+	merged := maps.Clone(live)
+	if merged == nil {
+		merged = make(map[string]string, len(desired))
+	}
+	maps.Copy(merged, desired)
+	return merged
+}
+
+// tagChanges returns the tags in desired that are missing from or differ on
+// live, i.e. what has to be written so that live matches mergeTags(live,
+// desired).
+func tagChanges(live, desired map[string]string) map[string]string {
+	changes := make(map[string]string)
+	for k, v := range mergeTags(live, desired) {
+		if cur, ok := live[k]; !ok || cur != v {
+			changes[k] = v
+		}
+	}
+	return changes
+}
+
+// ec2TagMap converts EC2 tags to a map.
+func ec2TagMap(tags []ec2types.Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, t := range tags {
+		m[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return m
+}
+
+// ec2Tags converts a tag map to EC2 tags in key order, so requests are
+// deterministic.
+func ec2Tags(tags map[string]string) []ec2types.Tag {
+	result := make([]ec2types.Tag, 0, len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		result = append(result, ec2types.Tag{Key: aws.String(k), Value: aws.String(tags[k])})
+	}
+	return result
+}
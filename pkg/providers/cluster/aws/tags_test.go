@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestMergeTags(t *testing.T) {
+	live := map[string]string{
+		"owner":         "secops",    // added out-of-band
+		"env":           "staging",   // drifted user tag
+		tagKeyCluster:   "other",     // drifted NIC tag
+		tagKeyManagedBy: tagValueNIC, // already correct
+	}
+	desired := nicTags("demo", "", "", map[string]string{"env": "prod"})
+
+	got := mergeTags(live, desired)
+	want := map[string]string{
+		"owner":         "secops",
+		"env":           "prod",
+		tagKeyCluster:   "demo",
+		tagKeyManagedBy: tagValueNIC,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("mergeTags() = %v, want %v", got, want)
+	}
+	if live[tagKeyCluster] != "other" {
+		t.Error("mergeTags mutated the live map")
+	}
+
+	changes := tagChanges(live, desired)
+	wantChanges := map[string]string{"env": "prod", tagKeyCluster: "demo"}
+	if !maps.Equal(changes, wantChanges) {
+		t.Errorf("tagChanges() = %v, want %v", changes, wantChanges)
+	}
+}
+
+func TestNICTagsIgnoresReservedUserTags(t *testing.T) {
+	got := nicTags("demo", "", "", map[string]string{tagKeyCluster: "spoofed", "env": "prod"})
+	if got[tagKeyCluster] != "demo" || got["env"] != "prod" || got[tagKeyManagedBy] != tagValueNIC {
+		t.Errorf("nicTags() = %v", got)
+	}
+}
+
+func TestEnvironmentTags(t *testing.T) {
+	user := map[string]string{"team": "data"}
+
+	got := withEnvironmentTag(user, "prod")
+	want := map[string]string{"team": "data", tagKeyEnvironment: "prod"}
+	if !maps.Equal(got, want) {
+		t.Errorf("withEnvironmentTag() = %v, want %v", got, want)
+	}
+	if _, ok := user[tagKeyEnvironment]; ok {
+		t.Error("withEnvironmentTag mutated the user tags")
+	}
+	if got := withEnvironmentTag(nil, ""); got != nil {
+		t.Errorf("withEnvironmentTag(nil, \"\") = %v, want nil", got)
+	}
+
+	if got := nicTags("demo", "prod", "", user); got[tagKeyEnvironment] != "prod" {
+		t.Errorf("nicTags() environment tag = %q, want prod", got[tagKeyEnvironment])
+	}
+	if _, ok := nicTags("demo", "", "", user)[tagKeyEnvironment]; ok {
+		t.Error("nicTags() set an environment tag without an environment")
+	}
+}
+
+func TestClusterIDTag(t *testing.T) {
+	user := map[string]string{"team": "data"}
+	got := withClusterIDTag(withEnvironmentTag(user, "prod"), "0b7e")
+	want := map[string]string{"team": "data", tagKeyEnvironment: "prod", tagKeyClusterID: "0b7e"}
+	if !maps.Equal(got, want) {
+		t.Errorf("withClusterIDTag() = %v, want %v", got, want)
+	}
+	if _, ok := user[tagKeyClusterID]; ok {
+		t.Error("withClusterIDTag mutated the user tags")
+	}
+	if got := withClusterIDTag(user, ""); !maps.Equal(got, user) {
+		t.Errorf("withClusterIDTag(user, \"\") = %v, want %v", got, user)
+	}
+
+	if got := nicTags("demo", "", "0b7e", user); got[tagKeyClusterID] != "0b7e" {
+		t.Errorf("nicTags() cluster ID tag = %q, want 0b7e", got[tagKeyClusterID])
+	}
+	if _, ok := nicTags("demo", "", "", user)[tagKeyClusterID]; ok {
+		t.Error("nicTags() set a cluster ID tag without a cluster ID")
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	if err := validateTags(map[string]string{"env": "prod", "nebari.dev/team": "data"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := validateTags(map[string]string{"env": "prod", "nic.nebari.dev/cluster": "x"})
+	if err == nil || !strings.Contains(err.Error(), "reserved for NIC") {
+		t.Errorf("error = %v, want reserved prefix error", err)
+	}
+}
+
+func TestEC2TagsSorted(t *testing.T) {
+	tags := ec2Tags(map[string]string{"b": "2", "a": "1", "c": "3"})
+	var keys []string
+	for _, tag := range tags {
+		keys = append(keys, *tag.Key)
+	}
+	if strings.Join(keys, ",") != "a,b,c" {
+		t.Errorf("ec2Tags keys = %v, want sorted", keys)
+	}
+	if !maps.Equal(ec2TagMap(tags), map[string]string{"a": "1", "b": "2", "c": "3"}) {
+		t.Errorf("ec2TagMap round trip = %v", ec2TagMap(tags))
+	}
+}
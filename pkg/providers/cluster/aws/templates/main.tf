@@ -39,3 +39,161 @@ module "eks_cluster" {
   longhorn_backup_bucket_force_destroy = var.backup_bucket_force_destroy
   enable_longhorn_backup_pod_identity  = var.backup_pod_identity_enable
 }
+
+# IAM roles for Kubernetes service accounts (IRSA). Each role trusts only its
+# own service account, through the OIDC provider the module creates when
+# enable_irsa is set.
+locals {
+  oidc_issuer = replace(module.eks_cluster.cluster_oidc_issuer_url, "https://", "")
+
+  service_account_policy_attachments = merge({}, [
+    for key, role in var.service_account_roles : {
+      for arn in role.policy_arns : "${key}|${arn}" => {
+        role       = key
+        policy_arn = arn
+      }
+    }
+  ]...)
+}
+
+data "aws_iam_policy_document" "service_account_assume_role" {
+  for_each = var.service_account_roles
+
+  statement {
+    actions = ["sts:AssumeRoleWithWebIdentity"]
+
+    principals {
+      type        = "Federated"
+      identifiers = [module.eks_cluster.oidc_provider_arn]
+    }
+
+    condition {
+      test     = "StringEquals"
+      variable = "${local.oidc_issuer}:sub"
+      values   = ["system:serviceaccount:${each.value.namespace}:${each.value.service_account}"]
+    }
+
+    condition {
+      test     = "StringEquals"
+      variable = "${local.oidc_issuer}:aud"
+      values   = ["sts.amazonaws.com"]
+    }
+  }
+}
+
+resource "aws_iam_role" "service_account" {
+  for_each = var.service_account_roles
+
+  name                 = "${var.project_name}-irsa-${each.key}"
+  assume_role_policy   = data.aws_iam_policy_document.service_account_assume_role[each.key].json
+  permissions_boundary = var.iam_role_permissions_boundary
+  tags                 = var.tags
+}
+
+resource "aws_iam_role_policy_attachment" "service_account" {
+  for_each = local.service_account_policy_attachments
+
+  role       = aws_iam_role.service_account[each.value.role].name
+  policy_arn = each.value.policy_arn
+}
+
+# Extra managed and inline policies on the cluster and node IAM roles the
+# module creates. The module takes no inputs for them, so they are attached
+# here. The role names are read from the cluster and one of its node groups
+# (all node groups share the module's node role) once the module has created
+# them.
+locals {
+  cluster_role_policies = length(var.additional_cluster_policy_arns) > 0 || length(var.cluster_inline_policies) > 0
+  node_role_policies    = length(var.additional_node_policy_arns) > 0 || length(var.node_inline_policies) > 0
+
+  cluster_role_name = one([for c in data.aws_eks_cluster.this : regex("[^/]+$", c.role_arn)])
+  node_role_name    = one([for ng in data.aws_eks_node_group.this : regex("[^/]+$", ng.node_role_arn)])
+}
+
+data "aws_eks_cluster" "this" {
+  count = local.cluster_role_policies ? 1 : 0
+
+  name       = module.eks_cluster.cluster_name
+  depends_on = [module.eks_cluster]
+}
+
+data "aws_eks_node_groups" "this" {
+  count = local.node_role_policies ? 1 : 0
+
+  cluster_name = module.eks_cluster.cluster_name
+  depends_on   = [module.eks_cluster]
+}
+
+data "aws_eks_node_group" "this" {
+  count = local.node_role_policies ? 1 : 0
+
+  cluster_name    = module.eks_cluster.cluster_name
+  node_group_name = sort(data.aws_eks_node_groups.this[0].names)[0]
+}
+
+resource "aws_iam_role_policy_attachment" "additional_cluster" {
+  for_each = toset(var.additional_cluster_policy_arns)
+
+  role       = local.cluster_role_name
+  policy_arn = each.value
+}
+
+resource "aws_iam_role_policy_attachment" "additional_node" {
+  for_each = toset(var.additional_node_policy_arns)
+
+  role       = local.node_role_name
+  policy_arn = each.value
+}
+
+resource "aws_iam_role_policy" "cluster_inline" {
+  for_each = var.cluster_inline_policies
+
+  name   = each.key
+  role   = local.cluster_role_name
+  policy = each.value
+}
+
+resource "aws_iam_role_policy" "node_inline" {
+  for_each = var.node_inline_policies
+
+  name   = each.key
+  role   = local.node_role_name
+  policy = each.value
+}
+
+# Load balancer role tags on the subnets of an existing VPC (subnet_roles).
+# The AWS Load Balancer Controller only places load balancers in subnets
+# whose role tag is 1; node-only subnets are tagged 0.
+resource "aws_ec2_tag" "subnet_role" {
+  for_each = var.subnet_role_tags
+
+  resource_id = each.value.subnet_id
+  key         = each.value.key
+  value       = each.value.value
+}
+
+# EKS access entries for the IAM principals in access_entries, each
+# associated with one EKS access policy, cluster-wide or scoped to
+# namespaces. The identity that created the cluster already has an entry.
+data "aws_partition" "current" {}
+
+resource "aws_eks_access_entry" "additional" {
+  for_each = var.access_entries
+
+  cluster_name  = module.eks_cluster.cluster_name
+  principal_arn = each.key
+  tags          = var.tags
+}
+
+resource "aws_eks_access_policy_association" "additional" {
+  for_each = var.access_entries
+
+  cluster_name  = module.eks_cluster.cluster_name
+  principal_arn = aws_eks_access_entry.additional[each.key].principal_arn
+  policy_arn    = "arn:${data.aws_partition.current.partition}:eks::aws:cluster-access-policy/${each.value.policy}"
+
+  access_scope {
+    type       = length(each.value.namespaces) > 0 ? "namespace" : "cluster"
+    namespaces = length(each.value.namespaces) > 0 ? each.value.namespaces : null
+  }
+}
@@ -48,3 +48,8 @@ output "longhorn_backup_bucket" {
   description = "Name of the Longhorn backup S3 bucket; empty when not created by NIC"
   value       = module.eks_cluster.longhorn_backup_bucket
 }
+
+output "service_account_role_arns" {
+  description = "ARNs of the IRSA roles, keyed by service_account_roles entry"
+  value       = { for key, role in aws_iam_role.service_account : key => role.arn }
+}
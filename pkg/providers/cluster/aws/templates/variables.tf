@@ -86,6 +86,26 @@ variable "iam_role_permissions_boundary" {
   default = null
 }
 
+variable "additional_node_policy_arns" {
+  type    = list(string)
+  default = []
+}
+
+variable "additional_cluster_policy_arns" {
+  type    = list(string)
+  default = []
+}
+
+variable "node_inline_policies" {
+  type    = map(string)
+  default = {}
+}
+
+variable "cluster_inline_policies" {
+  type    = map(string)
+  default = {}
+}
+
 variable "node_groups" {
   type = any
 }
@@ -157,3 +177,29 @@ variable "backup_pod_identity_enable" {
   type    = bool
   default = false
 }
+
+variable "service_account_roles" {
+  type = map(object({
+    namespace       = string
+    service_account = string
+    policy_arns     = list(string)
+  }))
+  default = {}
+}
+
+variable "subnet_role_tags" {
+  type = map(object({
+    subnet_id = string
+    key       = string
+    value     = string
+  }))
+  default = {}
+}
+
+variable "access_entries" {
+  type = map(object({
+    policy     = string
+    namespaces = list(string)
+  }))
+  default = {}
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"embed"
 	"maps"
+	"slices"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/storage/longhorn"
@@ -45,6 +46,10 @@ type TFVars struct {
 	ExistingClusterIAMRoleArn     *string              `json:"existing_cluster_iam_role_arn,omitempty"`
 	ExistingNodeIAMRoleArn        *string              `json:"existing_node_iam_role_arn,omitempty"`
 	IAMRolePermissionsBoundary    *string              `json:"iam_role_permissions_boundary,omitempty"`
+	AdditionalNodePolicyARNs      []string             `json:"additional_node_policy_arns,omitempty"`
+	AdditionalClusterPolicyARNs   []string             `json:"additional_cluster_policy_arns,omitempty"`
+	NodeInlinePolicies            map[string]string    `json:"node_inline_policies,omitempty"`
+	ClusterInlinePolicies         map[string]string    `json:"cluster_inline_policies,omitempty"`
 	NodeGroups                    map[string]NodeGroup `json:"node_groups"`
 	EFSEnabled                    bool                 `json:"efs_enabled"`
 	EFSPerformanceMode            string               `json:"efs_performance_mode,omitempty"`
@@ -63,15 +68,20 @@ type TFVars struct {
 	BackupBucketForceDestroy           bool   `json:"backup_bucket_force_destroy"`
 	// BackupPodIdentityEnable provisions a keyless IAM-role (EKS Pod Identity)
 	// association for Longhorn's service account, scoped to the backup bucket.
-	BackupPodIdentityEnable bool `json:"backup_pod_identity_enable"`
+	BackupPodIdentityEnable bool                             `json:"backup_pod_identity_enable"`
+	ServiceAccountRoles     map[string]serviceAccountRoleVar `json:"service_account_roles,omitempty"`
+	SubnetRoleTags          map[string]subnetRoleTag         `json:"subnet_role_tags,omitempty"`
+	AccessEntries           map[string]accessEntryVar        `json:"access_entries,omitempty"`
 }
 
 // resolveNodeGroupDefaults derives per-node-group defaults from the parsed
-// config: the EKS AMI type (NVIDIA for GPU groups, standard otherwise) and the
-// GPU taint. It returns a new map and never mutates the caller's node groups.
+// config: the EKS AMI type (NVIDIA for GPU groups, standard otherwise), the
+// GPU taint and the node-pool label. It returns a new map and never mutates
+// the caller's node groups.
 func resolveNodeGroupDefaults(nodeGroups map[string]NodeGroup) map[string]NodeGroup {
 	result := make(map[string]NodeGroup, len(nodeGroups))
 	for name, group := range nodeGroups {
+		group.Labels = cluster.NodeGroupLabels(name, group.Labels)
 		if group.AMIType == nil {
 			var ami string
 			switch {
@@ -87,6 +97,45 @@ func resolveNodeGroupDefaults(nodeGroups map[string]NodeGroup) map[string]NodeGr
 	return result
 }
 
+// applyLaunchProfiles resolves each node group's LaunchProfile reference
+// against profiles (see LaunchProfile for the precedence rules). Groups
+// without a reference, or referencing an unknown profile (rejected by
+// Validate), are returned unchanged. It returns a new map and never mutates
+// the caller's node groups or profiles.
+func applyLaunchProfiles(nodeGroups map[string]NodeGroup, profiles map[string]LaunchProfile) map[string]NodeGroup {
+	result := make(map[string]NodeGroup, len(nodeGroups))
+	for name, group := range nodeGroups {
+		profile, ok := profiles[group.LaunchProfile]
+		if group.LaunchProfile == "" || !ok {
+			result[name] = group
+			continue
+		}
+		if group.AMIType == nil {
+			group.AMIType = profile.AMIType
+		}
+		if group.DiskSize == nil {
+			group.DiskSize = profile.DiskSize
+		}
+		if len(profile.Labels) > 0 {
+			labels := make(map[string]string, len(profile.Labels)+len(group.Labels))
+			maps.Copy(labels, profile.Labels)
+			maps.Copy(labels, group.Labels)
+			group.Labels = labels
+		}
+		if len(profile.Taints) > 0 {
+			taints := slices.Clone(group.Taints)
+			for _, t := range profile.Taints {
+				if !slices.ContainsFunc(group.Taints, func(own Taint) bool { return own.Key == t.Key }) {
+					taints = append(taints, t)
+				}
+			}
+			group.Taints = taints
+		}
+		result[name] = group
+	}
+	return result
+}
+
 // applyGPUTaint ensures a GPU node group carries the nvidia.com/gpu taint so
 // that only pods tolerating it schedule onto GPU hardware. The NVIDIA GPU
 // Operator does not taint nodes itself; it only tolerates this taint on its own
@@ -153,7 +202,7 @@ func nodeGroupMatchesSelector(labels, sel map[string]string) bool {
 // PEM), empty when no bundle is configured. backup, when non-nil, asks the
 // module to provision the Longhorn backup bucket and/or Pod Identity role.
 func (c *Config) toTFVars(projectName, caBundle string, backup *cluster.BackupBucketSpec) TFVars {
-	nodeGroups := resolveNodeGroupDefaults(c.NodeGroups)
+	nodeGroups := resolveNodeGroupDefaults(applyLaunchProfiles(c.NodeGroups, c.LaunchProfiles))
 	// When Longhorn runs on dedicated nodes, the storage node group(s) must carry
 	// the create-default-disk label or Longhorn provisions no disks and every
 	// volume faults (#369). Inject it here so it is applied by kubelet at node
@@ -167,13 +216,13 @@ func (c *Config) toTFVars(projectName, caBundle string, backup *cluster.BackupBu
 		ProjectName:            projectName,
 		Tags:                   c.Tags,
 		AvailabilityZones:      c.AvailabilityZones,
-		CreateVPC:              c.ExistingVPCID == "" && len(c.ExistingPrivateSubnetIDs) == 0,
+		CreateVPC:              c.createsVPC(),
 		CreateSecurityGroup:    c.ExistingSecurityGroupID == "",
 		KubernetesVersion:      c.KubernetesVersion,
 		EndpointPrivateAccess:  c.EndpointPrivateAccess,
 		EndpointPublicAccess:   c.EndpointPublicAccess,
 		ClusterEnabledLogTypes: c.EnabledLogTypes,
-		CreateIAMRoles:         c.ExistingClusterRoleArn == "" && c.ExistingNodeRoleArn == "",
+		CreateIAMRoles:         c.createsIAMRoles(),
 		NodeGroups:             nodeGroups,
 		// Only provision the autoscaler's IAM role / pod identity association
 		// when the autoscaler itself will be installed (see provider deploy).
@@ -209,6 +258,21 @@ func (c *Config) toTFVars(projectName, caBundle string, backup *cluster.BackupBu
 	if c.EnableIRSA != nil {
 		vars.EnableIRSA = c.EnableIRSA
 	}
+	vars.ServiceAccountRoles = c.serviceAccountRoleVars()
+	vars.SubnetRoleTags = c.subnetRoleTags()
+	vars.AccessEntries = c.accessEntryVars()
+	if len(c.AdditionalNodePolicyARNs) > 0 {
+		vars.AdditionalNodePolicyARNs = c.AdditionalNodePolicyARNs
+	}
+	if len(c.AdditionalClusterPolicyARNs) > 0 {
+		vars.AdditionalClusterPolicyARNs = c.AdditionalClusterPolicyARNs
+	}
+	if len(c.NodeInlinePolicies) > 0 {
+		vars.NodeInlinePolicies = c.NodeInlinePolicies
+	}
+	if len(c.ClusterInlinePolicies) > 0 {
+		vars.ClusterInlinePolicies = c.ClusterInlinePolicies
+	}
 
 	if c.LonghornEnabled() {
 		vars.NodeSGAdditionalRules = map[string]any{
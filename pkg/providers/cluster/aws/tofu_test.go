@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
@@ -390,6 +392,32 @@ func TestToTFVarsEnableIRSA(t *testing.T) {
 	})
 }
 
+func TestToTFVarsNodeGroupLabels(t *testing.T) {
+	cfg := Config{
+		Region:            "us-west-2",
+		KubernetesVersion: "1.34",
+		NodeGroups: map[string]NodeGroup{
+			"general": {Instance: "m5.large"},
+			"user":    {Instance: "m5.xlarge", Labels: map[string]string{"team": "data"}},
+		},
+	}
+	vars := cfg.toTFVars("test", "", nil)
+
+	if got := vars.NodeGroups["general"].Labels[cluster.NodePoolLabel]; got != "general" {
+		t.Errorf("general %s = %q, want general", cluster.NodePoolLabel, got)
+	}
+	userLabels := vars.NodeGroups["user"].Labels
+	if userLabels["team"] != "data" {
+		t.Errorf("user label team = %q, want data", userLabels["team"])
+	}
+	if userLabels[cluster.NodePoolLabel] != "user" {
+		t.Errorf("user %s = %q, want user", cluster.NodePoolLabel, userLabels[cluster.NodePoolLabel])
+	}
+	if _, ok := cfg.NodeGroups["user"].Labels[cluster.NodePoolLabel]; ok {
+		t.Error("toTFVars mutated the caller's NodeGroup.Labels map")
+	}
+}
+
 func TestToTFVarsLonghornDiskLabel(t *testing.T) {
 	const diskLabel = longhorn.CreateDefaultDiskLabel
 
@@ -507,3 +535,94 @@ func TestToTFVarsBackupBucket(t *testing.T) {
 		}
 	})
 }
+
+func TestToTFVarsLaunchProfiles(t *testing.T) {
+	ami := "BOTTLEROCKET_x86_64"
+	disk := 200
+	ownDisk := 500
+	cfg := Config{
+		Region:            "us-west-2",
+		KubernetesVersion: "1.34",
+		LaunchProfiles: map[string]LaunchProfile{
+			"hardened": {
+				AMIType:  &ami,
+				DiskSize: &disk,
+				Labels:   map[string]string{"profile": "hardened", "tier": "shared"},
+				Taints:   []Taint{{Key: "dedicated", Value: "shared", Effect: "NO_SCHEDULE"}},
+			},
+		},
+		NodeGroups: map[string]NodeGroup{
+			"user":   {Instance: "m5.large", LaunchProfile: "hardened"},
+			"worker": {Instance: "m5.xlarge", LaunchProfile: "hardened"},
+			"big": {
+				Instance:      "m5.4xlarge",
+				LaunchProfile: "hardened",
+				DiskSize:      &ownDisk,
+				Labels:        map[string]string{"tier": "big"},
+				Taints:        []Taint{{Key: "dedicated", Value: "big", Effect: "NO_EXECUTE"}},
+			},
+			"general": {Instance: "m5.large"},
+		},
+	}
+	vars := cfg.toTFVars("test", "", nil)
+
+	user, worker := vars.NodeGroups["user"], vars.NodeGroups["worker"]
+	for name, group := range map[string]NodeGroup{"user": user, "worker": worker} {
+		if group.AMIType == nil || *group.AMIType != ami {
+			t.Errorf("%s AMIType = %v, want %s from the profile", name, group.AMIType, ami)
+		}
+		if group.DiskSize == nil || *group.DiskSize != disk {
+			t.Errorf("%s DiskSize = %v, want %d from the profile", name, group.DiskSize, disk)
+		}
+		if group.Labels["profile"] != "hardened" || group.Labels["tier"] != "shared" {
+			t.Errorf("%s Labels = %v, want the profile labels", name, group.Labels)
+		}
+		if len(group.Taints) != 1 || group.Taints[0].Value != "shared" {
+			t.Errorf("%s Taints = %v, want the profile taint", name, group.Taints)
+		}
+	}
+	if len(user.Taints) != len(worker.Taints) || user.Taints[0] != worker.Taints[0] {
+		t.Errorf("user and worker taints differ: %v vs %v", user.Taints, worker.Taints)
+	}
+
+	big := vars.NodeGroups["big"]
+	if *big.AMIType != ami {
+		t.Errorf("big AMIType = %s, want %s from the profile", *big.AMIType, ami)
+	}
+	if *big.DiskSize != ownDisk {
+		t.Errorf("big DiskSize = %d, want its own %d", *big.DiskSize, ownDisk)
+	}
+	if big.Labels["tier"] != "big" || big.Labels["profile"] != "hardened" {
+		t.Errorf("big Labels = %v, want own tier over the profile's", big.Labels)
+	}
+	if len(big.Taints) != 1 || big.Taints[0].Value != "big" {
+		t.Errorf("big Taints = %v, want only its own dedicated taint", big.Taints)
+	}
+
+	general := vars.NodeGroups["general"]
+	if *general.AMIType != "AL2023_x86_64_STANDARD" || general.DiskSize != nil {
+		t.Errorf("general picked up profile settings: AMIType=%s DiskSize=%v", *general.AMIType, general.DiskSize)
+	}
+
+	if cfg.NodeGroups["user"].DiskSize != nil || cfg.NodeGroups["big"].Labels["profile"] != "" {
+		t.Error("toTFVars mutated the configured node groups")
+	}
+}
+
+func TestToTFVarsPreservesAvailabilityZoneOrder(t *testing.T) {
+	azs := []string{"us-west-2c", "us-west-2a", "us-west-2b"}
+	cfg := Config{
+		Region:            "us-west-2",
+		KubernetesVersion: "1.34",
+		AvailabilityZones: azs,
+		NodeGroups:        map[string]NodeGroup{"general": {Instance: "m5.large"}},
+	}
+
+	data, err := json.Marshal(cfg.toTFVars("test", "", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"availability_zones":["us-west-2c","us-west-2a","us-west-2b"]`) {
+		t.Errorf("availability_zones reordered in tfvars JSON: %s", data)
+	}
+}
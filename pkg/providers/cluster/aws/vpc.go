@@ -0,0 +1,174 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/awssdk"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/netutil"
+)
+
+// VPCClient defines the EC2 operations needed for VPC pre-flight checks.
+type VPCClient interface {
+	DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error)
+}
+
+func newVPCClient(ctx context.Context, region string) (VPCClient, error) {
+	cfg, err := awssdk.LoadConfig(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return ec2.NewFromConfig(cfg), nil
+}
+
+// validateAvailabilityZones checks the explicit availability_zones list. The
+// list is passed to the module in the order given and subnets are assigned
+// to AZs by index, so a duplicate would shift every later subnet, and an AZ
+// from another region would fail only at apply time.
+func validateAvailabilityZones(region string, azs []string) error {
+	seen := make(map[string]int, len(azs))
+	for i, az := range azs {
+		if !strings.HasPrefix(az, region) || len(az) == len(region) {
+			return fmt.Errorf("invalid availability_zones[%d] %q: not an availability zone in region %s", i, az, region)
+		}
+		if j, ok := seen[az]; ok {
+			return fmt.Errorf("availability_zones[%d] duplicates availability_zones[%d] %q", i, j, az)
+		}
+		seen[az] = i
+	}
+	return nil
+}
+
+// minControlPlaneAZs is the EKS minimum number of availability zones for the
+// cluster's control plane subnets.
+const minControlPlaneAZs = 2
+
+// validatePeeredCIDRs checks that each peered_cidrs entry is a valid CIDR and
+// that none overlaps vpcCIDR. Routing to a peered network whose range
+// overlaps the cluster VPC silently fails, so this is a hard error.
+func validatePeeredCIDRs(vpcCIDR string, peered []string) error {
+	vpc, err := netutil.ParseCIDR(vpcCIDR)
+	if err != nil {
+		return fmt.Errorf("invalid vpc_cidr_block: %w", err)
+	}
+	for i, s := range peered {
+		p, err := netutil.ParseCIDR(s)
+		if err != nil {
+			return fmt.Errorf("invalid peered_cidrs[%d]: %w", i, err)
+		}
+		if netutil.Overlaps(vpc, p) {
+			return fmt.Errorf("vpc_cidr_block %s overlaps peered_cidrs[%d] %s: choose a non-overlapping vpc_cidr_block", vpc, i, p)
+		}
+	}
+	return nil
+}
+
+// preflightVPCOverlap runs checkVPCOverlap against the live account, skipping
+// the VPC of the project's cluster if it has already been deployed.
+func (p *Provider) preflightVPCOverlap(ctx context.Context, projectName string, awsCfg *Config) error {
+	eksClient, err := newEKSClient(ctx, awsCfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to create EKS client: %w", err)
+	}
+	ownVPCID, err := clusterVPCID(ctx, eksClient, projectName)
+	if err != nil {
+		return err
+	}
+	vpcClient, err := newVPCClient(ctx, awsCfg.Region)
+	if err != nil {
+		return err
+	}
+	return checkVPCOverlap(ctx, vpcClient, awsCfg.VPCCIDRBlock, ownVPCID)
+}
+
+// checkVPCOverlap lists the VPCs in the account and region and returns an
+// error naming the first one with a CIDR block that overlaps vpcCIDR. The VPC
+// with ID ownVPCID (the cluster's own VPC on a re-deploy) is skipped.
+func checkVPCOverlap(ctx context.Context, client VPCClient, vpcCIDR, ownVPCID string) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "aws.checkVPCOverlap")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("vpc_cidr_block", vpcCIDR))
+
+	want, err := netutil.ParseCIDR(vpcCIDR)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("invalid vpc_cidr_block: %w", err)
+	}
+
+	paginator := ec2.NewDescribeVpcsPaginator(client, &ec2.DescribeVpcsInput{})
+	checked := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to list VPCs: %w", err)
+		}
+		for _, vpc := range page.Vpcs {
+			id := aws.ToString(vpc.VpcId)
+			if id == ownVPCID {
+				continue
+			}
+			checked++
+			for _, block := range vpcCIDRBlocks(vpc) {
+				other, err := netutil.ParseCIDR(block)
+				if err != nil {
+					continue
+				}
+				if netutil.Overlaps(want, other) {
+					err := fmt.Errorf("vpc_cidr_block %s overlaps %s of existing VPC %s: peering or routing between them will not work", want, other, id)
+					span.RecordError(err)
+					return err
+				}
+			}
+		}
+	}
+
+	span.SetAttributes(attribute.Int("vpcs_checked", checked))
+	return nil
+}
+
+// vpcCIDRBlocks returns the IPv4 CIDR blocks currently associated with vpc.
+func vpcCIDRBlocks(vpc ec2types.Vpc) []string {
+	var blocks []string
+	for _, assoc := range vpc.CidrBlockAssociationSet {
+		if assoc.CidrBlockState != nil && assoc.CidrBlockState.State != ec2types.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		if assoc.CidrBlock != nil {
+			blocks = append(blocks, *assoc.CidrBlock)
+		}
+	}
+	if len(blocks) == 0 && vpc.CidrBlock != nil {
+		blocks = append(blocks, *vpc.CidrBlock)
+	}
+	return blocks
+}
+
+// clusterVPCID returns the VPC of an existing EKS cluster named clusterName,
+// or "" when the cluster does not exist yet.
+func clusterVPCID(ctx context.Context, client EKSClient, clusterName string) (string, error) {
+	out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: &clusterName})
+	if err != nil {
+		var notFound *ekstypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to describe cluster %s: %w", clusterName, err)
+	}
+	if out.Cluster == nil || out.Cluster.ResourcesVpcConfig == nil {
+		return "", nil
+	}
+	return aws.ToString(out.Cluster.ResourcesVpcConfig.VpcId), nil
+}
@@ -0,0 +1,207 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// mockVPCClient implements VPCClient, returning one page per entry in pages.
+type mockVPCClient struct {
+	pages [][]ec2types.Vpc
+	err   error
+}
+
+func (m *mockVPCClient) DescribeVpcs(_ context.Context, params *ec2.DescribeVpcsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	i := 0
+	if params.NextToken != nil {
+		i = len(*params.NextToken)
+	}
+	out := &ec2.DescribeVpcsOutput{}
+	if i < len(m.pages) {
+		out.Vpcs = m.pages[i]
+	}
+	if i+1 < len(m.pages) {
+		out.NextToken = aws.String(strings.Repeat("x", i+1))
+	}
+	return out, nil
+}
+
+func testVPC(id string, cidrs ...string) ec2types.Vpc {
+	vpc := ec2types.Vpc{VpcId: aws.String(id), CidrBlock: aws.String(cidrs[0])}
+	for _, c := range cidrs {
+		vpc.CidrBlockAssociationSet = append(vpc.CidrBlockAssociationSet, ec2types.VpcCidrBlockAssociation{
+			CidrBlock:      aws.String(c),
+			CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeAssociated},
+		})
+	}
+	return vpc
+}
+
+func TestValidatePeeredCIDRs(t *testing.T) {
+	tests := []struct {
+		name      string
+		vpcCIDR   string
+		peered    []string
+		errSubstr string
+	}{
+		{name: "no peered cidrs", vpcCIDR: "10.10.0.0/16"},
+		{name: "disjoint", vpcCIDR: "10.10.0.0/16", peered: []string{"10.20.0.0/16", "192.168.0.0/24"}},
+		{name: "overlap", vpcCIDR: "10.10.0.0/16", peered: []string{"10.20.0.0/16", "10.10.128.0/20"}, errSubstr: "overlaps peered_cidrs[1] 10.10.128.0/20"},
+		{name: "peered contains vpc", vpcCIDR: "10.10.0.0/16", peered: []string{"10.0.0.0/8"}, errSubstr: "overlaps peered_cidrs[0]"},
+		{name: "invalid entry", vpcCIDR: "10.10.0.0/16", peered: []string{"not-a-cidr"}, errSubstr: "invalid peered_cidrs[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePeeredCIDRs(tt.vpcCIDR, tt.peered)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestCheckVPCOverlap(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    *mockVPCClient
+		vpcCIDR   string
+		ownVPCID  string
+		errSubstr string
+	}{
+		{
+			name:    "no existing vpcs",
+			client:  &mockVPCClient{},
+			vpcCIDR: "10.10.0.0/16",
+		},
+		{
+			name:    "disjoint vpcs",
+			client:  &mockVPCClient{pages: [][]ec2types.Vpc{{testVPC("vpc-a", "172.31.0.0/16"), testVPC("vpc-b", "10.20.0.0/16")}}},
+			vpcCIDR: "10.10.0.0/16",
+		},
+		{
+			name:      "overlapping vpc",
+			client:    &mockVPCClient{pages: [][]ec2types.Vpc{{testVPC("vpc-a", "172.31.0.0/16"), testVPC("vpc-b", "10.10.64.0/18")}}},
+			vpcCIDR:   "10.10.0.0/16",
+			errSubstr: "overlaps 10.10.64.0/18 of existing VPC vpc-b",
+		},
+		{
+			name:      "overlapping secondary block on a later page",
+			client:    &mockVPCClient{pages: [][]ec2types.Vpc{{testVPC("vpc-a", "172.31.0.0/16")}, {testVPC("vpc-c", "10.30.0.0/16", "10.10.0.0/20")}}},
+			vpcCIDR:   "10.10.0.0/16",
+			errSubstr: "existing VPC vpc-c",
+		},
+		{
+			name:     "own vpc is skipped",
+			client:   &mockVPCClient{pages: [][]ec2types.Vpc{{testVPC("vpc-own", "10.10.0.0/16")}}},
+			vpcCIDR:  "10.10.0.0/16",
+			ownVPCID: "vpc-own",
+		},
+		{
+			name:      "list error",
+			client:    &mockVPCClient{err: errors.New("access denied")},
+			vpcCIDR:   "10.10.0.0/16",
+			errSubstr: "failed to list VPCs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVPCOverlap(context.Background(), tt.client, tt.vpcCIDR, tt.ownVPCID)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestVPCCIDRBlocksSkipsDisassociated(t *testing.T) {
+	vpc := testVPC("vpc-a", "10.10.0.0/16", "10.20.0.0/16")
+	vpc.CidrBlockAssociationSet[1].CidrBlockState.State = ec2types.VpcCidrBlockStateCodeDisassociated
+
+	got := vpcCIDRBlocks(vpc)
+	if len(got) != 1 || got[0] != "10.10.0.0/16" {
+		t.Errorf("vpcCIDRBlocks() = %v, want [10.10.0.0/16]", got)
+	}
+}
+
+func TestClusterVPCID(t *testing.T) {
+	t.Run("existing cluster", func(t *testing.T) {
+		client := &mockEKSClient{
+			DescribeClusterFunc: func(_ context.Context, _ *eks.DescribeClusterInput, _ ...func(*eks.Options)) (*eks.DescribeClusterOutput, error) {
+				return &eks.DescribeClusterOutput{Cluster: &ekstypes.Cluster{
+					ResourcesVpcConfig: &ekstypes.VpcConfigResponse{VpcId: aws.String("vpc-own")},
+				}}, nil
+			},
+		}
+		got, err := clusterVPCID(context.Background(), client, "demo")
+		if err != nil || got != "vpc-own" {
+			t.Fatalf("clusterVPCID() = %q, %v; want vpc-own, nil", got, err)
+		}
+	})
+
+	t.Run("cluster not found", func(t *testing.T) {
+		client := &mockEKSClient{
+			DescribeClusterFunc: func(_ context.Context, _ *eks.DescribeClusterInput, _ ...func(*eks.Options)) (*eks.DescribeClusterOutput, error) {
+				return nil, &ekstypes.ResourceNotFoundException{Message: aws.String("no such cluster")}
+			},
+		}
+		got, err := clusterVPCID(context.Background(), client, "demo")
+		if err != nil || got != "" {
+			t.Fatalf("clusterVPCID() = %q, %v; want empty, nil", got, err)
+		}
+	})
+}
+
+func TestValidateAvailabilityZones(t *testing.T) {
+	tests := []struct {
+		name      string
+		azs       []string
+		errSubstr string
+	}{
+		{name: "unset", azs: nil},
+		{name: "ordered list", azs: []string{"us-west-2c", "us-west-2a", "us-west-2b"}},
+		{name: "local zone", azs: []string{"us-west-2a", "us-west-2-lax-1a"}},
+		{name: "other region", azs: []string{"us-west-2a", "us-east-1a"}, errSubstr: "availability_zones[1] \"us-east-1a\": not an availability zone in region us-west-2"},
+		{name: "region name", azs: []string{"us-west-2"}, errSubstr: "not an availability zone"},
+		{name: "duplicate", azs: []string{"us-west-2a", "us-west-2b", "us-west-2a"}, errSubstr: "availability_zones[2] duplicates availability_zones[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAvailabilityZones("us-west-2", tt.azs)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+)
+
+// containerMetadataManagedBy is the metadata key marking a blob container as
+// NIC-managed. Container metadata names must be valid C# identifiers, so the
+// resource tag key (tagManagedBy) cannot be reused here.
+const containerMetadataManagedBy = "nic_managed_by"
+
+// blobContainerStore is the objectstore.Store for Azure Blob Storage: a
+// "bucket" is a private container in an existing storage account, which also
+// fixes its location, so BucketSpec.Region is ignored. BucketSpec.Tags are
+// written as container metadata.
+type blobContainerStore struct {
+	client        blobContainersAPI
+	resourceGroup string
+	account       string
+}
+
+var _ objectstore.Store = (*blobContainerStore)(nil)
+
+func newBlobContainerStore(client blobContainersAPI, resourceGroup, account string) *blobContainerStore {
+	return &blobContainerStore{client: client, resourceGroup: resourceGroup, account: account}
+}
+
+// EnsureBucket creates container spec.Name unless it already exists.
+func (s *blobContainerStore) EnsureBucket(ctx context.Context, spec objectstore.BucketSpec) (bool, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "azure.EnsureBlobContainer")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("storage_account", s.account),
+		attribute.String("container", spec.Name),
+	)
+
+	_, err := s.client.Get(ctx, s.resourceGroup, s.account, spec.Name, nil)
+	if err == nil {
+		return false, nil
+	}
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != 404 {
+		err = fmt.Errorf("get container %q: %w", spec.Name, err)
+		span.RecordError(err)
+		return false, err
+	}
+
+	metadata := make(map[string]*string, len(spec.Tags)+1)
+	for k, v := range spec.Tags {
+		metadata[k] = to.Ptr(v)
+	}
+	metadata[containerMetadataManagedBy] = to.Ptr(managedByValue)
+
+	_, err = s.client.Create(ctx, s.resourceGroup, s.account, spec.Name, armstorage.BlobContainer{
+		ContainerProperties: &armstorage.ContainerProperties{
+			PublicAccess: to.Ptr(armstorage.PublicAccessNone),
+			Metadata:     metadata,
+		},
+	}, nil)
+	if err != nil {
+		err = fmt.Errorf("create container %q: %w", spec.Name, err)
+		span.RecordError(err)
+		return false, err
+	}
+	span.SetAttributes(attribute.Bool("container_created", true))
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+)
+
+// fakeBlobContainers keeps containers in memory, keyed by name.
+type fakeBlobContainers struct {
+	containers map[string]armstorage.BlobContainer
+	creates    int
+}
+
+func (f *fakeBlobContainers) Get(_ context.Context, _, _, name string, _ *armstorage.BlobContainersClientGetOptions) (armstorage.BlobContainersClientGetResponse, error) {
+	c, ok := f.containers[name]
+	if !ok {
+		return armstorage.BlobContainersClientGetResponse{}, responseError(http.StatusNotFound)
+	}
+	return armstorage.BlobContainersClientGetResponse{BlobContainer: c}, nil
+}
+
+func (f *fakeBlobContainers) Create(_ context.Context, _, _, name string, c armstorage.BlobContainer, _ *armstorage.BlobContainersClientCreateOptions) (armstorage.BlobContainersClientCreateResponse, error) {
+	f.containers[name] = c
+	f.creates++
+	return armstorage.BlobContainersClientCreateResponse{BlobContainer: c}, nil
+}
+
+func TestBlobContainerStoreEnsureBucketIdempotent(t *testing.T) {
+	api := &fakeBlobContainers{containers: map[string]armstorage.BlobContainer{}}
+	store := newBlobContainerStore(api, "nic-tfstate-rg", "nictfstate0123456789abcd")
+	spec := objectstore.BucketSpec{Name: "longhorn-backups", Tags: map[string]string{"team": "data"}}
+
+	for i, want := range []bool{true, false} {
+		created, err := store.EnsureBucket(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("EnsureBucket() call %d error = %v", i+1, err)
+		}
+		if created != want {
+			t.Errorf("EnsureBucket() call %d created = %v, want %v", i+1, created, want)
+		}
+	}
+	if api.creates != 1 {
+		t.Errorf("Create called %d times, want 1", api.creates)
+	}
+
+	props := api.containers[spec.Name].ContainerProperties
+	if *props.PublicAccess != armstorage.PublicAccessNone {
+		t.Errorf("PublicAccess = %v, want None", *props.PublicAccess)
+	}
+	if got := props.Metadata[containerMetadataManagedBy]; got == nil || *got != managedByValue {
+		t.Errorf("managed-by metadata = %v, want %q", got, managedByValue)
+	}
+	if got := props.Metadata["team"]; got == nil || *got != "data" {
+		t.Errorf("team metadata = %v, want %q", got, "data")
+	}
+}
+
+func TestBlobContainerStoreEnsureBucketGetError(t *testing.T) {
+	api := &erroringBlobContainers{}
+	store := newBlobContainerStore(api, "rg", "sa")
+	if _, err := store.EnsureBucket(context.Background(), objectstore.BucketSpec{Name: "tfstate"}); err == nil {
+		t.Fatal("EnsureBucket() error = nil, want the Get failure")
+	}
+	if api.created {
+		t.Error("Create must not be called when Get fails with anything but 404")
+	}
+}
+
+// erroringBlobContainers fails every Get with a 403.
+type erroringBlobContainers struct{ created bool }
+
+func (f *erroringBlobContainers) Get(context.Context, string, string, string, *armstorage.BlobContainersClientGetOptions) (armstorage.BlobContainersClientGetResponse, error) {
+	return armstorage.BlobContainersClientGetResponse{}, responseError(http.StatusForbidden)
+}
+
+func (f *erroringBlobContainers) Create(context.Context, string, string, string, armstorage.BlobContainer, *armstorage.BlobContainersClientCreateOptions) (armstorage.BlobContainersClientCreateResponse, error) {
+	f.created = true
+	return armstorage.BlobContainersClientCreateResponse{}, nil
+}
@@ -3,8 +3,11 @@ package azure
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"regexp"
 	"strings"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/netutil"
 )
 
 // Config is the user-facing Azure cluster configuration as parsed from the
@@ -37,7 +40,10 @@ type NetworkConfig struct {
 	DNSServiceIP        string `yaml:"dns_service_ip,omitempty"`
 	// DataPlane selects the AKS network dataplane: "azure" (default) or
 	// "cilium" (Azure CNI Powered by Cilium).
-	DataPlane            string `yaml:"dataplane,omitempty"`
+	DataPlane string `yaml:"dataplane,omitempty"`
+	// Plugin selects the AKS network plugin: "azure" (default, Azure CNI
+	// overlay) or "kubenet".
+	Plugin               string `yaml:"plugin,omitempty"`
 	ExistingVNetID       string `yaml:"existing_vnet_id,omitempty"`
 	ExistingNodeSubnetID string `yaml:"existing_node_subnet_id,omitempty"`
 }
@@ -86,6 +92,17 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cluster.azure.kubernetes_version %q is not a valid semver-ish version (expected e.g. \"1.34\" or \"1.34.0\")", c.KubernetesVersion)
 	}
 
+	for i, r := range c.AuthorizedIPRanges {
+		if _, _, err := net.ParseCIDR(r); err != nil && net.ParseIP(r) == nil {
+			return fmt.Errorf("cluster.azure.authorized_ip_ranges[%d]: %q is not a valid CIDR or IP address", i, r)
+		}
+	}
+	// AKS rejects API server authorized ranges on private clusters; the
+	// endpoint is only reachable from the VNet anyway.
+	if c.PrivateClusterEnabled && len(c.AuthorizedIPRanges) > 0 {
+		return fmt.Errorf("cluster.azure.authorized_ip_ranges cannot be used with private_cluster_enabled: true")
+	}
+
 	if c.Network != nil {
 		if err := c.Network.validate(); err != nil {
 			return err
@@ -116,6 +133,17 @@ func (n *NetworkConfig) validate() error {
 		return fmt.Errorf("cluster.azure.network.dataplane %q is invalid (expected %q or %q)", n.DataPlane, dataPlaneAzure, dataPlaneCilium)
 	}
 
+	switch n.Plugin {
+	case "", networkPluginAzure, networkPluginKubenet:
+	default:
+		return fmt.Errorf("cluster.azure.network.plugin %q is invalid (expected %q or %q)", n.Plugin, networkPluginAzure, networkPluginKubenet)
+	}
+
+	// The cilium dataplane only runs on Azure CNI.
+	if n.Plugin == networkPluginKubenet && n.DataPlane == dataPlaneCilium {
+		return fmt.Errorf("cluster.azure.network.dataplane %q requires network.plugin: %q", dataPlaneCilium, networkPluginAzure)
+	}
+
 	// BYO networking: both ID fields must be set together.
 	if (n.ExistingVNetID != "") != (n.ExistingNodeSubnetID != "") {
 		if n.ExistingVNetID == "" {
@@ -124,22 +152,52 @@ func (n *NetworkConfig) validate() error {
 		return fmt.Errorf("cluster.azure.network.existing_node_subnet_id is required when existing_vnet_id is set")
 	}
 
-	for label, cidr := range map[string]string{
-		"vnet_cidr_block":        n.VNetCIDRBlock,
-		"node_subnet_cidr_block": n.NodeSubnetCIDRBlock,
-		"pod_cidr":               n.PodCIDR,
-		"service_cidr":           n.ServiceCIDR,
+	prefixes := make(map[string]netip.Prefix, 4)
+	for _, f := range []struct{ label, cidr string }{
+		{"vnet_cidr_block", n.VNetCIDRBlock},
+		{"node_subnet_cidr_block", n.NodeSubnetCIDRBlock},
+		{"pod_cidr", n.PodCIDR},
+		{"service_cidr", n.ServiceCIDR},
 	} {
-		if cidr == "" {
+		if f.cidr == "" {
 			continue
 		}
-		if _, _, err := net.ParseCIDR(cidr); err != nil {
-			return fmt.Errorf("cluster.azure.network.%s: %w", label, err)
+		p, err := netutil.ParseCIDR(f.cidr)
+		if err != nil {
+			return fmt.Errorf("cluster.azure.network.%s: %w", f.label, err)
+		}
+		prefixes[f.label] = p
+	}
+
+	// Only user-supplied ranges are cross-checked; the module defaults are
+	// known to be mutually consistent.
+	vnet, hasVNet := prefixes["vnet_cidr_block"]
+	subnet, hasSubnet := prefixes["node_subnet_cidr_block"]
+	if hasVNet && hasSubnet && !netutil.Contains(vnet, subnet) {
+		return fmt.Errorf("cluster.azure.network.node_subnet_cidr_block %s is not within vnet_cidr_block %s", subnet, vnet)
+	}
+	// With overlay networking pod and service ranges are virtual, but they
+	// still must not collide with the node subnet or each other.
+	for _, pair := range [][2]string{
+		{"pod_cidr", "node_subnet_cidr_block"},
+		{"service_cidr", "node_subnet_cidr_block"},
+		{"pod_cidr", "service_cidr"},
+	} {
+		a, okA := prefixes[pair[0]]
+		b, okB := prefixes[pair[1]]
+		if okA && okB && netutil.Overlaps(a, b) {
+			return fmt.Errorf("cluster.azure.network.%s %s overlaps %s %s", pair[0], a, pair[1], b)
 		}
 	}
 
-	if n.DNSServiceIP != "" && net.ParseIP(n.DNSServiceIP) == nil {
-		return fmt.Errorf("cluster.azure.network.dns_service_ip: %q is not a valid IP address", n.DNSServiceIP)
+	if n.DNSServiceIP != "" {
+		ip, err := netip.ParseAddr(n.DNSServiceIP)
+		if err != nil {
+			return fmt.Errorf("cluster.azure.network.dns_service_ip: %q is not a valid IP address", n.DNSServiceIP)
+		}
+		if svc, ok := prefixes["service_cidr"]; ok && !svc.Contains(ip) {
+			return fmt.Errorf("cluster.azure.network.dns_service_ip %s is not within service_cidr %s", ip, svc)
+		}
 	}
 
 	return nil
@@ -156,6 +156,120 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid kubenet",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network:    &NetworkConfig{Plugin: networkPluginKubenet},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid plugin",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network:    &NetworkConfig{Plugin: "flannel"},
+			},
+			wantErr:   true,
+			wantInErr: "network.plugin",
+		},
+		{
+			name: "cilium dataplane on kubenet",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network:    &NetworkConfig{Plugin: networkPluginKubenet, DataPlane: dataPlaneCilium},
+			},
+			wantErr:   true,
+			wantInErr: "network.plugin",
+		},
+		{
+			name: "authorized IP ranges",
+			cfg: Config{
+				Region:             "eastus",
+				NodeGroups:         map[string]NodeGroup{"system": validNodeGroup},
+				AuthorizedIPRanges: []string{"203.0.113.0/24", "198.51.100.7"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid authorized IP range",
+			cfg: Config{
+				Region:             "eastus",
+				NodeGroups:         map[string]NodeGroup{"system": validNodeGroup},
+				AuthorizedIPRanges: []string{"203.0.113.0/33"},
+			},
+			wantErr:   true,
+			wantInErr: "authorized_ip_ranges[0]",
+		},
+		{
+			name: "authorized IP ranges on private cluster",
+			cfg: Config{
+				Region:                "eastus",
+				NodeGroups:            map[string]NodeGroup{"system": validNodeGroup},
+				PrivateClusterEnabled: true,
+				AuthorizedIPRanges:    []string{"203.0.113.0/24"},
+			},
+			wantErr:   true,
+			wantInErr: "private_cluster_enabled",
+		},
+		{
+			name: "consistent custom network",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network: &NetworkConfig{
+					VNetCIDRBlock:       "10.0.0.0/16",
+					NodeSubnetCIDRBlock: "10.0.0.0/22",
+					PodCIDR:             "10.244.0.0/16",
+					ServiceCIDR:         "10.0.16.0/22",
+					DNSServiceIP:        "10.0.16.10",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "node subnet outside vnet",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network:    &NetworkConfig{VNetCIDRBlock: "10.0.0.0/16", NodeSubnetCIDRBlock: "10.1.0.0/22"},
+			},
+			wantErr:   true,
+			wantInErr: "not within vnet_cidr_block",
+		},
+		{
+			name: "pod CIDR overlaps node subnet",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network:    &NetworkConfig{NodeSubnetCIDRBlock: "10.0.0.0/22", PodCIDR: "10.0.0.0/16"},
+			},
+			wantErr:   true,
+			wantInErr: "pod_cidr",
+		},
+		{
+			name: "service CIDR overlaps pod CIDR",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network:    &NetworkConfig{PodCIDR: "10.244.0.0/16", ServiceCIDR: "10.244.16.0/22"},
+			},
+			wantErr:   true,
+			wantInErr: "overlaps",
+		},
+		{
+			name: "dns service IP outside service CIDR",
+			cfg: Config{
+				Region:     "eastus",
+				NodeGroups: map[string]NodeGroup{"system": validNodeGroup},
+				Network:    &NetworkConfig{ServiceCIDR: "10.0.16.0/22", DNSServiceIP: "10.0.32.10"},
+			},
+			wantErr:   true,
+			wantInErr: "dns_service_ip",
+		},
 	}
 
 	for _, tc := range cases {
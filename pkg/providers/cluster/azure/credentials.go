@@ -0,0 +1,52 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"go.opentelemetry.io/otel"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// armScope is the token scope for Azure Resource Manager, which every
+// operation of the provider goes through.
+const armScope = "https://management.azure.com/.default"
+
+// CheckCredentials verifies that the default Azure credential chain can get
+// a Resource Manager token, so missing credentials are reported before
+// deploy or destroy starts rather than deep inside it.
+func (p *Provider) CheckCredentials(ctx context.Context, _ *config.ClusterConfig) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "azure.CheckCredentials")
+	defer span.End()
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		span.RecordError(err)
+		return noCredentialsError(err)
+	}
+	if err := checkCredentials(ctx, cred); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// checkCredentials requests a Resource Manager token from cred.
+func checkCredentials(ctx context.Context, cred azcore.TokenCredential) error {
+	if _, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{armScope}}); err != nil {
+		return noCredentialsError(err)
+	}
+	return nil
+}
+
+// noCredentialsError wraps a credential chain failure in the user-facing
+// "no credentials" message.
+func noCredentialsError(err error) error {
+	return fmt.Errorf("%w: no usable Azure credentials found; run `az login`, or set AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET for a service principal: %w", cluster.ErrCredentials, err)
+}
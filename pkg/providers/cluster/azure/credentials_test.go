@@ -0,0 +1,45 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// fakeTokenCredential returns err from GetToken, or a token when err is nil.
+type fakeTokenCredential struct {
+	err    error
+	scopes []string
+}
+
+func (f *fakeTokenCredential) GetToken(_ context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.scopes = opts.Scopes
+	if f.err != nil {
+		return azcore.AccessToken{}, f.err
+	}
+	return azcore.AccessToken{Token: "token"}, nil
+}
+
+func TestCheckCredentials(t *testing.T) {
+	cred := &fakeTokenCredential{}
+	if err := checkCredentials(context.Background(), cred); err != nil {
+		t.Fatalf("checkCredentials() error = %v", err)
+	}
+	if len(cred.scopes) != 1 || cred.scopes[0] != armScope {
+		t.Errorf("requested scopes = %v, want [%s]", cred.scopes, armScope)
+	}
+
+	err := checkCredentials(context.Background(), &fakeTokenCredential{err: errors.New("DefaultAzureCredential: failed to acquire a token")})
+	if !errors.Is(err, cluster.ErrCredentials) {
+		t.Errorf("checkCredentials() error = %v, want ErrCredentials", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "run `az login`") {
+		t.Errorf("checkCredentials() error = %v, want the az login hint", err)
+	}
+}
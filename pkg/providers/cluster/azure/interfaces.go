@@ -6,6 +6,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v6"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 )
 
 // managedClustersAPI is the subset of armcontainerservice.ManagedClustersClient
@@ -25,3 +26,29 @@ type resourcesAPI interface {
 		options *armresources.ClientListOptions,
 	) *runtime.Pager[armresources.ClientListResponse]
 }
+
+// resourceGroupsAPI is the subset of armresources.ResourceGroupsClient used to
+// check a user-supplied resource group before deploying into it.
+type resourceGroupsAPI interface {
+	Get(
+		ctx context.Context,
+		resourceGroupName string,
+		options *armresources.ResourceGroupsClientGetOptions,
+	) (armresources.ResourceGroupsClientGetResponse, error)
+}
+
+// blobContainersAPI is the subset of armstorage.BlobContainersClient used by
+// blobContainerStore.
+type blobContainersAPI interface {
+	Get(
+		ctx context.Context,
+		resourceGroupName, accountName, containerName string,
+		options *armstorage.BlobContainersClientGetOptions,
+	) (armstorage.BlobContainersClientGetResponse, error)
+	Create(
+		ctx context.Context,
+		resourceGroupName, accountName, containerName string,
+		blobContainer armstorage.BlobContainer,
+		options *armstorage.BlobContainersClientCreateOptions,
+	) (armstorage.BlobContainersClientCreateResponse, error)
+}
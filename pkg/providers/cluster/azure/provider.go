@@ -131,6 +131,20 @@ func (p *Provider) Deploy(ctx context.Context, projectName string, clusterConfig
 		return err
 	}
 
+	// Deploying into a resource group NIC doesn't own: fail before touching
+	// the state backend if it's missing or unreadable. This is read-only, so
+	// dry runs check it too.
+	if cfg.usesExistingResourceGroup() {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Verifying existing resource group").
+			WithResource("resource-group").
+			WithAction("verify").
+			WithMetadata("resource_group", cfg.ResourceGroupName))
+		if err := verifyExistingResourceGroup(ctx, subID, cfg.ResourceGroupName); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
 	// A dry run must not create cloud resources. If the state backend already
 	// exists we read from it; if not, initTofuBackend falls back to a local
 	// backend below. A real deploy always bootstraps the backend first.
@@ -185,10 +199,14 @@ func (p *Provider) Deploy(ctx context.Context, projectName string, clusterConfig
 			WithResource("tofu").
 			WithAction("plan").
 			WithMetadata("cluster_name", projectName))
-		if _, err := tf.Plan(ctx); err != nil {
+		hasChanges, err := tf.Plan(ctx)
+		if err != nil {
 			span.RecordError(err)
 			return fmt.Errorf("tofu plan: %w", err)
 		}
+		if hasChanges && opts.FailOnChanges {
+			return cluster.ErrChangesPending
+		}
 		return nil
 	}
 
@@ -327,6 +345,13 @@ func (p *Provider) Destroy(ctx context.Context, projectName string, clusterConfi
 		return fmt.Errorf("tofu destroy: %w", err)
 	}
 
+	if cfg.usesExistingResourceGroup() {
+		status.Send(ctx, status.NewUpdate(status.LevelInfo, "Leaving existing resource group in place").
+			WithResource("resource-group").
+			WithAction("destroy").
+			WithMetadata("resource_group", cfg.ResourceGroupName))
+	}
+
 	// Best-effort orphan check (non-fatal — user can rerun nic destroy or az resource delete).
 	if err := reportOrphans(ctx, subID, projectName); err != nil {
 		status.Send(ctx, status.NewUpdate(status.LevelWarning, "Orphan cleanup encountered issues").
@@ -387,5 +412,8 @@ func (p *Provider) InfraSettings(_ *config.ClusterConfig) cluster.InfraSettings
 		StorageClass:    "managed-csi",
 		NeedsMetalLB:    false,
 		LonghornEnabled: false,
+		InternalLoadBalancerAnnotations: map[string]string{
+			"service.beta.kubernetes.io/azure-load-balancer-internal": "true",
+		},
 	}
 }
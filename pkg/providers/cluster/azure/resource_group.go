@@ -0,0 +1,58 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// usesExistingResourceGroup reports whether the cluster is deployed into a
+// user-supplied resource group. NIC never creates such a group, and the
+// Terraform module only reads it, so `tofu destroy` leaves it in place.
+func (c *Config) usesExistingResourceGroup() bool {
+	return c.ResourceGroupName != ""
+}
+
+// verifyExistingResourceGroup checks that the configured resource group exists
+// and that the current Azure credentials can read it.
+func verifyExistingResourceGroup(ctx context.Context, subscriptionID, name string) error {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return fmt.Errorf("azure credentials: %w: %w", cluster.ErrCredentials, err)
+	}
+	client, err := armresources.NewResourceGroupsClient(subscriptionID, cred, nil)
+	if err != nil {
+		return fmt.Errorf("create resource groups client: %w", err)
+	}
+	return checkExistingResourceGroup(ctx, client, name)
+}
+
+// checkExistingResourceGroup is the unit-testable inner of
+// verifyExistingResourceGroup.
+func checkExistingResourceGroup(ctx context.Context, api resourceGroupsAPI, name string) error {
+	resp, err := api.Get(ctx, name, nil)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) {
+			switch respErr.StatusCode {
+			case http.StatusNotFound:
+				return fmt.Errorf("cluster.azure.resource_group_name %q does not exist in the subscription; create it first or unset resource_group_name to let NIC create one", name)
+			case http.StatusForbidden, http.StatusUnauthorized:
+				return fmt.Errorf("read resource group %q: %w: %w", name, cluster.ErrCredentials, err)
+			}
+		}
+		return fmt.Errorf("get resource group %q: %w", name, err)
+	}
+	if p := resp.Properties; p != nil && p.ProvisioningState != nil && strings.EqualFold(*p.ProvisioningState, "Deleting") {
+		return fmt.Errorf("cluster.azure.resource_group_name %q is being deleted", name)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
+)
+
+// fakeResourceGroups returns state for every Get, or err when set.
+type fakeResourceGroups struct {
+	state string
+	err   error
+}
+
+func (f fakeResourceGroups) Get(_ context.Context, name string, _ *armresources.ResourceGroupsClientGetOptions) (armresources.ResourceGroupsClientGetResponse, error) {
+	if f.err != nil {
+		return armresources.ResourceGroupsClientGetResponse{}, f.err
+	}
+	return armresources.ResourceGroupsClientGetResponse{ResourceGroup: armresources.ResourceGroup{
+		Name:       to.Ptr(name),
+		Properties: &armresources.ResourceGroupProperties{ProvisioningState: to.Ptr(f.state)},
+	}}, nil
+}
+
+func responseError(code int) error {
+	req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/resourceGroups/my-rg", nil)
+	return &azcore.ResponseError{StatusCode: code, RawResponse: &http.Response{StatusCode: code, Request: req}}
+}
+
+func TestCheckExistingResourceGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		api       fakeResourceGroups
+		errSubstr string
+		wantCreds bool
+	}{
+		{name: "exists", api: fakeResourceGroups{state: "Succeeded"}},
+		{name: "missing", api: fakeResourceGroups{err: responseError(http.StatusNotFound)}, errSubstr: "does not exist"},
+		{name: "forbidden", api: fakeResourceGroups{err: responseError(http.StatusForbidden)}, errSubstr: "read resource group", wantCreds: true},
+		{name: "being deleted", api: fakeResourceGroups{state: "Deleting"}, errSubstr: "being deleted"},
+		{name: "other failure", api: fakeResourceGroups{err: errors.New("connection reset")}, errSubstr: "connection reset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkExistingResourceGroup(context.Background(), tt.api, "my-rg")
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+			}
+			if got := errors.Is(err, cluster.ErrCredentials); got != tt.wantCreds {
+				t.Errorf("errors.Is(err, ErrCredentials) = %v, want %v", got, tt.wantCreds)
+			}
+		})
+	}
+}
+
+// TestExistingResourceGroupNotManaged checks that deploy and destroy both hand
+// the module a read-only reference to a user-supplied group, so it's neither
+// created on deploy nor deleted on destroy.
+func TestExistingResourceGroupNotManaged(t *testing.T) {
+	cfg := Config{
+		Region:            "eastus",
+		ResourceGroupName: "shared-rg",
+		NodeGroups:        map[string]NodeGroup{"s": {Mode: modeSystem}},
+	}
+	if !cfg.usesExistingResourceGroup() {
+		t.Fatal("usesExistingResourceGroup() = false, want true")
+	}
+
+	backup := &cluster.BackupBucketSpec{}
+	for name, vars := range map[string]TFVars{
+		"deploy":  cfg.toTFVars("p", backup),
+		"destroy": cfg.toTFVars("p", nil),
+	} {
+		raw, err := json.Marshal(vars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(raw, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got["create_resource_group"] != false || got["existing_resource_group_name"] != "shared-rg" {
+			t.Errorf("%s tfvars: create_resource_group=%v existing_resource_group_name=%v, want false and shared-rg",
+				name, got["create_resource_group"], got["existing_resource_group_name"])
+		}
+	}
+
+	if (&Config{Region: "eastus"}).usesExistingResourceGroup() {
+		t.Error("usesExistingResourceGroup() = true without resource_group_name")
+	}
+}
@@ -2,7 +2,6 @@ package azure
 
 import (
 	"context"
-	"crypto/sha256"
 	"errors"
 	"fmt"
 
@@ -13,6 +12,9 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/objectstore"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 )
 
 // State backend constants. The resource group and container names are fixed
@@ -43,8 +45,7 @@ type stateBackendConfig struct {
 // plus the first 14 hex chars of SHA-256(subscriptionID), for a total of
 // 24 characters.
 func stateStorageAccountName(subscriptionID string) string {
-	hash := sha256.Sum256([]byte(subscriptionID))
-	return fmt.Sprintf("%s%x", stateStorageAccountStub, hash[:stateStorageAccountHashLen/2])
+	return stateStorageAccountStub + objectstore.Suffix(subscriptionID, stateStorageAccountHashLen)
 }
 
 // newStateBackendConfig builds the deterministic backend config struct (no
@@ -82,7 +83,7 @@ func ensureStateBackend(ctx context.Context, subscriptionID, location, projectNa
 	cred, err := azidentity.NewDefaultAzureCredential(nil)
 	if err != nil {
 		span.RecordError(err)
-		return stateBackendConfig{}, fmt.Errorf("azure credentials: %w", err)
+		return stateBackendConfig{}, fmt.Errorf("azure credentials: %w: %w", cluster.ErrCredentials, err)
 	}
 
 	if err := ensureStateResourceGroup(ctx, subscriptionID, location, cfg.RGName, cred); err != nil {
@@ -117,7 +118,7 @@ func stateBackendExists(ctx context.Context, subscriptionID string) (bool, error
 	cred, err := azidentity.NewDefaultAzureCredential(nil)
 	if err != nil {
 		span.RecordError(err)
-		return false, fmt.Errorf("azure credentials: %w", err)
+		return false, fmt.Errorf("azure credentials: %w: %w", cluster.ErrCredentials, err)
 	}
 
 	client, err := armstorage.NewAccountsClient(subscriptionID, cred, nil)
@@ -236,22 +237,6 @@ func ensureStateContainer(ctx context.Context, subscriptionID, rgName, saName, c
 		return fmt.Errorf("create blob containers client: %w", err)
 	}
 
-	_, err = client.Get(ctx, rgName, saName, containerName, nil)
-	if err == nil {
-		return nil
-	}
-	var respErr *azcore.ResponseError
-	if !errors.As(err, &respErr) || respErr.StatusCode != 404 {
-		return fmt.Errorf("get container %q: %w", containerName, err)
-	}
-
-	_, err = client.Create(ctx, rgName, saName, containerName, armstorage.BlobContainer{
-		ContainerProperties: &armstorage.ContainerProperties{
-			PublicAccess: to.Ptr(armstorage.PublicAccessNone),
-		},
-	}, nil)
-	if err != nil {
-		return fmt.Errorf("create container %q: %w", containerName, err)
-	}
-	return nil
+	_, err = newBlobContainerStore(client, rgName, saName).EnsureBucket(ctx, objectstore.BucketSpec{Name: containerName})
+	return err
 }
@@ -29,7 +29,7 @@ type TFVars struct {
 	ExistingVNetID            *string                `json:"existing_vnet_id,omitempty"`
 	ExistingNodeSubnetID      *string                `json:"existing_node_subnet_id,omitempty"`
 	NetworkPlugin             string                 `json:"network_plugin"`
-	NetworkPluginMode         string                 `json:"network_plugin_mode"`
+	NetworkPluginMode         *string                `json:"network_plugin_mode"`
 	NetworkDataPlane          string                 `json:"network_data_plane,omitempty"`
 	NodeProvisioningMode      string                 `json:"node_provisioning_mode,omitempty"`
 	PodCIDR                   string                 `json:"pod_cidr,omitempty"`
@@ -80,6 +80,14 @@ const (
 	dataPlaneCilium = "cilium"
 )
 
+// AKS network plugin values for network.plugin. Azure CNI is always run in
+// overlay mode; kubenet has no plugin mode.
+const (
+	networkPluginAzure   = "azure"
+	networkPluginKubenet = "kubenet"
+	networkPluginOverlay = "overlay"
+)
+
 // AKS node provisioning modes for node_provisioning_mode.
 const (
 	napModeManual = "Manual"
@@ -110,8 +118,8 @@ func (c *Config) toTFVars(projectName string, backup *cluster.BackupBucketSpec)
 		Tags:                  mergeTags(c.Tags, projectName),
 		CreateResourceGroup:   c.CreateResourceGroup == nil && c.ResourceGroupName == "" || (c.CreateResourceGroup != nil && *c.CreateResourceGroup),
 		CreateVNet:            c.Network == nil || c.Network.ExistingVNetID == "",
-		NetworkPlugin:         "azure",
-		NetworkPluginMode:     "overlay",
+		NetworkPlugin:         networkPluginAzure,
+		NetworkPluginMode:     ptrString(networkPluginOverlay),
 		PrivateClusterEnabled: c.PrivateClusterEnabled,
 		AuthorizedIPRanges:    c.AuthorizedIPRanges,
 		SKUTier:               defaultIfEmpty(c.SKUTier, "Free"),
@@ -120,7 +128,7 @@ func (c *Config) toTFVars(projectName string, backup *cluster.BackupBucketSpec)
 		NodeGroups:            convertNodeGroups(c.NodeGroups),
 	}
 
-	if c.ResourceGroupName != "" {
+	if c.usesExistingResourceGroup() {
 		vars.CreateResourceGroup = false
 		vars.ExistingResourceGroupName = &c.ResourceGroupName
 	}
@@ -136,6 +144,12 @@ func (c *Config) toTFVars(projectName string, backup *cluster.BackupBucketSpec)
 		vars.ServiceCIDR = c.Network.ServiceCIDR
 		vars.DNSServiceIP = c.Network.DNSServiceIP
 		vars.NetworkDataPlane = c.Network.DataPlane
+		if c.Network.Plugin == networkPluginKubenet {
+			// kubenet has no plugin mode; send null so the module does not
+			// set network_plugin_mode on the AKS network profile.
+			vars.NetworkPlugin = networkPluginKubenet
+			vars.NetworkPluginMode = nil
+		}
 		if c.Network.ExistingVNetID != "" {
 			vars.ExistingVNetID = &c.Network.ExistingVNetID
 		}
@@ -166,7 +180,7 @@ func convertNodeGroups(in map[string]NodeGroup) map[string]TFNodeGroup {
 			MaxCount:     ng.MaxNodes,
 			Mode:         mode,
 			OSDiskSizeGB: ng.OSDiskSizeGB,
-			Labels:       ng.Labels,
+			Labels:       cluster.NodeGroupLabels(name, ng.Labels),
 			Taints:       ng.Taints,
 			Zones:        ng.Zones,
 		}
@@ -184,6 +198,8 @@ func mergeTags(user map[string]string, projectName string) map[string]string {
 	return out
 }
 
+func ptrString(s string) *string { return &s }
+
 func defaultIfEmpty(s, def string) string {
 	if s == "" {
 		return def
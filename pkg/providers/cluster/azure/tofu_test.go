@@ -25,6 +25,27 @@ func TestToTFVarsSystemPoolResolution(t *testing.T) {
 	}
 }
 
+func TestToTFVarsNodeGroupLabels(t *testing.T) {
+	cfg := Config{
+		Region: "eastus",
+		NodeGroups: map[string]NodeGroup{
+			"sys":  {Instance: "Standard_D2_v3", Mode: modeSystem},
+			"user": {Instance: "Standard_D4_v3", Labels: map[string]string{"team": "data"}},
+		},
+	}
+	vars := cfg.toTFVars("myproj", nil)
+
+	if got := vars.NodeGroups["sys"].Labels[cluster.NodePoolLabel]; got != "sys" {
+		t.Errorf("sys %s = %q, want sys", cluster.NodePoolLabel, got)
+	}
+	if got := vars.NodeGroups["user"].Labels["team"]; got != "data" {
+		t.Errorf("user label team = %q, want data", got)
+	}
+	if got := vars.NodeGroups["user"].Labels[cluster.NodePoolLabel]; got != "user" {
+		t.Errorf("user %s = %q, want user", cluster.NodePoolLabel, got)
+	}
+}
+
 func TestToTFVarsCreateFlags(t *testing.T) {
 	t.Run("create RG by default", func(t *testing.T) {
 		cfg := Config{Region: "eastus", NodeGroups: map[string]NodeGroup{"s": {Mode: modeSystem}}}
@@ -117,6 +138,64 @@ func TestToTFVarsDataPlaneAndNAP(t *testing.T) {
 	})
 }
 
+func TestToTFVarsNetworkPlugin(t *testing.T) {
+	t.Run("defaults to azure CNI overlay", func(t *testing.T) {
+		cfg := Config{Region: "eastus", NodeGroups: map[string]NodeGroup{"s": {Mode: modeSystem}}}
+		vars := cfg.toTFVars("p", nil)
+		if vars.NetworkPlugin != networkPluginAzure {
+			t.Errorf("NetworkPlugin = %q, want %q", vars.NetworkPlugin, networkPluginAzure)
+		}
+		if vars.NetworkPluginMode == nil || *vars.NetworkPluginMode != networkPluginOverlay {
+			t.Errorf("NetworkPluginMode = %v, want %q", vars.NetworkPluginMode, networkPluginOverlay)
+		}
+	})
+	t.Run("kubenet", func(t *testing.T) {
+		cfg := Config{
+			Region:     "eastus",
+			NodeGroups: map[string]NodeGroup{"s": {Mode: modeSystem}},
+			Network:    &NetworkConfig{Plugin: networkPluginKubenet},
+		}
+		vars := cfg.toTFVars("p", nil)
+		if vars.NetworkPlugin != networkPluginKubenet {
+			t.Errorf("NetworkPlugin = %q, want %q", vars.NetworkPlugin, networkPluginKubenet)
+		}
+		b, err := json.Marshal(vars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !contains(string(b), `"network_plugin_mode":null`) {
+			t.Errorf("expected network_plugin_mode to be null for kubenet, got: %s", b)
+		}
+	})
+}
+
+func TestToTFVarsPrivateCluster(t *testing.T) {
+	cfg := Config{
+		Region:                "eastus",
+		NodeGroups:            map[string]NodeGroup{"s": {Mode: modeSystem}},
+		PrivateClusterEnabled: true,
+	}
+	vars := cfg.toTFVars("p", nil)
+	if !vars.PrivateClusterEnabled {
+		t.Error("PrivateClusterEnabled = false, want true")
+	}
+}
+
+func TestToTFVarsAuthorizedIPRanges(t *testing.T) {
+	cfg := Config{
+		Region:             "eastus",
+		NodeGroups:         map[string]NodeGroup{"s": {Mode: modeSystem}},
+		AuthorizedIPRanges: []string{"203.0.113.0/24"},
+	}
+	b, err := json.Marshal(cfg.toTFVars("p", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(string(b), `"authorized_ip_ranges":["203.0.113.0/24"]`) {
+		t.Errorf("expected authorized_ip_ranges in JSON, got: %s", b)
+	}
+}
+
 func TestToTFVarsOmitsEmptyPointers(t *testing.T) {
 	cfg := Config{Region: "eastus", NodeGroups: map[string]NodeGroup{"s": {Mode: modeSystem}}}
 	vars := cfg.toTFVars("p", nil)
@@ -0,0 +1,13 @@
+package cluster
+
+import "errors"
+
+var (
+	// ErrCredentials is wrapped by provider errors caused by missing,
+	// expired, or rejected cloud credentials.
+	ErrCredentials = errors.New("cloud credentials unavailable")
+
+	// ErrChangesPending is returned by a dry-run Deploy when
+	// DeployOptions.FailOnChanges is set and the plan is not empty.
+	ErrChangesPending = errors.New("infrastructure changes pending")
+)
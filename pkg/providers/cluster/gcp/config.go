@@ -1,21 +1,49 @@
 package gcp
 
+import (
+	"fmt"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/netutil"
+)
+
 // Config represents GCP-specific configuration
 type Config struct {
-	Project                        string               `yaml:"project"`
-	Region                         string               `yaml:"region"`
-	KubernetesVersion              string               `yaml:"kubernetes_version"`
-	AvailabilityZones              []string             `yaml:"availability_zones,omitempty"`
-	ReleaseChannel                 string               `yaml:"release_channel,omitempty"`
-	NodeGroups                     map[string]NodeGroup `yaml:"node_groups,omitempty"`
-	Tags                           []string             `yaml:"tags,omitempty"`
-	NetworkingMode                 string               `yaml:"networking_mode,omitempty"`
-	Network                        string               `yaml:"network,omitempty"`
-	Subnetwork                     string               `yaml:"subnetwork,omitempty"`
-	IPAllocationPolicy             map[string]string    `yaml:"ip_allocation_policy,omitempty"`
-	MasterAuthorizedNetworksConfig map[string]string    `yaml:"master_authorized_networks_config,omitempty"`
-	PrivateClusterConfig           map[string]any       `yaml:"private_cluster_config,omitempty"`
-	AdditionalFields               map[string]any       `yaml:",inline"`
+	// Project is the ID of an existing GCP project. NIC deploys into it
+	// as-is: it never creates the project and never deletes it on destroy.
+	Project           string               `yaml:"project"`
+	Region            string               `yaml:"region"`
+	KubernetesVersion string               `yaml:"kubernetes_version"`
+	AvailabilityZones []string             `yaml:"availability_zones,omitempty"`
+	ReleaseChannel    string               `yaml:"release_channel,omitempty"`
+	NodeGroups        map[string]NodeGroup `yaml:"node_groups,omitempty"`
+	Tags              []string             `yaml:"tags,omitempty"`
+	NetworkingMode    string               `yaml:"networking_mode,omitempty"`
+	// DatapathProvider selects the GKE dataplane: "LEGACY_DATAPATH" (default)
+	// or "ADVANCED_DATAPATH" (Dataplane V2, eBPF with built-in NetworkPolicy).
+	// Validated only: the GCP provider is a stub and does not apply it yet.
+	DatapathProvider string `yaml:"datapath_provider,omitempty"`
+	// NetworkPolicy enables the Calico NetworkPolicy addon ("CALICO"). Only
+	// valid with the legacy dataplane; Dataplane V2 enforces policy natively.
+	// Validated only, like DatapathProvider.
+	NetworkPolicy                  string            `yaml:"network_policy,omitempty"`
+	Network                        string            `yaml:"network,omitempty"`
+	Subnetwork                     string            `yaml:"subnetwork,omitempty"`
+	IPAllocationPolicy             map[string]string `yaml:"ip_allocation_policy,omitempty"`
+	MasterAuthorizedNetworksConfig map[string]string `yaml:"master_authorized_networks_config,omitempty"`
+	// AuthorizedNetworks is a flat list of CIDRs allowed to reach the control
+	// plane endpoint. Merged with MasterAuthorizedNetworksConfig.
+	AuthorizedNetworks   []string              `yaml:"authorized_networks,omitempty"`
+	PrivateClusterConfig *PrivateClusterConfig `yaml:"private_cluster_config,omitempty"`
+	AdditionalFields     map[string]any        `yaml:",inline"`
+}
+
+// PrivateClusterConfig mirrors the GKE privateClusterConfig block. Private
+// nodes get internal IPs only; a private endpoint additionally removes the
+// control plane's public IP.
+type PrivateClusterConfig struct {
+	EnablePrivateNodes    bool   `yaml:"enable_private_nodes"`
+	EnablePrivateEndpoint bool   `yaml:"enable_private_endpoint,omitempty"`
+	MasterIPv4CIDRBlock   string `yaml:"master_ipv4_cidr_block,omitempty"`
 }
 
 // NodeGroup represents GCP-specific node group configuration
@@ -27,6 +55,9 @@ type NodeGroup struct {
 	Preemptible       bool               `yaml:"preemptible,omitempty"`
 	Labels            map[string]string  `yaml:"labels,omitempty"`
 	GuestAccelerators []GuestAccelerator `yaml:"guest_accelerators,omitempty"`
+	// LocalSSDCount attaches this many 375 GB local NVMe SSDs to each node.
+	// The allowed counts depend on the machine family and size.
+	LocalSSDCount int `yaml:"local_ssd_count,omitempty"`
 }
 
 // Taint represents a Kubernetes taint
@@ -40,4 +71,111 @@ type Taint struct {
 type GuestAccelerator struct {
 	Name  string `yaml:"name"`
 	Count int    `yaml:"count,omitempty"`
+	// GPUDriverVersion has GKE install the NVIDIA driver on the nodes:
+	// "DEFAULT", "LATEST" or "INSTALLATION_DISABLED". Empty leaves driver
+	// installation to the cluster (e.g. the NVIDIA GPU Operator).
+	GPUDriverVersion string `yaml:"gpu_driver_version,omitempty"`
+}
+
+// GKE datapath providers for datapath_provider.
+const (
+	datapathLegacy   = "LEGACY_DATAPATH"
+	datapathAdvanced = "ADVANCED_DATAPATH"
+)
+
+// networkPolicyCalico is the only NetworkPolicy addon provider GKE offers.
+const networkPolicyCalico = "CALICO"
+
+// networkingModeRoute is the routes-based (non VPC-native) networking mode.
+const networkingModeRoute = "ROUTE"
+
+// Validate checks that the Config is internally consistent. It does NOT make
+// any cloud calls.
+func (c *Config) Validate() error {
+	switch c.DatapathProvider {
+	case "", datapathLegacy, datapathAdvanced:
+	default:
+		return fmt.Errorf("cluster.gcp.datapath_provider %q is invalid (expected %q or %q)", c.DatapathProvider, datapathLegacy, datapathAdvanced)
+	}
+
+	switch c.NetworkPolicy {
+	case "", networkPolicyCalico:
+	default:
+		return fmt.Errorf("cluster.gcp.network_policy %q is invalid (expected %q)", c.NetworkPolicy, networkPolicyCalico)
+	}
+
+	if c.DatapathProvider == datapathAdvanced {
+		if c.NetworkPolicy != "" {
+			return fmt.Errorf("cluster.gcp.network_policy must be unset with datapath_provider: %q (Dataplane V2 enforces NetworkPolicy natively)", datapathAdvanced)
+		}
+		if c.NetworkingMode == networkingModeRoute {
+			return fmt.Errorf("cluster.gcp.datapath_provider %q requires VPC-native networking (networking_mode must not be %q)", datapathAdvanced, networkingModeRoute)
+		}
+	}
+
+	if c.PrivateClusterConfig != nil {
+		if err := c.PrivateClusterConfig.validate(); err != nil {
+			return err
+		}
+	}
+
+	for name, ng := range c.NodeGroups {
+		if err := ng.validate(name); err != nil {
+			return err
+		}
+	}
+
+	for i, cidr := range c.AuthorizedNetworks {
+		if _, err := netutil.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("cluster.gcp.authorized_networks[%d]: %w", i, err)
+		}
+	}
+	for name, cidr := range c.MasterAuthorizedNetworksConfig {
+		if _, err := netutil.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("cluster.gcp.master_authorized_networks_config.%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// MasterAuthorizedCIDRs returns the control plane allowlist as GKE expects it:
+// display name to CIDR. Entries from authorized_networks are named by index so
+// they cannot collide with user-named entries.
+func (c *Config) MasterAuthorizedCIDRs() map[string]string {
+	if len(c.AuthorizedNetworks) == 0 && len(c.MasterAuthorizedNetworksConfig) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(c.AuthorizedNetworks)+len(c.MasterAuthorizedNetworksConfig))
+	for i, cidr := range c.AuthorizedNetworks {
+		out[fmt.Sprintf("authorized-network-%d", i)] = cidr
+	}
+	for name, cidr := range c.MasterAuthorizedNetworksConfig {
+		out[name] = cidr
+	}
+	return out
+}
+
+// masterCIDRPrefixLen is the control-plane range size GKE requires for
+// private clusters.
+const masterCIDRPrefixLen = 28
+
+func (p *PrivateClusterConfig) validate() error {
+	if p.EnablePrivateEndpoint && !p.EnablePrivateNodes {
+		return fmt.Errorf("cluster.gcp.private_cluster_config.enable_private_endpoint requires enable_private_nodes: true")
+	}
+	if !p.EnablePrivateNodes {
+		return nil
+	}
+	if p.MasterIPv4CIDRBlock == "" {
+		return fmt.Errorf("cluster.gcp.private_cluster_config.master_ipv4_cidr_block is required when enable_private_nodes is true")
+	}
+	prefix, err := netutil.ParseCIDR(p.MasterIPv4CIDRBlock)
+	if err != nil {
+		return fmt.Errorf("cluster.gcp.private_cluster_config.master_ipv4_cidr_block: %w", err)
+	}
+	if !prefix.Addr().Is4() || prefix.Bits() != masterCIDRPrefixLen {
+		return fmt.Errorf("cluster.gcp.private_cluster_config.master_ipv4_cidr_block %q must be an IPv4 /%d range", p.MasterIPv4CIDRBlock, masterCIDRPrefixLen)
+	}
+	return nil
 }
@@ -0,0 +1,156 @@
+package gcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       Config
+		wantErr   bool
+		wantInErr string
+	}{
+		{
+			name:    "minimal valid",
+			cfg:     Config{Project: "p", Region: "us-central1"},
+			wantErr: false,
+		},
+		{
+			name:    "dataplane v2",
+			cfg:     Config{DatapathProvider: datapathAdvanced, NetworkingMode: "VPC_NATIVE"},
+			wantErr: false,
+		},
+		{
+			name:    "calico on legacy dataplane",
+			cfg:     Config{DatapathProvider: datapathLegacy, NetworkPolicy: networkPolicyCalico},
+			wantErr: false,
+		},
+		{
+			name:      "invalid datapath provider",
+			cfg:       Config{DatapathProvider: "CILIUM"},
+			wantErr:   true,
+			wantInErr: "datapath_provider",
+		},
+		{
+			name:      "invalid network policy",
+			cfg:       Config{NetworkPolicy: "cilium"},
+			wantErr:   true,
+			wantInErr: "network_policy",
+		},
+		{
+			name:      "calico with dataplane v2",
+			cfg:       Config{DatapathProvider: datapathAdvanced, NetworkPolicy: networkPolicyCalico},
+			wantErr:   true,
+			wantInErr: "network_policy",
+		},
+		{
+			name:      "dataplane v2 with routes-based networking",
+			cfg:       Config{DatapathProvider: datapathAdvanced, NetworkingMode: networkingModeRoute},
+			wantErr:   true,
+			wantInErr: "networking_mode",
+		},
+		{
+			name: "private cluster",
+			cfg: Config{PrivateClusterConfig: &PrivateClusterConfig{
+				EnablePrivateNodes:    true,
+				EnablePrivateEndpoint: true,
+				MasterIPv4CIDRBlock:   "172.16.0.0/28",
+			}},
+			wantErr: false,
+		},
+		{
+			name:      "private nodes without master CIDR",
+			cfg:       Config{PrivateClusterConfig: &PrivateClusterConfig{EnablePrivateNodes: true}},
+			wantErr:   true,
+			wantInErr: "master_ipv4_cidr_block is required",
+		},
+		{
+			name: "master CIDR wrong size",
+			cfg: Config{PrivateClusterConfig: &PrivateClusterConfig{
+				EnablePrivateNodes:  true,
+				MasterIPv4CIDRBlock: "172.16.0.0/24",
+			}},
+			wantErr:   true,
+			wantInErr: "/28",
+		},
+		{
+			name: "master CIDR not a CIDR",
+			cfg: Config{PrivateClusterConfig: &PrivateClusterConfig{
+				EnablePrivateNodes:  true,
+				MasterIPv4CIDRBlock: "172.16.0.0",
+			}},
+			wantErr:   true,
+			wantInErr: "master_ipv4_cidr_block",
+		},
+		{
+			name:      "private endpoint without private nodes",
+			cfg:       Config{PrivateClusterConfig: &PrivateClusterConfig{EnablePrivateEndpoint: true}},
+			wantErr:   true,
+			wantInErr: "enable_private_nodes",
+		},
+		{
+			name: "authorized networks",
+			cfg: Config{
+				AuthorizedNetworks:             []string{"203.0.113.0/24"},
+				MasterAuthorizedNetworksConfig: map[string]string{"vpn": "198.51.100.0/24"},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "invalid authorized network",
+			cfg:       Config{AuthorizedNetworks: []string{"203.0.113.0/24", "not-a-cidr"}},
+			wantErr:   true,
+			wantInErr: "authorized_networks[1]",
+		},
+		{
+			name:      "invalid named authorized network",
+			cfg:       Config{MasterAuthorizedNetworksConfig: map[string]string{"office": "203.0.113.1"}},
+			wantErr:   true,
+			wantInErr: "master_authorized_networks_config.office",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected error containing %q, got nil", tc.wantInErr)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if tc.wantErr && tc.wantInErr != "" && !strings.Contains(err.Error(), tc.wantInErr) {
+				t.Fatalf("error %q does not contain expected substring %q", err.Error(), tc.wantInErr)
+			}
+		})
+	}
+}
+
+func TestMasterAuthorizedCIDRs(t *testing.T) {
+	t.Run("nil when unset", func(t *testing.T) {
+		if got := (&Config{}).MasterAuthorizedCIDRs(); got != nil {
+			t.Errorf("MasterAuthorizedCIDRs() = %v, want nil", got)
+		}
+	})
+	t.Run("merges list and named entries", func(t *testing.T) {
+		cfg := Config{
+			AuthorizedNetworks:             []string{"203.0.113.0/24"},
+			MasterAuthorizedNetworksConfig: map[string]string{"vpn": "198.51.100.0/24"},
+		}
+		got := cfg.MasterAuthorizedCIDRs()
+		want := map[string]string{
+			"authorized-network-0": "203.0.113.0/24",
+			"vpn":                  "198.51.100.0/24",
+		}
+		if len(got) != len(want) {
+			t.Fatalf("MasterAuthorizedCIDRs() = %v, want %v", got, want)
+		}
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("MasterAuthorizedCIDRs()[%q] = %q, want %q", k, got[k], v)
+			}
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package gcp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GKE GPU driver versions for guest_accelerators[].gpu_driver_version.
+const (
+	gpuDriverDefault  = "DEFAULT"
+	gpuDriverLatest   = "LATEST"
+	gpuDriverDisabled = "INSTALLATION_DISABLED"
+)
+
+// localSSDCounts lists the local SSD counts each machine family accepts. A
+// family mapped to nil cannot attach local SSDs. Families not listed here
+// (e.g. the -lssd shapes, whose SSDs come with the machine type) are not
+// checked.
+var localSSDCounts = map[string][]int{
+	"n1":  {1, 2, 3, 4, 5, 6, 7, 8, 16, 24},
+	"n2":  {1, 2, 4, 8, 16, 24},
+	"n2d": {1, 2, 4, 8, 16, 24},
+	"c2":  {1, 2, 4, 8},
+	"c2d": {1, 2, 4, 8},
+	"e2":  nil,
+	"t2d": nil,
+	"t2a": nil,
+}
+
+// validate checks the node group's local SSDs and accelerators. name is the
+// node group's key in cluster.gcp.node_groups.
+func (ng NodeGroup) validate(name string) error {
+	if ng.LocalSSDCount < 0 {
+		return fmt.Errorf("cluster.gcp.node_groups.%s.local_ssd_count cannot be negative", name)
+	}
+	if ng.LocalSSDCount > 0 {
+		family, _, _ := strings.Cut(ng.Instance, "-")
+		if allowed, ok := localSSDCounts[family]; ok {
+			if allowed == nil {
+				return fmt.Errorf("cluster.gcp.node_groups.%s: machine type %s does not support local SSDs", name, ng.Instance)
+			}
+			if !slices.Contains(allowed, ng.LocalSSDCount) {
+				return fmt.Errorf("cluster.gcp.node_groups.%s.local_ssd_count %d is not valid for %s machine types (allowed: %v)", name, ng.LocalSSDCount, family, allowed)
+			}
+		}
+	}
+
+	for i, acc := range ng.GuestAccelerators {
+		switch acc.GPUDriverVersion {
+		case "", gpuDriverDefault, gpuDriverLatest, gpuDriverDisabled:
+		default:
+			return fmt.Errorf("cluster.gcp.node_groups.%s.guest_accelerators[%d].gpu_driver_version %q is invalid (expected %q, %q or %q)",
+				name, i, acc.GPUDriverVersion, gpuDriverDefault, gpuDriverLatest, gpuDriverDisabled)
+		}
+	}
+	return nil
+}
+
+// nodeConfig is the subset of the GKE NodeConfig that node group settings map
+// onto, with the API's field names.
+type nodeConfig struct {
+	MachineType   string              `json:"machineType"`
+	Preemptible   bool                `json:"preemptible,omitempty"`
+	Labels        map[string]string   `json:"labels,omitempty"`
+	LocalSSDCount int                 `json:"localSsdCount,omitempty"`
+	Accelerators  []acceleratorConfig `json:"accelerators,omitempty"`
+}
+
+type acceleratorConfig struct {
+	AcceleratorType             string                       `json:"acceleratorType"`
+	AcceleratorCount            int                          `json:"acceleratorCount"`
+	GPUDriverInstallationConfig *gpuDriverInstallationConfig `json:"gpuDriverInstallationConfig,omitempty"`
+}
+
+type gpuDriverInstallationConfig struct {
+	GPUDriverVersion string `json:"gpuDriverVersion"`
+}
+
+// nodeConfig returns the GKE node configuration for the node group. An
+// accelerator without a count gets one GPU per node.
+func (ng NodeGroup) nodeConfig() nodeConfig {
+	nc := nodeConfig{
+		MachineType:   ng.Instance,
+		Preemptible:   ng.Preemptible,
+		Labels:        ng.Labels,
+		LocalSSDCount: ng.LocalSSDCount,
+	}
+	for _, acc := range ng.GuestAccelerators {
+		ac := acceleratorConfig{AcceleratorType: acc.Name, AcceleratorCount: max(acc.Count, 1)}
+		if acc.GPUDriverVersion != "" {
+			ac.GPUDriverInstallationConfig = &gpuDriverInstallationConfig{GPUDriverVersion: acc.GPUDriverVersion}
+		}
+		nc.Accelerators = append(nc.Accelerators, ac)
+	}
+	return nc
+}
@@ -0,0 +1,82 @@
+package gcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNodeGroupValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		group     NodeGroup
+		errSubstr string
+	}{
+		{name: "no local ssds", group: NodeGroup{Instance: "e2-standard-4"}},
+		{name: "n2 valid count", group: NodeGroup{Instance: "n2-standard-8", LocalSSDCount: 4}},
+		{name: "unlisted family not checked", group: NodeGroup{Instance: "c3-standard-8-lssd", LocalSSDCount: 2}},
+		{name: "n2 invalid count", group: NodeGroup{Instance: "n2-standard-8", LocalSSDCount: 3}, errSubstr: "not valid for n2 machine types"},
+		{name: "c2 over limit", group: NodeGroup{Instance: "c2-standard-30", LocalSSDCount: 16}, errSubstr: "local_ssd_count 16"},
+		{name: "e2 unsupported", group: NodeGroup{Instance: "e2-standard-4", LocalSSDCount: 1}, errSubstr: "does not support local SSDs"},
+		{name: "negative", group: NodeGroup{Instance: "n2-standard-8", LocalSSDCount: -1}, errSubstr: "cannot be negative"},
+		{
+			name:  "driver version",
+			group: NodeGroup{Instance: "n1-standard-8", GuestAccelerators: []GuestAccelerator{{Name: "nvidia-tesla-t4", Count: 1, GPUDriverVersion: "LATEST"}}},
+		},
+		{
+			name:      "invalid driver version",
+			group:     NodeGroup{Instance: "n1-standard-8", GuestAccelerators: []GuestAccelerator{{Name: "nvidia-tesla-t4", GPUDriverVersion: "535"}}},
+			errSubstr: "guest_accelerators[0].gpu_driver_version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.group.validate("workers")
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error = %v, want substring %q", err, tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestNodeGroupNodeConfig(t *testing.T) {
+	ng := NodeGroup{
+		Instance:      "n1-standard-8",
+		LocalSSDCount: 2,
+		GuestAccelerators: []GuestAccelerator{
+			{Name: "nvidia-tesla-t4", Count: 2, GPUDriverVersion: gpuDriverLatest},
+			{Name: "nvidia-l4"},
+		},
+	}
+
+	data, err := json.Marshal(ng.nodeConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`"machineType":"n1-standard-8"`,
+		`"localSsdCount":2`,
+		`{"acceleratorType":"nvidia-tesla-t4","acceleratorCount":2,"gpuDriverInstallationConfig":{"gpuDriverVersion":"LATEST"}}`,
+		`{"acceleratorType":"nvidia-l4","acceleratorCount":1}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("node config missing %s: %s", want, got)
+		}
+	}
+
+	data, err = json.Marshal(NodeGroup{Instance: "e2-standard-4"}.nodeConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "localSsdCount") || strings.Contains(string(data), "accelerators") {
+		t.Errorf("unset local SSDs and accelerators should be omitted: %s", data)
+	}
+}
@@ -26,10 +26,11 @@ func (p *Provider) Name() string {
 	return "gcp"
 }
 
-// Validate validates the GCP configuration (stub implementation)
-func (p *Provider) Validate(ctx context.Context, projectName string, _ *config.ClusterConfig) error {
+// Validate validates the GCP configuration (stub implementation: only the
+// offline config checks in Config.Validate are performed)
+func (p *Provider) Validate(ctx context.Context, projectName string, clusterConfig *config.ClusterConfig) error {
 	tracer := otel.Tracer("nebari-infrastructure-core")
-	_, span := tracer.Start(ctx, "gcp.Validate")
+	ctx, span := tracer.Start(ctx, "gcp.Validate")
 	defer span.End()
 
 	span.SetAttributes(
@@ -37,6 +38,25 @@ func (p *Provider) Validate(ctx context.Context, projectName string, _ *config.C
 		attribute.String("project_name", projectName),
 	)
 
+	if rawCfg := clusterConfig.ProviderConfig(); rawCfg != nil {
+		var gcpCfg Config
+		if err := config.UnmarshalProviderConfig(ctx, rawCfg, &gcpCfg); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("parse gcp config: %w", err)
+		}
+		if err := gcpCfg.Validate(); err != nil {
+			span.RecordError(err)
+			return err
+		}
+		// The project is never created by NIC, so there is nothing to
+		// fall back to when it's missing.
+		if gcpCfg.Project == "" {
+			err := fmt.Errorf("cluster.gcp.project is required (the ID of an existing GCP project)")
+			span.RecordError(err)
+			return err
+		}
+	}
+
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Validating GCP provider configuration").
 		WithResource("provider").
 		WithAction("validate").
@@ -143,5 +163,8 @@ func (p *Provider) InfraSettings(_ *config.ClusterConfig) cluster.InfraSettings
 		StorageClass:    "standard-rwo",
 		NeedsMetalLB:    false,
 		LonghornEnabled: false,
+		InternalLoadBalancerAnnotations: map[string]string{
+			"networking.gke.io/load-balancer-type": "Internal",
+		},
 	}
 }
@@ -1,6 +1,8 @@
 package gcp
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
@@ -37,3 +39,16 @@ func TestInfraSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_RequiresProject(t *testing.T) {
+	p := NewProvider()
+	missing := &config.ClusterConfig{Providers: map[string]any{"gcp": map[string]any{"region": "us-central1"}}}
+	if err := p.Validate(context.Background(), "demo", missing); err == nil || !strings.Contains(err.Error(), "cluster.gcp.project is required") {
+		t.Errorf("Validate() error = %v, want project required", err)
+	}
+
+	present := &config.ClusterConfig{Providers: map[string]any{"gcp": map[string]any{"project": "my-project", "region": "us-central1"}}}
+	if err := p.Validate(context.Background(), "demo", present); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
@@ -382,6 +382,10 @@ func (p *Provider) InfraSettings(clusterConfig *config.ClusterConfig) cluster.In
 		StorageClass:    longhorn.StorageClassName,
 		NeedsMetalLB:    false,
 		LonghornEnabled: true, // Hetzner default — see Config.LonghornEnabled
+		InternalLoadBalancerAnnotations: map[string]string{
+			"load-balancer.hetzner.cloud/disable-public-network": "true",
+			"load-balancer.hetzner.cloud/use-private-ip":         "true",
+		},
 	}
 
 	// Derive LB annotations from location, and fall back to "hcloud-volumes"
@@ -0,0 +1,20 @@
+package cluster
+
+import "maps"
+
+// NodePoolLabel is the Kubernetes node label every provider stamps on a node
+// group's nodes, carrying the node group name from the config. It gives users
+// a provider-neutral selector for pinning workloads to a node group (zone and
+// instance type are already covered by the well-known topology.kubernetes.io/zone
+// and node.kubernetes.io/instance-type labels kubelet sets).
+const NodePoolLabel = "nic.nebari.dev/node-pool"
+
+// NodeGroupLabels returns the Kubernetes labels for the node group called name:
+// the user's labels plus NodePoolLabel. A user-supplied NodePoolLabel wins. The
+// input map is never mutated.
+func NodeGroupLabels(name string, user map[string]string) map[string]string {
+	out := make(map[string]string, len(user)+1)
+	out[NodePoolLabel] = name
+	maps.Copy(out, user)
+	return out
+}
@@ -0,0 +1,52 @@
+package cluster
+
+import "testing"
+
+func TestNodeGroupLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		group string
+		user  map[string]string
+		want  map[string]string
+	}{
+		{
+			name:  "no user labels",
+			group: "general",
+			want:  map[string]string{NodePoolLabel: "general"},
+		},
+		{
+			name:  "user labels preserved",
+			group: "gpu",
+			user:  map[string]string{"team": "ml"},
+			want:  map[string]string{NodePoolLabel: "gpu", "team": "ml"},
+		},
+		{
+			name:  "user node-pool label wins",
+			group: "gpu",
+			user:  map[string]string{NodePoolLabel: "accelerated"},
+			want:  map[string]string{NodePoolLabel: "accelerated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NodeGroupLabels(tt.group, tt.user)
+			if len(got) != len(tt.want) {
+				t.Fatalf("NodeGroupLabels() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("label %q = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+
+	t.Run("does not mutate input", func(t *testing.T) {
+		user := map[string]string{"team": "ml"}
+		_ = NodeGroupLabels("gpu", user)
+		if _, ok := user[NodePoolLabel]; ok {
+			t.Error("NodeGroupLabels mutated the caller's map")
+		}
+	})
+}
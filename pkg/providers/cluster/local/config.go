@@ -38,4 +38,30 @@ type MetalLBConfig struct {
 	// AddressPool is the IP range for MetalLB's IPAddressPool. When unset, NIC
 	// derives a pool from the kind Docker network during Deploy.
 	AddressPool string `yaml:"address_pool,omitempty"`
+
+	// Mode is how the pool is announced: "l2" (default) or "bgp". BGP needs
+	// at least one peer, for example a top-of-rack switch.
+	Mode string `yaml:"mode,omitempty"`
+
+	// Peers are the BGP routers MetalLB peers with. Only valid in bgp mode.
+	Peers []MetalLBPeer `yaml:"peers,omitempty"`
+
+	// BGPAdvertisements configure how the pool is announced to the peers.
+	// Only valid in bgp mode; when empty the pool is advertised with
+	// MetalLB's defaults.
+	BGPAdvertisements []MetalLBBGPAdvertisement `yaml:"bgp_advertisements,omitempty"`
+}
+
+// MetalLBPeer is a BGP peer of MetalLB's speakers.
+type MetalLBPeer struct {
+	PeerAddress string `yaml:"peer_address"`
+	MyASN       uint32 `yaml:"my_asn"`
+	PeerASN     uint32 `yaml:"peer_asn"`
+}
+
+// MetalLBBGPAdvertisement configures a MetalLB BGPAdvertisement of the pool.
+type MetalLBBGPAdvertisement struct {
+	AggregationLength int      `yaml:"aggregation_length,omitempty"`
+	LocalPref         uint32   `yaml:"local_pref,omitempty"`
+	Communities       []string `yaml:"communities,omitempty"`
 }
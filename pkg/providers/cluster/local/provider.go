@@ -3,6 +3,7 @@ package local
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"path/filepath"
 
 	"go.opentelemetry.io/otel"
@@ -96,6 +97,11 @@ func (p *Provider) Validate(ctx context.Context, projectName string, clusterConf
 		}
 	}
 
+	if err := validateMetalLB(localCfg.MetalLB); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	status.Send(ctx, status.NewUpdate(status.LevelInfo, "Successfully validated local provider configuration").
 		WithResource("provider").
 		WithAction("validate").
@@ -296,6 +302,42 @@ func (p *Provider) Summary(clusterConfig *config.ClusterConfig) map[string]strin
 	return result
 }
 
+// validateMetalLB checks the MetalLB mode and, in bgp mode, its peers and
+// advertisements. A nil config is valid (L2 with a derived pool).
+func validateMetalLB(cfg *MetalLBConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	switch cfg.Mode {
+	case "", cluster.MetalLBModeL2:
+		if len(cfg.Peers) > 0 || len(cfg.BGPAdvertisements) > 0 {
+			return fmt.Errorf("metallb peers and bgp_advertisements require mode %q", cluster.MetalLBModeBGP)
+		}
+		return nil
+	case cluster.MetalLBModeBGP:
+	default:
+		return fmt.Errorf("metallb mode %q is invalid: must be %q or %q", cfg.Mode, cluster.MetalLBModeL2, cluster.MetalLBModeBGP)
+	}
+
+	if len(cfg.Peers) == 0 {
+		return fmt.Errorf("metallb mode %q requires at least one peer", cluster.MetalLBModeBGP)
+	}
+	for i, peer := range cfg.Peers {
+		if _, err := netip.ParseAddr(peer.PeerAddress); err != nil {
+			return fmt.Errorf("metallb peers[%d]: peer_address %q is not an IP address", i, peer.PeerAddress)
+		}
+		if peer.MyASN == 0 || peer.PeerASN == 0 {
+			return fmt.Errorf("metallb peers[%d]: my_asn and peer_asn are required", i)
+		}
+	}
+	for i, adv := range cfg.BGPAdvertisements {
+		if adv.AggregationLength < 0 || adv.AggregationLength > 128 {
+			return fmt.Errorf("metallb bgp_advertisements[%d]: aggregation_length %d must be between 0 and 128", i, adv.AggregationLength)
+		}
+	}
+	return nil
+}
+
 // InfraSettings returns local provider Kubernetes infrastructure settings.
 // Values are read from the local provider config block, falling back to defaults.
 // Parse errors are intentionally ignored: InfraSettings is called after Validate()
@@ -307,6 +349,7 @@ func (p *Provider) InfraSettings(cfg *config.ClusterConfig) cluster.InfraSetting
 		StorageClass:        defaultStorageClass,
 		NeedsMetalLB:        true,
 		MetalLBAddressPool:  defaultMetalLBAddressPool,
+		MetalLBMode:         cluster.MetalLBModeL2,
 		SupportsLocalGitOps: true,
 		LonghornEnabled:     false,
 	}
@@ -335,5 +378,15 @@ func (p *Provider) InfraSettings(cfg *config.ClusterConfig) cluster.InfraSetting
 		settings.MetalLBAddressPool = p.metalLBPool
 	}
 
+	if localCfg.MetalLB != nil && localCfg.MetalLB.Mode == cluster.MetalLBModeBGP {
+		settings.MetalLBMode = cluster.MetalLBModeBGP
+		for _, peer := range localCfg.MetalLB.Peers {
+			settings.MetalLBBGPPeers = append(settings.MetalLBBGPPeers, cluster.MetalLBBGPPeer(peer))
+		}
+		for _, adv := range localCfg.MetalLB.BGPAdvertisements {
+			settings.MetalLBBGPAdvertisements = append(settings.MetalLBBGPAdvertisements, cluster.MetalLBBGPAdvertisement(adv))
+		}
+	}
+
 	return settings
 }
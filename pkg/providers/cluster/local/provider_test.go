@@ -2,6 +2,7 @@ package local
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -220,6 +221,116 @@ func TestInfraSettingsKindModeExplicitPool(t *testing.T) {
 	}
 }
 
+func TestValidateMetalLB(t *testing.T) {
+	p := NewProvider()
+	ctx := context.Background()
+	peer := map[string]any{"peer_address": "10.0.0.1", "my_asn": 64512, "peer_asn": 64500}
+
+	tests := []struct {
+		name    string
+		metalLB map[string]any
+		wantErr string
+	}{
+		{name: "l2 mode", metalLB: map[string]any{"mode": "l2"}},
+		{
+			name: "bgp with peers and advertisements",
+			metalLB: map[string]any{
+				"mode":  "bgp",
+				"peers": []any{peer, map[string]any{"peer_address": "fd00::1", "my_asn": 64512, "peer_asn": 64501}},
+				"bgp_advertisements": []any{
+					map[string]any{"aggregation_length": 24, "local_pref": 100, "communities": []any{"65535:65282"}},
+				},
+			},
+		},
+		{name: "unknown mode", metalLB: map[string]any{"mode": "ospf"}, wantErr: `metallb mode "ospf" is invalid`},
+		{name: "bgp without peers", metalLB: map[string]any{"mode": "bgp"}, wantErr: "requires at least one peer"},
+		{
+			name:    "peers without bgp mode",
+			metalLB: map[string]any{"peers": []any{peer}},
+			wantErr: `require mode "bgp"`,
+		},
+		{
+			name: "invalid peer address",
+			metalLB: map[string]any{
+				"mode":  "bgp",
+				"peers": []any{map[string]any{"peer_address": "tor-switch", "my_asn": 64512, "peer_asn": 64500}},
+			},
+			wantErr: `peers[0]: peer_address "tor-switch" is not an IP address`,
+		},
+		{
+			name: "missing ASN",
+			metalLB: map[string]any{
+				"mode":  "bgp",
+				"peers": []any{map[string]any{"peer_address": "10.0.0.1", "my_asn": 64512}},
+			},
+			wantErr: "peers[0]: my_asn and peer_asn are required",
+		},
+		{
+			name: "aggregation length out of range",
+			metalLB: map[string]any{
+				"mode":               "bgp",
+				"peers":              []any{peer},
+				"bgp_advertisements": []any{map[string]any{"aggregation_length": 129}},
+			},
+			wantErr: "bgp_advertisements[0]: aggregation_length 129",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.ClusterConfig{Providers: map[string]any{"local": map[string]any{"metallb": tt.metalLB}}}
+
+			err := p.Validate(ctx, "test-project", cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInfraSettingsMetalLBBGP(t *testing.T) {
+	p := NewProvider()
+
+	if got := p.InfraSettings(&config.ClusterConfig{}).MetalLBMode; got != cluster.MetalLBModeL2 {
+		t.Errorf("default MetalLBMode = %q, want %q", got, cluster.MetalLBModeL2)
+	}
+
+	cfg := &config.ClusterConfig{
+		Providers: map[string]any{
+			"local": map[string]any{
+				"metallb": map[string]any{
+					"address_pool":       "10.0.0.100-10.0.0.110",
+					"mode":               "bgp",
+					"peers":              []any{map[string]any{"peer_address": "10.0.0.1", "my_asn": 64512, "peer_asn": 64500}},
+					"bgp_advertisements": []any{map[string]any{"local_pref": 100}},
+				},
+			},
+		},
+	}
+
+	settings := p.InfraSettings(cfg)
+	if settings.MetalLBMode != cluster.MetalLBModeBGP {
+		t.Errorf("MetalLBMode = %q, want %q", settings.MetalLBMode, cluster.MetalLBModeBGP)
+	}
+	if settings.MetalLBAddressPool != "10.0.0.100-10.0.0.110" {
+		t.Errorf("MetalLBAddressPool = %q, want the pool in BGP mode too", settings.MetalLBAddressPool)
+	}
+	wantPeers := []cluster.MetalLBBGPPeer{{PeerAddress: "10.0.0.1", MyASN: 64512, PeerASN: 64500}}
+	if !reflect.DeepEqual(settings.MetalLBBGPPeers, wantPeers) {
+		t.Errorf("MetalLBBGPPeers = %+v, want %+v", settings.MetalLBBGPPeers, wantPeers)
+	}
+	wantAdvertisements := []cluster.MetalLBBGPAdvertisement{{LocalPref: 100}}
+	if !reflect.DeepEqual(settings.MetalLBBGPAdvertisements, wantAdvertisements) {
+		t.Errorf("MetalLBBGPAdvertisements = %+v, want %+v", settings.MetalLBBGPAdvertisements, wantAdvertisements)
+	}
+}
+
 func TestSummaryKindMode(t *testing.T) {
 	p := NewProvider()
 
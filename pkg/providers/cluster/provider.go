@@ -41,6 +41,25 @@ type DeployOptions struct {
 	// BackupBucket, when non-nil, asks the provider to provision a Longhorn
 	// backup bucket/container in its Terraform module.
 	BackupBucket *BackupBucketSpec
+
+	// FailOnChanges makes a dry run return ErrChangesPending when the plan
+	// is not empty. Providers that cannot compute a plan ignore it.
+	FailOnChanges bool
+
+	// Environment is the config's environment (empty when unset). Providers
+	// that tag resources record it alongside their own tags.
+	Environment string
+
+	// ClusterID is the deployment's stable unique ID, assigned on the first
+	// deploy and kept in the deploy state (empty in a dry run before the
+	// first deploy). Providers that tag resources record it, so costs can be
+	// attributed to one deployment even when project names are reused.
+	ClusterID string
+
+	// RecreateFailedNodeGroups deletes node groups the cloud reports as
+	// failed (e.g. CREATE_FAILED for lack of capacity) before applying, so
+	// they are created again. Without it failed groups are only reported.
+	RecreateFailedNodeGroups bool
 }
 
 // DestroyOptions holds runtime flags for infrastructure destruction.
@@ -58,6 +77,11 @@ type DestroyOptions struct {
 	// providers remove it from Terraform state before destroy so it (and its
 	// backups) survive teardown.
 	BackupBucket *BackupBucketSpec
+
+	// Environment and ClusterID mirror DeployOptions so the destroy plan
+	// matches what was deployed.
+	Environment string
+	ClusterID   string
 }
 
 // InfraSettings describes provider-specific Kubernetes infrastructure settings.
@@ -77,10 +101,28 @@ type InfraSettings struct {
 	// (e.g., {"load-balancer.hetzner.cloud/location": "ash"}).
 	LoadBalancerAnnotations map[string]string
 
+	// InternalLoadBalancerAnnotations are merged over LoadBalancerAnnotations
+	// when the gateway is internal (gateway.internal in the config) so the cloud
+	// provisions a private load balancer only. Empty when the provider has no
+	// such annotation; NIC then warns that the load balancer may stay public.
+	InternalLoadBalancerAnnotations map[string]string
+
 	// MetalLBAddressPool is the IP range for MetalLB's IPAddressPool.
 	// Only used when NeedsMetalLB is true (e.g., "192.168.1.100-192.168.1.110").
 	MetalLBAddressPool string
 
+	// MetalLBMode is how MetalLB announces the pool: MetalLBModeL2 (the
+	// default when empty) or MetalLBModeBGP. Only used when NeedsMetalLB is true.
+	MetalLBMode string
+
+	// MetalLBBGPPeers are the routers MetalLB peers with in MetalLBModeBGP.
+	MetalLBBGPPeers []MetalLBBGPPeer
+
+	// MetalLBBGPAdvertisements configure how the pool is announced to the
+	// peers in MetalLBModeBGP. Empty means a single advertisement with
+	// MetalLB's defaults.
+	MetalLBBGPAdvertisements []MetalLBBGPAdvertisement
+
 	// KeycloakBasePath is appended to the Keycloak service URL for the operator.
 	// Most providers leave this empty. Providers using the Keycloak legacy chart
 	// (keycloakx) need "/auth" because that chart serves under the /auth context path,
@@ -110,6 +152,33 @@ type InfraSettings struct {
 	LonghornEnabled bool
 }
 
+// MetalLB modes for InfraSettings.MetalLBMode.
+const (
+	// MetalLBModeL2 answers ARP/NDP for service IPs on the local network.
+	MetalLBModeL2 = "l2"
+	// MetalLBModeBGP announces service IPs to BGP peers, such as a ToR switch.
+	MetalLBModeBGP = "bgp"
+)
+
+// MetalLBBGPPeer is a BGP router MetalLB's speakers peer with.
+type MetalLBBGPPeer struct {
+	PeerAddress string
+	MyASN       uint32
+	PeerASN     uint32
+}
+
+// MetalLBBGPAdvertisement configures how MetalLB announces the address pool
+// to its BGP peers. Zero values leave MetalLB's defaults.
+type MetalLBBGPAdvertisement struct {
+	// AggregationLength is the prefix length routes are aggregated to
+	// (MetalLB defaults to 32, one route per IP).
+	AggregationLength int
+	// LocalPref is the BGP LOCAL_PREF attribute, for iBGP peers.
+	LocalPref uint32
+	// Communities are BGP communities attached to the routes, e.g. "65535:65282".
+	Communities []string
+}
+
 // Provider defines the interface that all cloud providers must implement.
 //
 // This interface establishes the abstraction boundary between CLI commands and
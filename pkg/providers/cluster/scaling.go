@@ -0,0 +1,48 @@
+package cluster
+
+import "fmt"
+
+// NodeGroupScaling is an out-of-band change to a node group's size, applied
+// without a full deploy (e.g. scaling dev node groups to zero overnight).
+// Nil fields leave the current value unchanged. A Max of zero means "scale
+// to zero": providers whose APIs require a positive maximum keep the
+// current maximum and set the minimum and desired size to zero instead.
+type NodeGroupScaling struct {
+	Min     *int
+	Max     *int
+	Desired *int
+}
+
+// Validate checks the requested sizes against each other. Providers re-check
+// the merged result once the current sizes are known.
+func (s NodeGroupScaling) Validate() error {
+	if s.Min == nil && s.Max == nil && s.Desired == nil {
+		return fmt.Errorf("at least one of min, max, or desired must be set")
+	}
+	for _, f := range []struct {
+		name string
+		v    *int
+	}{{"min", s.Min}, {"max", s.Max}, {"desired", s.Desired}} {
+		if f.v != nil && *f.v < 0 {
+			return fmt.Errorf("%s cannot be negative (got %d)", f.name, *f.v)
+		}
+	}
+	if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
+		return fmt.Errorf("min (%d) cannot be greater than max (%d)", *s.Min, *s.Max)
+	}
+	if s.Desired != nil && s.Min != nil && *s.Desired < *s.Min {
+		return fmt.Errorf("desired (%d) cannot be less than min (%d)", *s.Desired, *s.Min)
+	}
+	if s.Desired != nil && s.Max != nil && *s.Max > 0 && *s.Desired > *s.Max {
+		return fmt.Errorf("desired (%d) cannot be greater than max (%d)", *s.Desired, *s.Max)
+	}
+	if s.Max != nil && *s.Max == 0 && s.Desired != nil && *s.Desired > 0 {
+		return fmt.Errorf("desired (%d) must be zero when scaling to zero (max 0)", *s.Desired)
+	}
+	return nil
+}
+
+// ScaleToZero reports whether s asks for the node group to be emptied.
+func (s NodeGroupScaling) ScaleToZero() bool {
+	return s.Max != nil && *s.Max == 0
+}
@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNodeGroupScalingValidate(t *testing.T) {
+	n := func(v int) *int { return &v }
+
+	tests := []struct {
+		name      string
+		scaling   NodeGroupScaling
+		errSubstr string // "" means no error expected
+	}{
+		{name: "scale to zero", scaling: NodeGroupScaling{Min: n(0), Max: n(0)}},
+		{name: "desired only", scaling: NodeGroupScaling{Desired: n(2)}},
+		{name: "full range", scaling: NodeGroupScaling{Min: n(1), Max: n(5), Desired: n(3)}},
+		{name: "nothing set", scaling: NodeGroupScaling{}, errSubstr: "at least one"},
+		{name: "negative min", scaling: NodeGroupScaling{Min: n(-1)}, errSubstr: "min cannot be negative"},
+		{name: "min greater than max", scaling: NodeGroupScaling{Min: n(3), Max: n(1)}, errSubstr: "min (3) cannot be greater than max (1)"},
+		{name: "desired below min", scaling: NodeGroupScaling{Min: n(2), Desired: n(1)}, errSubstr: "less than min"},
+		{name: "desired above max", scaling: NodeGroupScaling{Max: n(2), Desired: n(3)}, errSubstr: "greater than max"},
+		{name: "desired with scale to zero", scaling: NodeGroupScaling{Max: n(0), Desired: n(1)}, errSubstr: "must be zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scaling.Validate()
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errSubstr)
+			}
+			if !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
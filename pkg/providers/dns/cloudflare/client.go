@@ -20,6 +20,10 @@ type CloudflareClient interface {
 	// Returns an error if the zone is not found or the token lacks access.
 	ResolveZoneID(ctx context.Context, zoneName string) (string, error)
 
+	// GetZoneName returns the name of the zone with the given ID.
+	// Returns an error if the zone is not found or the token lacks access.
+	GetZoneName(ctx context.Context, zoneID string) (string, error)
+
 	// ListDNSRecords returns DNS records matching the given name and type.
 	// Both name and recordType can be empty to list all records.
 	ListDNSRecords(ctx context.Context, zoneID string, name string, recordType string) ([]DNSRecordResult, error)
@@ -3,6 +3,9 @@ package cloudflare
 // Config represents Cloudflare-specific DNS configuration
 // Secrets like API tokens are read from environment variables, not config
 type Config struct {
-	ZoneName         string         `yaml:"zone_name" json:"zone_name"` // Domain zone (e.g., example.com)
+	ZoneName string `yaml:"zone_name" json:"zone_name"` // Domain zone (e.g., example.com)
+	// ZoneID skips looking the zone up by name. The zone it names must be
+	// readable with the API token and be ZoneName.
+	ZoneID           string         `yaml:"zone_id,omitempty" json:"zone_id,omitempty"`
 	AdditionalFields map[string]any `yaml:",inline" json:"-"`
 }
@@ -5,12 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
-	"os"
 	"strings"
+	"sync"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/secretenv"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
 )
 
@@ -21,19 +22,29 @@ const (
 )
 
 // Provider implements the Cloudflare DNS provider.
-// Stateless -- config is parsed on each call, matching the cloud provider pattern.
+// Config is parsed on each call, matching the cloud provider pattern. The only
+// state kept between calls is the zone lookups, which do not change.
 type Provider struct {
 	client CloudflareClient // nil = use real SDK client; set via NewProviderForTesting
+
+	mu        sync.Mutex
+	zoneIDs   map[string]string // zone name -> zone ID, from ResolveZoneID
+	zoneNames map[string]string // configured zone ID -> zone name, from GetZoneName
 }
 
 // NewProvider creates a new Cloudflare DNS provider.
 func NewProvider() *Provider {
-	return &Provider{}
+	return &Provider{
+		zoneIDs:   map[string]string{},
+		zoneNames: map[string]string{},
+	}
 }
 
 // NewProviderForTesting creates a provider with an injected mock client.
 func NewProviderForTesting(client CloudflareClient) *Provider {
-	return &Provider{client: client}
+	p := NewProvider()
+	p.client = client
+	return p
 }
 
 // Name returns the provider name.
@@ -78,15 +89,10 @@ func (p *Provider) ProvisionRecords(ctx context.Context, domain string, dnsConfi
 		return err
 	}
 
-	// Resolve zone ID from zone name
-	status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Resolving Cloudflare zone ID for %s", cfCfg.ZoneName)).
-		WithResource("dns-zone").
-		WithAction("resolving"))
-
-	zoneID, err := client.ResolveZoneID(ctx, cfCfg.ZoneName)
+	zoneID, err := p.resolveZoneID(ctx, client, cfCfg)
 	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("zone not found for %q: %w", cfCfg.ZoneName, err)
+		return err
 	}
 	span.SetAttributes(attribute.String("zone_id", zoneID))
 
@@ -161,15 +167,10 @@ func (p *Provider) DestroyRecords(ctx context.Context, domain string, dnsConfig
 		return err
 	}
 
-	// Resolve zone ID from zone name
-	status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Resolving Cloudflare zone ID for %s", cfCfg.ZoneName)).
-		WithResource("dns-zone").
-		WithAction("resolving"))
-
-	zoneID, err := client.ResolveZoneID(ctx, cfCfg.ZoneName)
+	zoneID, err := p.resolveZoneID(ctx, client, cfCfg)
 	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("zone not found for %q: %w", cfCfg.ZoneName, err)
+		return err
 	}
 	span.SetAttributes(attribute.String("zone_id", zoneID))
 
@@ -193,6 +194,50 @@ func (p *Provider) DestroyRecords(ctx context.Context, domain string, dnsConfig
 	return nil
 }
 
+// resolveZoneID returns the ID of the zone in cfCfg. A configured zone_id
+// skips the lookup by name, but is checked once to be readable with the API
+// token and to be the zone_name zone. Results are cached on the provider, so
+// later record operations make no zone API calls.
+func (p *Provider) resolveZoneID(ctx context.Context, client CloudflareClient, cfCfg *Config) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cfCfg.ZoneID != "" {
+		name, ok := p.zoneNames[cfCfg.ZoneID]
+		if !ok {
+			status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Checking Cloudflare zone %s", cfCfg.ZoneID)).
+				WithResource("dns-zone").
+				WithAction("resolving"))
+
+			var err error
+			name, err = client.GetZoneName(ctx, cfCfg.ZoneID)
+			if err != nil {
+				return "", fmt.Errorf("zone %q is not accessible: %w", cfCfg.ZoneID, err)
+			}
+			p.zoneNames[cfCfg.ZoneID] = name
+		}
+		if name != cfCfg.ZoneName {
+			return "", fmt.Errorf("zone_id %q is zone %q, not zone_name %q", cfCfg.ZoneID, name, cfCfg.ZoneName)
+		}
+		return cfCfg.ZoneID, nil
+	}
+
+	if zoneID, ok := p.zoneIDs[cfCfg.ZoneName]; ok {
+		return zoneID, nil
+	}
+
+	status.Send(ctx, status.NewUpdate(status.LevelProgress, fmt.Sprintf("Resolving Cloudflare zone ID for %s", cfCfg.ZoneName)).
+		WithResource("dns-zone").
+		WithAction("resolving"))
+
+	zoneID, err := client.ResolveZoneID(ctx, cfCfg.ZoneName)
+	if err != nil {
+		return "", fmt.Errorf("zone not found for %q: %w", cfCfg.ZoneName, err)
+	}
+	p.zoneIDs[cfCfg.ZoneName] = zoneID
+	return zoneID, nil
+}
+
 // deleteRecordIfExists lists DNS records matching the given name and type,
 // then deletes each one found. No records found is a no-op (idempotent).
 func (p *Provider) deleteRecordIfExists(ctx context.Context, client CloudflareClient, zoneID, name, recordType string) error {
@@ -258,11 +303,22 @@ func extractCloudflareConfig(domain string, dnsConfig map[string]any) (*Config,
 	return &cfCfg, nil
 }
 
-// getAPIToken reads the Cloudflare API token from the environment.
+// DNS01APIToken returns the API token cert-manager's Cloudflare solver uses
+// for Let's Encrypt DNS-01 challenges. The token needs Zone:DNS:Edit on the
+// zone, the same permission ProvisionRecords needs.
+func (p *Provider) DNS01APIToken() (string, error) {
+	return getAPIToken()
+}
+
+// getAPIToken reads the Cloudflare API token from CLOUDFLARE_API_TOKEN or the
+// file named by CLOUDFLARE_API_TOKEN_FILE.
 func getAPIToken() (string, error) {
-	token := os.Getenv("CLOUDFLARE_API_TOKEN")
+	token, err := secretenv.Lookup("CLOUDFLARE_API_TOKEN")
+	if err != nil {
+		return "", err
+	}
 	if token == "" {
-		return "", fmt.Errorf("CLOUDFLARE_API_TOKEN environment variable is required")
+		return "", fmt.Errorf("CLOUDFLARE_API_TOKEN (or CLOUDFLARE_API_TOKEN_FILE) environment variable is required")
 	}
 	return token, nil
 }
@@ -289,8 +345,8 @@ func recordTypeForEndpoint(endpoint string) string {
 }
 
 // ensureRecord creates or updates a DNS record to match the desired state.
-// If a single record exists with the correct content, it is a no-op.
-// If a single record exists with different content, it is updated.
+// If a single record exists with the correct content and TTL, it is a no-op.
+// If a single record exists with a different content or TTL, it is updated.
 // If multiple records exist, duplicates are deleted and the first is updated.
 // If no record exists, one is created.
 func ensureRecord(ctx context.Context, client CloudflareClient, zoneID, name, recordType, content string) error {
@@ -321,7 +377,7 @@ func ensureRecord(ctx context.Context, client CloudflareClient, zoneID, name, re
 		}
 
 		rec := existing[0]
-		if rec.Content == content {
+		if rec.Content == content && rec.TTL == defaultTTL {
 			// Record already matches -- no-op
 			span.SetAttributes(attribute.String("action", "no-op"))
 			return nil
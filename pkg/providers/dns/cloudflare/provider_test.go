@@ -3,6 +3,8 @@ package cloudflare
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -11,6 +13,7 @@ import (
 // Each method delegates to a function field if non-nil, otherwise returns a sensible default.
 type mockClient struct {
 	resolveZoneIDFn   func(ctx context.Context, zoneName string) (string, error)
+	getZoneNameFn     func(ctx context.Context, zoneID string) (string, error)
 	listDNSRecordsFn  func(ctx context.Context, zoneID string, name string, recordType string) ([]DNSRecordResult, error)
 	createDNSRecordFn func(ctx context.Context, zoneID string, name string, recordType string, content string, ttl int) error
 	updateDNSRecordFn func(ctx context.Context, zoneID string, recordID string, name string, recordType string, content string, ttl int) error
@@ -24,6 +27,13 @@ func (m *mockClient) ResolveZoneID(ctx context.Context, zoneName string) (string
 	return "zone-123", nil
 }
 
+func (m *mockClient) GetZoneName(ctx context.Context, zoneID string) (string, error) {
+	if m.getZoneNameFn != nil {
+		return m.getZoneNameFn(ctx, zoneID)
+	}
+	return "example.com", nil
+}
+
 func (m *mockClient) ListDNSRecords(ctx context.Context, zoneID string, name string, recordType string) ([]DNSRecordResult, error) {
 	if m.listDNSRecordsFn != nil {
 		return m.listDNSRecordsFn(ctx, zoneID, name, recordType)
@@ -158,6 +168,28 @@ func TestProvisionRecords(t *testing.T) {
 			wantCreates: nil,
 			wantUpdates: nil,
 		},
+		{
+			name:       "updates existing record when only TTL differs",
+			domain:     "nebari.example.com",
+			dnsConfig:  baseDNS,
+			lbEndpoint: "203.0.113.42",
+			envToken:   "test-token",
+			mock: &mockClient{
+				listDNSRecordsFn: func(_ context.Context, _ string, name string, _ string) ([]DNSRecordResult, error) {
+					ttl := 300
+					if name == "nebari.example.com" {
+						ttl = 60
+					}
+					return []DNSRecordResult{{
+						ID: "rec-" + name, Name: name, Type: "A",
+						Content: "203.0.113.42", TTL: ttl,
+					}}, nil
+				},
+			},
+			wantUpdates: []string{
+				"nebari.example.com:A:203.0.113.42",
+			},
+		},
 		{
 			name:           "error when DNS config missing",
 			domain:         "nebari.example.com",
@@ -405,6 +437,139 @@ func TestDestroyRecords(t *testing.T) {
 	}
 }
 
+func TestZoneResolution(t *testing.T) {
+	tests := []struct {
+		name            string
+		dnsConfig       map[string]any
+		zoneName        string // returned by GetZoneName
+		getZoneErr      error
+		wantErrContain  string
+		wantZoneID      string
+		wantResolves    int
+		wantGetZoneName int
+	}{
+		{
+			name:         "zone name is resolved once across record operations",
+			dnsConfig:    map[string]any{"zone_name": "example.com"},
+			wantZoneID:   "zone-123",
+			wantResolves: 1,
+		},
+		{
+			name:            "zone ID skips the name lookup",
+			dnsConfig:       map[string]any{"zone_name": "example.com", "zone_id": "zone-abc"},
+			zoneName:        "example.com",
+			wantZoneID:      "zone-abc",
+			wantGetZoneName: 1,
+		},
+		{
+			name:            "zone ID of another zone is rejected",
+			dnsConfig:       map[string]any{"zone_name": "example.com", "zone_id": "zone-abc"},
+			zoneName:        "other.com",
+			wantErrContain:  `is zone "other.com"`,
+			wantGetZoneName: 1,
+		},
+		{
+			name:            "inaccessible zone ID is rejected",
+			dnsConfig:       map[string]any{"zone_name": "example.com", "zone_id": "zone-abc"},
+			getZoneErr:      fmt.Errorf("403 forbidden"),
+			wantErrContain:  "not accessible",
+			wantGetZoneName: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("CLOUDFLARE_API_TOKEN", "test-token")
+
+			var resolves, getZoneNames int
+			var zoneIDs []string
+			mock := &mockClient{
+				resolveZoneIDFn: func(_ context.Context, _ string) (string, error) {
+					resolves++
+					return "zone-123", nil
+				},
+				getZoneNameFn: func(_ context.Context, _ string) (string, error) {
+					getZoneNames++
+					return tc.zoneName, tc.getZoneErr
+				},
+				listDNSRecordsFn: func(_ context.Context, zoneID, _, _ string) ([]DNSRecordResult, error) {
+					zoneIDs = append(zoneIDs, zoneID)
+					return nil, nil
+				},
+			}
+
+			provider := NewProviderForTesting(mock)
+			ctx := context.Background()
+			err := provider.ProvisionRecords(ctx, "nebari.example.com", tc.dnsConfig, "1.2.3.4")
+			if err == nil {
+				err = provider.DestroyRecords(ctx, "nebari.example.com", tc.dnsConfig)
+			}
+
+			if tc.wantErrContain != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrContain) {
+					t.Fatalf("error = %v, want it to contain %q", err, tc.wantErrContain)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resolves != tc.wantResolves {
+				t.Errorf("ResolveZoneID called %d times, want %d", resolves, tc.wantResolves)
+			}
+			if getZoneNames != tc.wantGetZoneName {
+				t.Errorf("GetZoneName called %d times, want %d", getZoneNames, tc.wantGetZoneName)
+			}
+			for _, id := range zoneIDs {
+				if id != tc.wantZoneID {
+					t.Errorf("record operation used zone %q, want %q", id, tc.wantZoneID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAPIToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "cloudflare-token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		envToken       string
+		tokenFile      string
+		want           string
+		wantErrContain string
+	}{
+		{name: "token from environment", envToken: "env-token", want: "env-token"},
+		{name: "token from _FILE variant", tokenFile: tokenFile, want: "file-token"},
+		{name: "both set to different values", envToken: "env-token", tokenFile: tokenFile, wantErrContain: "set only one"},
+		{name: "unreadable file", tokenFile: filepath.Join(t.TempDir(), "missing"), wantErrContain: "CLOUDFLARE_API_TOKEN_FILE"},
+		{name: "neither set", wantErrContain: "CLOUDFLARE_API_TOKEN"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("CLOUDFLARE_API_TOKEN", tc.envToken)
+			t.Setenv("CLOUDFLARE_API_TOKEN_FILE", tc.tokenFile)
+
+			got, err := getAPIToken()
+			if tc.wantErrContain != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrContain) {
+					t.Fatalf("getAPIToken() error = %v, want containing %q", err, tc.wantErrContain)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("getAPIToken() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 // wrapCreate wraps an optional createDNSRecordFn to also track calls.
 func wrapCreate(original func(context.Context, string, string, string, string, int) error, tracker *[]string) func(context.Context, string, string, string, string, int) error {
 	return func(ctx context.Context, zoneID string, name string, recordType string, content string, ttl int) error {
@@ -0,0 +1,25 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/validation"
+)
+
+// ValidationRules implements validation.Source.
+func (p *Provider) ValidationRules() []validation.Rule {
+	return []validation.Rule{
+		{Name: "api-token", Severity: validation.SeverityWarning, Check: checkAPIToken},
+	}
+}
+
+// checkAPIToken flags a missing API token, without which deploy skips DNS
+// provisioning and leaves the records to be created by hand.
+func checkAPIToken(_ context.Context, _ *config.NebariConfig) error {
+	if _, err := getAPIToken(); err != nil {
+		return fmt.Errorf("%w; DNS records will not be provisioned", err)
+	}
+	return nil
+}
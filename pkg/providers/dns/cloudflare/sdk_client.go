@@ -53,6 +53,26 @@ func (c *sdkClient) ResolveZoneID(ctx context.Context, zoneName string) (string,
 	return "", err
 }
 
+// GetZoneName returns the name of the zone with the given ID.
+func (c *sdkClient) GetZoneName(ctx context.Context, zoneID string) (string, error) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "cloudflare.sdk.GetZoneName")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	zone, err := c.api.Zones.Get(ctx, zones.ZoneGetParams{
+		ZoneID: cfapi.F(zoneID),
+	})
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to get zone %q: %w (check that the zone exists and your API token has Zone:Read permission)", zoneID, err)
+	}
+
+	span.SetAttributes(attribute.String("zone_name", zone.Name))
+	return zone.Name, nil
+}
+
 // ListDNSRecords returns DNS records matching the given name and type.
 func (c *sdkClient) ListDNSRecords(ctx context.Context, zoneID string, name string, recordType string) ([]DNSRecordResult, error) {
 	tracer := otel.Tracer("nebari-infrastructure-core")
@@ -0,0 +1,137 @@
+// Package redact hides secret values before configuration or diagnostics are
+// shown to users. A value is sensitive when its key names a credential
+// (token, password, secret, private key, ...); keys that only reference a
+// secret elsewhere, such as token_env, secret_name or existing_secret, are left
+// readable.
+package redact
+
+import "strings"
+
+// Placeholder replaces every redacted value.
+const Placeholder = "***"
+
+// sensitiveWords mark a key as naming a credential. Keys are normalised to
+// lower case with "-" and camel case folded to "_" before matching.
+var sensitiveWords = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"api_key",
+	"apikey",
+	"access_key",
+	"account_key",
+	"private_key",
+	"client_secret",
+	"credentials",
+}
+
+// referenceSuffixes mark a key whose value points at a secret (an environment
+// variable, a file, a Kubernetes object) rather than holding it.
+var referenceSuffixes = []string{"_env", "_file", "_name", "_ref", "_namespace", "_path"}
+
+// referencePrefixes mark a key that names an existing secret to look up, such
+// as existing_secret.
+var referencePrefixes = []string{"existing_"}
+
+// IsSensitiveKey reports whether a value stored under key should be redacted.
+func IsSensitiveKey(key string) bool {
+	k := normalizeKey(key)
+	for _, suffix := range referenceSuffixes {
+		if strings.HasSuffix(k, suffix) {
+			return false
+		}
+	}
+	for _, prefix := range referencePrefixes {
+		if strings.HasPrefix(k, prefix) {
+			return false
+		}
+	}
+	for _, word := range sensitiveWords {
+		if strings.Contains(k, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeKey lower-cases key and turns "-" and camelCase boundaries into
+// "_", so apiToken, api-token and API_TOKEN all read as api_token.
+func normalizeKey(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case r == '-':
+			b.WriteByte('_')
+		case r >= 'A' && r <= 'Z':
+			if i > 0 && key[i-1] >= 'a' && key[i-1] <= 'z' {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r + ('a' - 'A'))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Value returns v with every value stored under a sensitive key replaced by
+// Placeholder, descending into maps and slices as produced by YAML or JSON
+// decoding. A list or map under a sensitive key is replaced as a whole. v is
+// not modified. Empty values are kept so an unset secret stays visibly unset.
+func Value(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			if IsSensitiveKey(k) {
+				out[k] = scrub(val)
+				continue
+			}
+			out[k] = Value(val)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(t))
+		for k, val := range t {
+			if IsSensitiveKey(k) && val != "" {
+				val = Placeholder
+			}
+			out[k] = val
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, val := range t {
+			out[i] = Value(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// scrub returns Placeholder for any non-empty value.
+func scrub(v any) any {
+	switch t := v.(type) {
+	case nil:
+		return v
+	case string:
+		if t == "" {
+			return v
+		}
+	case map[string]any:
+		if len(t) == 0 {
+			return v
+		}
+	case map[string]string:
+		if len(t) == 0 {
+			return v
+		}
+	case []any:
+		if len(t) == 0 {
+			return v
+		}
+	}
+	return Placeholder
+}
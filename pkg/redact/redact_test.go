@@ -0,0 +1,105 @@
+package redact
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"api_token", true},
+		{"apiToken", true},
+		{"API-TOKEN", true},
+		{"password", true},
+		{"client_secret", true},
+		{"secret_access_key", true},
+		{"private_key", true},
+		{"token_env", false},
+		{"secret_access_key_env", false},
+		{"ssh_key_file", false},
+		{"secret_name", false},
+		{"existing_secret", false},
+		{"region", false},
+		{"key", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := IsSensitiveKey(tt.key); got != tt.want {
+				t.Errorf("IsSensitiveKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	in := map[string]any{
+		"region": "us-west-2",
+		"dns": map[string]any{
+			"api_token":     "cf-123",
+			"api_token_env": "CLOUDFLARE_API_TOKEN",
+		},
+		"users": []any{map[string]any{"name": "admin", "password": "hunter2"}},
+		// existing_secret names a Kubernetes secret rather than holding one.
+		"existing_secret": map[string]any{"name": "tls", "namespace": "certs"},
+		"token":           "",
+		"passwords":       []any{"hunter2", "hunter3"},
+		"credentials":     map[string]any{"user": "admin", "pass": "hunter2"},
+		"labels":          map[string]string{"team": "data", "api_key": "k-123"},
+		"secret":          []any{},
+	}
+	want := map[string]any{
+		"region": "us-west-2",
+		"dns": map[string]any{
+			"api_token":     Placeholder,
+			"api_token_env": "CLOUDFLARE_API_TOKEN",
+		},
+		"users":           []any{map[string]any{"name": "admin", "password": Placeholder}},
+		"existing_secret": map[string]any{"name": "tls", "namespace": "certs"},
+		"token":           "",
+		"passwords":       Placeholder,
+		"credentials":     Placeholder,
+		"labels":          map[string]string{"team": "data", "api_key": Placeholder},
+		"secret":          []any{},
+	}
+
+	got := Value(in)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Value() = %v, want %v", got, want)
+	}
+	if in["dns"].(map[string]any)["api_token"] != "cf-123" {
+		t.Error("Value mutated its input")
+	}
+}
+
+func TestReplaceAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: ReplaceAttr}))
+
+	logger.Info("connecting",
+		"api_token", "cf-123",
+		"api_token_env", "CLOUDFLARE_API_TOKEN",
+		slog.Group("admin", "password", "hunter2"),
+		"detail", map[string]any{"client_secret": "s3cr3t", "realm": "nebari"},
+		"headers", map[string]string{"x_api_key": "k-123", "accept": "application/json"},
+		"tokens", []string{"t-1", "t-2"},
+	)
+
+	out := buf.String()
+	for _, leaked := range []string{"cf-123", "hunter2", "s3cr3t", "k-123", "t-1"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("log output leaked %q: %s", leaked, out)
+		}
+	}
+	for _, want := range []string{"CLOUDFLARE_API_TOKEN", "nebari", "application/json", Placeholder} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output missing %q: %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package redact
+
+import "log/slog"
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr hook that redacts
+// attributes whose key is sensitive and scrubs sensitive keys inside
+// map-valued attributes (map[string]any or map[string]string). Install it on every handler that writes logs users
+// may share:
+//
+//	slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{ReplaceAttr: redact.ReplaceAttr})
+func ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		return a
+	}
+	if IsSensitiveKey(a.Key) {
+		if a.Value.Kind() == slog.KindString && a.Value.String() == "" {
+			return a
+		}
+		return slog.String(a.Key, Placeholder)
+	}
+	if a.Value.Kind() == slog.KindAny {
+		switch m := a.Value.Any().(type) {
+		case map[string]any, map[string]string:
+			return slog.Any(a.Key, Value(m))
+		}
+	}
+	return a
+}
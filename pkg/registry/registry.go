@@ -4,8 +4,11 @@
 package registry
 
 import (
+	"context"
+
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/cluster"
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/providers/dns"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/validation"
 )
 
 // Registry holds all registered providers as ProviderList instances
@@ -15,6 +18,9 @@ import (
 type Registry struct {
 	ClusterProviders *ProviderList[cluster.Provider]
 	DNSProviders     *ProviderList[dns.Provider]
+	// Rules are the validation rules providers contribute, scoped to the
+	// provider that registered them (see RegisterRules).
+	Rules *validation.Rules
 }
 
 // NewRegistry creates and returns a new empty Registry.
@@ -22,5 +28,46 @@ func NewRegistry() *Registry {
 	return &Registry{
 		ClusterProviders: newProviderList[cluster.Provider]("ClusterProviders"),
 		DNSProviders:     newProviderList[dns.Provider]("DNSProviders"),
+		Rules:            validation.NewRules(),
+	}
+}
+
+// RegisterRules registers the validation rules of every registered provider
+// that implements validation.Source, each scoped to that provider. Call it
+// once, after all providers are registered.
+func (r *Registry) RegisterRules(ctx context.Context) error {
+	for _, name := range r.ClusterProviders.List(ctx) {
+		p, err := r.ClusterProviders.Get(ctx, name)
+		if err != nil {
+			return err
+		}
+		if err := registerSourceRules(ctx, r.Rules, validation.ClusterScope(name), p); err != nil {
+			return err
+		}
+	}
+	for _, name := range r.DNSProviders.List(ctx) {
+		p, err := r.DNSProviders.Get(ctx, name)
+		if err != nil {
+			return err
+		}
+		if err := registerSourceRules(ctx, r.Rules, validation.DNSScope(name), p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registerSourceRules registers provider's rules under scope when it
+// implements validation.Source.
+func registerSourceRules(ctx context.Context, rules *validation.Rules, scope string, provider any) error {
+	source, ok := provider.(validation.Source)
+	if !ok {
+		return nil
+	}
+	for _, rule := range source.ValidationRules() {
+		if err := rules.Register(ctx, scope, rule); err != nil {
+			return err
+		}
 	}
+	return nil
 }
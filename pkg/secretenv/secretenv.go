@@ -0,0 +1,75 @@
+// Package secretenv resolves credentials that may be supplied either directly
+// in an environment variable or, following the Docker/Kubernetes secret
+// convention, in a file named by the same variable with a _FILE suffix (e.g.
+// CLOUDFLARE_API_TOKEN_FILE=/run/secrets/cloudflare-token).
+package secretenv
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FileSuffix is appended to a variable name to form its file variant.
+const FileSuffix = "_FILE"
+
+// CredentialVars are the credential variables NIC and the tools it drives
+// (cloud SDK credential chains, OpenTofu, hetzner-k3s) read from the process
+// environment. Export resolves their _FILE variants at CLI startup.
+var CredentialVars = []string{
+	"AWS_ACCESS_KEY_ID",
+	"AWS_SECRET_ACCESS_KEY",
+	"AWS_SESSION_TOKEN",
+	"AZURE_CLIENT_ID",
+	"AZURE_CLIENT_SECRET",
+	"AZURE_TENANT_ID",
+	"CLOUDFLARE_API_TOKEN",
+	"HETZNER_TOKEN",
+}
+
+// Lookup returns the value of the environment variable name or, when it is
+// unset or empty, the contents of the file named by name+FileSuffix with
+// trailing newlines removed. It returns "" and a nil error when neither is
+// set. Setting both to different values is an error, since it is ambiguous
+// which one is meant.
+func Lookup(name string) (string, error) {
+	value := os.Getenv(name)
+	path := os.Getenv(name + FileSuffix)
+	if path == "" {
+		return value, nil
+	}
+
+	// G304: the path is intentionally operator-supplied via the environment.
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s%s: %w", name, FileSuffix, err)
+	}
+	fileValue := strings.TrimRight(string(data), "\r\n")
+	switch {
+	case fileValue == "":
+		return "", fmt.Errorf("%s%s points to empty file %s", name, FileSuffix, path)
+	case value != "" && value != fileValue:
+		return "", fmt.Errorf("both %s and %s%s are set; set only one", name, name, FileSuffix)
+	}
+	return fileValue, nil
+}
+
+// Export resolves each variable in names with Lookup and, when the value came
+// from a file, sets it in the process environment. It is idempotent. This lets credential
+// chains that only read environment variables (cloud SDKs, OpenTofu and other
+// subprocesses) pick up file-mounted secrets.
+func Export(names ...string) error {
+	for _, name := range names {
+		if os.Getenv(name+FileSuffix) == "" {
+			continue
+		}
+		value, err := Lookup(name)
+		if err != nil {
+			return err
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package secretenv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testVar = "NIC_SECRETENV_TEST"
+
+func writeSecret(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		file      string // file content; "" means the _FILE variable is unset
+		want      string
+		errSubstr string
+	}{
+		{name: "neither set"},
+		{name: "value only", value: "direct", want: "direct"},
+		{name: "file only", file: "from-file", want: "from-file"},
+		{name: "trailing newlines trimmed", file: "from-file\r\n\n", want: "from-file"},
+		{name: "both set to the same value", value: "same", file: "same\n", want: "same"},
+		{name: "both set to different values", value: "direct", file: "from-file", errSubstr: "set only one"},
+		{name: "empty file", file: "\n", errSubstr: "empty file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(testVar, tt.value)
+			path := ""
+			if tt.file != "" {
+				path = writeSecret(t, tt.file)
+			}
+			t.Setenv(testVar+FileSuffix, path)
+
+			got, err := Lookup(testVar)
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("Lookup() error = %v, want containing %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Lookup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup_MissingFile(t *testing.T) {
+	t.Setenv(testVar, "")
+	t.Setenv(testVar+FileSuffix, filepath.Join(t.TempDir(), "missing"))
+
+	if _, err := Lookup(testVar); err == nil || !strings.Contains(err.Error(), testVar+FileSuffix) {
+		t.Fatalf("Lookup() error = %v, want it to name %s%s", err, testVar, FileSuffix)
+	}
+}
+
+func TestExport(t *testing.T) {
+	t.Setenv(testVar, "")
+	t.Setenv(testVar+FileSuffix, writeSecret(t, "from-file\n"))
+	t.Setenv("NIC_SECRETENV_UNSET", "")
+
+	// Export runs twice to check it is idempotent once the value is set.
+	for range 2 {
+		if err := Export(testVar, "NIC_SECRETENV_UNSET"); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+	}
+	if got := os.Getenv(testVar); got != "from-file" {
+		t.Errorf("%s = %q, want from-file", testVar, got)
+	}
+	if got := os.Getenv("NIC_SECRETENV_UNSET"); got != "" {
+		t.Errorf("NIC_SECRETENV_UNSET = %q, want it left empty", got)
+	}
+}
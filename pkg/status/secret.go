@@ -0,0 +1,37 @@
+package status
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/redact"
+)
+
+// Secret wraps a sensitive value so it can be attached to an Update (or a log
+// record) without being revealed: every rendering - fmt, JSON, slog - prints
+// redact.Placeholder regardless of the metadata key it is stored under.
+//
+//	status.NewUpdate(status.LevelInfo, "Admin user created").
+//		WithMetadata("initial_login", status.Secret(password))
+type Secret string
+
+// String implements fmt.Stringer.
+func (Secret) String() string { return redact.Placeholder }
+
+// GoString implements fmt.GoStringer so %#v is redacted too.
+func (Secret) GoString() string { return redact.Placeholder }
+
+// LogValue implements slog.LogValuer.
+func (Secret) LogValue() slog.Value { return slog.StringValue(redact.Placeholder) }
+
+// MarshalJSON implements json.Marshaler.
+func (Secret) MarshalJSON() ([]byte, error) { return json.Marshal(redact.Placeholder) }
+
+// redactMetadata returns metadata with values under sensitive keys replaced
+// by redact.Placeholder. The caller's map is not modified.
+func redactMetadata(metadata map[string]any) map[string]any {
+	if len(metadata) == 0 {
+		return metadata
+	}
+	return redact.Value(metadata).(map[string]any)
+}
@@ -0,0 +1,81 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/redact"
+)
+
+func TestSecret_Renderings(t *testing.T) {
+	s := Secret("hunter2")
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("msg", "value", s)
+	jsonBytes, err := json.Marshal(map[string]any{"value": s})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renderings := map[string]string{
+		"%v":   fmt.Sprintf("%v", s),
+		"%s":   fmt.Sprintf("%s", s),
+		"%#v":  fmt.Sprintf("%#v", s),
+		"json": string(jsonBytes),
+		"slog": buf.String(),
+	}
+	for name, got := range renderings {
+		if strings.Contains(got, "hunter2") || !strings.Contains(got, redact.Placeholder) {
+			t.Errorf("%s rendering = %q, want it redacted", name, got)
+		}
+	}
+}
+
+func TestSend_RedactsMetadata(t *testing.T) {
+	ch := make(chan Update, 10)
+	ctx := WithChannel(context.Background(), ch)
+
+	update := NewUpdate(LevelInfo, "Admin user created").
+		WithMetadata("password", "generated-pw").
+		WithMetadata("initial_login", Secret("hunter2")).
+		WithMetadata("keycloak", map[string]any{"client_secret": "s3cr3t", "realm": "nebari"}).
+		WithMetadata("secret_name", "keycloak-admin")
+	Send(ctx, update)
+
+	var received Update
+	select {
+	case received = <-ch:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Timeout waiting for status update")
+	}
+
+	// Render the way the CLI does, as a JSON log line.
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	attrs := make([]any, 0, 2*len(received.Metadata))
+	for k, v := range received.Metadata {
+		attrs = append(attrs, k, v)
+	}
+	logger.Info(received.Message, attrs...)
+	out := buf.String()
+
+	for _, leaked := range []string{"generated-pw", "hunter2", "s3cr3t"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("output leaked %q: %s", leaked, out)
+		}
+	}
+	for _, want := range []string{"keycloak-admin", "nebari", redact.Placeholder} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %s", want, out)
+		}
+	}
+	if update.Metadata["password"] != "generated-pw" {
+		t.Error("Send mutated the caller's metadata")
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -87,20 +90,28 @@ func (s Update) WithMetadata(key string, value any) Update {
 }
 
 // Send sends a status update through the channel stored in the context (if present)
+// and records it as an event on the context's current span, so traces carry the
+// same progress messages users see.
+// Metadata values under sensitive keys (see redact.IsSensitiveKey) are replaced
+// with redact.Placeholder before the update leaves Send; wrap values whose key
+// doesn't give them away in Secret.
 // This function is non-blocking and will drop the message if the channel is full.
 // It is safe to call after the channel has been closed.
 func Send(ctx context.Context, update Update) {
+	// Set timestamp if not already set
+	if update.Timestamp.IsZero() {
+		update.Timestamp = time.Now()
+	}
+	update.Metadata = redactMetadata(update.Metadata)
+
+	recordSpanEvent(ctx, update)
+
 	ch := getChannel(ctx)
 	if ch == nil {
 		// No status channel in context - silently skip
 		return
 	}
 
-	// Set timestamp if not already set
-	if update.Timestamp.IsZero() {
-		update.Timestamp = time.Now()
-	}
-
 	// Recover from send on closed channel. This can happen when the status
 	// handler has been cleaned up but the context (with the now-closed channel)
 	// is still in use by downstream code.
@@ -117,6 +128,30 @@ func Send(ctx context.Context, update Update) {
 	}
 }
 
+// recordSpanEvent adds update as an event on the span in ctx. It is a no-op
+// when ctx carries no span or the span is not recording.
+func recordSpanEvent(ctx context.Context, update Update) {
+	if ctx == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("status.level", string(update.Level)),
+		attribute.String("status.message", update.Message),
+	}
+	if update.Resource != "" {
+		attrs = append(attrs, attribute.String("status.resource", update.Resource))
+	}
+	if update.Action != "" {
+		attrs = append(attrs, attribute.String("status.action", update.Action))
+	}
+	span.AddEvent("status", trace.WithAttributes(attrs...), trace.WithTimestamp(update.Timestamp))
+}
+
 // Sendf sends a formatted status update message
 func Sendf(ctx context.Context, level Level, format string, args ...any) {
 	Send(ctx, Update{
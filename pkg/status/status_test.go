@@ -4,6 +4,10 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewUpdate(t *testing.T) {
@@ -124,6 +128,49 @@ func TestSend_WithChannel(t *testing.T) {
 	}
 }
 
+func TestSend_RecordsSpanEvent(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "deploy")
+
+	Send(ctx, NewUpdate(LevelProgress, "Creating VPC").WithResource("vpc").WithAction("creating"))
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(spans))
+	}
+	events := spans[0].Events()
+	if len(events) != 1 {
+		t.Fatalf("span events = %d, want 1", len(events))
+	}
+	if events[0].Name != "status" {
+		t.Errorf("event name = %q, want %q", events[0].Name, "status")
+	}
+
+	want := map[attribute.Key]string{
+		"status.level":    "progress",
+		"status.message":  "Creating VPC",
+		"status.resource": "vpc",
+		"status.action":   "creating",
+	}
+	got := make(map[attribute.Key]string)
+	for _, kv := range events[0].Attributes {
+		got[kv.Key] = kv.Value.AsString()
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("event attribute %s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestSend_NoSpan(t *testing.T) {
+	// A context without a span (or a nil context) must not panic
+	Send(context.Background(), NewUpdate(LevelInfo, "test"))
+	Send(nil, NewUpdate(LevelInfo, "test")) //nolint:staticcheck // exercising nil-context guard
+}
+
 func TestSend_FullChannel(t *testing.T) {
 	// Create a channel with buffer size 1
 	ch := make(chan Update, 1)
@@ -0,0 +1,115 @@
+// Package steptiming records how long each step of a deploy takes so the
+// steps that dominate wall time can be found.
+//
+// Orchestration code brackets its steps with Start, which opens an OTel span
+// for the step; steps timed elsewhere, such as the resources OpenTofu
+// applies, are reported with Record. When the context carries a Recorder,
+// every step is also collected for an end-of-run "slowest steps" summary.
+package steptiming
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Step is one completed step.
+type Step struct {
+	// Name identifies the step, e.g. "infrastructure" or an OpenTofu
+	// resource address.
+	Name string
+	// Duration is the step's wall time.
+	Duration time.Duration
+	// Failed reports whether the step returned an error.
+	Failed bool
+}
+
+// Recorder collects the steps of one run. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	steps []Step
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context whose steps are collected into r.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the Recorder attached to ctx, or nil.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Start begins step name under a "nic.Step" span, so the span's duration is
+// the step's. The returned function ends the span and records the step; pass
+// it the step's error, if any.
+func Start(ctx context.Context, name string) (context.Context, func(error)) {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "nic.Step", trace.WithAttributes(attribute.String("step", name)))
+	start := time.Now()
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		if r := FromContext(ctx); r != nil {
+			r.add(Step{Name: name, Duration: time.Since(start), Failed: err != nil})
+		}
+	}
+}
+
+// Record reports a step timed by someone else: it adds a "step_complete"
+// event to the span in ctx and collects the step into the context's
+// Recorder if there is one.
+func Record(ctx context.Context, name string, d time.Duration, failed bool) {
+	trace.SpanFromContext(ctx).AddEvent("step_complete", trace.WithAttributes(
+		attribute.String("step", name),
+		attribute.Int64("duration_ms", d.Milliseconds()),
+		attribute.Bool("error", failed),
+	))
+	if r := FromContext(ctx); r != nil {
+		r.add(Step{Name: name, Duration: d, Failed: failed})
+	}
+}
+
+func (r *Recorder) add(s Step) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, s)
+}
+
+// Slowest returns up to n steps ordered by duration, slowest first. Ties are
+// ordered by name so the result is stable.
+func (r *Recorder) Slowest(n int) []Step {
+	r.mu.Lock()
+	all := slices.Clone(r.steps)
+	r.mu.Unlock()
+
+	slices.SortFunc(all, func(a, b Step) int {
+		if c := cmp.Compare(b.Duration, a.Duration); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	if len(all) > n {
+		all = all[:n]
+	}
+	return all
+}
@@ -0,0 +1,67 @@
+package steptiming
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecorderSlowest(t *testing.T) {
+	r := NewRecorder()
+	ctx := WithRecorder(context.Background(), r)
+
+	Record(ctx, "module.vpc.aws_nat_gateway.this[0]", 90*time.Second, false)
+	Record(ctx, "module.eks.aws_eks_cluster.this[0]", 9*time.Minute, false)
+	Record(ctx, "module.eks.aws_eks_node_group.this[\"general\"]", 3*time.Minute, true)
+	Record(ctx, "b", time.Second, false)
+	Record(ctx, "a", time.Second, false)
+
+	got := r.Slowest(4)
+	want := []Step{
+		{Name: "module.eks.aws_eks_cluster.this[0]", Duration: 9 * time.Minute},
+		{Name: "module.eks.aws_eks_node_group.this[\"general\"]", Duration: 3 * time.Minute, Failed: true},
+		{Name: "module.vpc.aws_nat_gateway.this[0]", Duration: 90 * time.Second},
+		{Name: "a", Duration: time.Second},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Slowest(4) returned %d steps, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Slowest(4)[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStart(t *testing.T) {
+	r := NewRecorder()
+	ctx := WithRecorder(context.Background(), r)
+
+	_, end := Start(ctx, "gitops")
+	time.Sleep(5 * time.Millisecond)
+	end(nil)
+	_, end = Start(ctx, "dns")
+	end(errors.New("zone not found"))
+
+	got := r.Slowest(10)
+	if len(got) != 2 {
+		t.Fatalf("recorded %d steps, want 2", len(got))
+	}
+	if got[0].Name != "gitops" || got[0].Duration < 5*time.Millisecond || got[0].Failed {
+		t.Errorf("slowest = %+v, want a successful gitops step of at least 5ms", got[0])
+	}
+	if got[1].Name != "dns" || !got[1].Failed {
+		t.Errorf("second = %+v, want a failed dns step", got[1])
+	}
+}
+
+func TestWithoutRecorder(t *testing.T) {
+	// Steps outside a recorded run still reach spans and must not panic.
+	_, end := Start(context.Background(), "gitops")
+	end(nil)
+	Record(context.Background(), "gitops", time.Millisecond, false)
+	if FromContext(context.Background()) != nil {
+		t.Error("FromContext() on a bare context should be nil")
+	}
+}
@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 const (
@@ -19,10 +23,29 @@ const (
 	serviceVersion = "1.0.0"
 )
 
-// Setup initializes OpenTelemetry based on environment configuration.
+// Options controls how Setup configures OpenTelemetry.
+type Options struct {
+	// Disabled installs no-op tracer and meter providers, so every
+	// otel.Tracer(...) span in the CLI becomes a no-op. NIC_TELEMETRY=off has
+	// the same effect.
+	Disabled bool
+}
+
+// Setup initializes OpenTelemetry based on opts and environment configuration.
+// NIC_TELEMETRY: "off" disables telemetry entirely (same as Options.Disabled)
 // OTEL_EXPORTER: "none" (default), "console", "otlp", or "both"
 // OTEL_ENDPOINT: OTLP endpoint (default: "localhost:4317")
-func Setup(ctx context.Context) (trace.Tracer, func(context.Context) error, error) {
+// OTEL_SAMPLE_RATIO: fraction of root traces to sample, 0 to 1 (default: 1)
+func Setup(ctx context.Context, opts Options) (trace.Tracer, func(context.Context) error, error) {
+	if opts.Disabled || telemetryOff(os.Getenv("NIC_TELEMETRY")) {
+		return setupNoop()
+	}
+
+	ratio, err := sampleRatio(os.Getenv("OTEL_SAMPLE_RATIO"))
+	if err != nil {
+		return nil, nil, err
+	}
+
 	exporterType := os.Getenv("OTEL_EXPORTER")
 	if exporterType == "" {
 		exporterType = "none"
@@ -98,6 +121,7 @@ func Setup(ctx context.Context) (trace.Tracer, func(context.Context) error, erro
 	var batchOptions []sdktrace.BatchSpanProcessorOption
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
 	)
 
 	for _, exporter := range exporters {
@@ -115,3 +139,38 @@ func Setup(ctx context.Context) (trace.Tracer, func(context.Context) error, erro
 
 	return tracer, shutdown, nil
 }
+
+// setupNoop installs no-op tracer and meter providers and returns a tracer
+// and shutdown function that do nothing.
+func setupNoop() (trace.Tracer, func(context.Context) error, error) {
+	tp := noop.NewTracerProvider()
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(metricnoop.NewMeterProvider())
+
+	shutdown := func(context.Context) error { return nil }
+	return tp.Tracer(serviceName), shutdown, nil
+}
+
+// telemetryOff reports whether the NIC_TELEMETRY value disables telemetry.
+func telemetryOff(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "off", "false", "0", "disabled":
+		return true
+	}
+	return false
+}
+
+// sampleRatio parses OTEL_SAMPLE_RATIO. An empty value samples every trace.
+func sampleRatio(v string) (float64, error) {
+	if v == "" {
+		return 1, nil
+	}
+	ratio, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid OTEL_SAMPLE_RATIO %q: %w", v, err)
+	}
+	if !(ratio >= 0 && ratio <= 1) {
+		return 0, fmt.Errorf("invalid OTEL_SAMPLE_RATIO %q: must be between 0 and 1", v)
+	}
+	return ratio, nil
+}
@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSetupDisabled(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		opts Options
+	}{
+		{name: "env off", env: "off"},
+		{name: "env false", env: "FALSE"},
+		{name: "option disabled", opts: Options{Disabled: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NIC_TELEMETRY", tt.env)
+			ctx := context.Background()
+
+			tracer, shutdown, err := Setup(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("Setup() error = %v", err)
+			}
+
+			if _, ok := otel.GetTracerProvider().(noop.TracerProvider); !ok {
+				t.Errorf("global tracer provider = %T, want noop.TracerProvider", otel.GetTracerProvider())
+			}
+
+			_, span := tracer.Start(ctx, "test")
+			if span.IsRecording() {
+				t.Error("span from disabled tracer is recording")
+			}
+			span.End()
+
+			_, span = otel.Tracer("nebari-infrastructure-core").Start(ctx, "test")
+			if span.IsRecording() {
+				t.Error("span from global tracer is recording")
+			}
+			span.End()
+
+			if err := shutdown(ctx); err != nil {
+				t.Errorf("shutdown() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSampleRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "empty defaults to always", value: "", want: 1},
+		{name: "fraction", value: "0.25", want: 0.25},
+		{name: "zero", value: "0", want: 0},
+		{name: "not a number", value: "half", wantErr: true},
+		{name: "NaN", value: "NaN", wantErr: true},
+		{name: "above one", value: "1.5", wantErr: true},
+		{name: "negative", value: "-0.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sampleRatio(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sampleRatio(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("sampleRatio(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupInvalidSampleRatio(t *testing.T) {
+	t.Setenv("NIC_TELEMETRY", "")
+	t.Setenv("OTEL_SAMPLE_RATIO", "2")
+
+	if _, _, err := Setup(context.Background(), Options{}); err == nil {
+		t.Fatal("Setup() expected error for out-of-range OTEL_SAMPLE_RATIO")
+	}
+}
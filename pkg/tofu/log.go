@@ -1,9 +1,12 @@
 package tofu
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/steptiming"
 )
 
 // tofuEvent captures the two fields we need to populate a status.Update's
@@ -17,6 +20,19 @@ type tofuEvent struct {
 	Message string `json:"@message"`
 }
 
+// tofuHookEvent captures the fields of an apply_complete or apply_errored
+// event needed to time one resource.
+type tofuHookEvent struct {
+	Type string `json:"type"`
+	Hook struct {
+		Resource struct {
+			Addr string `json:"addr"`
+		} `json:"resource"`
+		Action         string  `json:"action"`
+		ElapsedSeconds float64 `json:"elapsed_seconds"`
+	} `json:"hook"`
+}
+
 // mapStatusLevel converts a tofu UI event's @level field into the equivalent
 // status.Level. Per the OpenTofu machine-readable UI spec, @level is one of
 // "info" (normal), "warn", or "error" (surfacing diagnostics). Any other
@@ -51,3 +67,21 @@ func jsonLineMapper(line []byte) status.Update {
 	return status.NewUpdate(mapStatusLevel(ev.Level), ev.Message).
 		WithMetadata(status.MetadataKeyDetail, detail)
 }
+
+// timedJSONLineMapper wraps jsonLineMapper and additionally reports every
+// resource tofu finishes applying as a steptiming step named
+// "<address> (<action>)", so per-resource durations (VPC, IAM roles, the
+// cluster, each node group) reach the deploy's slowest-steps report.
+func timedJSONLineMapper(ctx context.Context) status.LineMapper {
+	return func(line []byte) status.Update {
+		var ev tofuHookEvent
+		if err := json.Unmarshal(line, &ev); err == nil && ev.Hook.Resource.Addr != "" {
+			switch ev.Type {
+			case "apply_complete", "apply_errored":
+				d := time.Duration(ev.Hook.ElapsedSeconds * float64(time.Second))
+				steptiming.Record(ctx, ev.Hook.Resource.Addr+" ("+ev.Hook.Action+")", d, ev.Type == "apply_errored")
+			}
+		}
+		return jsonLineMapper(line)
+	}
+}
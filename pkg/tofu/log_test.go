@@ -1,11 +1,14 @@
 package tofu
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nebari-dev/nebari-infrastructure-core/pkg/status"
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/steptiming"
 )
 
 func TestMapStatusLevel(t *testing.T) {
@@ -122,3 +125,34 @@ func TestJSONLineMapper(t *testing.T) {
 		}
 	})
 }
+
+func TestTimedJSONLineMapper(t *testing.T) {
+	rec := steptiming.NewRecorder()
+	mapper := timedJSONLineMapper(steptiming.WithRecorder(context.Background(), rec))
+
+	lines := []string{
+		`{"@level":"info","@message":"module.eks.aws_eks_cluster.this[0]: Creation complete after 9m2s","type":"apply_complete","hook":{"resource":{"addr":"module.eks.aws_eks_cluster.this[0]"},"action":"create","elapsed_seconds":542}}`,
+		`{"@level":"error","@message":"module.eks.aws_eks_node_group.this[\"gpu\"]: Creation errored after 30s","type":"apply_errored","hook":{"resource":{"addr":"module.eks.aws_eks_node_group.this[\"gpu\"]"},"action":"create","elapsed_seconds":30}}`,
+		`{"@level":"info","@message":"module.vpc.aws_vpc.this[0]: Creating...","type":"apply_start","hook":{"resource":{"addr":"module.vpc.aws_vpc.this[0]"},"action":"create"}}`,
+		`not json`,
+	}
+	for _, line := range lines {
+		if u := mapper([]byte(line)); u.Message == "" {
+			t.Errorf("mapper dropped the status message for %q", line)
+		}
+	}
+
+	got := rec.Slowest(10)
+	want := []steptiming.Step{
+		{Name: "module.eks.aws_eks_cluster.this[0] (create)", Duration: 542 * time.Second},
+		{Name: `module.eks.aws_eks_node_group.this["gpu"] (create)`, Duration: 30 * time.Second, Failed: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("recorded %d steps, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("step %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -63,7 +63,7 @@ func (te *TerraformExecutor) SetExtraEnv(overrides map[string]string) error {
 // Both writers buffer partial lines, so we Flush both after op returns to
 // drain any final non-newline-terminated content.
 func (te *TerraformExecutor) streamThroughStatus(ctx context.Context, op func(io.Writer) error) error {
-	stdout := status.NewWriter(ctx, jsonLineMapper)
+	stdout := status.NewWriter(ctx, timedJSONLineMapper(ctx))
 	stderr := status.NewWriter(ctx, status.RawMapper(status.LevelError))
 
 	te.SetStderr(stderr)
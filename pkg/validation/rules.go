@@ -0,0 +1,174 @@
+// Package validation runs named configuration checks registered by cluster
+// and DNS providers, so the validate command reports them uniformly without
+// the config package knowing about any particular provider.
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+// Severity decides whether a failing rule fails validation.
+type Severity string
+
+const (
+	// SeverityError fails validation.
+	SeverityError Severity = "error"
+	// SeverityWarning is reported but does not fail validation.
+	SeverityWarning Severity = "warning"
+)
+
+// Rule is a named configuration check. Check returns nil when the config
+// passes, or an error describing the problem.
+type Rule struct {
+	Name     string
+	Severity Severity
+	Check    func(ctx context.Context, cfg *config.NebariConfig) error
+}
+
+// Source is implemented by providers that contribute validation rules.
+type Source interface {
+	ValidationRules() []Rule
+}
+
+// ClusterScope returns the scope of rules that only apply when the cluster
+// provider name is selected.
+func ClusterScope(name string) string { return "cluster/" + name }
+
+// DNSScope returns the scope of rules that only apply when the DNS provider
+// name is configured.
+func DNSScope(name string) string { return "dns/" + name }
+
+// Finding is a rule that did not pass.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Err      error
+}
+
+func (f Finding) String() string { return fmt.Sprintf("%s: %v", f.Rule, f.Err) }
+
+type scopedRule struct {
+	scope string
+	rule  Rule
+}
+
+// Rules is a thread-safe set of registered rules. The zero value is not
+// usable; create one with NewRules.
+type Rules struct {
+	mu    sync.RWMutex
+	rules []scopedRule
+}
+
+// NewRules returns an empty rule set.
+func NewRules() *Rules {
+	return &Rules{}
+}
+
+// Register adds rule under scope: ClusterScope or DNSScope, or "" for a rule
+// that always runs. Rule names must be unique within a scope.
+func (r *Rules) Register(ctx context.Context, scope string, rule Rule) error {
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	_, span := tracer.Start(ctx, "validation.Register")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("scope", scope), attribute.String("rule", rule.Name))
+
+	var err error
+	switch {
+	case rule.Name == "":
+		err = fmt.Errorf("validation rule in scope %q has no name", scope)
+	case rule.Check == nil:
+		err = fmt.Errorf("validation rule %q has no check", rule.Name)
+	case rule.Severity != SeverityError && rule.Severity != SeverityWarning:
+		err = fmt.Errorf("validation rule %q has invalid severity %q", rule.Name, rule.Severity)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.ContainsFunc(r.rules, func(s scopedRule) bool { return s.scope == scope && s.rule.Name == rule.Name }) {
+		err := fmt.Errorf("validation rule %q is already registered in scope %q", rule.Name, scope)
+		span.RecordError(err)
+		return err
+	}
+	r.rules = append(r.rules, scopedRule{scope: scope, rule: rule})
+	return nil
+}
+
+// Run checks cfg against every rule whose scope applies to it, in
+// registration order, and returns the rules that did not pass. A nil
+// receiver runs no rules.
+func (r *Rules) Run(ctx context.Context, cfg *config.NebariConfig) []Finding {
+	if r == nil {
+		return nil
+	}
+	tracer := otel.Tracer("nebari-infrastructure-core")
+	ctx, span := tracer.Start(ctx, "validation.Run")
+	defer span.End()
+
+	scopes := activeScopes(cfg)
+
+	r.mu.RLock()
+	rules := slices.Clone(r.rules)
+	r.mu.RUnlock()
+
+	var findings []Finding
+	for _, s := range rules {
+		if s.scope != "" && !slices.Contains(scopes, s.scope) {
+			continue
+		}
+		if err := s.rule.Check(ctx, cfg); err != nil {
+			findings = append(findings, Finding{Rule: qualifiedName(s), Severity: s.rule.Severity, Err: err})
+		}
+	}
+	span.SetAttributes(attribute.Int("findings", len(findings)))
+	return findings
+}
+
+// Errors joins the error-severity findings, or returns nil when there are
+// none.
+func Errors(findings []Finding) error {
+	var errs []error
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			errs = append(errs, errors.New(f.String()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// activeScopes lists the scopes selected by cfg: its cluster provider and
+// the DNS providers it provisions records with.
+func activeScopes(cfg *config.NebariConfig) []string {
+	var scopes []string
+	if cfg.Cluster != nil {
+		scopes = append(scopes, ClusterScope(cfg.Cluster.ProviderName()))
+	}
+	for _, dns := range []*config.DNSConfig{cfg.DNS, cfg.RecordsDNS()} {
+		if dns != nil && len(dns.Providers) > 0 {
+			scopes = append(scopes, DNSScope(dns.ProviderName()))
+		}
+	}
+	return scopes
+}
+
+// qualifiedName prefixes a scoped rule's name with its scope.
+func qualifiedName(s scopedRule) string {
+	if s.scope == "" {
+		return s.rule.Name
+	}
+	return s.scope + "/" + s.rule.Name
+}
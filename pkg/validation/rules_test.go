@@ -0,0 +1,122 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nebari-dev/nebari-infrastructure-core/pkg/config"
+)
+
+func testConfig(cluster, dns string) *config.NebariConfig {
+	cfg := &config.NebariConfig{
+		ProjectName: "test",
+		Cluster:     &config.ClusterConfig{Providers: map[string]any{cluster: map[string]any{}}},
+	}
+	if dns != "" {
+		cfg.DNS = &config.DNSConfig{Providers: map[string]any{dns: map[string]any{}}}
+	}
+	return cfg
+}
+
+func failing(msg string) func(context.Context, *config.NebariConfig) error {
+	return func(context.Context, *config.NebariConfig) error { return errors.New(msg) }
+}
+
+func passing(context.Context, *config.NebariConfig) error { return nil }
+
+func TestRulesRun(t *testing.T) {
+	ctx := context.Background()
+	rules := NewRules()
+	for _, r := range []struct {
+		scope string
+		rule  Rule
+	}{
+		{"", Rule{Name: "global", Severity: SeverityWarning, Check: failing("global warning")}},
+		{"", Rule{Name: "passes", Severity: SeverityError, Check: passing}},
+		{ClusterScope("aws"), Rule{Name: "aws-error", Severity: SeverityError, Check: failing("aws error")}},
+		{ClusterScope("local"), Rule{Name: "local-warning", Severity: SeverityWarning, Check: failing("local warning")}},
+		{DNSScope("cloudflare"), Rule{Name: "token", Severity: SeverityWarning, Check: failing("no token")}},
+	} {
+		if err := rules.Register(ctx, r.scope, r.rule); err != nil {
+			t.Fatalf("Register(%s) error: %v", r.rule.Name, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		cfg       *config.NebariConfig
+		wantRules []string
+		wantErr   string // "" means warnings only
+	}{
+		{name: "local without dns", cfg: testConfig("local", ""), wantRules: []string{"global", "cluster/local/local-warning"}},
+		{
+			name:      "aws with cloudflare",
+			cfg:       testConfig("aws", "cloudflare"),
+			wantRules: []string{"global", "cluster/aws/aws-error", "dns/cloudflare/token"},
+			wantErr:   "cluster/aws/aws-error: aws error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := rules.Run(ctx, tt.cfg)
+			var got []string
+			for _, f := range findings {
+				got = append(got, f.Rule)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("findings = %v, want %v", got, tt.wantRules)
+			}
+			err := Errors(findings)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("warnings should not fail validation, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Errors() = %v, want containing %q", err, tt.wantErr)
+			}
+			if strings.Contains(err.Error(), "warning") || strings.Contains(err.Error(), "no token") {
+				t.Errorf("Errors() = %v, should leave out warnings", err)
+			}
+		})
+	}
+}
+
+func TestRulesRegister(t *testing.T) {
+	ctx := context.Background()
+	rules := NewRules()
+	if err := rules.Register(ctx, ClusterScope("aws"), Rule{Name: "a", Severity: SeverityError, Check: passing}); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	// The same name in another scope is a different rule.
+	if err := rules.Register(ctx, ClusterScope("gcp"), Rule{Name: "a", Severity: SeverityError, Check: passing}); err != nil {
+		t.Fatalf("Register() in another scope error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		rule      Rule
+		errSubstr string
+	}{
+		{name: "duplicate", rule: Rule{Name: "a", Severity: SeverityError, Check: passing}, errSubstr: "already registered"},
+		{name: "no name", rule: Rule{Severity: SeverityError, Check: passing}, errSubstr: "has no name"},
+		{name: "no check", rule: Rule{Name: "b", Severity: SeverityError}, errSubstr: "has no check"},
+		{name: "bad severity", rule: Rule{Name: "c", Severity: "info", Check: passing}, errSubstr: `invalid severity "info"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.Register(ctx, ClusterScope("aws"), tt.rule)
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("Register() error = %v, want containing %q", err, tt.errSubstr)
+			}
+		})
+	}
+
+	var nilRules *Rules
+	if findings := nilRules.Run(ctx, testConfig("aws", "")); findings != nil {
+		t.Errorf("nil Rules ran %v", findings)
+	}
+}